@@ -155,10 +155,11 @@ func (x *RequestMetadata) GetTenantId() string {
 	return ""
 }
 
-// PageInfo represents pagination information
+// PageInfo represents pagination information. It is recomputed for every
+// request, so it stays consistent when the page size changes between pages.
 type PageInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                               // Current page (1-indexed)
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                               // Page served (1-indexed); a requested page past the end is clamped to the last page
 	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`       // Items per page
 	TotalItems    int64                  `protobuf:"varint,3,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"` // Total number of items
 	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"` // Total number of pages
@@ -240,6 +241,59 @@ func (x *PageInfo) GetHasPrev() bool {
 	return false
 }
 
+// SortSpec orders results by a single field; repeated specs sort lexicographically
+type SortSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // e.g., "priority", "due_date"
+	Order         SortOrder              `protobuf:"varint,2,opt,name=order,proto3,enum=todo.v1.SortOrder" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortSpec) Reset() {
+	*x = SortSpec{}
+	mi := &file_api_proto_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortSpec) ProtoMessage() {}
+
+func (x *SortSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortSpec.ProtoReflect.Descriptor instead.
+func (*SortSpec) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SortSpec) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SortSpec) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
 type ErrorDetail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
@@ -251,7 +305,7 @@ type ErrorDetail struct {
 
 func (x *ErrorDetail) Reset() {
 	*x = ErrorDetail{}
-	mi := &file_api_proto_v1_common_proto_msgTypes[2]
+	mi := &file_api_proto_v1_common_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -263,7 +317,7 @@ func (x *ErrorDetail) String() string {
 func (*ErrorDetail) ProtoMessage() {}
 
 func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_common_proto_msgTypes[2]
+	mi := &file_api_proto_v1_common_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +330,7 @@ func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
 func (*ErrorDetail) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_common_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_v1_common_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ErrorDetail) GetField() string {
@@ -311,7 +365,7 @@ type ErrorResponse struct {
 
 func (x *ErrorResponse) Reset() {
 	*x = ErrorResponse{}
-	mi := &file_api_proto_v1_common_proto_msgTypes[3]
+	mi := &file_api_proto_v1_common_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -323,7 +377,7 @@ func (x *ErrorResponse) String() string {
 func (*ErrorResponse) ProtoMessage() {}
 
 func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_common_proto_msgTypes[3]
+	mi := &file_api_proto_v1_common_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -336,7 +390,7 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_common_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_v1_common_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ErrorResponse) GetCode() string {
@@ -381,7 +435,10 @@ const file_api_proto_v1_common_proto_rawDesc = "" +
 	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
 	"totalPages\x12\x19\n" +
 	"\bhas_next\x18\x05 \x01(\bR\ahasNext\x12\x19\n" +
-	"\bhas_prev\x18\x06 \x01(\bR\ahasPrev\"\\\n" +
+	"\bhas_prev\x18\x06 \x01(\bR\ahasPrev\"J\n" +
+	"\bSortSpec\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12(\n" +
+	"\x05order\x18\x02 \x01(\x0e2\x12.todo.v1.SortOrderR\x05order\"\\\n" +
 	"\vErrorDetail\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
@@ -409,21 +466,23 @@ func file_api_proto_v1_common_proto_rawDescGZIP() []byte {
 }
 
 var file_api_proto_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_proto_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_api_proto_v1_common_proto_goTypes = []any{
 	(SortOrder)(0),          // 0: todo.v1.SortOrder
 	(*RequestMetadata)(nil), // 1: todo.v1.RequestMetadata
 	(*PageInfo)(nil),        // 2: todo.v1.PageInfo
-	(*ErrorDetail)(nil),     // 3: todo.v1.ErrorDetail
-	(*ErrorResponse)(nil),   // 4: todo.v1.ErrorResponse
+	(*SortSpec)(nil),        // 3: todo.v1.SortSpec
+	(*ErrorDetail)(nil),     // 4: todo.v1.ErrorDetail
+	(*ErrorResponse)(nil),   // 5: todo.v1.ErrorResponse
 }
 var file_api_proto_v1_common_proto_depIdxs = []int32{
-	3, // 0: todo.v1.ErrorResponse.details:type_name -> todo.v1.ErrorDetail
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 0: todo.v1.SortSpec.order:type_name -> todo.v1.SortOrder
+	4, // 1: todo.v1.ErrorResponse.details:type_name -> todo.v1.ErrorDetail
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_v1_common_proto_init() }
@@ -437,7 +496,7 @@ func file_api_proto_v1_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1_common_proto_rawDesc), len(file_api_proto_v1_common_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,7 @@ package todov1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -135,6 +136,66 @@ func (TodoPriority) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{1}
 }
 
+// DueBucket groups todos by when they fall due, with days and weeks taken in
+// the timezone of the request
+type DueBucket int32
+
+const (
+	DueBucket_DUE_BUCKET_UNSPECIFIED DueBucket = 0
+	DueBucket_DUE_BUCKET_OVERDUE     DueBucket = 1 // Past the deadline and neither completed nor archived
+	DueBucket_DUE_BUCKET_TODAY       DueBucket = 2
+	DueBucket_DUE_BUCKET_THIS_WEEK   DueBucket = 3 // After today, up to the end of Sunday
+	DueBucket_DUE_BUCKET_LATER       DueBucket = 4 // From next Monday on
+	DueBucket_DUE_BUCKET_NONE        DueBucket = 5 // No due date
+)
+
+// Enum value maps for DueBucket.
+var (
+	DueBucket_name = map[int32]string{
+		0: "DUE_BUCKET_UNSPECIFIED",
+		1: "DUE_BUCKET_OVERDUE",
+		2: "DUE_BUCKET_TODAY",
+		3: "DUE_BUCKET_THIS_WEEK",
+		4: "DUE_BUCKET_LATER",
+		5: "DUE_BUCKET_NONE",
+	}
+	DueBucket_value = map[string]int32{
+		"DUE_BUCKET_UNSPECIFIED": 0,
+		"DUE_BUCKET_OVERDUE":     1,
+		"DUE_BUCKET_TODAY":       2,
+		"DUE_BUCKET_THIS_WEEK":   3,
+		"DUE_BUCKET_LATER":       4,
+		"DUE_BUCKET_NONE":        5,
+	}
+)
+
+func (x DueBucket) Enum() *DueBucket {
+	p := new(DueBucket)
+	*p = x
+	return p
+}
+
+func (x DueBucket) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DueBucket) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_v1_todo_proto_enumTypes[2].Descriptor()
+}
+
+func (DueBucket) Type() protoreflect.EnumType {
+	return &file_api_proto_v1_todo_proto_enumTypes[2]
+}
+
+func (x DueBucket) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DueBucket.Descriptor instead.
+func (DueBucket) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{2}
+}
+
 // Todo represent a task item
 type Todo struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
@@ -145,13 +206,34 @@ type Todo struct {
 	Priority    TodoPriority           `protobuf:"varint,5,opt,name=priority,proto3,enum=todo.v1.TodoPriority" json:"priority,omitempty"`
 	DueDate     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
 	// Tags for categorization
-	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
-	OwnerId       string                 `protobuf:"bytes,8,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	AssignedTo    string                 `protobuf:"bytes,9,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
-	TenantId      string                 `protobuf:"bytes,10,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Version       int64                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"` // Optimistic locking version
+	Tags       []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	OwnerId    string                 `protobuf:"bytes,8,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	AssignedTo string                 `protobuf:"bytes,9,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	TenantId   string                 `protobuf:"bytes,10,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version    int64                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"` // Optimistic locking version
+	// Past due date and neither completed nor archived, computed at read time
+	Overdue bool `protobuf:"varint,14,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// Optional IANA timezone (e.g. "Europe/Berlin"); when set the todo is due
+	// at the end of the due_date's day in that zone
+	DueDateTimezone string `protobuf:"bytes,15,opt,name=due_date_timezone,json=dueDateTimezone,proto3" json:"due_date_timezone,omitempty"`
+	// Set only on soft-deleted todos, which admins may fetch explicitly
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// When the todo last became completed; cleared when it is reopened
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Expected and spent effort, in minutes
+	EstimatedMinutes *int32 `protobuf:"varint,18,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	LoggedMinutes    int32  `protobuf:"varint,19,opt,name=logged_minutes,json=loggedMinutes,proto3" json:"logged_minutes,omitempty"`
+	// When to remind about the todo, and when that reminder was sent
+	RemindAt   *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	RemindedAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=reminded_at,json=remindedAt,proto3" json:"reminded_at,omitempty"`
+	// Who last completed the todo; cleared when it is reopened
+	CompletedBy string `protobuf:"bytes,22,opt,name=completed_by,json=completedBy,proto3" json:"completed_by,omitempty"`
+	// When the todo was archived; cleared when it leaves the archive
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// The todo this one is a subtask of; fixed at creation
+	ParentId      string `protobuf:"bytes,24,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -277,16 +359,99 @@ func (x *Todo) GetVersion() int64 {
 	return 0
 }
 
+func (x *Todo) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *Todo) GetDueDateTimezone() string {
+	if x != nil {
+		return x.DueDateTimezone
+	}
+	return ""
+}
+
+func (x *Todo) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Todo) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Todo) GetEstimatedMinutes() int32 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *Todo) GetLoggedMinutes() int32 {
+	if x != nil {
+		return x.LoggedMinutes
+	}
+	return 0
+}
+
+func (x *Todo) GetRemindAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindAt
+	}
+	return nil
+}
+
+func (x *Todo) GetRemindedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindedAt
+	}
+	return nil
+}
+
+func (x *Todo) GetCompletedBy() string {
+	if x != nil {
+		return x.CompletedBy
+	}
+	return ""
+}
+
+func (x *Todo) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Todo) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
 // CreateTodoRequest creates a new todo
 type CreateTodoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Priority      TodoPriority           `protobuf:"varint,4,opt,name=priority,proto3,enum=todo.v1.TodoPriority" json:"priority,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
-	AssignedTo    string                 `protobuf:"bytes,7,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Metadata         *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Priority         TodoPriority           `protobuf:"varint,4,opt,name=priority,proto3,enum=todo.v1.TodoPriority" json:"priority,omitempty"`
+	DueDate          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Tags             []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	AssignedTo       string                 `protobuf:"bytes,7,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	DueDateTimezone  string                 `protobuf:"bytes,8,opt,name=due_date_timezone,json=dueDateTimezone,proto3" json:"due_date_timezone,omitempty"`
+	EstimatedMinutes *int32                 `protobuf:"varint,9,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	RemindAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	// Creates the todo as a subtask of this one, which the caller must be
+	// allowed to update
+	ParentId      string `protobuf:"bytes,11,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -370,6 +535,34 @@ func (x *CreateTodoRequest) GetAssignedTo() string {
 	return ""
 }
 
+func (x *CreateTodoRequest) GetDueDateTimezone() string {
+	if x != nil {
+		return x.DueDateTimezone
+	}
+	return ""
+}
+
+func (x *CreateTodoRequest) GetEstimatedMinutes() int32 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *CreateTodoRequest) GetRemindAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindAt
+	}
+	return nil
+}
+
+func (x *CreateTodoRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
 type CreateTodoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
@@ -415,11 +608,13 @@ func (x *CreateTodoResponse) GetTodo() *Todo {
 }
 
 type GetTodoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id       string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Return the todo even when soft-deleted; admin only
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetTodoRequest) Reset() {
@@ -466,6 +661,13 @@ func (x *GetTodoRequest) GetId() string {
 	return ""
 }
 
+func (x *GetTodoRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
 type GetTodoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
@@ -510,14 +712,131 @@ func (x *GetTodoResponse) GetTodo() *Todo {
 	return nil
 }
 
+type GetTodoDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoDetailRequest) Reset() {
+	*x = GetTodoDetailRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoDetailRequest) ProtoMessage() {}
+
+func (x *GetTodoDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetTodoDetailRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTodoDetailRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetTodoDetailRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetTodoDetailResponse carries what a todo's detail view shows
+type GetTodoDetailResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Todo         *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	CommentCount int64                  `protobuf:"varint,2,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
+	// Live subtasks the caller may read, oldest first
+	Subtasks      []*Todo `protobuf:"bytes,3,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoDetailResponse) Reset() {
+	*x = GetTodoDetailResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoDetailResponse) ProtoMessage() {}
+
+func (x *GetTodoDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetTodoDetailResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTodoDetailResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *GetTodoDetailResponse) GetCommentCount() int64 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
+func (x *GetTodoDetailResponse) GetSubtasks() []*Todo {
+	if x != nil {
+		return x.Subtasks
+	}
+	return nil
+}
+
 type UpdateTodoRequest struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Id       string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	// Fields to update (following Google API design)
+	// Fields to update (following Google API design). Without a mask every
+	// mutable field but owner_id is replaced, as in UpsertTodo: fields unset
+	// in todo are cleared, while unspecified priority and status are kept.
 	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	Todo       *Todo                  `protobuf:"bytes,4,opt,name=todo,proto3" json:"todo,omitempty"`
-	// Version for optimistic locking
+	// Version the client last read, for optimistic locking; the update is
+	// rejected with ABORTED when the todo has changed since. 0 skips the check.
 	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -525,7 +844,7 @@ type UpdateTodoRequest struct {
 
 func (x *UpdateTodoRequest) Reset() {
 	*x = UpdateTodoRequest{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[5]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -537,7 +856,7 @@ func (x *UpdateTodoRequest) String() string {
 func (*UpdateTodoRequest) ProtoMessage() {}
 
 func (x *UpdateTodoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[5]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +869,7 @@ func (x *UpdateTodoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTodoRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTodoRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateTodoRequest) GetMetadata() *RequestMetadata {
@@ -597,7 +916,7 @@ type UpdateTodoResponse struct {
 
 func (x *UpdateTodoResponse) Reset() {
 	*x = UpdateTodoResponse{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[6]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -609,7 +928,7 @@ func (x *UpdateTodoResponse) String() string {
 func (*UpdateTodoResponse) ProtoMessage() {}
 
 func (x *UpdateTodoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[6]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,7 +941,7 @@ func (x *UpdateTodoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTodoResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTodoResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateTodoResponse) GetTodo() *Todo {
@@ -634,16 +953,19 @@ func (x *UpdateTodoResponse) GetTodo() *Todo {
 
 // DeleteTodoRequest soft-deletes a todo
 type DeleteTodoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id       string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Remove the todo and its history for good instead of soft-deleting it;
+	// requires the hard-delete permission
+	Hard          bool `protobuf:"varint,3,opt,name=hard,proto3" json:"hard,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteTodoRequest) Reset() {
 	*x = DeleteTodoRequest{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[7]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -655,7 +977,7 @@ func (x *DeleteTodoRequest) String() string {
 func (*DeleteTodoRequest) ProtoMessage() {}
 
 func (x *DeleteTodoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[7]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -668,7 +990,7 @@ func (x *DeleteTodoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTodoRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTodoRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteTodoRequest) GetMetadata() *RequestMetadata {
@@ -685,6 +1007,13 @@ func (x *DeleteTodoRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteTodoRequest) GetHard() bool {
+	if x != nil {
+		return x.Hard
+	}
+	return false
+}
+
 type DeleteTodoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -694,7 +1023,7 @@ type DeleteTodoResponse struct {
 
 func (x *DeleteTodoResponse) Reset() {
 	*x = DeleteTodoResponse{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[8]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -706,7 +1035,7 @@ func (x *DeleteTodoResponse) String() string {
 func (*DeleteTodoResponse) ProtoMessage() {}
 
 func (x *DeleteTodoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[8]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +1048,7 @@ func (x *DeleteTodoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTodoResponse.ProtoReflect.Descriptor instead.
 func (*DeleteTodoResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteTodoResponse) GetSuccess() bool {
@@ -733,7 +1062,7 @@ func (x *DeleteTodoResponse) GetSuccess() bool {
 type ListTodosRequest struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	// Pagination
+	// Pagination; a page past the end returns the last page, see PageInfo.page
 	Page     int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// Filtering
@@ -748,14 +1077,38 @@ type ListTodosRequest struct {
 	SortBy    string    `protobuf:"bytes,10,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"` // e.g., "created_at", "due_date", "priority"
 	SortOrder SortOrder `protobuf:"varint,11,opt,name=sort_order,json=sortOrder,proto3,enum=todo.v1.SortOrder" json:"sort_order,omitempty"`
 	// Search query (full-text search on title/description)
-	SearchQuery   string `protobuf:"bytes,12,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	SearchQuery string `protobuf:"bytes,12,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	// Only return todos past their due date that are not completed or archived
+	OverdueOnly bool `protobuf:"varint,13,opt,name=overdue_only,json=overdueOnly,proto3" json:"overdue_only,omitempty"`
+	// Creation and last-modification range filtering
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo   *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	// Exclude todos carrying any of these tags
+	ExcludeTagsFilter []string `protobuf:"bytes,18,rep,name=exclude_tags_filter,json=excludeTagsFilter,proto3" json:"exclude_tags_filter,omitempty"`
+	// Multi-column sorting, taking precedence over sort_by/sort_order when set
+	Sort []*SortSpec `protobuf:"bytes,19,rep,name=sort,proto3" json:"sort,omitempty"`
+	// Return only refs (id, version, updated_at) instead of full todos
+	IdsOnly bool `protobuf:"varint,20,opt,name=ids_only,json=idsOnly,proto3" json:"ids_only,omitempty"`
+	// When set, only todos without (true) or with (false) an assignee or due date
+	AssignedToIsNull *bool `protobuf:"varint,21,opt,name=assigned_to_is_null,json=assignedToIsNull,proto3,oneof" json:"assigned_to_is_null,omitempty"`
+	DueDateIsNull    *bool `protobuf:"varint,22,opt,name=due_date_is_null,json=dueDateIsNull,proto3,oneof" json:"due_date_is_null,omitempty"`
+	// Shorthands for assigned_to_filter and an owner filter naming the caller;
+	// an explicit assigned_to_filter takes precedence over assigned_to_me
+	AssignedToMe bool `protobuf:"varint,23,opt,name=assigned_to_me,json=assignedToMe,proto3" json:"assigned_to_me,omitempty"`
+	OwnedByMe    bool `protobuf:"varint,24,opt,name=owned_by_me,json=ownedByMe,proto3" json:"owned_by_me,omitempty"`
+	// Only todos in this due bucket, resolved server-side; timezone is the
+	// IANA zone its days and weeks are taken in and defaults to UTC
+	DueBucket     DueBucket `protobuf:"varint,25,opt,name=due_bucket,json=dueBucket,proto3,enum=todo.v1.DueBucket" json:"due_bucket,omitempty"`
+	Timezone      string    `protobuf:"bytes,26,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListTodosRequest) Reset() {
 	*x = ListTodosRequest{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[9]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -767,7 +1120,7 @@ func (x *ListTodosRequest) String() string {
 func (*ListTodosRequest) ProtoMessage() {}
 
 func (x *ListTodosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[9]
+	mi := &file_api_proto_v1_todo_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,7 +1133,7 @@ func (x *ListTodosRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTodosRequest.ProtoReflect.Descriptor instead.
 func (*ListTodosRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListTodosRequest) GetMetadata() *RequestMetadata {
@@ -867,29 +1220,3139 @@ func (x *ListTodosRequest) GetSearchQuery() string {
 	return ""
 }
 
-type ListTodosResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Todos         []*Todo                `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
-	PageInfo      *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListTodosResponse) Reset() {
-	*x = ListTodosResponse{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListTodosRequest) GetOverdueOnly() bool {
+	if x != nil {
+		return x.OverdueOnly
+	}
+	return false
+}
+
+func (x *ListTodosRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetExcludeTagsFilter() []string {
+	if x != nil {
+		return x.ExcludeTagsFilter
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetSort() []*SortSpec {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListTodosRequest) GetIdsOnly() bool {
+	if x != nil {
+		return x.IdsOnly
+	}
+	return false
+}
+
+func (x *ListTodosRequest) GetAssignedToIsNull() bool {
+	if x != nil && x.AssignedToIsNull != nil {
+		return *x.AssignedToIsNull
+	}
+	return false
+}
+
+func (x *ListTodosRequest) GetDueDateIsNull() bool {
+	if x != nil && x.DueDateIsNull != nil {
+		return *x.DueDateIsNull
+	}
+	return false
+}
+
+func (x *ListTodosRequest) GetAssignedToMe() bool {
+	if x != nil {
+		return x.AssignedToMe
+	}
+	return false
+}
+
+func (x *ListTodosRequest) GetOwnedByMe() bool {
+	if x != nil {
+		return x.OwnedByMe
+	}
+	return false
+}
+
+func (x *ListTodosRequest) GetDueBucket() DueBucket {
+	if x != nil {
+		return x.DueBucket
+	}
+	return DueBucket_DUE_BUCKET_UNSPECIFIED
+}
+
+func (x *ListTodosRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// TodoRef identifies a todo revision without its content
+type TodoRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoRef) Reset() {
+	*x = TodoRef{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoRef) ProtoMessage() {}
+
+func (x *TodoRef) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoRef.ProtoReflect.Descriptor instead.
+func (*TodoRef) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TodoRef) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TodoRef) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TodoRef) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListTodosResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Todos    []*Todo                `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	PageInfo *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	// Populated instead of todos when ids_only is set
+	Refs          []*TodoRef `protobuf:"bytes,3,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTodosResponse) Reset() {
+	*x = ListTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ListTodosResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTodosResponse) ProtoMessage() {}
+func (*ListTodosResponse) ProtoMessage() {}
+
+func (x *ListTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTodosResponse.ProtoReflect.Descriptor instead.
+func (*ListTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTodosResponse) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+func (x *ListTodosResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *ListTodosResponse) GetRefs() []*TodoRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+// DryRunListTodosResponse is the SQL a ListTodos request would run
+type DryRunListTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sql           string                 `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	WhereClause   string                 `protobuf:"bytes,2,opt,name=where_clause,json=whereClause,proto3" json:"where_clause,omitempty"`
+	OrderByClause string                 `protobuf:"bytes,3,opt,name=order_by_clause,json=orderByClause,proto3" json:"order_by_clause,omitempty"`
+	ArgCount      int32                  `protobuf:"varint,4,opt,name=arg_count,json=argCount,proto3" json:"arg_count,omitempty"` // the query refers to placeholders $1..$arg_count
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DryRunListTodosResponse) Reset() {
+	*x = DryRunListTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DryRunListTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunListTodosResponse) ProtoMessage() {}
+
+func (x *DryRunListTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunListTodosResponse.ProtoReflect.Descriptor instead.
+func (*DryRunListTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DryRunListTodosResponse) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *DryRunListTodosResponse) GetWhereClause() string {
+	if x != nil {
+		return x.WhereClause
+	}
+	return ""
+}
+
+func (x *DryRunListTodosResponse) GetOrderByClause() string {
+	if x != nil {
+		return x.OrderByClause
+	}
+	return ""
+}
+
+func (x *DryRunListTodosResponse) GetArgCount() int32 {
+	if x != nil {
+		return x.ArgCount
+	}
+	return 0
+}
+
+// AnalyzeListTodosResponse is the executed plan of a ListTodos request
+type AnalyzeListTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlanJson      string                 `protobuf:"bytes,1,opt,name=plan_json,json=planJson,proto3" json:"plan_json,omitempty"` // output of EXPLAIN (ANALYZE, FORMAT JSON)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeListTodosResponse) Reset() {
+	*x = AnalyzeListTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeListTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeListTodosResponse) ProtoMessage() {}
+
+func (x *AnalyzeListTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeListTodosResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeListTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AnalyzeListTodosResponse) GetPlanJson() string {
+	if x != nil {
+		return x.PlanJson
+	}
+	return ""
+}
+
+// UpdateTodoStatusRequest handles state transitions
+type UpdateTodoStatusRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Metadata  *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id        string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	NewStatus TodoStatus             `protobuf:"varint,3,opt,name=new_status,json=newStatus,proto3,enum=todo.v1.TodoStatus" json:"new_status,omitempty"`
+	// Optional reason for status change (audit trail)
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Version for optimistic locking
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTodoStatusRequest) Reset() {
+	*x = UpdateTodoStatusRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTodoStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTodoStatusRequest) ProtoMessage() {}
+
+func (x *UpdateTodoStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTodoStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTodoStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateTodoStatusRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateTodoStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTodoStatusRequest) GetNewStatus() TodoStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return TodoStatus_TODO_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateTodoStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateTodoStatusRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateTodoStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTodoStatusResponse) Reset() {
+	*x = UpdateTodoStatusResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTodoStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTodoStatusResponse) ProtoMessage() {}
+
+func (x *UpdateTodoStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTodoStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTodoStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateTodoStatusResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+// SnoozeTodoRequest moves a todo's due date forward
+type SnoozeTodoRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id       string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Duration *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Snooze an overdue todo relative to now instead of its old due date
+	FromNow bool `protobuf:"varint,4,opt,name=from_now,json=fromNow,proto3" json:"from_now,omitempty"`
+	// Version for optimistic locking, unchecked when 0
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeTodoRequest) Reset() {
+	*x = SnoozeTodoRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeTodoRequest) ProtoMessage() {}
+
+func (x *SnoozeTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeTodoRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeTodoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SnoozeTodoRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SnoozeTodoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SnoozeTodoRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *SnoozeTodoRequest) GetFromNow() bool {
+	if x != nil {
+		return x.FromNow
+	}
+	return false
+}
+
+func (x *SnoozeTodoRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SnoozeTodoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeTodoResponse) Reset() {
+	*x = SnoozeTodoResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeTodoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeTodoResponse) ProtoMessage() {}
+
+func (x *SnoozeTodoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeTodoResponse.ProtoReflect.Descriptor instead.
+func (*SnoozeTodoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SnoozeTodoResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+// LogTimeRequest adds spent effort to a todo
+type LogTimeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id       string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Minutes  int32                  `protobuf:"varint,3,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	// Version for optimistic locking, unchecked when 0
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogTimeRequest) Reset() {
+	*x = LogTimeRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogTimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogTimeRequest) ProtoMessage() {}
+
+func (x *LogTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogTimeRequest.ProtoReflect.Descriptor instead.
+func (*LogTimeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LogTimeRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *LogTimeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LogTimeRequest) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *LogTimeRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type LogTimeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogTimeResponse) Reset() {
+	*x = LogTimeResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogTimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogTimeResponse) ProtoMessage() {}
+
+func (x *LogTimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogTimeResponse.ProtoReflect.Descriptor instead.
+func (*LogTimeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LogTimeResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+// BatchCreateTodosRequest for bulk operations
+type BatchCreateTodosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Requests      []*CreateTodoRequest   `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTodosRequest) Reset() {
+	*x = BatchCreateTodosRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTodosRequest) ProtoMessage() {}
+
+func (x *BatchCreateTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTodosRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTodosRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BatchCreateTodosRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BatchCreateTodosRequest) GetRequests() []*CreateTodoRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type BatchCreateTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todos         []*Todo                `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	Errors        []*ErrorDetail         `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTodosResponse) Reset() {
+	*x = BatchCreateTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTodosResponse) ProtoMessage() {}
+
+func (x *BatchCreateTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTodosResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BatchCreateTodosResponse) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+func (x *BatchCreateTodosResponse) GetErrors() []*ErrorDetail {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// TodoHistoryEntry is a single recorded change to a todo
+type TodoHistoryEntry struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TodoId     string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	ActorId    string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ChangeType string                 `protobuf:"bytes,4,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"` // CREATED, UPDATED, STATUS_CHANGED or DELETED
+	// JSON object keyed by field name with {"old": ..., "new": ...} values
+	Diff          string                 `protobuf:"bytes,5,opt,name=diff,proto3" json:"diff,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoHistoryEntry) Reset() {
+	*x = TodoHistoryEntry{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoHistoryEntry) ProtoMessage() {}
+
+func (x *TodoHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoHistoryEntry.ProtoReflect.Descriptor instead.
+func (*TodoHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TodoHistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TodoHistoryEntry) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *TodoHistoryEntry) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *TodoHistoryEntry) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *TodoHistoryEntry) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *TodoHistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetTodoHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 50, capped at 200
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoHistoryRequest) Reset() {
+	*x = GetTodoHistoryRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoHistoryRequest) ProtoMessage() {}
+
+func (x *GetTodoHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTodoHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTodoHistoryRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetTodoHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTodoHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetTodoHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*TodoHistoryEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoHistoryResponse) Reset() {
+	*x = GetTodoHistoryResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoHistoryResponse) ProtoMessage() {}
+
+func (x *GetTodoHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTodoHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTodoHistoryResponse) GetEntries() []*TodoHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetTodoStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoStatsRequest) Reset() {
+	*x = GetTodoStatsRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoStatsRequest) ProtoMessage() {}
+
+func (x *GetTodoStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTodoStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTodoStatsRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// StatusCount is the number of todos in a given status
+type StatusCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        TodoStatus             `protobuf:"varint,1,opt,name=status,proto3,enum=todo.v1.TodoStatus" json:"status,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusCount) Reset() {
+	*x = StatusCount{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusCount) ProtoMessage() {}
+
+func (x *StatusCount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusCount.ProtoReflect.Descriptor instead.
+func (*StatusCount) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StatusCount) GetStatus() TodoStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TodoStatus_TODO_STATUS_UNSPECIFIED
+}
+
+func (x *StatusCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetTodoStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*StatusCount         `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"` // One entry per status, including zero counts
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTodoStatsResponse) Reset() {
+	*x = GetTodoStatsResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTodoStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTodoStatsResponse) ProtoMessage() {}
+
+func (x *GetTodoStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTodoStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTodoStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTodoStatsResponse) GetCounts() []*StatusCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *GetTodoStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetWorkloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkloadRequest) Reset() {
+	*x = GetWorkloadRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkloadRequest) ProtoMessage() {}
+
+func (x *GetWorkloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkloadRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkloadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetWorkloadRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// AssigneeWorkload counts the todos assigned to one user
+type AssigneeWorkload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssigneeId    string                 `protobuf:"bytes,1,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"` // Empty for the unassigned bucket
+	Counts        []*StatusCount         `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty"`                           // One entry per status, including zero counts
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssigneeWorkload) Reset() {
+	*x = AssigneeWorkload{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssigneeWorkload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssigneeWorkload) ProtoMessage() {}
+
+func (x *AssigneeWorkload) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssigneeWorkload.ProtoReflect.Descriptor instead.
+func (*AssigneeWorkload) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AssigneeWorkload) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *AssigneeWorkload) GetCounts() []*StatusCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *AssigneeWorkload) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetWorkloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignees     []*AssigneeWorkload    `protobuf:"bytes,1,rep,name=assignees,proto3" json:"assignees,omitempty"` // Busiest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkloadResponse) Reset() {
+	*x = GetWorkloadResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkloadResponse) ProtoMessage() {}
+
+func (x *GetWorkloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkloadResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkloadResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetWorkloadResponse) GetAssignees() []*AssigneeWorkload {
+	if x != nil {
+		return x.Assignees
+	}
+	return nil
+}
+
+// BatchGetTodosRequest fetches several todos in one round trip
+type BatchGetTodosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"` // At most 100 ids
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetTodosRequest) Reset() {
+	*x = BatchGetTodosRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTodosRequest) ProtoMessage() {}
+
+func (x *BatchGetTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTodosRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetTodosRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BatchGetTodosRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BatchGetTodosRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Readable todos in request order; missing or forbidden ids are omitted
+	Todos         []*Todo `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetTodosResponse) Reset() {
+	*x = BatchGetTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTodosResponse) ProtoMessage() {}
+
+func (x *BatchGetTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTodosResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BatchGetTodosResponse) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+// UpsertTodoRequest creates todo with its client-generated id, or replaces
+// the stored todo when version matches its current version
+type UpsertTodoRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Todo     *Todo                  `protobuf:"bytes,2,opt,name=todo,proto3" json:"todo,omitempty"` // id must be a UUID
+	// Current version of the stored todo; 0 when the client expects to create it
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertTodoRequest) Reset() {
+	*x = UpsertTodoRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertTodoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertTodoRequest) ProtoMessage() {}
+
+func (x *UpsertTodoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertTodoRequest.ProtoReflect.Descriptor instead.
+func (*UpsertTodoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpsertTodoRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpsertTodoRequest) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *UpsertTodoRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpsertTodoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertTodoResponse) Reset() {
+	*x = UpsertTodoResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertTodoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertTodoResponse) ProtoMessage() {}
+
+func (x *UpsertTodoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertTodoResponse.ProtoReflect.Descriptor instead.
+func (*UpsertTodoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpsertTodoResponse) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *UpsertTodoResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// BulkDeleteTodosRequest soft-deletes every todo matching the filters. At
+// least one filter must be set; an empty request is rejected.
+type BulkDeleteTodosRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Metadata         *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	StatusFilter     []TodoStatus           `protobuf:"varint,2,rep,packed,name=status_filter,json=statusFilter,proto3,enum=todo.v1.TodoStatus" json:"status_filter,omitempty"`
+	PriorityFilter   []TodoPriority         `protobuf:"varint,3,rep,packed,name=priority_filter,json=priorityFilter,proto3,enum=todo.v1.TodoPriority" json:"priority_filter,omitempty"`
+	TagsFilter       []string               `protobuf:"bytes,4,rep,name=tags_filter,json=tagsFilter,proto3" json:"tags_filter,omitempty"`
+	AssignedToFilter string                 `protobuf:"bytes,5,opt,name=assigned_to_filter,json=assignedToFilter,proto3" json:"assigned_to_filter,omitempty"`
+	DueDateFrom      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date_from,json=dueDateFrom,proto3" json:"due_date_from,omitempty"`
+	DueDateTo        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_date_to,json=dueDateTo,proto3" json:"due_date_to,omitempty"`
+	SearchQuery      string                 `protobuf:"bytes,8,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	OverdueOnly      bool                   `protobuf:"varint,9,opt,name=overdue_only,json=overdueOnly,proto3" json:"overdue_only,omitempty"`
+	// The remaining filters of ListTodosRequest, with the same meaning
+	CreatedFrom       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	ExcludeTagsFilter []string               `protobuf:"bytes,14,rep,name=exclude_tags_filter,json=excludeTagsFilter,proto3" json:"exclude_tags_filter,omitempty"`
+	AssignedToIsNull  *bool                  `protobuf:"varint,15,opt,name=assigned_to_is_null,json=assignedToIsNull,proto3,oneof" json:"assigned_to_is_null,omitempty"`
+	DueDateIsNull     *bool                  `protobuf:"varint,16,opt,name=due_date_is_null,json=dueDateIsNull,proto3,oneof" json:"due_date_is_null,omitempty"`
+	DueBucket         DueBucket              `protobuf:"varint,17,opt,name=due_bucket,json=dueBucket,proto3,enum=todo.v1.DueBucket" json:"due_bucket,omitempty"`
+	Timezone          string                 `protobuf:"bytes,18,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BulkDeleteTodosRequest) Reset() {
+	*x = BulkDeleteTodosRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteTodosRequest) ProtoMessage() {}
+
+func (x *BulkDeleteTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteTodosRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTodosRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BulkDeleteTodosRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetStatusFilter() []TodoStatus {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetPriorityFilter() []TodoPriority {
+	if x != nil {
+		return x.PriorityFilter
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetTagsFilter() []string {
+	if x != nil {
+		return x.TagsFilter
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetAssignedToFilter() string {
+	if x != nil {
+		return x.AssignedToFilter
+	}
+	return ""
+}
+
+func (x *BulkDeleteTodosRequest) GetDueDateFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDateFrom
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetDueDateTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDateTo
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetSearchQuery() string {
+	if x != nil {
+		return x.SearchQuery
+	}
+	return ""
+}
+
+func (x *BulkDeleteTodosRequest) GetOverdueOnly() bool {
+	if x != nil {
+		return x.OverdueOnly
+	}
+	return false
+}
+
+func (x *BulkDeleteTodosRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetExcludeTagsFilter() []string {
+	if x != nil {
+		return x.ExcludeTagsFilter
+	}
+	return nil
+}
+
+func (x *BulkDeleteTodosRequest) GetAssignedToIsNull() bool {
+	if x != nil && x.AssignedToIsNull != nil {
+		return *x.AssignedToIsNull
+	}
+	return false
+}
+
+func (x *BulkDeleteTodosRequest) GetDueDateIsNull() bool {
+	if x != nil && x.DueDateIsNull != nil {
+		return *x.DueDateIsNull
+	}
+	return false
+}
+
+func (x *BulkDeleteTodosRequest) GetDueBucket() DueBucket {
+	if x != nil {
+		return x.DueBucket
+	}
+	return DueBucket_DUE_BUCKET_UNSPECIFIED
+}
+
+func (x *BulkDeleteTodosRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type BulkDeleteTodosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedCount  int64                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteTodosResponse) Reset() {
+	*x = BulkDeleteTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteTodosResponse) ProtoMessage() {}
+
+func (x *BulkDeleteTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteTodosResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BulkDeleteTodosResponse) GetDeletedCount() int64 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"` // matched case-insensitively; empty lists every tag
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`  // Defaults to 20, capped at 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListTagsRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListTagsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"` // Most used first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddTagToTodosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"` // At most 100 ids
+	Tag           string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagToTodosRequest) Reset() {
+	*x = AddTagToTodosRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagToTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagToTodosRequest) ProtoMessage() {}
+
+func (x *AddTagToTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagToTodosRequest.ProtoReflect.Descriptor instead.
+func (*AddTagToTodosRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddTagToTodosRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AddTagToTodosRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *AddTagToTodosRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type AddTagToTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Todos that did not have the tag yet; missing and deleted ids are skipped
+	UpdatedCount  int64 `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagToTodosResponse) Reset() {
+	*x = AddTagToTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagToTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagToTodosResponse) ProtoMessage() {}
+
+func (x *AddTagToTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagToTodosResponse.ProtoReflect.Descriptor instead.
+func (*AddTagToTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AddTagToTodosResponse) GetUpdatedCount() int64 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+type RemoveTagFromTodosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"` // At most 100 ids
+	Tag           string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagFromTodosRequest) Reset() {
+	*x = RemoveTagFromTodosRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagFromTodosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagFromTodosRequest) ProtoMessage() {}
+
+func (x *RemoveTagFromTodosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagFromTodosRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagFromTodosRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RemoveTagFromTodosRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RemoveTagFromTodosRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *RemoveTagFromTodosRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type RemoveTagFromTodosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Todos that had the tag; missing and deleted ids are skipped
+	UpdatedCount  int64 `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagFromTodosResponse) Reset() {
+	*x = RemoveTagFromTodosResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagFromTodosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagFromTodosResponse) ProtoMessage() {}
+
+func (x *RemoveTagFromTodosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagFromTodosResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTagFromTodosResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RemoveTagFromTodosResponse) GetUpdatedCount() int64 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+type ListDueSoonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Within        *durationpb.Duration   `protobuf:"bytes,2,opt,name=within,proto3" json:"within,omitempty"` // Defaults to 7 days, capped at 90 days
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDueSoonRequest) Reset() {
+	*x = ListDueSoonRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDueSoonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDueSoonRequest) ProtoMessage() {}
+
+func (x *ListDueSoonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDueSoonRequest.ProtoReflect.Descriptor instead.
+func (*ListDueSoonRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListDueSoonRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListDueSoonRequest) GetWithin() *durationpb.Duration {
+	if x != nil {
+		return x.Within
+	}
+	return nil
+}
+
+type ListDueSoonResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todos         []*Todo                `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"` // Open todos not yet overdue, soonest due first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDueSoonResponse) Reset() {
+	*x = ListDueSoonResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDueSoonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDueSoonResponse) ProtoMessage() {}
+
+func (x *ListDueSoonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDueSoonResponse.ProtoReflect.Descriptor instead.
+func (*ListDueSoonResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListDueSoonResponse) GetTodos() []*Todo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+type ListArchivedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedRequest) Reset() {
+	*x = ListArchivedRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedRequest) ProtoMessage() {}
+
+func (x *ListArchivedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListArchivedRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListArchivedRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListArchivedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ArchivedTodo is an archived todo and when it is due to be purged
+type ArchivedTodo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	PurgeAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"` // Unset when archived todos are kept indefinitely
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivedTodo) Reset() {
+	*x = ArchivedTodo{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivedTodo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivedTodo) ProtoMessage() {}
+
+func (x *ArchivedTodo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivedTodo.ProtoReflect.Descriptor instead.
+func (*ArchivedTodo) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ArchivedTodo) GetTodo() *Todo {
+	if x != nil {
+		return x.Todo
+	}
+	return nil
+}
+
+func (x *ArchivedTodo) GetPurgeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return nil
+}
+
+type ListArchivedResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Todos    []*ArchivedTodo        `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"` // Most recently archived first
+	PageInfo *PageInfo              `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	// How long todos stay archived before they are purged; unset when they
+	// are kept indefinitely
+	Retention     *durationpb.Duration `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedResponse) Reset() {
+	*x = ListArchivedResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedResponse) ProtoMessage() {}
+
+func (x *ListArchivedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedResponse.ProtoReflect.Descriptor instead.
+func (*ListArchivedResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListArchivedResponse) GetTodos() []*ArchivedTodo {
+	if x != nil {
+		return x.Todos
+	}
+	return nil
+}
+
+func (x *ListArchivedResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *ListArchivedResponse) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+// TodoComment is a comment left on a todo
+type TodoComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoComment) Reset() {
+	*x = TodoComment{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoComment) ProtoMessage() {}
+
+func (x *TodoComment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoComment.ProtoReflect.Descriptor instead.
+func (*TodoComment) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TodoComment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TodoComment) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *TodoComment) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *TodoComment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *TodoComment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AddCommentRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AddCommentRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AddCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *TodoComment           `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentResponse) ProtoMessage() {}
+
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AddCommentResponse) GetComment() *TodoComment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 50, capped at 200
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListCommentsRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListCommentsRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*TodoComment         `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListCommentsResponse) GetComments() []*TodoComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteCommentRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DeleteCommentRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *DeleteCommentRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+type DeleteCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentResponse) ProtoMessage() {}
+
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeleteCommentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// AddDependencyRequest marks todo_id as blocked by depends_on_id
+type AddDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	DependsOnId   string                 `protobuf:"bytes,3,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AddDependencyRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AddDependencyRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetDependsOnId() string {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return ""
+}
+
+type AddDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyResponse) Reset() {
+	*x = AddDependencyResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyResponse) ProtoMessage() {}
+
+func (x *AddDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyResponse.ProtoReflect.Descriptor instead.
+func (*AddDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AddDependencyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RemoveDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	DependsOnId   string                 `protobuf:"bytes,3,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyRequest) ProtoMessage() {}
+
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RemoveDependencyRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RemoveDependencyRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetDependsOnId() string {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return ""
+}
+
+type RemoveDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyResponse) Reset() {
+	*x = RemoveDependencyResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyResponse) ProtoMessage() {}
+
+func (x *RemoveDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RemoveDependencyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListDependenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDependenciesRequest) Reset() {
+	*x = ListDependenciesRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDependenciesRequest) ProtoMessage() {}
+
+func (x *ListDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListDependenciesRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListDependenciesRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+type ListDependenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedBy     []*Todo                `protobuf:"bytes,1,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"` // Todos this todo waits on, oldest first
+	Blocks        []*Todo                `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`                        // Todos waiting on this todo, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDependenciesResponse) Reset() {
+	*x = ListDependenciesResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDependenciesResponse) ProtoMessage() {}
+
+func (x *ListDependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDependenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListDependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListDependenciesResponse) GetBlockedBy() []*Todo {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+func (x *ListDependenciesResponse) GetBlocks() []*Todo {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+// TodoAttachment is a file attached to a todo
+type TodoAttachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	UploadedBy    string                 `protobuf:"bytes,6,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TodoAttachment) Reset() {
+	*x = TodoAttachment{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TodoAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TodoAttachment) ProtoMessage() {}
+
+func (x *TodoAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TodoAttachment.ProtoReflect.Descriptor instead.
+func (*TodoAttachment) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *TodoAttachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TodoAttachment) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
+	}
+	return ""
+}
+
+func (x *TodoAttachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *TodoAttachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *TodoAttachment) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *TodoAttachment) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *TodoAttachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateAttachmentUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttachmentUploadURLRequest) Reset() {
+	*x = CreateAttachmentUploadURLRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttachmentUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttachmentUploadURLRequest) ProtoMessage() {}
 
-func (x *ListTodosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[10]
+func (x *CreateAttachmentUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,54 +4363,72 @@ func (x *ListTodosResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTodosResponse.ProtoReflect.Descriptor instead.
-func (*ListTodosResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CreateAttachmentUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *ListTodosResponse) GetTodos() []*Todo {
+func (x *CreateAttachmentUploadURLRequest) GetMetadata() *RequestMetadata {
 	if x != nil {
-		return x.Todos
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *ListTodosResponse) GetPageInfo() *PageInfo {
+func (x *CreateAttachmentUploadURLRequest) GetTodoId() string {
 	if x != nil {
-		return x.PageInfo
+		return x.TodoId
 	}
-	return nil
+	return ""
 }
 
-// UpdateTodoStatusRequest handles state transitions
-type UpdateTodoStatusRequest struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	Metadata  *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Id        string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	NewStatus TodoStatus             `protobuf:"varint,3,opt,name=new_status,json=newStatus,proto3,enum=todo.v1.TodoStatus" json:"new_status,omitempty"`
-	// Optional reason for status change (audit trail)
-	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
-	// Version for optimistic locking
-	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+func (x *CreateAttachmentUploadURLRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+// CreateAttachmentUploadURLResponse holds a presigned URL the client PUTs
+// the file to before calling ConfirmAttachmentUpload with object_key
+type CreateAttachmentUploadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadUrl     string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTodoStatusRequest) Reset() {
-	*x = UpdateTodoStatusRequest{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[11]
+func (x *CreateAttachmentUploadURLResponse) Reset() {
+	*x = CreateAttachmentUploadURLResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTodoStatusRequest) String() string {
+func (x *CreateAttachmentUploadURLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTodoStatusRequest) ProtoMessage() {}
+func (*CreateAttachmentUploadURLResponse) ProtoMessage() {}
 
-func (x *UpdateTodoStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[11]
+func (x *CreateAttachmentUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -958,68 +4439,114 @@ func (x *UpdateTodoStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTodoStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTodoStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CreateAttachmentUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *UpdateTodoStatusRequest) GetMetadata() *RequestMetadata {
+func (x *CreateAttachmentUploadURLResponse) GetUploadUrl() string {
 	if x != nil {
-		return x.Metadata
+		return x.UploadUrl
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateTodoStatusRequest) GetId() string {
+func (x *CreateAttachmentUploadURLResponse) GetObjectKey() string {
 	if x != nil {
-		return x.Id
+		return x.ObjectKey
 	}
 	return ""
 }
 
-func (x *UpdateTodoStatusRequest) GetNewStatus() TodoStatus {
+func (x *CreateAttachmentUploadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.NewStatus
+		return x.ExpiresAt
 	}
-	return TodoStatus_TODO_STATUS_UNSPECIFIED
+	return nil
 }
 
-func (x *UpdateTodoStatusRequest) GetReason() string {
+type ConfirmAttachmentUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,3,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmAttachmentUploadRequest) Reset() {
+	*x = ConfirmAttachmentUploadRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmAttachmentUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmAttachmentUploadRequest) ProtoMessage() {}
+
+func (x *ConfirmAttachmentUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[66]
 	if x != nil {
-		return x.Reason
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmAttachmentUploadRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmAttachmentUploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ConfirmAttachmentUploadRequest) GetMetadata() *RequestMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ConfirmAttachmentUploadRequest) GetTodoId() string {
+	if x != nil {
+		return x.TodoId
 	}
 	return ""
 }
 
-func (x *UpdateTodoStatusRequest) GetVersion() int64 {
+func (x *ConfirmAttachmentUploadRequest) GetObjectKey() string {
 	if x != nil {
-		return x.Version
+		return x.ObjectKey
 	}
-	return 0
+	return ""
 }
 
-type UpdateTodoStatusResponse struct {
+type ConfirmAttachmentUploadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Todo          *Todo                  `protobuf:"bytes,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	Attachment    *TodoAttachment        `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTodoStatusResponse) Reset() {
-	*x = UpdateTodoStatusResponse{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[12]
+func (x *ConfirmAttachmentUploadResponse) Reset() {
+	*x = ConfirmAttachmentUploadResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTodoStatusResponse) String() string {
+func (x *ConfirmAttachmentUploadResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTodoStatusResponse) ProtoMessage() {}
+func (*ConfirmAttachmentUploadResponse) ProtoMessage() {}
 
-func (x *UpdateTodoStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[12]
+func (x *ConfirmAttachmentUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1030,42 +4557,41 @@ func (x *UpdateTodoStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTodoStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTodoStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ConfirmAttachmentUploadResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmAttachmentUploadResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *UpdateTodoStatusResponse) GetTodo() *Todo {
+func (x *ConfirmAttachmentUploadResponse) GetAttachment() *TodoAttachment {
 	if x != nil {
-		return x.Todo
+		return x.Attachment
 	}
 	return nil
 }
 
-// BatchCreateTodosRequest for bulk operations
-type BatchCreateTodosRequest struct {
+type ListAttachmentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *RequestMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Requests      []*CreateTodoRequest   `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	TodoId        string                 `protobuf:"bytes,2,opt,name=todo_id,json=todoId,proto3" json:"todo_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchCreateTodosRequest) Reset() {
-	*x = BatchCreateTodosRequest{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[13]
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchCreateTodosRequest) String() string {
+func (x *ListAttachmentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchCreateTodosRequest) ProtoMessage() {}
+func (*ListAttachmentsRequest) ProtoMessage() {}
 
-func (x *BatchCreateTodosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[13]
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1076,48 +4602,47 @@ func (x *BatchCreateTodosRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchCreateTodosRequest.ProtoReflect.Descriptor instead.
-func (*BatchCreateTodosRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *BatchCreateTodosRequest) GetMetadata() *RequestMetadata {
+func (x *ListAttachmentsRequest) GetMetadata() *RequestMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *BatchCreateTodosRequest) GetRequests() []*CreateTodoRequest {
+func (x *ListAttachmentsRequest) GetTodoId() string {
 	if x != nil {
-		return x.Requests
+		return x.TodoId
 	}
-	return nil
+	return ""
 }
 
-type BatchCreateTodosResponse struct {
+type ListAttachmentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Todos         []*Todo                `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
-	Errors        []*ErrorDetail         `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Attachments   []*TodoAttachment      `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"` // Oldest first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchCreateTodosResponse) Reset() {
-	*x = BatchCreateTodosResponse{}
-	mi := &file_api_proto_v1_todo_proto_msgTypes[14]
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchCreateTodosResponse) String() string {
+func (x *ListAttachmentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchCreateTodosResponse) ProtoMessage() {}
+func (*ListAttachmentsResponse) ProtoMessage() {}
 
-func (x *BatchCreateTodosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_todo_proto_msgTypes[14]
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,38 +4653,137 @@ func (x *BatchCreateTodosResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchCreateTodosResponse.ProtoReflect.Descriptor instead.
-func (*BatchCreateTodosResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *BatchCreateTodosResponse) GetTodos() []*Todo {
+func (x *ListAttachmentsResponse) GetAttachments() []*TodoAttachment {
 	if x != nil {
-		return x.Todos
+		return x.Attachments
 	}
 	return nil
 }
 
-func (x *BatchCreateTodosResponse) GetErrors() []*ErrorDetail {
+// MoveTodosToTenantRequest moves todos of one tenant to another, for account
+// merges. Only platform admins may call it.
+type MoveTodosToTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // At most 100 ids; ids not in from_tenant_id are skipped
+	FromTenantId  string                 `protobuf:"bytes,2,opt,name=from_tenant_id,json=fromTenantId,proto3" json:"from_tenant_id,omitempty"`
+	ToTenantId    string                 `protobuf:"bytes,3,opt,name=to_tenant_id,json=toTenantId,proto3" json:"to_tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTodosToTenantRequest) Reset() {
+	*x = MoveTodosToTenantRequest{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTodosToTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTodosToTenantRequest) ProtoMessage() {}
+
+func (x *MoveTodosToTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[70]
 	if x != nil {
-		return x.Errors
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTodosToTenantRequest.ProtoReflect.Descriptor instead.
+func (*MoveTodosToTenantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *MoveTodosToTenantRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
 	}
 	return nil
 }
 
+func (x *MoveTodosToTenantRequest) GetFromTenantId() string {
+	if x != nil {
+		return x.FromTenantId
+	}
+	return ""
+}
+
+func (x *MoveTodosToTenantRequest) GetToTenantId() string {
+	if x != nil {
+		return x.ToTenantId
+	}
+	return ""
+}
+
+type MoveTodosToTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovedCount    int64                  `protobuf:"varint,1,opt,name=moved_count,json=movedCount,proto3" json:"moved_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTodosToTenantResponse) Reset() {
+	*x = MoveTodosToTenantResponse{}
+	mi := &file_api_proto_v1_todo_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTodosToTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTodosToTenantResponse) ProtoMessage() {}
+
+func (x *MoveTodosToTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_todo_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTodosToTenantResponse.ProtoReflect.Descriptor instead.
+func (*MoveTodosToTenantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_todo_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *MoveTodosToTenantResponse) GetMovedCount() int64 {
+	if x != nil {
+		return x.MovedCount
+	}
+	return 0
+}
+
 var File_api_proto_v1_todo_proto protoreflect.FileDescriptor
 
 const file_api_proto_v1_todo_proto_rawDesc = "" +
 	"\n" +
-	"\x17api/proto/v1/todo.proto\x12\atodo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x19api/proto/v1/common.proto\"\xe2\x03\n" +
+	"\x17api/proto/v1/todo.proto\x12\atodo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x19api/proto/v1/common.proto\x1a\x1bapi/proto/v1/validate.proto\"\xb1\b\n" +
 	"\x04Todo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12+\n" +
-	"\x06status\x18\x04 \x01(\x0e2\x13.todo.v1.TodoStatusR\x06status\x121\n" +
-	"\bpriority\x18\x05 \x01(\x0e2\x15.todo.v1.TodoPriorityR\bpriority\x125\n" +
-	"\bdue_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x12\n" +
-	"\x04tags\x18\a \x03(\tR\x04tags\x12\x19\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\x05title\x18\x02 \x01(\tB\a\x8a\xb5\x18\x03\b\xe8\aR\x05title\x12)\n" +
+	"\vdescription\x18\x03 \x01(\tB\a\x8a\xb5\x18\x03\b\x90NR\vdescription\x123\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x13.todo.v1.TodoStatusB\x06\x8a\xb5\x18\x02 \x01R\x06status\x129\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x15.todo.v1.TodoPriorityB\x06\x8a\xb5\x18\x02 \x01R\bpriority\x125\n" +
+	"\bdue_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x1d\n" +
+	"\x04tags\x18\a \x03(\tB\t\x8a\xb5\x18\x05\b\xc8\x01(dR\x04tags\x12\x19\n" +
 	"\bowner_id\x18\b \x01(\tR\aownerId\x12\x1f\n" +
 	"\vassigned_to\x18\t \x01(\tR\n" +
 	"assignedTo\x12\x1b\n" +
@@ -1169,23 +4793,52 @@ const file_api_proto_v1_todo_proto_rawDesc = "" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
-	"\aversion\x18\r \x01(\x03R\aversion\"\xa0\x02\n" +
+	"\aversion\x18\r \x01(\x03R\aversion\x12\x18\n" +
+	"\aoverdue\x18\x0e \x01(\bR\aoverdue\x12*\n" +
+	"\x11due_date_timezone\x18\x0f \x01(\tR\x0fdueDateTimezone\x129\n" +
+	"\n" +
+	"deleted_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12=\n" +
+	"\fcompleted_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x120\n" +
+	"\x11estimated_minutes\x18\x12 \x01(\x05H\x00R\x10estimatedMinutes\x88\x01\x01\x12%\n" +
+	"\x0elogged_minutes\x18\x13 \x01(\x05R\rloggedMinutes\x127\n" +
+	"\tremind_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\bremindAt\x12;\n" +
+	"\vreminded_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"remindedAt\x12!\n" +
+	"\fcompleted_by\x18\x16 \x01(\tR\vcompletedBy\x12;\n" +
+	"\varchived_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x12\x1b\n" +
+	"\tparent_id\x18\x18 \x01(\tR\bparentIdB\x14\n" +
+	"\x12_estimated_minutes\"\x8f\x04\n" +
 	"\x11CreateTodoRequest\x124\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x121\n" +
-	"\bpriority\x18\x04 \x01(\x0e2\x15.todo.v1.TodoPriorityR\bpriority\x125\n" +
-	"\bdue_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x12\n" +
-	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1f\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x1d\n" +
+	"\x05title\x18\x02 \x01(\tB\a\x8a\xb5\x18\x03\b\xe8\aR\x05title\x12)\n" +
+	"\vdescription\x18\x03 \x01(\tB\a\x8a\xb5\x18\x03\b\x90NR\vdescription\x129\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x15.todo.v1.TodoPriorityB\x06\x8a\xb5\x18\x02 \x01R\bpriority\x125\n" +
+	"\bdue_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x1d\n" +
+	"\x04tags\x18\x06 \x03(\tB\t\x8a\xb5\x18\x05\b\xc8\x01(dR\x04tags\x12\x1f\n" +
 	"\vassigned_to\x18\a \x01(\tR\n" +
-	"assignedTo\"7\n" +
+	"assignedTo\x12*\n" +
+	"\x11due_date_timezone\x18\b \x01(\tR\x0fdueDateTimezone\x120\n" +
+	"\x11estimated_minutes\x18\t \x01(\x05H\x00R\x10estimatedMinutes\x88\x01\x01\x127\n" +
+	"\tremind_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bremindAt\x12\x1b\n" +
+	"\tparent_id\x18\v \x01(\tR\bparentIdB\x14\n" +
+	"\x12_estimated_minutes\"7\n" +
 	"\x12CreateTodoResponse\x12!\n" +
-	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"V\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"\x7f\n" +
 	"\x0eGetTodoRequest\x124\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\"4\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bR\x0eincludeDeleted\"4\n" +
 	"\x0fGetTodoResponse\x12!\n" +
-	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"\xd3\x01\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"\\\n" +
+	"\x14GetTodoDetailRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x8a\x01\n" +
+	"\x15GetTodoDetailResponse\x12!\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\x12#\n" +
+	"\rcomment_count\x18\x02 \x01(\x03R\fcommentCount\x12)\n" +
+	"\bsubtasks\x18\x03 \x03(\v2\r.todo.v1.TodoR\bsubtasks\"\xd3\x01\n" +
 	"\x11UpdateTodoRequest\x124\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\tR\x02id\x12;\n" +
@@ -1194,18 +4847,20 @@ const file_api_proto_v1_todo_proto_rawDesc = "" +
 	"\x04todo\x18\x04 \x01(\v2\r.todo.v1.TodoR\x04todo\x12\x18\n" +
 	"\aversion\x18\x05 \x01(\x03R\aversion\"7\n" +
 	"\x12UpdateTodoResponse\x12!\n" +
-	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"Y\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"m\n" +
 	"\x11DeleteTodoRequest\x124\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\".\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04hard\x18\x03 \x01(\bR\x04hard\".\n" +
 	"\x12DeleteTodoResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xad\x04\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x85\n" +
+	"\n" +
 	"\x10ListTodosRequest\x124\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x128\n" +
-	"\rstatus_filter\x18\x04 \x03(\x0e2\x13.todo.v1.TodoStatusR\fstatusFilter\x12>\n" +
-	"\x0fpriority_filter\x18\x05 \x03(\x0e2\x15.todo.v1.TodoPriorityR\x0epriorityFilter\x12\x1f\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x1a\n" +
+	"\x04page\x18\x02 \x01(\x05B\x06\x8a\xb5\x18\x02\x10\x00R\x04page\x12&\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\t\x8a\xb5\x18\x05\x10\x00\x18\xe8\aR\bpageSize\x12@\n" +
+	"\rstatus_filter\x18\x04 \x03(\x0e2\x13.todo.v1.TodoStatusB\x06\x8a\xb5\x18\x02 \x01R\fstatusFilter\x12F\n" +
+	"\x0fpriority_filter\x18\x05 \x03(\x0e2\x15.todo.v1.TodoPriorityB\x06\x8a\xb5\x18\x02 \x01R\x0epriorityFilter\x12\x1f\n" +
 	"\vtags_filter\x18\x06 \x03(\tR\n" +
 	"tagsFilter\x12,\n" +
 	"\x12assigned_to_filter\x18\a \x01(\tR\x10assignedToFilter\x12>\n" +
@@ -1215,25 +4870,270 @@ const file_api_proto_v1_todo_proto_rawDesc = "" +
 	" \x01(\tR\x06sortBy\x121\n" +
 	"\n" +
 	"sort_order\x18\v \x01(\x0e2\x12.todo.v1.SortOrderR\tsortOrder\x12!\n" +
-	"\fsearch_query\x18\f \x01(\tR\vsearchQuery\"h\n" +
+	"\fsearch_query\x18\f \x01(\tR\vsearchQuery\x12!\n" +
+	"\foverdue_only\x18\r \x01(\bR\voverdueOnly\x12=\n" +
+	"\fcreated_from\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\fupdated_from\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12.\n" +
+	"\x13exclude_tags_filter\x18\x12 \x03(\tR\x11excludeTagsFilter\x12%\n" +
+	"\x04sort\x18\x13 \x03(\v2\x11.todo.v1.SortSpecR\x04sort\x12\x19\n" +
+	"\bids_only\x18\x14 \x01(\bR\aidsOnly\x122\n" +
+	"\x13assigned_to_is_null\x18\x15 \x01(\bH\x00R\x10assignedToIsNull\x88\x01\x01\x12,\n" +
+	"\x10due_date_is_null\x18\x16 \x01(\bH\x01R\rdueDateIsNull\x88\x01\x01\x12$\n" +
+	"\x0eassigned_to_me\x18\x17 \x01(\bR\fassignedToMe\x12\x1e\n" +
+	"\vowned_by_me\x18\x18 \x01(\bR\townedByMe\x129\n" +
+	"\n" +
+	"due_bucket\x18\x19 \x01(\x0e2\x12.todo.v1.DueBucketB\x06\x8a\xb5\x18\x02 \x01R\tdueBucket\x12\x1a\n" +
+	"\btimezone\x18\x1a \x01(\tR\btimezoneB\x16\n" +
+	"\x14_assigned_to_is_nullB\x13\n" +
+	"\x11_due_date_is_null\"n\n" +
+	"\aTodoRef\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8e\x01\n" +
 	"\x11ListTodosResponse\x12#\n" +
 	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\x12.\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x11.todo.v1.PageInfoR\bpageInfo\"\xc5\x01\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x11.todo.v1.PageInfoR\bpageInfo\x12$\n" +
+	"\x04refs\x18\x03 \x03(\v2\x10.todo.v1.TodoRefR\x04refs\"\x93\x01\n" +
+	"\x17DryRunListTodosResponse\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x12!\n" +
+	"\fwhere_clause\x18\x02 \x01(\tR\vwhereClause\x12&\n" +
+	"\x0forder_by_clause\x18\x03 \x01(\tR\rorderByClause\x12\x1b\n" +
+	"\targ_count\x18\x04 \x01(\x05R\bargCount\"7\n" +
+	"\x18AnalyzeListTodosResponse\x12\x1b\n" +
+	"\tplan_json\x18\x01 \x01(\tR\bplanJson\"\xcd\x01\n" +
 	"\x17UpdateTodoStatusRequest\x124\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\x122\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12:\n" +
 	"\n" +
-	"new_status\x18\x03 \x01(\x0e2\x13.todo.v1.TodoStatusR\tnewStatus\x12\x16\n" +
+	"new_status\x18\x03 \x01(\x0e2\x13.todo.v1.TodoStatusB\x06\x8a\xb5\x18\x02 \x01R\tnewStatus\x12\x16\n" +
 	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
 	"\aversion\x18\x05 \x01(\x03R\aversion\"=\n" +
 	"\x18UpdateTodoStatusResponse\x12!\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"\xc5\x01\n" +
+	"\x11SnoozeTodoRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x19\n" +
+	"\bfrom_now\x18\x04 \x01(\bR\afromNow\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"7\n" +
+	"\x12SnoozeTodoResponse\x12!\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"\x8a\x01\n" +
+	"\x0eLogTimeRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x18\n" +
+	"\aminutes\x18\x03 \x01(\x05R\aminutes\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"4\n" +
+	"\x0fLogTimeResponse\x12!\n" +
 	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\"\x87\x01\n" +
 	"\x17BatchCreateTodosRequest\x124\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x126\n" +
 	"\brequests\x18\x02 \x03(\v2\x1a.todo.v1.CreateTodoRequestR\brequests\"m\n" +
 	"\x18BatchCreateTodosResponse\x12#\n" +
 	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\x12,\n" +
-	"\x06errors\x18\x02 \x03(\v2\x14.todo.v1.ErrorDetailR\x06errors*\x94\x01\n" +
+	"\x06errors\x18\x02 \x03(\v2\x14.todo.v1.ErrorDetailR\x06errors\"\xc6\x01\n" +
+	"\x10TodoHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x1f\n" +
+	"\vchange_type\x18\x04 \x01(\tR\n" +
+	"changeType\x12\x12\n" +
+	"\x04diff\x18\x05 \x01(\tR\x04diff\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"s\n" +
+	"\x15GetTodoHistoryRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"M\n" +
+	"\x16GetTodoHistoryResponse\x123\n" +
+	"\aentries\x18\x01 \x03(\v2\x19.todo.v1.TodoHistoryEntryR\aentries\"K\n" +
+	"\x13GetTodoStatsRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\"P\n" +
+	"\vStatusCount\x12+\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x13.todo.v1.TodoStatusR\x06status\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"Z\n" +
+	"\x14GetTodoStatsResponse\x12,\n" +
+	"\x06counts\x18\x01 \x03(\v2\x14.todo.v1.StatusCountR\x06counts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"J\n" +
+	"\x12GetWorkloadRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\"w\n" +
+	"\x10AssigneeWorkload\x12\x1f\n" +
+	"\vassignee_id\x18\x01 \x01(\tR\n" +
+	"assigneeId\x12,\n" +
+	"\x06counts\x18\x02 \x03(\v2\x14.todo.v1.StatusCountR\x06counts\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"N\n" +
+	"\x13GetWorkloadResponse\x127\n" +
+	"\tassignees\x18\x01 \x03(\v2\x19.todo.v1.AssigneeWorkloadR\tassignees\"^\n" +
+	"\x14BatchGetTodosRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"<\n" +
+	"\x15BatchGetTodosResponse\x12#\n" +
+	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\"\x86\x01\n" +
+	"\x11UpsertTodoRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12!\n" +
+	"\x04todo\x18\x02 \x01(\v2\r.todo.v1.TodoR\x04todo\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"Q\n" +
+	"\x12UpsertTodoResponse\x12!\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"\xf3\a\n" +
+	"\x16BulkDeleteTodosRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12@\n" +
+	"\rstatus_filter\x18\x02 \x03(\x0e2\x13.todo.v1.TodoStatusB\x06\x8a\xb5\x18\x02 \x01R\fstatusFilter\x12F\n" +
+	"\x0fpriority_filter\x18\x03 \x03(\x0e2\x15.todo.v1.TodoPriorityB\x06\x8a\xb5\x18\x02 \x01R\x0epriorityFilter\x12\x1f\n" +
+	"\vtags_filter\x18\x04 \x03(\tR\n" +
+	"tagsFilter\x12,\n" +
+	"\x12assigned_to_filter\x18\x05 \x01(\tR\x10assignedToFilter\x12>\n" +
+	"\rdue_date_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vdueDateFrom\x12:\n" +
+	"\vdue_date_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdueDateTo\x12!\n" +
+	"\fsearch_query\x18\b \x01(\tR\vsearchQuery\x12!\n" +
+	"\foverdue_only\x18\t \x01(\bR\voverdueOnly\x12=\n" +
+	"\fcreated_from\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\fupdated_from\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12.\n" +
+	"\x13exclude_tags_filter\x18\x0e \x03(\tR\x11excludeTagsFilter\x122\n" +
+	"\x13assigned_to_is_null\x18\x0f \x01(\bH\x00R\x10assignedToIsNull\x88\x01\x01\x12,\n" +
+	"\x10due_date_is_null\x18\x10 \x01(\bH\x01R\rdueDateIsNull\x88\x01\x01\x129\n" +
+	"\n" +
+	"due_bucket\x18\x11 \x01(\x0e2\x12.todo.v1.DueBucketB\x06\x8a\xb5\x18\x02 \x01R\tdueBucket\x12\x1a\n" +
+	"\btimezone\x18\x12 \x01(\tR\btimezoneB\x16\n" +
+	"\x14_assigned_to_is_nullB\x13\n" +
+	"\x11_due_date_is_null\">\n" +
+	"\x17BulkDeleteTodosResponse\x12#\n" +
+	"\rdeleted_count\x18\x01 \x01(\x03R\fdeletedCount\"u\n" +
+	"\x0fListTagsRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"&\n" +
+	"\x10ListTagsResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"p\n" +
+	"\x14AddTagToTodosRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\"<\n" +
+	"\x15AddTagToTodosResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x03R\fupdatedCount\"u\n" +
+	"\x19RemoveTagFromTodosRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\"A\n" +
+	"\x1aRemoveTagFromTodosResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x03R\fupdatedCount\"}\n" +
+	"\x12ListDueSoonRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x121\n" +
+	"\x06within\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06within\":\n" +
+	"\x13ListDueSoonResponse\x12#\n" +
+	"\x05todos\x18\x01 \x03(\v2\r.todo.v1.TodoR\x05todos\"\x8f\x01\n" +
+	"\x13ListArchivedRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x1a\n" +
+	"\x04page\x18\x02 \x01(\x05B\x06\x8a\xb5\x18\x02\x10\x00R\x04page\x12&\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\t\x8a\xb5\x18\x05\x10\x00\x18\xe8\aR\bpageSize\"h\n" +
+	"\fArchivedTodo\x12!\n" +
+	"\x04todo\x18\x01 \x01(\v2\r.todo.v1.TodoR\x04todo\x125\n" +
+	"\bpurge_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\apurgeAt\"\xac\x01\n" +
+	"\x14ListArchivedResponse\x12+\n" +
+	"\x05todos\x18\x01 \x03(\v2\x15.todo.v1.ArchivedTodoR\x05todos\x12.\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x11.todo.v1.PageInfoR\bpageInfo\x127\n" +
+	"\tretention\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tretention\"\xa2\x01\n" +
+	"\vTodoComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"v\n" +
+	"\x11AddCommentRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"D\n" +
+	"\x12AddCommentResponse\x12.\n" +
+	"\acomment\x18\x01 \x01(\v2\x14.todo.v1.TodoCommentR\acomment\"z\n" +
+	"\x13ListCommentsRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"H\n" +
+	"\x14ListCommentsResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.todo.v1.TodoCommentR\bcomments\"\x84\x01\n" +
+	"\x14DeleteCommentRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x03 \x01(\tR\tcommentId\"1\n" +
+	"\x15DeleteCommentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x89\x01\n" +
+	"\x14AddDependencyRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\"\n" +
+	"\rdepends_on_id\x18\x03 \x01(\tR\vdependsOnId\"1\n" +
+	"\x15AddDependencyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8c\x01\n" +
+	"\x17RemoveDependencyRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\"\n" +
+	"\rdepends_on_id\x18\x03 \x01(\tR\vdependsOnId\"4\n" +
+	"\x18RemoveDependencyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"h\n" +
+	"\x17ListDependenciesRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\"o\n" +
+	"\x18ListDependenciesResponse\x12,\n" +
+	"\n" +
+	"blocked_by\x18\x01 \x03(\v2\r.todo.v1.TodoR\tblockedBy\x12%\n" +
+	"\x06blocks\x18\x02 \x03(\v2\r.todo.v1.TodoR\x06blocks\"\xf3\x01\n" +
+	"\x0eTodoAttachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x1f\n" +
+	"\vuploaded_by\x18\x06 \x01(\tR\n" +
+	"uploadedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcf\x01\n" +
+	" CreateAttachmentUploadURLRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\"\x9c\x01\n" +
+	"!CreateAttachmentUploadURLResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x8e\x01\n" +
+	"\x1eConfirmAttachmentUploadRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x03 \x01(\tR\tobjectKey\"Z\n" +
+	"\x1fConfirmAttachmentUploadResponse\x127\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x17.todo.v1.TodoAttachmentR\n" +
+	"attachment\"g\n" +
+	"\x16ListAttachmentsRequest\x124\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.todo.v1.RequestMetadataR\bmetadata\x12\x17\n" +
+	"\atodo_id\x18\x02 \x01(\tR\x06todoId\"T\n" +
+	"\x17ListAttachmentsResponse\x129\n" +
+	"\vattachments\x18\x01 \x03(\v2\x17.todo.v1.TodoAttachmentR\vattachments\"t\n" +
+	"\x18MoveTodosToTenantRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12$\n" +
+	"\x0efrom_tenant_id\x18\x02 \x01(\tR\ffromTenantId\x12 \n" +
+	"\fto_tenant_id\x18\x03 \x01(\tR\n" +
+	"toTenantId\"<\n" +
+	"\x19MoveTodosToTenantResponse\x12\x1f\n" +
+	"\vmoved_count\x18\x01 \x01(\x03R\n" +
+	"movedCount*\x94\x01\n" +
 	"\n" +
 	"TodoStatus\x12\x1b\n" +
 	"\x17TODO_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
@@ -1246,18 +5146,55 @@ const file_api_proto_v1_todo_proto_rawDesc = "" +
 	"\x11TODO_PRIORITY_LOW\x10\x01\x12\x18\n" +
 	"\x14TODO_PRIORITY_MEDIUM\x10\x02\x12\x16\n" +
 	"\x12TODO_PRIORITY_HIGH\x10\x03\x12\x1a\n" +
-	"\x16TODO_PRIORITY_CRITICAL\x10\x042\x96\x04\n" +
+	"\x16TODO_PRIORITY_CRITICAL\x10\x04*\x9a\x01\n" +
+	"\tDueBucket\x12\x1a\n" +
+	"\x16DUE_BUCKET_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DUE_BUCKET_OVERDUE\x10\x01\x12\x14\n" +
+	"\x10DUE_BUCKET_TODAY\x10\x02\x12\x18\n" +
+	"\x14DUE_BUCKET_THIS_WEEK\x10\x03\x12\x14\n" +
+	"\x10DUE_BUCKET_LATER\x10\x04\x12\x13\n" +
+	"\x0fDUE_BUCKET_NONE\x10\x052\x9c\x15\n" +
 	"\vTodoService\x12E\n" +
 	"\n" +
 	"CreateTodo\x12\x1a.todo.v1.CreateTodoRequest\x1a\x1b.todo.v1.CreateTodoResponse\x12<\n" +
-	"\aGetTodo\x12\x17.todo.v1.GetTodoRequest\x1a\x18.todo.v1.GetTodoResponse\x12E\n" +
+	"\aGetTodo\x12\x17.todo.v1.GetTodoRequest\x1a\x18.todo.v1.GetTodoResponse\x12N\n" +
+	"\rGetTodoDetail\x12\x1d.todo.v1.GetTodoDetailRequest\x1a\x1e.todo.v1.GetTodoDetailResponse\x12E\n" +
 	"\n" +
 	"UpdateTodo\x12\x1a.todo.v1.UpdateTodoRequest\x1a\x1b.todo.v1.UpdateTodoResponse\x12E\n" +
 	"\n" +
 	"DeleteTodo\x12\x1a.todo.v1.DeleteTodoRequest\x1a\x1b.todo.v1.DeleteTodoResponse\x12B\n" +
-	"\tListTodos\x12\x19.todo.v1.ListTodosRequest\x1a\x1a.todo.v1.ListTodosResponse\x12W\n" +
-	"\x10UpdateTodoStatus\x12 .todo.v1.UpdateTodoStatusRequest\x1a!.todo.v1.UpdateTodoStatusResponse\x12W\n" +
-	"\x10BatchCreateTodos\x12 .todo.v1.BatchCreateTodosRequest\x1a!.todo.v1.BatchCreateTodosResponseB5Z3github.com/dmehra2102/TaskForge/api/proto/v1;todov1b\x06proto3"
+	"\tListTodos\x12\x19.todo.v1.ListTodosRequest\x1a\x1a.todo.v1.ListTodosResponse\x12N\n" +
+	"\x0fDryRunListTodos\x12\x19.todo.v1.ListTodosRequest\x1a .todo.v1.DryRunListTodosResponse\x12P\n" +
+	"\x10AnalyzeListTodos\x12\x19.todo.v1.ListTodosRequest\x1a!.todo.v1.AnalyzeListTodosResponse\x12W\n" +
+	"\x10UpdateTodoStatus\x12 .todo.v1.UpdateTodoStatusRequest\x1a!.todo.v1.UpdateTodoStatusResponse\x12E\n" +
+	"\n" +
+	"SnoozeTodo\x12\x1a.todo.v1.SnoozeTodoRequest\x1a\x1b.todo.v1.SnoozeTodoResponse\x12<\n" +
+	"\aLogTime\x12\x17.todo.v1.LogTimeRequest\x1a\x18.todo.v1.LogTimeResponse\x12W\n" +
+	"\x10BatchCreateTodos\x12 .todo.v1.BatchCreateTodosRequest\x1a!.todo.v1.BatchCreateTodosResponse\x12Q\n" +
+	"\x0eGetTodoHistory\x12\x1e.todo.v1.GetTodoHistoryRequest\x1a\x1f.todo.v1.GetTodoHistoryResponse\x12K\n" +
+	"\fGetTodoStats\x12\x1c.todo.v1.GetTodoStatsRequest\x1a\x1d.todo.v1.GetTodoStatsResponse\x12H\n" +
+	"\vGetWorkload\x12\x1b.todo.v1.GetWorkloadRequest\x1a\x1c.todo.v1.GetWorkloadResponse\x12N\n" +
+	"\rBatchGetTodos\x12\x1d.todo.v1.BatchGetTodosRequest\x1a\x1e.todo.v1.BatchGetTodosResponse\x12E\n" +
+	"\n" +
+	"UpsertTodo\x12\x1a.todo.v1.UpsertTodoRequest\x1a\x1b.todo.v1.UpsertTodoResponse\x12T\n" +
+	"\x0fBulkDeleteTodos\x12\x1f.todo.v1.BulkDeleteTodosRequest\x1a .todo.v1.BulkDeleteTodosResponse\x125\n" +
+	"\tWatchTodo\x12\x17.todo.v1.GetTodoRequest\x1a\r.todo.v1.Todo0\x01\x12?\n" +
+	"\bListTags\x12\x18.todo.v1.ListTagsRequest\x1a\x19.todo.v1.ListTagsResponse\x12N\n" +
+	"\rAddTagToTodos\x12\x1d.todo.v1.AddTagToTodosRequest\x1a\x1e.todo.v1.AddTagToTodosResponse\x12]\n" +
+	"\x12RemoveTagFromTodos\x12\".todo.v1.RemoveTagFromTodosRequest\x1a#.todo.v1.RemoveTagFromTodosResponse\x12H\n" +
+	"\vListDueSoon\x12\x1b.todo.v1.ListDueSoonRequest\x1a\x1c.todo.v1.ListDueSoonResponse\x12K\n" +
+	"\fListArchived\x12\x1c.todo.v1.ListArchivedRequest\x1a\x1d.todo.v1.ListArchivedResponse\x12E\n" +
+	"\n" +
+	"AddComment\x12\x1a.todo.v1.AddCommentRequest\x1a\x1b.todo.v1.AddCommentResponse\x12K\n" +
+	"\fListComments\x12\x1c.todo.v1.ListCommentsRequest\x1a\x1d.todo.v1.ListCommentsResponse\x12N\n" +
+	"\rDeleteComment\x12\x1d.todo.v1.DeleteCommentRequest\x1a\x1e.todo.v1.DeleteCommentResponse\x12N\n" +
+	"\rAddDependency\x12\x1d.todo.v1.AddDependencyRequest\x1a\x1e.todo.v1.AddDependencyResponse\x12W\n" +
+	"\x10RemoveDependency\x12 .todo.v1.RemoveDependencyRequest\x1a!.todo.v1.RemoveDependencyResponse\x12W\n" +
+	"\x10ListDependencies\x12 .todo.v1.ListDependenciesRequest\x1a!.todo.v1.ListDependenciesResponse\x12r\n" +
+	"\x19CreateAttachmentUploadURL\x12).todo.v1.CreateAttachmentUploadURLRequest\x1a*.todo.v1.CreateAttachmentUploadURLResponse\x12l\n" +
+	"\x17ConfirmAttachmentUpload\x12'.todo.v1.ConfirmAttachmentUploadRequest\x1a(.todo.v1.ConfirmAttachmentUploadResponse\x12T\n" +
+	"\x0fListAttachments\x12\x1f.todo.v1.ListAttachmentsRequest\x1a .todo.v1.ListAttachmentsResponse\x12Z\n" +
+	"\x11MoveTodosToTenant\x12!.todo.v1.MoveTodosToTenantRequest\x1a\".todo.v1.MoveTodosToTenantResponseB5Z3github.com/dmehra2102/TaskForge/api/proto/v1;todov1b\x06proto3"
 
 var (
 	file_api_proto_v1_todo_proto_rawDescOnce sync.Once
@@ -1271,84 +5208,274 @@ func file_api_proto_v1_todo_proto_rawDescGZIP() []byte {
 	return file_api_proto_v1_todo_proto_rawDescData
 }
 
-var file_api_proto_v1_todo_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proto_v1_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_api_proto_v1_todo_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_proto_v1_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 72)
 var file_api_proto_v1_todo_proto_goTypes = []any{
-	(TodoStatus)(0),                  // 0: todo.v1.TodoStatus
-	(TodoPriority)(0),                // 1: todo.v1.TodoPriority
-	(*Todo)(nil),                     // 2: todo.v1.Todo
-	(*CreateTodoRequest)(nil),        // 3: todo.v1.CreateTodoRequest
-	(*CreateTodoResponse)(nil),       // 4: todo.v1.CreateTodoResponse
-	(*GetTodoRequest)(nil),           // 5: todo.v1.GetTodoRequest
-	(*GetTodoResponse)(nil),          // 6: todo.v1.GetTodoResponse
-	(*UpdateTodoRequest)(nil),        // 7: todo.v1.UpdateTodoRequest
-	(*UpdateTodoResponse)(nil),       // 8: todo.v1.UpdateTodoResponse
-	(*DeleteTodoRequest)(nil),        // 9: todo.v1.DeleteTodoRequest
-	(*DeleteTodoResponse)(nil),       // 10: todo.v1.DeleteTodoResponse
-	(*ListTodosRequest)(nil),         // 11: todo.v1.ListTodosRequest
-	(*ListTodosResponse)(nil),        // 12: todo.v1.ListTodosResponse
-	(*UpdateTodoStatusRequest)(nil),  // 13: todo.v1.UpdateTodoStatusRequest
-	(*UpdateTodoStatusResponse)(nil), // 14: todo.v1.UpdateTodoStatusResponse
-	(*BatchCreateTodosRequest)(nil),  // 15: todo.v1.BatchCreateTodosRequest
-	(*BatchCreateTodosResponse)(nil), // 16: todo.v1.BatchCreateTodosResponse
-	(*timestamppb.Timestamp)(nil),    // 17: google.protobuf.Timestamp
-	(*RequestMetadata)(nil),          // 18: todo.v1.RequestMetadata
-	(*fieldmaskpb.FieldMask)(nil),    // 19: google.protobuf.FieldMask
-	(SortOrder)(0),                   // 20: todo.v1.SortOrder
-	(*PageInfo)(nil),                 // 21: todo.v1.PageInfo
-	(*ErrorDetail)(nil),              // 22: todo.v1.ErrorDetail
+	(TodoStatus)(0),                           // 0: todo.v1.TodoStatus
+	(TodoPriority)(0),                         // 1: todo.v1.TodoPriority
+	(DueBucket)(0),                            // 2: todo.v1.DueBucket
+	(*Todo)(nil),                              // 3: todo.v1.Todo
+	(*CreateTodoRequest)(nil),                 // 4: todo.v1.CreateTodoRequest
+	(*CreateTodoResponse)(nil),                // 5: todo.v1.CreateTodoResponse
+	(*GetTodoRequest)(nil),                    // 6: todo.v1.GetTodoRequest
+	(*GetTodoResponse)(nil),                   // 7: todo.v1.GetTodoResponse
+	(*GetTodoDetailRequest)(nil),              // 8: todo.v1.GetTodoDetailRequest
+	(*GetTodoDetailResponse)(nil),             // 9: todo.v1.GetTodoDetailResponse
+	(*UpdateTodoRequest)(nil),                 // 10: todo.v1.UpdateTodoRequest
+	(*UpdateTodoResponse)(nil),                // 11: todo.v1.UpdateTodoResponse
+	(*DeleteTodoRequest)(nil),                 // 12: todo.v1.DeleteTodoRequest
+	(*DeleteTodoResponse)(nil),                // 13: todo.v1.DeleteTodoResponse
+	(*ListTodosRequest)(nil),                  // 14: todo.v1.ListTodosRequest
+	(*TodoRef)(nil),                           // 15: todo.v1.TodoRef
+	(*ListTodosResponse)(nil),                 // 16: todo.v1.ListTodosResponse
+	(*DryRunListTodosResponse)(nil),           // 17: todo.v1.DryRunListTodosResponse
+	(*AnalyzeListTodosResponse)(nil),          // 18: todo.v1.AnalyzeListTodosResponse
+	(*UpdateTodoStatusRequest)(nil),           // 19: todo.v1.UpdateTodoStatusRequest
+	(*UpdateTodoStatusResponse)(nil),          // 20: todo.v1.UpdateTodoStatusResponse
+	(*SnoozeTodoRequest)(nil),                 // 21: todo.v1.SnoozeTodoRequest
+	(*SnoozeTodoResponse)(nil),                // 22: todo.v1.SnoozeTodoResponse
+	(*LogTimeRequest)(nil),                    // 23: todo.v1.LogTimeRequest
+	(*LogTimeResponse)(nil),                   // 24: todo.v1.LogTimeResponse
+	(*BatchCreateTodosRequest)(nil),           // 25: todo.v1.BatchCreateTodosRequest
+	(*BatchCreateTodosResponse)(nil),          // 26: todo.v1.BatchCreateTodosResponse
+	(*TodoHistoryEntry)(nil),                  // 27: todo.v1.TodoHistoryEntry
+	(*GetTodoHistoryRequest)(nil),             // 28: todo.v1.GetTodoHistoryRequest
+	(*GetTodoHistoryResponse)(nil),            // 29: todo.v1.GetTodoHistoryResponse
+	(*GetTodoStatsRequest)(nil),               // 30: todo.v1.GetTodoStatsRequest
+	(*StatusCount)(nil),                       // 31: todo.v1.StatusCount
+	(*GetTodoStatsResponse)(nil),              // 32: todo.v1.GetTodoStatsResponse
+	(*GetWorkloadRequest)(nil),                // 33: todo.v1.GetWorkloadRequest
+	(*AssigneeWorkload)(nil),                  // 34: todo.v1.AssigneeWorkload
+	(*GetWorkloadResponse)(nil),               // 35: todo.v1.GetWorkloadResponse
+	(*BatchGetTodosRequest)(nil),              // 36: todo.v1.BatchGetTodosRequest
+	(*BatchGetTodosResponse)(nil),             // 37: todo.v1.BatchGetTodosResponse
+	(*UpsertTodoRequest)(nil),                 // 38: todo.v1.UpsertTodoRequest
+	(*UpsertTodoResponse)(nil),                // 39: todo.v1.UpsertTodoResponse
+	(*BulkDeleteTodosRequest)(nil),            // 40: todo.v1.BulkDeleteTodosRequest
+	(*BulkDeleteTodosResponse)(nil),           // 41: todo.v1.BulkDeleteTodosResponse
+	(*ListTagsRequest)(nil),                   // 42: todo.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),                  // 43: todo.v1.ListTagsResponse
+	(*AddTagToTodosRequest)(nil),              // 44: todo.v1.AddTagToTodosRequest
+	(*AddTagToTodosResponse)(nil),             // 45: todo.v1.AddTagToTodosResponse
+	(*RemoveTagFromTodosRequest)(nil),         // 46: todo.v1.RemoveTagFromTodosRequest
+	(*RemoveTagFromTodosResponse)(nil),        // 47: todo.v1.RemoveTagFromTodosResponse
+	(*ListDueSoonRequest)(nil),                // 48: todo.v1.ListDueSoonRequest
+	(*ListDueSoonResponse)(nil),               // 49: todo.v1.ListDueSoonResponse
+	(*ListArchivedRequest)(nil),               // 50: todo.v1.ListArchivedRequest
+	(*ArchivedTodo)(nil),                      // 51: todo.v1.ArchivedTodo
+	(*ListArchivedResponse)(nil),              // 52: todo.v1.ListArchivedResponse
+	(*TodoComment)(nil),                       // 53: todo.v1.TodoComment
+	(*AddCommentRequest)(nil),                 // 54: todo.v1.AddCommentRequest
+	(*AddCommentResponse)(nil),                // 55: todo.v1.AddCommentResponse
+	(*ListCommentsRequest)(nil),               // 56: todo.v1.ListCommentsRequest
+	(*ListCommentsResponse)(nil),              // 57: todo.v1.ListCommentsResponse
+	(*DeleteCommentRequest)(nil),              // 58: todo.v1.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),             // 59: todo.v1.DeleteCommentResponse
+	(*AddDependencyRequest)(nil),              // 60: todo.v1.AddDependencyRequest
+	(*AddDependencyResponse)(nil),             // 61: todo.v1.AddDependencyResponse
+	(*RemoveDependencyRequest)(nil),           // 62: todo.v1.RemoveDependencyRequest
+	(*RemoveDependencyResponse)(nil),          // 63: todo.v1.RemoveDependencyResponse
+	(*ListDependenciesRequest)(nil),           // 64: todo.v1.ListDependenciesRequest
+	(*ListDependenciesResponse)(nil),          // 65: todo.v1.ListDependenciesResponse
+	(*TodoAttachment)(nil),                    // 66: todo.v1.TodoAttachment
+	(*CreateAttachmentUploadURLRequest)(nil),  // 67: todo.v1.CreateAttachmentUploadURLRequest
+	(*CreateAttachmentUploadURLResponse)(nil), // 68: todo.v1.CreateAttachmentUploadURLResponse
+	(*ConfirmAttachmentUploadRequest)(nil),    // 69: todo.v1.ConfirmAttachmentUploadRequest
+	(*ConfirmAttachmentUploadResponse)(nil),   // 70: todo.v1.ConfirmAttachmentUploadResponse
+	(*ListAttachmentsRequest)(nil),            // 71: todo.v1.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),           // 72: todo.v1.ListAttachmentsResponse
+	(*MoveTodosToTenantRequest)(nil),          // 73: todo.v1.MoveTodosToTenantRequest
+	(*MoveTodosToTenantResponse)(nil),         // 74: todo.v1.MoveTodosToTenantResponse
+	(*timestamppb.Timestamp)(nil),             // 75: google.protobuf.Timestamp
+	(*RequestMetadata)(nil),                   // 76: todo.v1.RequestMetadata
+	(*fieldmaskpb.FieldMask)(nil),             // 77: google.protobuf.FieldMask
+	(SortOrder)(0),                            // 78: todo.v1.SortOrder
+	(*SortSpec)(nil),                          // 79: todo.v1.SortSpec
+	(*PageInfo)(nil),                          // 80: todo.v1.PageInfo
+	(*durationpb.Duration)(nil),               // 81: google.protobuf.Duration
+	(*ErrorDetail)(nil),                       // 82: todo.v1.ErrorDetail
 }
 var file_api_proto_v1_todo_proto_depIdxs = []int32{
-	0,  // 0: todo.v1.Todo.status:type_name -> todo.v1.TodoStatus
-	1,  // 1: todo.v1.Todo.priority:type_name -> todo.v1.TodoPriority
-	17, // 2: todo.v1.Todo.due_date:type_name -> google.protobuf.Timestamp
-	17, // 3: todo.v1.Todo.created_at:type_name -> google.protobuf.Timestamp
-	17, // 4: todo.v1.Todo.updated_at:type_name -> google.protobuf.Timestamp
-	18, // 5: todo.v1.CreateTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
-	1,  // 6: todo.v1.CreateTodoRequest.priority:type_name -> todo.v1.TodoPriority
-	17, // 7: todo.v1.CreateTodoRequest.due_date:type_name -> google.protobuf.Timestamp
-	2,  // 8: todo.v1.CreateTodoResponse.todo:type_name -> todo.v1.Todo
-	18, // 9: todo.v1.GetTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
-	2,  // 10: todo.v1.GetTodoResponse.todo:type_name -> todo.v1.Todo
-	18, // 11: todo.v1.UpdateTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
-	19, // 12: todo.v1.UpdateTodoRequest.update_mask:type_name -> google.protobuf.FieldMask
-	2,  // 13: todo.v1.UpdateTodoRequest.todo:type_name -> todo.v1.Todo
-	2,  // 14: todo.v1.UpdateTodoResponse.todo:type_name -> todo.v1.Todo
-	18, // 15: todo.v1.DeleteTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
-	18, // 16: todo.v1.ListTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
-	0,  // 17: todo.v1.ListTodosRequest.status_filter:type_name -> todo.v1.TodoStatus
-	1,  // 18: todo.v1.ListTodosRequest.priority_filter:type_name -> todo.v1.TodoPriority
-	17, // 19: todo.v1.ListTodosRequest.due_date_from:type_name -> google.protobuf.Timestamp
-	17, // 20: todo.v1.ListTodosRequest.due_date_to:type_name -> google.protobuf.Timestamp
-	20, // 21: todo.v1.ListTodosRequest.sort_order:type_name -> todo.v1.SortOrder
-	2,  // 22: todo.v1.ListTodosResponse.todos:type_name -> todo.v1.Todo
-	21, // 23: todo.v1.ListTodosResponse.page_info:type_name -> todo.v1.PageInfo
-	18, // 24: todo.v1.UpdateTodoStatusRequest.metadata:type_name -> todo.v1.RequestMetadata
-	0,  // 25: todo.v1.UpdateTodoStatusRequest.new_status:type_name -> todo.v1.TodoStatus
-	2,  // 26: todo.v1.UpdateTodoStatusResponse.todo:type_name -> todo.v1.Todo
-	18, // 27: todo.v1.BatchCreateTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
-	3,  // 28: todo.v1.BatchCreateTodosRequest.requests:type_name -> todo.v1.CreateTodoRequest
-	2,  // 29: todo.v1.BatchCreateTodosResponse.todos:type_name -> todo.v1.Todo
-	22, // 30: todo.v1.BatchCreateTodosResponse.errors:type_name -> todo.v1.ErrorDetail
-	3,  // 31: todo.v1.TodoService.CreateTodo:input_type -> todo.v1.CreateTodoRequest
-	5,  // 32: todo.v1.TodoService.GetTodo:input_type -> todo.v1.GetTodoRequest
-	7,  // 33: todo.v1.TodoService.UpdateTodo:input_type -> todo.v1.UpdateTodoRequest
-	9,  // 34: todo.v1.TodoService.DeleteTodo:input_type -> todo.v1.DeleteTodoRequest
-	11, // 35: todo.v1.TodoService.ListTodos:input_type -> todo.v1.ListTodosRequest
-	13, // 36: todo.v1.TodoService.UpdateTodoStatus:input_type -> todo.v1.UpdateTodoStatusRequest
-	15, // 37: todo.v1.TodoService.BatchCreateTodos:input_type -> todo.v1.BatchCreateTodosRequest
-	4,  // 38: todo.v1.TodoService.CreateTodo:output_type -> todo.v1.CreateTodoResponse
-	6,  // 39: todo.v1.TodoService.GetTodo:output_type -> todo.v1.GetTodoResponse
-	8,  // 40: todo.v1.TodoService.UpdateTodo:output_type -> todo.v1.UpdateTodoResponse
-	10, // 41: todo.v1.TodoService.DeleteTodo:output_type -> todo.v1.DeleteTodoResponse
-	12, // 42: todo.v1.TodoService.ListTodos:output_type -> todo.v1.ListTodosResponse
-	14, // 43: todo.v1.TodoService.UpdateTodoStatus:output_type -> todo.v1.UpdateTodoStatusResponse
-	16, // 44: todo.v1.TodoService.BatchCreateTodos:output_type -> todo.v1.BatchCreateTodosResponse
-	38, // [38:45] is the sub-list for method output_type
-	31, // [31:38] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	0,   // 0: todo.v1.Todo.status:type_name -> todo.v1.TodoStatus
+	1,   // 1: todo.v1.Todo.priority:type_name -> todo.v1.TodoPriority
+	75,  // 2: todo.v1.Todo.due_date:type_name -> google.protobuf.Timestamp
+	75,  // 3: todo.v1.Todo.created_at:type_name -> google.protobuf.Timestamp
+	75,  // 4: todo.v1.Todo.updated_at:type_name -> google.protobuf.Timestamp
+	75,  // 5: todo.v1.Todo.deleted_at:type_name -> google.protobuf.Timestamp
+	75,  // 6: todo.v1.Todo.completed_at:type_name -> google.protobuf.Timestamp
+	75,  // 7: todo.v1.Todo.remind_at:type_name -> google.protobuf.Timestamp
+	75,  // 8: todo.v1.Todo.reminded_at:type_name -> google.protobuf.Timestamp
+	75,  // 9: todo.v1.Todo.archived_at:type_name -> google.protobuf.Timestamp
+	76,  // 10: todo.v1.CreateTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
+	1,   // 11: todo.v1.CreateTodoRequest.priority:type_name -> todo.v1.TodoPriority
+	75,  // 12: todo.v1.CreateTodoRequest.due_date:type_name -> google.protobuf.Timestamp
+	75,  // 13: todo.v1.CreateTodoRequest.remind_at:type_name -> google.protobuf.Timestamp
+	3,   // 14: todo.v1.CreateTodoResponse.todo:type_name -> todo.v1.Todo
+	76,  // 15: todo.v1.GetTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 16: todo.v1.GetTodoResponse.todo:type_name -> todo.v1.Todo
+	76,  // 17: todo.v1.GetTodoDetailRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 18: todo.v1.GetTodoDetailResponse.todo:type_name -> todo.v1.Todo
+	3,   // 19: todo.v1.GetTodoDetailResponse.subtasks:type_name -> todo.v1.Todo
+	76,  // 20: todo.v1.UpdateTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
+	77,  // 21: todo.v1.UpdateTodoRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,   // 22: todo.v1.UpdateTodoRequest.todo:type_name -> todo.v1.Todo
+	3,   // 23: todo.v1.UpdateTodoResponse.todo:type_name -> todo.v1.Todo
+	76,  // 24: todo.v1.DeleteTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 25: todo.v1.ListTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
+	0,   // 26: todo.v1.ListTodosRequest.status_filter:type_name -> todo.v1.TodoStatus
+	1,   // 27: todo.v1.ListTodosRequest.priority_filter:type_name -> todo.v1.TodoPriority
+	75,  // 28: todo.v1.ListTodosRequest.due_date_from:type_name -> google.protobuf.Timestamp
+	75,  // 29: todo.v1.ListTodosRequest.due_date_to:type_name -> google.protobuf.Timestamp
+	78,  // 30: todo.v1.ListTodosRequest.sort_order:type_name -> todo.v1.SortOrder
+	75,  // 31: todo.v1.ListTodosRequest.created_from:type_name -> google.protobuf.Timestamp
+	75,  // 32: todo.v1.ListTodosRequest.created_to:type_name -> google.protobuf.Timestamp
+	75,  // 33: todo.v1.ListTodosRequest.updated_from:type_name -> google.protobuf.Timestamp
+	75,  // 34: todo.v1.ListTodosRequest.updated_to:type_name -> google.protobuf.Timestamp
+	79,  // 35: todo.v1.ListTodosRequest.sort:type_name -> todo.v1.SortSpec
+	2,   // 36: todo.v1.ListTodosRequest.due_bucket:type_name -> todo.v1.DueBucket
+	75,  // 37: todo.v1.TodoRef.updated_at:type_name -> google.protobuf.Timestamp
+	3,   // 38: todo.v1.ListTodosResponse.todos:type_name -> todo.v1.Todo
+	80,  // 39: todo.v1.ListTodosResponse.page_info:type_name -> todo.v1.PageInfo
+	15,  // 40: todo.v1.ListTodosResponse.refs:type_name -> todo.v1.TodoRef
+	76,  // 41: todo.v1.UpdateTodoStatusRequest.metadata:type_name -> todo.v1.RequestMetadata
+	0,   // 42: todo.v1.UpdateTodoStatusRequest.new_status:type_name -> todo.v1.TodoStatus
+	3,   // 43: todo.v1.UpdateTodoStatusResponse.todo:type_name -> todo.v1.Todo
+	76,  // 44: todo.v1.SnoozeTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
+	81,  // 45: todo.v1.SnoozeTodoRequest.duration:type_name -> google.protobuf.Duration
+	3,   // 46: todo.v1.SnoozeTodoResponse.todo:type_name -> todo.v1.Todo
+	76,  // 47: todo.v1.LogTimeRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 48: todo.v1.LogTimeResponse.todo:type_name -> todo.v1.Todo
+	76,  // 49: todo.v1.BatchCreateTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
+	4,   // 50: todo.v1.BatchCreateTodosRequest.requests:type_name -> todo.v1.CreateTodoRequest
+	3,   // 51: todo.v1.BatchCreateTodosResponse.todos:type_name -> todo.v1.Todo
+	82,  // 52: todo.v1.BatchCreateTodosResponse.errors:type_name -> todo.v1.ErrorDetail
+	75,  // 53: todo.v1.TodoHistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	76,  // 54: todo.v1.GetTodoHistoryRequest.metadata:type_name -> todo.v1.RequestMetadata
+	27,  // 55: todo.v1.GetTodoHistoryResponse.entries:type_name -> todo.v1.TodoHistoryEntry
+	76,  // 56: todo.v1.GetTodoStatsRequest.metadata:type_name -> todo.v1.RequestMetadata
+	0,   // 57: todo.v1.StatusCount.status:type_name -> todo.v1.TodoStatus
+	31,  // 58: todo.v1.GetTodoStatsResponse.counts:type_name -> todo.v1.StatusCount
+	76,  // 59: todo.v1.GetWorkloadRequest.metadata:type_name -> todo.v1.RequestMetadata
+	31,  // 60: todo.v1.AssigneeWorkload.counts:type_name -> todo.v1.StatusCount
+	34,  // 61: todo.v1.GetWorkloadResponse.assignees:type_name -> todo.v1.AssigneeWorkload
+	76,  // 62: todo.v1.BatchGetTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 63: todo.v1.BatchGetTodosResponse.todos:type_name -> todo.v1.Todo
+	76,  // 64: todo.v1.UpsertTodoRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 65: todo.v1.UpsertTodoRequest.todo:type_name -> todo.v1.Todo
+	3,   // 66: todo.v1.UpsertTodoResponse.todo:type_name -> todo.v1.Todo
+	76,  // 67: todo.v1.BulkDeleteTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
+	0,   // 68: todo.v1.BulkDeleteTodosRequest.status_filter:type_name -> todo.v1.TodoStatus
+	1,   // 69: todo.v1.BulkDeleteTodosRequest.priority_filter:type_name -> todo.v1.TodoPriority
+	75,  // 70: todo.v1.BulkDeleteTodosRequest.due_date_from:type_name -> google.protobuf.Timestamp
+	75,  // 71: todo.v1.BulkDeleteTodosRequest.due_date_to:type_name -> google.protobuf.Timestamp
+	75,  // 72: todo.v1.BulkDeleteTodosRequest.created_from:type_name -> google.protobuf.Timestamp
+	75,  // 73: todo.v1.BulkDeleteTodosRequest.created_to:type_name -> google.protobuf.Timestamp
+	75,  // 74: todo.v1.BulkDeleteTodosRequest.updated_from:type_name -> google.protobuf.Timestamp
+	75,  // 75: todo.v1.BulkDeleteTodosRequest.updated_to:type_name -> google.protobuf.Timestamp
+	2,   // 76: todo.v1.BulkDeleteTodosRequest.due_bucket:type_name -> todo.v1.DueBucket
+	76,  // 77: todo.v1.ListTagsRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 78: todo.v1.AddTagToTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 79: todo.v1.RemoveTagFromTodosRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 80: todo.v1.ListDueSoonRequest.metadata:type_name -> todo.v1.RequestMetadata
+	81,  // 81: todo.v1.ListDueSoonRequest.within:type_name -> google.protobuf.Duration
+	3,   // 82: todo.v1.ListDueSoonResponse.todos:type_name -> todo.v1.Todo
+	76,  // 83: todo.v1.ListArchivedRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 84: todo.v1.ArchivedTodo.todo:type_name -> todo.v1.Todo
+	75,  // 85: todo.v1.ArchivedTodo.purge_at:type_name -> google.protobuf.Timestamp
+	51,  // 86: todo.v1.ListArchivedResponse.todos:type_name -> todo.v1.ArchivedTodo
+	80,  // 87: todo.v1.ListArchivedResponse.page_info:type_name -> todo.v1.PageInfo
+	81,  // 88: todo.v1.ListArchivedResponse.retention:type_name -> google.protobuf.Duration
+	75,  // 89: todo.v1.TodoComment.created_at:type_name -> google.protobuf.Timestamp
+	76,  // 90: todo.v1.AddCommentRequest.metadata:type_name -> todo.v1.RequestMetadata
+	53,  // 91: todo.v1.AddCommentResponse.comment:type_name -> todo.v1.TodoComment
+	76,  // 92: todo.v1.ListCommentsRequest.metadata:type_name -> todo.v1.RequestMetadata
+	53,  // 93: todo.v1.ListCommentsResponse.comments:type_name -> todo.v1.TodoComment
+	76,  // 94: todo.v1.DeleteCommentRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 95: todo.v1.AddDependencyRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 96: todo.v1.RemoveDependencyRequest.metadata:type_name -> todo.v1.RequestMetadata
+	76,  // 97: todo.v1.ListDependenciesRequest.metadata:type_name -> todo.v1.RequestMetadata
+	3,   // 98: todo.v1.ListDependenciesResponse.blocked_by:type_name -> todo.v1.Todo
+	3,   // 99: todo.v1.ListDependenciesResponse.blocks:type_name -> todo.v1.Todo
+	75,  // 100: todo.v1.TodoAttachment.created_at:type_name -> google.protobuf.Timestamp
+	76,  // 101: todo.v1.CreateAttachmentUploadURLRequest.metadata:type_name -> todo.v1.RequestMetadata
+	75,  // 102: todo.v1.CreateAttachmentUploadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	76,  // 103: todo.v1.ConfirmAttachmentUploadRequest.metadata:type_name -> todo.v1.RequestMetadata
+	66,  // 104: todo.v1.ConfirmAttachmentUploadResponse.attachment:type_name -> todo.v1.TodoAttachment
+	76,  // 105: todo.v1.ListAttachmentsRequest.metadata:type_name -> todo.v1.RequestMetadata
+	66,  // 106: todo.v1.ListAttachmentsResponse.attachments:type_name -> todo.v1.TodoAttachment
+	4,   // 107: todo.v1.TodoService.CreateTodo:input_type -> todo.v1.CreateTodoRequest
+	6,   // 108: todo.v1.TodoService.GetTodo:input_type -> todo.v1.GetTodoRequest
+	8,   // 109: todo.v1.TodoService.GetTodoDetail:input_type -> todo.v1.GetTodoDetailRequest
+	10,  // 110: todo.v1.TodoService.UpdateTodo:input_type -> todo.v1.UpdateTodoRequest
+	12,  // 111: todo.v1.TodoService.DeleteTodo:input_type -> todo.v1.DeleteTodoRequest
+	14,  // 112: todo.v1.TodoService.ListTodos:input_type -> todo.v1.ListTodosRequest
+	14,  // 113: todo.v1.TodoService.DryRunListTodos:input_type -> todo.v1.ListTodosRequest
+	14,  // 114: todo.v1.TodoService.AnalyzeListTodos:input_type -> todo.v1.ListTodosRequest
+	19,  // 115: todo.v1.TodoService.UpdateTodoStatus:input_type -> todo.v1.UpdateTodoStatusRequest
+	21,  // 116: todo.v1.TodoService.SnoozeTodo:input_type -> todo.v1.SnoozeTodoRequest
+	23,  // 117: todo.v1.TodoService.LogTime:input_type -> todo.v1.LogTimeRequest
+	25,  // 118: todo.v1.TodoService.BatchCreateTodos:input_type -> todo.v1.BatchCreateTodosRequest
+	28,  // 119: todo.v1.TodoService.GetTodoHistory:input_type -> todo.v1.GetTodoHistoryRequest
+	30,  // 120: todo.v1.TodoService.GetTodoStats:input_type -> todo.v1.GetTodoStatsRequest
+	33,  // 121: todo.v1.TodoService.GetWorkload:input_type -> todo.v1.GetWorkloadRequest
+	36,  // 122: todo.v1.TodoService.BatchGetTodos:input_type -> todo.v1.BatchGetTodosRequest
+	38,  // 123: todo.v1.TodoService.UpsertTodo:input_type -> todo.v1.UpsertTodoRequest
+	40,  // 124: todo.v1.TodoService.BulkDeleteTodos:input_type -> todo.v1.BulkDeleteTodosRequest
+	6,   // 125: todo.v1.TodoService.WatchTodo:input_type -> todo.v1.GetTodoRequest
+	42,  // 126: todo.v1.TodoService.ListTags:input_type -> todo.v1.ListTagsRequest
+	44,  // 127: todo.v1.TodoService.AddTagToTodos:input_type -> todo.v1.AddTagToTodosRequest
+	46,  // 128: todo.v1.TodoService.RemoveTagFromTodos:input_type -> todo.v1.RemoveTagFromTodosRequest
+	48,  // 129: todo.v1.TodoService.ListDueSoon:input_type -> todo.v1.ListDueSoonRequest
+	50,  // 130: todo.v1.TodoService.ListArchived:input_type -> todo.v1.ListArchivedRequest
+	54,  // 131: todo.v1.TodoService.AddComment:input_type -> todo.v1.AddCommentRequest
+	56,  // 132: todo.v1.TodoService.ListComments:input_type -> todo.v1.ListCommentsRequest
+	58,  // 133: todo.v1.TodoService.DeleteComment:input_type -> todo.v1.DeleteCommentRequest
+	60,  // 134: todo.v1.TodoService.AddDependency:input_type -> todo.v1.AddDependencyRequest
+	62,  // 135: todo.v1.TodoService.RemoveDependency:input_type -> todo.v1.RemoveDependencyRequest
+	64,  // 136: todo.v1.TodoService.ListDependencies:input_type -> todo.v1.ListDependenciesRequest
+	67,  // 137: todo.v1.TodoService.CreateAttachmentUploadURL:input_type -> todo.v1.CreateAttachmentUploadURLRequest
+	69,  // 138: todo.v1.TodoService.ConfirmAttachmentUpload:input_type -> todo.v1.ConfirmAttachmentUploadRequest
+	71,  // 139: todo.v1.TodoService.ListAttachments:input_type -> todo.v1.ListAttachmentsRequest
+	73,  // 140: todo.v1.TodoService.MoveTodosToTenant:input_type -> todo.v1.MoveTodosToTenantRequest
+	5,   // 141: todo.v1.TodoService.CreateTodo:output_type -> todo.v1.CreateTodoResponse
+	7,   // 142: todo.v1.TodoService.GetTodo:output_type -> todo.v1.GetTodoResponse
+	9,   // 143: todo.v1.TodoService.GetTodoDetail:output_type -> todo.v1.GetTodoDetailResponse
+	11,  // 144: todo.v1.TodoService.UpdateTodo:output_type -> todo.v1.UpdateTodoResponse
+	13,  // 145: todo.v1.TodoService.DeleteTodo:output_type -> todo.v1.DeleteTodoResponse
+	16,  // 146: todo.v1.TodoService.ListTodos:output_type -> todo.v1.ListTodosResponse
+	17,  // 147: todo.v1.TodoService.DryRunListTodos:output_type -> todo.v1.DryRunListTodosResponse
+	18,  // 148: todo.v1.TodoService.AnalyzeListTodos:output_type -> todo.v1.AnalyzeListTodosResponse
+	20,  // 149: todo.v1.TodoService.UpdateTodoStatus:output_type -> todo.v1.UpdateTodoStatusResponse
+	22,  // 150: todo.v1.TodoService.SnoozeTodo:output_type -> todo.v1.SnoozeTodoResponse
+	24,  // 151: todo.v1.TodoService.LogTime:output_type -> todo.v1.LogTimeResponse
+	26,  // 152: todo.v1.TodoService.BatchCreateTodos:output_type -> todo.v1.BatchCreateTodosResponse
+	29,  // 153: todo.v1.TodoService.GetTodoHistory:output_type -> todo.v1.GetTodoHistoryResponse
+	32,  // 154: todo.v1.TodoService.GetTodoStats:output_type -> todo.v1.GetTodoStatsResponse
+	35,  // 155: todo.v1.TodoService.GetWorkload:output_type -> todo.v1.GetWorkloadResponse
+	37,  // 156: todo.v1.TodoService.BatchGetTodos:output_type -> todo.v1.BatchGetTodosResponse
+	39,  // 157: todo.v1.TodoService.UpsertTodo:output_type -> todo.v1.UpsertTodoResponse
+	41,  // 158: todo.v1.TodoService.BulkDeleteTodos:output_type -> todo.v1.BulkDeleteTodosResponse
+	3,   // 159: todo.v1.TodoService.WatchTodo:output_type -> todo.v1.Todo
+	43,  // 160: todo.v1.TodoService.ListTags:output_type -> todo.v1.ListTagsResponse
+	45,  // 161: todo.v1.TodoService.AddTagToTodos:output_type -> todo.v1.AddTagToTodosResponse
+	47,  // 162: todo.v1.TodoService.RemoveTagFromTodos:output_type -> todo.v1.RemoveTagFromTodosResponse
+	49,  // 163: todo.v1.TodoService.ListDueSoon:output_type -> todo.v1.ListDueSoonResponse
+	52,  // 164: todo.v1.TodoService.ListArchived:output_type -> todo.v1.ListArchivedResponse
+	55,  // 165: todo.v1.TodoService.AddComment:output_type -> todo.v1.AddCommentResponse
+	57,  // 166: todo.v1.TodoService.ListComments:output_type -> todo.v1.ListCommentsResponse
+	59,  // 167: todo.v1.TodoService.DeleteComment:output_type -> todo.v1.DeleteCommentResponse
+	61,  // 168: todo.v1.TodoService.AddDependency:output_type -> todo.v1.AddDependencyResponse
+	63,  // 169: todo.v1.TodoService.RemoveDependency:output_type -> todo.v1.RemoveDependencyResponse
+	65,  // 170: todo.v1.TodoService.ListDependencies:output_type -> todo.v1.ListDependenciesResponse
+	68,  // 171: todo.v1.TodoService.CreateAttachmentUploadURL:output_type -> todo.v1.CreateAttachmentUploadURLResponse
+	70,  // 172: todo.v1.TodoService.ConfirmAttachmentUpload:output_type -> todo.v1.ConfirmAttachmentUploadResponse
+	72,  // 173: todo.v1.TodoService.ListAttachments:output_type -> todo.v1.ListAttachmentsResponse
+	74,  // 174: todo.v1.TodoService.MoveTodosToTenant:output_type -> todo.v1.MoveTodosToTenantResponse
+	141, // [141:175] is the sub-list for method output_type
+	107, // [107:141] is the sub-list for method input_type
+	107, // [107:107] is the sub-list for extension type_name
+	107, // [107:107] is the sub-list for extension extendee
+	0,   // [0:107] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_v1_todo_proto_init() }
@@ -1357,13 +5484,18 @@ func file_api_proto_v1_todo_proto_init() {
 		return
 	}
 	file_api_proto_v1_common_proto_init()
+	file_api_proto_v1_validate_proto_init()
+	file_api_proto_v1_todo_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_proto_v1_todo_proto_msgTypes[1].OneofWrappers = []any{}
+	file_api_proto_v1_todo_proto_msgTypes[11].OneofWrappers = []any{}
+	file_api_proto_v1_todo_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1_todo_proto_rawDesc), len(file_api_proto_v1_todo_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   15,
+			NumEnums:      3,
+			NumMessages:   72,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
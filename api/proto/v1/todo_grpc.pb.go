@@ -19,13 +19,40 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TodoService_CreateTodo_FullMethodName       = "/todo.v1.TodoService/CreateTodo"
-	TodoService_GetTodo_FullMethodName          = "/todo.v1.TodoService/GetTodo"
-	TodoService_UpdateTodo_FullMethodName       = "/todo.v1.TodoService/UpdateTodo"
-	TodoService_DeleteTodo_FullMethodName       = "/todo.v1.TodoService/DeleteTodo"
-	TodoService_ListTodos_FullMethodName        = "/todo.v1.TodoService/ListTodos"
-	TodoService_UpdateTodoStatus_FullMethodName = "/todo.v1.TodoService/UpdateTodoStatus"
-	TodoService_BatchCreateTodos_FullMethodName = "/todo.v1.TodoService/BatchCreateTodos"
+	TodoService_CreateTodo_FullMethodName                = "/todo.v1.TodoService/CreateTodo"
+	TodoService_GetTodo_FullMethodName                   = "/todo.v1.TodoService/GetTodo"
+	TodoService_GetTodoDetail_FullMethodName             = "/todo.v1.TodoService/GetTodoDetail"
+	TodoService_UpdateTodo_FullMethodName                = "/todo.v1.TodoService/UpdateTodo"
+	TodoService_DeleteTodo_FullMethodName                = "/todo.v1.TodoService/DeleteTodo"
+	TodoService_ListTodos_FullMethodName                 = "/todo.v1.TodoService/ListTodos"
+	TodoService_DryRunListTodos_FullMethodName           = "/todo.v1.TodoService/DryRunListTodos"
+	TodoService_AnalyzeListTodos_FullMethodName          = "/todo.v1.TodoService/AnalyzeListTodos"
+	TodoService_UpdateTodoStatus_FullMethodName          = "/todo.v1.TodoService/UpdateTodoStatus"
+	TodoService_SnoozeTodo_FullMethodName                = "/todo.v1.TodoService/SnoozeTodo"
+	TodoService_LogTime_FullMethodName                   = "/todo.v1.TodoService/LogTime"
+	TodoService_BatchCreateTodos_FullMethodName          = "/todo.v1.TodoService/BatchCreateTodos"
+	TodoService_GetTodoHistory_FullMethodName            = "/todo.v1.TodoService/GetTodoHistory"
+	TodoService_GetTodoStats_FullMethodName              = "/todo.v1.TodoService/GetTodoStats"
+	TodoService_GetWorkload_FullMethodName               = "/todo.v1.TodoService/GetWorkload"
+	TodoService_BatchGetTodos_FullMethodName             = "/todo.v1.TodoService/BatchGetTodos"
+	TodoService_UpsertTodo_FullMethodName                = "/todo.v1.TodoService/UpsertTodo"
+	TodoService_BulkDeleteTodos_FullMethodName           = "/todo.v1.TodoService/BulkDeleteTodos"
+	TodoService_WatchTodo_FullMethodName                 = "/todo.v1.TodoService/WatchTodo"
+	TodoService_ListTags_FullMethodName                  = "/todo.v1.TodoService/ListTags"
+	TodoService_AddTagToTodos_FullMethodName             = "/todo.v1.TodoService/AddTagToTodos"
+	TodoService_RemoveTagFromTodos_FullMethodName        = "/todo.v1.TodoService/RemoveTagFromTodos"
+	TodoService_ListDueSoon_FullMethodName               = "/todo.v1.TodoService/ListDueSoon"
+	TodoService_ListArchived_FullMethodName              = "/todo.v1.TodoService/ListArchived"
+	TodoService_AddComment_FullMethodName                = "/todo.v1.TodoService/AddComment"
+	TodoService_ListComments_FullMethodName              = "/todo.v1.TodoService/ListComments"
+	TodoService_DeleteComment_FullMethodName             = "/todo.v1.TodoService/DeleteComment"
+	TodoService_AddDependency_FullMethodName             = "/todo.v1.TodoService/AddDependency"
+	TodoService_RemoveDependency_FullMethodName          = "/todo.v1.TodoService/RemoveDependency"
+	TodoService_ListDependencies_FullMethodName          = "/todo.v1.TodoService/ListDependencies"
+	TodoService_CreateAttachmentUploadURL_FullMethodName = "/todo.v1.TodoService/CreateAttachmentUploadURL"
+	TodoService_ConfirmAttachmentUpload_FullMethodName   = "/todo.v1.TodoService/ConfirmAttachmentUpload"
+	TodoService_ListAttachments_FullMethodName           = "/todo.v1.TodoService/ListAttachments"
+	TodoService_MoveTodosToTenant_FullMethodName         = "/todo.v1.TodoService/MoveTodosToTenant"
 )
 
 // TodoServiceClient is the client API for TodoService service.
@@ -38,16 +65,70 @@ type TodoServiceClient interface {
 	CreateTodo(ctx context.Context, in *CreateTodoRequest, opts ...grpc.CallOption) (*CreateTodoResponse, error)
 	// Get a todo by ID
 	GetTodo(ctx context.Context, in *GetTodoRequest, opts ...grpc.CallOption) (*GetTodoResponse, error)
+	// Get a todo with its comment count for a detail view
+	GetTodoDetail(ctx context.Context, in *GetTodoDetailRequest, opts ...grpc.CallOption) (*GetTodoDetailResponse, error)
 	// Update an existing todo
 	UpdateTodo(ctx context.Context, in *UpdateTodoRequest, opts ...grpc.CallOption) (*UpdateTodoResponse, error)
 	// Delete a todo (soft delete)
 	DeleteTodo(ctx context.Context, in *DeleteTodoRequest, opts ...grpc.CallOption) (*DeleteTodoResponse, error)
 	// List todos with filtering and pagination
 	ListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*ListTodosResponse, error)
+	// Show the SQL a ListTodos request would run, without running it (admin only)
+	DryRunListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*DryRunListTodosResponse, error)
+	// Run a ListTodos request under EXPLAIN ANALYZE and return its plan (admin only, when enabled)
+	AnalyzeListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*AnalyzeListTodosResponse, error)
 	// Update todo status (enforces state machine)
 	UpdateTodoStatus(ctx context.Context, in *UpdateTodoStatusRequest, opts ...grpc.CallOption) (*UpdateTodoStatusResponse, error)
+	// Move the due date of a todo forward
+	SnoozeTodo(ctx context.Context, in *SnoozeTodoRequest, opts ...grpc.CallOption) (*SnoozeTodoResponse, error)
+	// Add spent effort to a todo
+	LogTime(ctx context.Context, in *LogTimeRequest, opts ...grpc.CallOption) (*LogTimeResponse, error)
 	// Batch create todos
 	BatchCreateTodos(ctx context.Context, in *BatchCreateTodosRequest, opts ...grpc.CallOption) (*BatchCreateTodosResponse, error)
+	// Get the change history of a todo
+	GetTodoHistory(ctx context.Context, in *GetTodoHistoryRequest, opts ...grpc.CallOption) (*GetTodoHistoryResponse, error)
+	// Count todos per status for dashboards
+	GetTodoStats(ctx context.Context, in *GetTodoStatsRequest, opts ...grpc.CallOption) (*GetTodoStatsResponse, error)
+	// Count the tenant's todos per assignee and status (managers and admins only)
+	GetWorkload(ctx context.Context, in *GetWorkloadRequest, opts ...grpc.CallOption) (*GetWorkloadResponse, error)
+	// Get several todos by ID
+	BatchGetTodos(ctx context.Context, in *BatchGetTodosRequest, opts ...grpc.CallOption) (*BatchGetTodosResponse, error)
+	// Create or update a todo with a client-supplied id
+	UpsertTodo(ctx context.Context, in *UpsertTodoRequest, opts ...grpc.CallOption) (*UpsertTodoResponse, error)
+	// Delete every todo matching a filter (soft delete)
+	BulkDeleteTodos(ctx context.Context, in *BulkDeleteTodosRequest, opts ...grpc.CallOption) (*BulkDeleteTodosResponse, error)
+	// Stream the current state of a todo and every subsequent change until it is deleted
+	WatchTodo(ctx context.Context, in *GetTodoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Todo], error)
+	// List the tags used in the tenant, for autocomplete
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// Add a tag to several todos at once; all or none are changed
+	AddTagToTodos(ctx context.Context, in *AddTagToTodosRequest, opts ...grpc.CallOption) (*AddTagToTodosResponse, error)
+	// Remove a tag from several todos at once
+	RemoveTagFromTodos(ctx context.Context, in *RemoveTagFromTodosRequest, opts ...grpc.CallOption) (*RemoveTagFromTodosResponse, error)
+	// List the open todos falling due soon, for dashboards
+	ListDueSoon(ctx context.Context, in *ListDueSoonRequest, opts ...grpc.CallOption) (*ListDueSoonResponse, error)
+	// List archived todos with when each will be purged
+	ListArchived(ctx context.Context, in *ListArchivedRequest, opts ...grpc.CallOption) (*ListArchivedResponse, error)
+	// Comment on a todo
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error)
+	// List the comments of a todo
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// Delete a comment (author or admin only)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	// Mark a todo as blocked by another; cycles are rejected
+	AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*AddDependencyResponse, error)
+	// Remove a dependency between two todos
+	RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*RemoveDependencyResponse, error)
+	// List the todos a todo is blocked by and the todos it blocks
+	ListDependencies(ctx context.Context, in *ListDependenciesRequest, opts ...grpc.CallOption) (*ListDependenciesResponse, error)
+	// Get a presigned URL to upload an attachment to
+	CreateAttachmentUploadURL(ctx context.Context, in *CreateAttachmentUploadURLRequest, opts ...grpc.CallOption) (*CreateAttachmentUploadURLResponse, error)
+	// Record an attachment once its upload has completed
+	ConfirmAttachmentUpload(ctx context.Context, in *ConfirmAttachmentUploadRequest, opts ...grpc.CallOption) (*ConfirmAttachmentUploadResponse, error)
+	// List the attachments of a todo
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	// Move todos between tenants during an account merge (platform admin only)
+	MoveTodosToTenant(ctx context.Context, in *MoveTodosToTenantRequest, opts ...grpc.CallOption) (*MoveTodosToTenantResponse, error)
 }
 
 type todoServiceClient struct {
@@ -78,6 +159,16 @@ func (c *todoServiceClient) GetTodo(ctx context.Context, in *GetTodoRequest, opt
 	return out, nil
 }
 
+func (c *todoServiceClient) GetTodoDetail(ctx context.Context, in *GetTodoDetailRequest, opts ...grpc.CallOption) (*GetTodoDetailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTodoDetailResponse)
+	err := c.cc.Invoke(ctx, TodoService_GetTodoDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoServiceClient) UpdateTodo(ctx context.Context, in *UpdateTodoRequest, opts ...grpc.CallOption) (*UpdateTodoResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateTodoResponse)
@@ -108,6 +199,26 @@ func (c *todoServiceClient) ListTodos(ctx context.Context, in *ListTodosRequest,
 	return out, nil
 }
 
+func (c *todoServiceClient) DryRunListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*DryRunListTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DryRunListTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_DryRunListTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AnalyzeListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*AnalyzeListTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeListTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_AnalyzeListTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoServiceClient) UpdateTodoStatus(ctx context.Context, in *UpdateTodoStatusRequest, opts ...grpc.CallOption) (*UpdateTodoStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateTodoStatusResponse)
@@ -118,6 +229,26 @@ func (c *todoServiceClient) UpdateTodoStatus(ctx context.Context, in *UpdateTodo
 	return out, nil
 }
 
+func (c *todoServiceClient) SnoozeTodo(ctx context.Context, in *SnoozeTodoRequest, opts ...grpc.CallOption) (*SnoozeTodoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnoozeTodoResponse)
+	err := c.cc.Invoke(ctx, TodoService_SnoozeTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) LogTime(ctx context.Context, in *LogTimeRequest, opts ...grpc.CallOption) (*LogTimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogTimeResponse)
+	err := c.cc.Invoke(ctx, TodoService_LogTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoServiceClient) BatchCreateTodos(ctx context.Context, in *BatchCreateTodosRequest, opts ...grpc.CallOption) (*BatchCreateTodosResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BatchCreateTodosResponse)
@@ -128,6 +259,235 @@ func (c *todoServiceClient) BatchCreateTodos(ctx context.Context, in *BatchCreat
 	return out, nil
 }
 
+func (c *todoServiceClient) GetTodoHistory(ctx context.Context, in *GetTodoHistoryRequest, opts ...grpc.CallOption) (*GetTodoHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTodoHistoryResponse)
+	err := c.cc.Invoke(ctx, TodoService_GetTodoHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetTodoStats(ctx context.Context, in *GetTodoStatsRequest, opts ...grpc.CallOption) (*GetTodoStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTodoStatsResponse)
+	err := c.cc.Invoke(ctx, TodoService_GetTodoStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) GetWorkload(ctx context.Context, in *GetWorkloadRequest, opts ...grpc.CallOption) (*GetWorkloadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkloadResponse)
+	err := c.cc.Invoke(ctx, TodoService_GetWorkload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BatchGetTodos(ctx context.Context, in *BatchGetTodosRequest, opts ...grpc.CallOption) (*BatchGetTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_BatchGetTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) UpsertTodo(ctx context.Context, in *UpsertTodoRequest, opts ...grpc.CallOption) (*UpsertTodoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertTodoResponse)
+	err := c.cc.Invoke(ctx, TodoService_UpsertTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) BulkDeleteTodos(ctx context.Context, in *BulkDeleteTodosRequest, opts ...grpc.CallOption) (*BulkDeleteTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkDeleteTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_BulkDeleteTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) WatchTodo(ctx context.Context, in *GetTodoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Todo], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TodoService_ServiceDesc.Streams[0], TodoService_WatchTodo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTodoRequest, Todo]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_WatchTodoClient = grpc.ServerStreamingClient[Todo]
+
+func (c *todoServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AddTagToTodos(ctx context.Context, in *AddTagToTodosRequest, opts ...grpc.CallOption) (*AddTagToTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTagToTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_AddTagToTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) RemoveTagFromTodos(ctx context.Context, in *RemoveTagFromTodosRequest, opts ...grpc.CallOption) (*RemoveTagFromTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTagFromTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_RemoveTagFromTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListDueSoon(ctx context.Context, in *ListDueSoonRequest, opts ...grpc.CallOption) (*ListDueSoonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDueSoonResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListDueSoon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListArchived(ctx context.Context, in *ListArchivedRequest, opts ...grpc.CallOption) (*ListArchivedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArchivedResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListArchived_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddCommentResponse)
+	err := c.cc.Invoke(ctx, TodoService_AddComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, TodoService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*AddDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDependencyResponse)
+	err := c.cc.Invoke(ctx, TodoService_AddDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*RemoveDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDependencyResponse)
+	err := c.cc.Invoke(ctx, TodoService_RemoveDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListDependencies(ctx context.Context, in *ListDependenciesRequest, opts ...grpc.CallOption) (*ListDependenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDependenciesResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListDependencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) CreateAttachmentUploadURL(ctx context.Context, in *CreateAttachmentUploadURLRequest, opts ...grpc.CallOption) (*CreateAttachmentUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAttachmentUploadURLResponse)
+	err := c.cc.Invoke(ctx, TodoService_CreateAttachmentUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ConfirmAttachmentUpload(ctx context.Context, in *ConfirmAttachmentUploadRequest, opts ...grpc.CallOption) (*ConfirmAttachmentUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmAttachmentUploadResponse)
+	err := c.cc.Invoke(ctx, TodoService_ConfirmAttachmentUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, TodoService_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) MoveTodosToTenant(ctx context.Context, in *MoveTodosToTenantRequest, opts ...grpc.CallOption) (*MoveTodosToTenantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveTodosToTenantResponse)
+	err := c.cc.Invoke(ctx, TodoService_MoveTodosToTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TodoServiceServer is the server API for TodoService service.
 // All implementations must embed UnimplementedTodoServiceServer
 // for forward compatibility.
@@ -138,16 +498,70 @@ type TodoServiceServer interface {
 	CreateTodo(context.Context, *CreateTodoRequest) (*CreateTodoResponse, error)
 	// Get a todo by ID
 	GetTodo(context.Context, *GetTodoRequest) (*GetTodoResponse, error)
+	// Get a todo with its comment count for a detail view
+	GetTodoDetail(context.Context, *GetTodoDetailRequest) (*GetTodoDetailResponse, error)
 	// Update an existing todo
 	UpdateTodo(context.Context, *UpdateTodoRequest) (*UpdateTodoResponse, error)
 	// Delete a todo (soft delete)
 	DeleteTodo(context.Context, *DeleteTodoRequest) (*DeleteTodoResponse, error)
 	// List todos with filtering and pagination
 	ListTodos(context.Context, *ListTodosRequest) (*ListTodosResponse, error)
+	// Show the SQL a ListTodos request would run, without running it (admin only)
+	DryRunListTodos(context.Context, *ListTodosRequest) (*DryRunListTodosResponse, error)
+	// Run a ListTodos request under EXPLAIN ANALYZE and return its plan (admin only, when enabled)
+	AnalyzeListTodos(context.Context, *ListTodosRequest) (*AnalyzeListTodosResponse, error)
 	// Update todo status (enforces state machine)
 	UpdateTodoStatus(context.Context, *UpdateTodoStatusRequest) (*UpdateTodoStatusResponse, error)
+	// Move the due date of a todo forward
+	SnoozeTodo(context.Context, *SnoozeTodoRequest) (*SnoozeTodoResponse, error)
+	// Add spent effort to a todo
+	LogTime(context.Context, *LogTimeRequest) (*LogTimeResponse, error)
 	// Batch create todos
 	BatchCreateTodos(context.Context, *BatchCreateTodosRequest) (*BatchCreateTodosResponse, error)
+	// Get the change history of a todo
+	GetTodoHistory(context.Context, *GetTodoHistoryRequest) (*GetTodoHistoryResponse, error)
+	// Count todos per status for dashboards
+	GetTodoStats(context.Context, *GetTodoStatsRequest) (*GetTodoStatsResponse, error)
+	// Count the tenant's todos per assignee and status (managers and admins only)
+	GetWorkload(context.Context, *GetWorkloadRequest) (*GetWorkloadResponse, error)
+	// Get several todos by ID
+	BatchGetTodos(context.Context, *BatchGetTodosRequest) (*BatchGetTodosResponse, error)
+	// Create or update a todo with a client-supplied id
+	UpsertTodo(context.Context, *UpsertTodoRequest) (*UpsertTodoResponse, error)
+	// Delete every todo matching a filter (soft delete)
+	BulkDeleteTodos(context.Context, *BulkDeleteTodosRequest) (*BulkDeleteTodosResponse, error)
+	// Stream the current state of a todo and every subsequent change until it is deleted
+	WatchTodo(*GetTodoRequest, grpc.ServerStreamingServer[Todo]) error
+	// List the tags used in the tenant, for autocomplete
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// Add a tag to several todos at once; all or none are changed
+	AddTagToTodos(context.Context, *AddTagToTodosRequest) (*AddTagToTodosResponse, error)
+	// Remove a tag from several todos at once
+	RemoveTagFromTodos(context.Context, *RemoveTagFromTodosRequest) (*RemoveTagFromTodosResponse, error)
+	// List the open todos falling due soon, for dashboards
+	ListDueSoon(context.Context, *ListDueSoonRequest) (*ListDueSoonResponse, error)
+	// List archived todos with when each will be purged
+	ListArchived(context.Context, *ListArchivedRequest) (*ListArchivedResponse, error)
+	// Comment on a todo
+	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
+	// List the comments of a todo
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// Delete a comment (author or admin only)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	// Mark a todo as blocked by another; cycles are rejected
+	AddDependency(context.Context, *AddDependencyRequest) (*AddDependencyResponse, error)
+	// Remove a dependency between two todos
+	RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error)
+	// List the todos a todo is blocked by and the todos it blocks
+	ListDependencies(context.Context, *ListDependenciesRequest) (*ListDependenciesResponse, error)
+	// Get a presigned URL to upload an attachment to
+	CreateAttachmentUploadURL(context.Context, *CreateAttachmentUploadURLRequest) (*CreateAttachmentUploadURLResponse, error)
+	// Record an attachment once its upload has completed
+	ConfirmAttachmentUpload(context.Context, *ConfirmAttachmentUploadRequest) (*ConfirmAttachmentUploadResponse, error)
+	// List the attachments of a todo
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	// Move todos between tenants during an account merge (platform admin only)
+	MoveTodosToTenant(context.Context, *MoveTodosToTenantRequest) (*MoveTodosToTenantResponse, error)
 	mustEmbedUnimplementedTodoServiceServer()
 }
 
@@ -164,6 +578,9 @@ func (UnimplementedTodoServiceServer) CreateTodo(context.Context, *CreateTodoReq
 func (UnimplementedTodoServiceServer) GetTodo(context.Context, *GetTodoRequest) (*GetTodoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTodo not implemented")
 }
+func (UnimplementedTodoServiceServer) GetTodoDetail(context.Context, *GetTodoDetailRequest) (*GetTodoDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTodoDetail not implemented")
+}
 func (UnimplementedTodoServiceServer) UpdateTodo(context.Context, *UpdateTodoRequest) (*UpdateTodoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTodo not implemented")
 }
@@ -173,12 +590,90 @@ func (UnimplementedTodoServiceServer) DeleteTodo(context.Context, *DeleteTodoReq
 func (UnimplementedTodoServiceServer) ListTodos(context.Context, *ListTodosRequest) (*ListTodosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTodos not implemented")
 }
+func (UnimplementedTodoServiceServer) DryRunListTodos(context.Context, *ListTodosRequest) (*DryRunListTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunListTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) AnalyzeListTodos(context.Context, *ListTodosRequest) (*AnalyzeListTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeListTodos not implemented")
+}
 func (UnimplementedTodoServiceServer) UpdateTodoStatus(context.Context, *UpdateTodoStatusRequest) (*UpdateTodoStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTodoStatus not implemented")
 }
+func (UnimplementedTodoServiceServer) SnoozeTodo(context.Context, *SnoozeTodoRequest) (*SnoozeTodoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) LogTime(context.Context, *LogTimeRequest) (*LogTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogTime not implemented")
+}
 func (UnimplementedTodoServiceServer) BatchCreateTodos(context.Context, *BatchCreateTodosRequest) (*BatchCreateTodosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateTodos not implemented")
 }
+func (UnimplementedTodoServiceServer) GetTodoHistory(context.Context, *GetTodoHistoryRequest) (*GetTodoHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTodoHistory not implemented")
+}
+func (UnimplementedTodoServiceServer) GetTodoStats(context.Context, *GetTodoStatsRequest) (*GetTodoStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTodoStats not implemented")
+}
+func (UnimplementedTodoServiceServer) GetWorkload(context.Context, *GetWorkloadRequest) (*GetWorkloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkload not implemented")
+}
+func (UnimplementedTodoServiceServer) BatchGetTodos(context.Context, *BatchGetTodosRequest) (*BatchGetTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) UpsertTodo(context.Context, *UpsertTodoRequest) (*UpsertTodoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) BulkDeleteTodos(context.Context, *BulkDeleteTodosRequest) (*BulkDeleteTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) WatchTodo(*GetTodoRequest, grpc.ServerStreamingServer[Todo]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTodo not implemented")
+}
+func (UnimplementedTodoServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTodoServiceServer) AddTagToTodos(context.Context, *AddTagToTodosRequest) (*AddTagToTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTagToTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) RemoveTagFromTodos(context.Context, *RemoveTagFromTodosRequest) (*RemoveTagFromTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTagFromTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) ListDueSoon(context.Context, *ListDueSoonRequest) (*ListDueSoonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDueSoon not implemented")
+}
+func (UnimplementedTodoServiceServer) ListArchived(context.Context, *ListArchivedRequest) (*ListArchivedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchived not implemented")
+}
+func (UnimplementedTodoServiceServer) AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedTodoServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedTodoServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedTodoServiceServer) AddDependency(context.Context, *AddDependencyRequest) (*AddDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDependency not implemented")
+}
+func (UnimplementedTodoServiceServer) RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDependency not implemented")
+}
+func (UnimplementedTodoServiceServer) ListDependencies(context.Context, *ListDependenciesRequest) (*ListDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDependencies not implemented")
+}
+func (UnimplementedTodoServiceServer) CreateAttachmentUploadURL(context.Context, *CreateAttachmentUploadURLRequest) (*CreateAttachmentUploadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttachmentUploadURL not implemented")
+}
+func (UnimplementedTodoServiceServer) ConfirmAttachmentUpload(context.Context, *ConfirmAttachmentUploadRequest) (*ConfirmAttachmentUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmAttachmentUpload not implemented")
+}
+func (UnimplementedTodoServiceServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedTodoServiceServer) MoveTodosToTenant(context.Context, *MoveTodosToTenantRequest) (*MoveTodosToTenantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTodosToTenant not implemented")
+}
 func (UnimplementedTodoServiceServer) mustEmbedUnimplementedTodoServiceServer() {}
 func (UnimplementedTodoServiceServer) testEmbeddedByValue()                     {}
 
@@ -236,6 +731,24 @@ func _TodoService_GetTodo_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TodoService_GetTodoDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTodoDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetTodoDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_GetTodoDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetTodoDetail(ctx, req.(*GetTodoDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TodoService_UpdateTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateTodoRequest)
 	if err := dec(in); err != nil {
@@ -290,57 +803,522 @@ func _TodoService_ListTodos_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TodoService_UpdateTodoStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTodoStatusRequest)
+func _TodoService_DryRunListTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTodosRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).UpdateTodoStatus(ctx, in)
+		return srv.(TodoServiceServer).DryRunListTodos(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TodoService_UpdateTodoStatus_FullMethodName,
+		FullMethod: TodoService_DryRunListTodos_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).UpdateTodoStatus(ctx, req.(*UpdateTodoStatusRequest))
+		return srv.(TodoServiceServer).DryRunListTodos(ctx, req.(*ListTodosRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TodoService_BatchCreateTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BatchCreateTodosRequest)
+func _TodoService_AnalyzeListTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTodosRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TodoServiceServer).BatchCreateTodos(ctx, in)
+		return srv.(TodoServiceServer).AnalyzeListTodos(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TodoService_BatchCreateTodos_FullMethodName,
+		FullMethod: TodoService_AnalyzeListTodos_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).BatchCreateTodos(ctx, req.(*BatchCreateTodosRequest))
+		return srv.(TodoServiceServer).AnalyzeListTodos(ctx, req.(*ListTodosRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// TodoService_ServiceDesc is the grpc.ServiceDesc for TodoService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var TodoService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "todo.v1.TodoService",
-	HandlerType: (*TodoServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateTodo",
-			Handler:    _TodoService_CreateTodo_Handler,
-		},
+func _TodoService_UpdateTodoStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTodoStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).UpdateTodoStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_UpdateTodoStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).UpdateTodoStatus(ctx, req.(*UpdateTodoStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_SnoozeTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).SnoozeTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_SnoozeTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).SnoozeTodo(ctx, req.(*SnoozeTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_LogTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).LogTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_LogTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).LogTime(ctx, req.(*LogTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchCreateTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchCreateTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BatchCreateTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchCreateTodos(ctx, req.(*BatchCreateTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetTodoHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTodoHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetTodoHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_GetTodoHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetTodoHistory(ctx, req.(*GetTodoHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetTodoStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTodoStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetTodoStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_GetTodoStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetTodoStats(ctx, req.(*GetTodoStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_GetWorkload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetWorkload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_GetWorkload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetWorkload(ctx, req.(*GetWorkloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BatchGetTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BatchGetTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BatchGetTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BatchGetTodos(ctx, req.(*BatchGetTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_UpsertTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).UpsertTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_UpsertTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).UpsertTodo(ctx, req.(*UpsertTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_BulkDeleteTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).BulkDeleteTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_BulkDeleteTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).BulkDeleteTodos(ctx, req.(*BulkDeleteTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_WatchTodo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTodoRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServiceServer).WatchTodo(m, &grpc.GenericServerStream[GetTodoRequest, Todo]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TodoService_WatchTodoServer = grpc.ServerStreamingServer[Todo]
+
+func _TodoService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_AddTagToTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagToTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).AddTagToTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_AddTagToTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).AddTagToTodos(ctx, req.(*AddTagToTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_RemoveTagFromTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagFromTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RemoveTagFromTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_RemoveTagFromTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RemoveTagFromTodos(ctx, req.(*RemoveTagFromTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListDueSoon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDueSoonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListDueSoon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListDueSoon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListDueSoon(ctx, req.(*ListDueSoonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListArchived_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListArchived(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListArchived_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListArchived(ctx, req.(*ListArchivedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).AddDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_AddDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).AddDependency(ctx, req.(*AddDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).RemoveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_RemoveDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).RemoveDependency(ctx, req.(*RemoveDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListDependencies(ctx, req.(*ListDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_CreateAttachmentUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttachmentUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).CreateAttachmentUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_CreateAttachmentUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).CreateAttachmentUploadURL(ctx, req.(*CreateAttachmentUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ConfirmAttachmentUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmAttachmentUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ConfirmAttachmentUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ConfirmAttachmentUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ConfirmAttachmentUpload(ctx, req.(*ConfirmAttachmentUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_MoveTodosToTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTodosToTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).MoveTodosToTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_MoveTodosToTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).MoveTodosToTenant(ctx, req.(*MoveTodosToTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TodoService_ServiceDesc is the grpc.ServiceDesc for TodoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TodoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.TodoService",
+	HandlerType: (*TodoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTodo",
+			Handler:    _TodoService_CreateTodo_Handler,
+		},
 		{
 			MethodName: "GetTodo",
 			Handler:    _TodoService_GetTodo_Handler,
 		},
+		{
+			MethodName: "GetTodoDetail",
+			Handler:    _TodoService_GetTodoDetail_Handler,
+		},
 		{
 			MethodName: "UpdateTodo",
 			Handler:    _TodoService_UpdateTodo_Handler,
@@ -353,15 +1331,121 @@ var TodoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTodos",
 			Handler:    _TodoService_ListTodos_Handler,
 		},
+		{
+			MethodName: "DryRunListTodos",
+			Handler:    _TodoService_DryRunListTodos_Handler,
+		},
+		{
+			MethodName: "AnalyzeListTodos",
+			Handler:    _TodoService_AnalyzeListTodos_Handler,
+		},
 		{
 			MethodName: "UpdateTodoStatus",
 			Handler:    _TodoService_UpdateTodoStatus_Handler,
 		},
+		{
+			MethodName: "SnoozeTodo",
+			Handler:    _TodoService_SnoozeTodo_Handler,
+		},
+		{
+			MethodName: "LogTime",
+			Handler:    _TodoService_LogTime_Handler,
+		},
 		{
 			MethodName: "BatchCreateTodos",
 			Handler:    _TodoService_BatchCreateTodos_Handler,
 		},
+		{
+			MethodName: "GetTodoHistory",
+			Handler:    _TodoService_GetTodoHistory_Handler,
+		},
+		{
+			MethodName: "GetTodoStats",
+			Handler:    _TodoService_GetTodoStats_Handler,
+		},
+		{
+			MethodName: "GetWorkload",
+			Handler:    _TodoService_GetWorkload_Handler,
+		},
+		{
+			MethodName: "BatchGetTodos",
+			Handler:    _TodoService_BatchGetTodos_Handler,
+		},
+		{
+			MethodName: "UpsertTodo",
+			Handler:    _TodoService_UpsertTodo_Handler,
+		},
+		{
+			MethodName: "BulkDeleteTodos",
+			Handler:    _TodoService_BulkDeleteTodos_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _TodoService_ListTags_Handler,
+		},
+		{
+			MethodName: "AddTagToTodos",
+			Handler:    _TodoService_AddTagToTodos_Handler,
+		},
+		{
+			MethodName: "RemoveTagFromTodos",
+			Handler:    _TodoService_RemoveTagFromTodos_Handler,
+		},
+		{
+			MethodName: "ListDueSoon",
+			Handler:    _TodoService_ListDueSoon_Handler,
+		},
+		{
+			MethodName: "ListArchived",
+			Handler:    _TodoService_ListArchived_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _TodoService_AddComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _TodoService_ListComments_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _TodoService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "AddDependency",
+			Handler:    _TodoService_AddDependency_Handler,
+		},
+		{
+			MethodName: "RemoveDependency",
+			Handler:    _TodoService_RemoveDependency_Handler,
+		},
+		{
+			MethodName: "ListDependencies",
+			Handler:    _TodoService_ListDependencies_Handler,
+		},
+		{
+			MethodName: "CreateAttachmentUploadURL",
+			Handler:    _TodoService_CreateAttachmentUploadURL_Handler,
+		},
+		{
+			MethodName: "ConfirmAttachmentUpload",
+			Handler:    _TodoService_ConfirmAttachmentUpload_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _TodoService_ListAttachments_Handler,
+		},
+		{
+			MethodName: "MoveTodosToTenant",
+			Handler:    _TodoService_MoveTodosToTenant_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTodo",
+			Handler:       _TodoService_WatchTodo_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/v1/todo.proto",
 }
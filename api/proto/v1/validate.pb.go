@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.12
+// source: api/proto/v1/validate.proto
+
+package todov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldRules declares constraints on a request field that the validation
+// interceptor checks before the handler runs. They are outer bounds only:
+// limits that are configurable, such as the title length, are enforced by
+// the domain, so these ceilings must stay at or above any sane setting.
+type FieldRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxLen        *uint32                `protobuf:"varint,1,opt,name=max_len,json=maxLen,proto3,oneof" json:"max_len,omitempty"`          // strings: maximum length in characters
+	Gte           *int64                 `protobuf:"varint,2,opt,name=gte,proto3,oneof" json:"gte,omitempty"`                              // integers: minimum value
+	Lte           *int64                 `protobuf:"varint,3,opt,name=lte,proto3,oneof" json:"lte,omitempty"`                              // integers: maximum value
+	DefinedOnly   bool                   `protobuf:"varint,4,opt,name=defined_only,json=definedOnly,proto3" json:"defined_only,omitempty"` // enums: only values the enum declares
+	MaxItems      *uint32                `protobuf:"varint,5,opt,name=max_items,json=maxItems,proto3,oneof" json:"max_items,omitempty"`    // repeated fields: maximum number of elements
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_api_proto_v1_validate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_validate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetMaxLen() uint32 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetGte() int64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetLte() int64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetDefinedOnly() bool {
+	if x != nil {
+		return x.DefinedOnly
+	}
+	return false
+}
+
+func (x *FieldRules) GetMaxItems() uint32 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+var file_api_proto_v1_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         50001,
+		Name:          "todo.v1.rules",
+		Tag:           "bytes,50001,opt,name=rules",
+		Filename:      "api/proto/v1/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Rules for a field; on a repeated field they apply to each element
+	//
+	// optional todo.v1.FieldRules rules = 50001;
+	E_Rules = &file_api_proto_v1_validate_proto_extTypes[0]
+)
+
+var File_api_proto_v1_validate_proto protoreflect.FileDescriptor
+
+const file_api_proto_v1_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x1bapi/proto/v1/validate.proto\x12\atodo.v1\x1a google/protobuf/descriptor.proto\"\xc7\x01\n" +
+	"\n" +
+	"FieldRules\x12\x1c\n" +
+	"\amax_len\x18\x01 \x01(\rH\x00R\x06maxLen\x88\x01\x01\x12\x15\n" +
+	"\x03gte\x18\x02 \x01(\x03H\x01R\x03gte\x88\x01\x01\x12\x15\n" +
+	"\x03lte\x18\x03 \x01(\x03H\x02R\x03lte\x88\x01\x01\x12!\n" +
+	"\fdefined_only\x18\x04 \x01(\bR\vdefinedOnly\x12 \n" +
+	"\tmax_items\x18\x05 \x01(\rH\x03R\bmaxItems\x88\x01\x01B\n" +
+	"\n" +
+	"\b_max_lenB\x06\n" +
+	"\x04_gteB\x06\n" +
+	"\x04_lteB\f\n" +
+	"\n" +
+	"_max_items:J\n" +
+	"\x05rules\x12\x1d.google.protobuf.FieldOptions\x18ц\x03 \x01(\v2\x13.todo.v1.FieldRulesR\x05rulesB5Z3github.com/dmehra2102/TaskForge/api/proto/v1;todov1b\x06proto3"
+
+var (
+	file_api_proto_v1_validate_proto_rawDescOnce sync.Once
+	file_api_proto_v1_validate_proto_rawDescData []byte
+)
+
+func file_api_proto_v1_validate_proto_rawDescGZIP() []byte {
+	file_api_proto_v1_validate_proto_rawDescOnce.Do(func() {
+		file_api_proto_v1_validate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_v1_validate_proto_rawDesc), len(file_api_proto_v1_validate_proto_rawDesc)))
+	})
+	return file_api_proto_v1_validate_proto_rawDescData
+}
+
+var file_api_proto_v1_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_proto_v1_validate_proto_goTypes = []any{
+	(*FieldRules)(nil),                // 0: todo.v1.FieldRules
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_api_proto_v1_validate_proto_depIdxs = []int32{
+	1, // 0: todo.v1.rules:extendee -> google.protobuf.FieldOptions
+	0, // 1: todo.v1.rules:type_name -> todo.v1.FieldRules
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_v1_validate_proto_init() }
+func file_api_proto_v1_validate_proto_init() {
+	if File_api_proto_v1_validate_proto != nil {
+		return
+	}
+	file_api_proto_v1_validate_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1_validate_proto_rawDesc), len(file_api_proto_v1_validate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proto_v1_validate_proto_goTypes,
+		DependencyIndexes: file_api_proto_v1_validate_proto_depIdxs,
+		MessageInfos:      file_api_proto_v1_validate_proto_msgTypes,
+		ExtensionInfos:    file_api_proto_v1_validate_proto_extTypes,
+	}.Build()
+	File_api_proto_v1_validate_proto = out.File
+	file_api_proto_v1_validate_proto_goTypes = nil
+	file_api_proto_v1_validate_proto_depIdxs = nil
+}
@@ -12,8 +12,14 @@ import (
 
 	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
 	"github.com/dmehra2102/TaskForge/internal/app"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/internal/events"
+	"github.com/dmehra2102/TaskForge/internal/gateway"
+	"github.com/dmehra2102/TaskForge/internal/health"
 	"github.com/dmehra2102/TaskForge/internal/infrastructure/config"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/memory"
 	infrapostgres "github.com/dmehra2102/TaskForge/internal/infrastructure/postgres"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/storage"
 	"github.com/dmehra2102/TaskForge/internal/interceptors"
 	"github.com/dmehra2102/TaskForge/pkg/auth"
 	"github.com/golang-migrate/migrate/v4"
@@ -26,10 +32,13 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
@@ -49,7 +58,7 @@ func main() {
 	}
 
 	// Initialize logger
-	logger := initLogger(cfg.Environment)
+	logger := initLogger(cfg.Environment, cfg.GetObservabilityConfig())
 	defer logger.Sync()
 
 	logger.Info("Starting todo service",
@@ -58,43 +67,108 @@ func main() {
 	)
 
 	// Initialize OpenTelemetry
-	shutdown, err := initTracer(cfg.JaegerEndpoint)
+	shutdown := initTracer(cfg.GetObservabilityConfig(), logger)
+	defer shutdown(context.Background())
+
+	// Initialize the repository; requests run tenant-scoped while the relay
+	// and maintenance jobs below span tenants
+	var (
+		db          *sql.DB
+		repo        storeRepository
+		serviceRepo domain.Repository
+	)
+	switch cfg.Repository {
+	case config.RepositoryMemory:
+		logger.Warn("Using the in-memory repository, data is lost on restart")
+		memRepo := memory.NewInMemoryRepository()
+		repo, serviceRepo = memRepo, memRepo
+	default:
+		pgDB, pgRepo, closeRepo := initPostgresRepository(cfg, logger)
+		defer closeRepo()
+
+		retryPolicy := infrapostgres.RetryPolicy{
+			MaxAttempts: cfg.DBRetryMaxAttempts,
+			BaseDelay:   cfg.DBRetryBaseDelay,
+			MaxDelay:    cfg.DBRetryMaxDelay,
+		}
+		db, repo = pgDB, pgRepo
+		serviceRepo = infrapostgres.NewRetryingRepository(infrapostgres.NewTenantScopedRepository(pgRepo), retryPolicy)
+	}
+
+	authz := auth.NewAuthorizer()
+
+	if err := domain.SetLimits(cfg.GetLimits()); err != nil {
+		logger.Fatal("Invalid field limits", zap.Error(err))
+	}
+	if err := domain.SetIDScheme(domain.IDScheme(cfg.IDScheme)); err != nil {
+		logger.Fatal("Invalid id scheme", zap.Error(err))
+	}
+	domain.SetAllowPastDueDate(cfg.AllowPastDueDate)
+
+	transitions, err := domain.ParseTransitionPolicy(cfg.StatusTransitions)
 	if err != nil {
-		logger.Fatal("Failed to initialize tracer", zap.Error(err))
+		logger.Fatal("Invalid status transition policy", zap.Error(err))
 	}
-	defer shutdown(context.Background())
 
-	// Initialize database
-	db, err := initDatabase(cfg.DatabaseURL)
+	priorityWeights, err := domain.ParsePriorityWeights(cfg.PriorityWeights)
 	if err != nil {
-		logger.Fatal("Failed to initialize database", zap.Error(err))
+		logger.Fatal("Invalid priority weights", zap.Error(err))
 	}
-	defer db.Close()
 
-	// Run migrations
-	if err := runMigrations(cfg.DatabaseURL, cfg.MigrationsPath); err != nil {
-		logger.Fatal("Failed to run migrations", zap.Error(err))
+	keyFunc, err := auth.NewKeyfunc(cfg.GetJWTKeyConfig())
+	if err != nil {
+		logger.Fatal("Failed to initialize JWT verification", zap.Error(err))
 	}
 
-	repo := infrapostgres.NewPostgresRepository(db)
-	authz := auth.NewAuthorizer()
+	apiKeys, err := cfg.GetAPIKeyStore()
+	if err != nil {
+		logger.Fatal("Failed to load API keys", zap.Error(err))
+	}
 
-	grpcServer := initGRPCServer(cfg, logger)
+	tenantIDs, err := cfg.GetTenantIDValidator()
+	if err != nil {
+		logger.Fatal("Invalid tenant ID pattern", zap.Error(err))
+	}
 
-	// Service Registry
-	todoService := app.NewTodoServiceServer(repo, logger, authz)
-	todov1.RegisterTodoServiceServer(grpcServer, todoService)
+	drainer := interceptors.NewStreamDrainer()
+	grpcServer := initGRPCServer(cfg, logger, keyFunc, apiKeys, tenantIDs, drainer)
+
+	// Relayed events are fanned out to WatchTodo streams
+	broker := events.NewBroker()
+
+	serviceOpts := []app.Option{
+		app.WithTenantQuota(cfg.MaxTodosPerTenant, cfg.AdminBypassTenantQuota),
+		app.WithTransitionPolicy(transitions),
+		app.WithPriorityWeights(priorityWeights),
+		app.WithDependencyBlocking(cfg.BlockOnDependencies),
+		app.WithPageSizes(cfg.GetPageSizes()),
+		app.WithMaxBatchSize(cfg.MaxBatchSize),
+		app.WithQueryAnalysis(cfg.EnableQueryAnalysis),
+		app.WithMetricsTenants(cfg.MetricsTenants),
+		app.WithEventSubscriber(broker),
+		app.WithDeleteMode(domain.DeleteMode(cfg.DeleteMode)),
+		app.WithArchiveRetention(time.Duration(cfg.ArchiveRetentionDays) * 24 * time.Hour),
+	}
 
-	// Register health service
-	healthServer := health.NewServer()
-	healthpb.RegisterHealthServer(grpcServer, healthServer)
-	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	if cfg.UserDirectory == config.UserDirectoryPostgres {
+		serviceOpts = append(serviceOpts, app.WithUserDirectory(infrapostgres.NewUserDirectory(db)))
+	}
 
-	// Register reflection for development
-	if cfg.Environment != "production" {
-		reflection.Register(grpcServer)
+	// objectStorage stays nil when attachments are disabled
+	var objectStorage domain.ObjectStorage
+	if cfg.AttachmentsBucket != "" {
+		s3Storage, err := storage.NewS3Storage(context.Background(), cfg.AWSRegion, cfg.AttachmentsBucket)
+		if err != nil {
+			logger.Fatal("Failed to initialize attachment storage", zap.Error(err))
+		}
+		objectStorage = s3Storage
+		serviceOpts = append(serviceOpts, app.WithObjectStorage(objectStorage, cfg.AttachmentURLExpiry, cfg.MaxAttachmentSize))
 	}
 
+	// Service Registry
+	todoService := app.NewTodoServiceServer(serviceRepo, logger, authz, serviceOpts...)
+	healthServer := registerServices(grpcServer, cfg, todoService)
+
 	// Start server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	if err != nil {
@@ -104,6 +178,38 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Relay outbox events; no external transport is wired yet, only watchers
+	relay := events.NewRelay(repo, broker, logger, cfg.OutboxPollInterval)
+	go relay.Run(ctx)
+
+	// Report NOT_SERVING while the database is unreachable
+	if healthServer != nil && db != nil {
+		checker := health.NewDatabaseHealthChecker(db, healthServer, logger, cfg.HealthCheckInterval, cfg.HealthCheckFailureThreshold)
+		go checker.Run(ctx)
+	}
+
+	// Background maintenance spans tenants, which row-level security only
+	// allows for contexts that opt in
+	jobCtx := domain.WithCrossTenant(ctx)
+	if cfg.RetentionDays > 0 {
+		retention := time.Duration(cfg.RetentionDays) * 24 * time.Hour
+		go runPurgeJob(jobCtx, repo, objectStorage, retention, logger)
+	}
+	if cfg.AutoArchiveDays > 0 {
+		age := time.Duration(cfg.AutoArchiveDays) * 24 * time.Hour
+		go runArchiveJob(jobCtx, repo, age, cfg.AutoArchiveInterval, logger)
+	}
+	if cfg.ArchiveRetentionDays > 0 {
+		retention := time.Duration(cfg.ArchiveRetentionDays) * 24 * time.Hour
+		go runArchivePurgeJob(jobCtx, repo, objectStorage, retention, logger)
+	}
+	if cfg.EnableEscalation {
+		go runEscalationJob(jobCtx, repo, cfg.EscalationInterval, logger)
+	}
+	if cfg.EnableReminders {
+		go runReminderJob(jobCtx, repo, cfg.ReminderInterval, cfg.ReminderWindow, logger)
+	}
+
 	go func() {
 		logger.Info("Server starting", zap.Int("port", cfg.Port))
 		if err := grpcServer.Serve(lis); err != nil {
@@ -111,15 +217,30 @@ func main() {
 		}
 	}()
 
+	var gw *gateway.Gateway
+	if cfg.EnableGateway {
+		gw = startGateway(ctx, cfg, logger)
+	}
+
 	<-ctx.Done()
 	logger.Info("Shutting down gracefully...")
 
 	// Graceful shutdown with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	done := make(chan struct{})
 	go func() {
+		// Ask open streams to finish first; GracefulStop would otherwise wait on them
+		if err := drainer.Drain(shutdownCtx); err != nil {
+			logger.Warn("Streams did not drain before shutdown timeout", zap.Error(err))
+		}
+		if gw != nil {
+			if err := gw.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("Gateway did not shut down cleanly", zap.Error(err))
+			}
+		}
+		broker.Close()
 		grpcServer.GracefulStop()
 		close(done)
 	}()
@@ -133,16 +254,23 @@ func main() {
 	}
 }
 
-func initLogger(environment string) *zap.Logger {
-	var logger *zap.Logger
-	var err error
-
+// initLogger builds a logger at the configured level and format. The
+// environment only picks the remaining defaults, such as sampling and
+// development-mode stack traces.
+func initLogger(environment string, cfg config.ObservabilityConfig) *zap.Logger {
+	zapCfg := zap.NewDevelopmentConfig()
 	if environment == "production" {
-		logger, err = zap.NewProduction()
-	} else {
-		logger, err = zap.NewDevelopment()
+		zapCfg = zap.NewProductionConfig()
+	}
+
+	level, err := zapcore.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to initialize logger: %v", err))
 	}
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+	zapCfg.Encoding = cfg.LogFormat
 
+	logger, err := zapCfg.Build()
 	if err != nil {
 		panic(fmt.Sprintf("Failed to initialize logger: %v", err))
 	}
@@ -150,14 +278,26 @@ func initLogger(environment string) *zap.Logger {
 	return logger
 }
 
-func initTracer(jaegerEndpoint string) (func(context.Context) error, error) {
-	exporter, err := otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpoint(jaegerEndpoint))
+// initTracer exports traces to the Jaeger endpoint, sampling the configured
+// ratio of new traces; spans follow their parent's sampling decision. Tracing
+// falls back to a no-op provider when disabled or when the exporter cannot be
+// created, so the service starts without a collector.
+func initTracer(cfg config.ObservabilityConfig, logger *zap.Logger) func(context.Context) error {
+	if !cfg.EnableTracing {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return func(context.Context) error { return nil }
+	}
+
+	exporter, err := otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpoint(cfg.JaegerEndpoint))
 	if err != nil {
-		return nil, fmt.Errorf("Failed to create jaeger exporter: %w", err)
+		logger.Warn("Failed to create jaeger exporter, tracing disabled", zap.Error(err))
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return func(context.Context) error { return nil }
 	}
 
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
 		sdktrace.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
 			semconv.ServiceNameKey.String(serviceName),
@@ -167,7 +307,49 @@ func initTracer(jaegerEndpoint string) (func(context.Context) error, error) {
 
 	otel.SetTracerProvider(tp)
 
-	return tp.Shutdown, nil
+	return tp.Shutdown
+}
+
+// storeRepository is what the service runs on besides its requests: the
+// relay drains its outbox and the maintenance jobs sweep every tenant
+type storeRepository interface {
+	domain.Repository
+	events.OutboxStore
+}
+
+// initPostgresRepository connects to the database and its optional read
+// replica, runs migrations and builds the repository on them. The returned
+// function closes all of it.
+func initPostgresRepository(cfg *config.Config, logger *zap.Logger) (*sql.DB, *infrapostgres.PostgresRepository, func()) {
+	db, err := initDatabase(cfg.DatabaseURL)
+	if err != nil {
+		logger.Fatal("Failed to initialize database", zap.Error(err))
+	}
+
+	if err := runMigrations(cfg.DatabaseURL, cfg.MigrationsPath); err != nil {
+		logger.Fatal("Failed to run migrations", zap.Error(err))
+	}
+
+	var replicaDB *sql.DB
+	if cfg.DatabaseReplicaURL != "" {
+		replicaDB, err = initDatabase(cfg.DatabaseReplicaURL)
+		if err != nil {
+			logger.Fatal("Failed to initialize read replica", zap.Error(err))
+		}
+	}
+
+	repo := infrapostgres.NewPostgresRepository(db, logger,
+		infrapostgres.WithSlowQueryThreshold(cfg.SlowQueryThreshold),
+		infrapostgres.WithReplica(replicaDB),
+	)
+
+	return db, repo, func() {
+		repo.Close()
+		if replicaDB != nil {
+			replicaDB.Close()
+		}
+		db.Close()
+	}
 }
 
 func initDatabase(databaseURL string) (*sql.DB, error) {
@@ -221,7 +403,194 @@ func runMigrations(databaseURL, migrationsPath string) error {
 	return nil
 }
 
-func initGRPCServer(cfg *config.Config, logger *zap.Logger) *grpc.Server {
+// runPurgeJob hard-deletes todos soft-deleted longer than retention, and the
+// stored files of their attachments, once at startup and then daily until ctx
+// is cancelled.
+func runPurgeJob(ctx context.Context, repo domain.Repository, objectStorage domain.ObjectStorage, retention time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		purged, objectKeys, err := repo.PurgeDeleted(ctx, time.Now().UTC().Add(-retention))
+		if err != nil {
+			logger.Error("Failed to purge deleted todos", zap.Error(err))
+		} else {
+			logger.Info("Purged deleted todos", zap.Int64("count", purged))
+			deleteObjects(ctx, objectStorage, objectKeys, logger)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteObjects removes the stored files of attachments whose todos were
+// purged. The todos are already gone, so a failure is only logged.
+func deleteObjects(ctx context.Context, objectStorage domain.ObjectStorage, keys []string, logger *zap.Logger) {
+	if objectStorage == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := objectStorage.Delete(ctx, key); err != nil {
+			logger.Error("Failed to delete attachment object", zap.Error(err), zap.String("object_key", key))
+		}
+	}
+}
+
+// runArchivePurgeJob hard-deletes todos archived longer than retention, and
+// the stored files of their attachments, once at startup and then daily until
+// ctx is cancelled.
+func runArchivePurgeJob(ctx context.Context, repo domain.Repository, objectStorage domain.ObjectStorage, retention time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		purged, objectKeys, err := repo.PurgeArchived(ctx, time.Now().UTC().Add(-retention))
+		if err != nil {
+			logger.Error("Failed to purge archived todos", zap.Error(err))
+		} else {
+			logger.Info("Purged archived todos", zap.Int64("count", purged))
+			deleteObjects(ctx, objectStorage, objectKeys, logger)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runEscalationJob raises the priority of overdue todos across all tenants
+// every interval until ctx is cancelled.
+func runEscalationJob(ctx context.Context, repo domain.Repository, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			escalated, err := repo.EscalateOverdue(ctx, "")
+			if err != nil {
+				logger.Error("Failed to escalate overdue todos", zap.Error(err))
+				continue
+			}
+			logger.Info("Escalated overdue todos", zap.Int64("count", escalated))
+		}
+	}
+}
+
+// runArchiveJob archives todos completed more than age ago across all
+// tenants every interval until ctx is cancelled.
+func runArchiveJob(ctx context.Context, repo domain.Repository, age, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			archived, err := repo.ArchiveCompletedOlderThan(ctx, "", age)
+			if err != nil {
+				logger.Error("Failed to archive completed todos", zap.Error(err))
+				continue
+			}
+			logger.Info("Archived completed todos", zap.Int64("count", archived))
+		}
+	}
+}
+
+// runReminderJob sends the reminders coming due within window every interval
+// until ctx is cancelled. Reminder events reach subscribers through the outbox.
+func runReminderJob(ctx context.Context, repo domain.Repository, interval, window time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reminded, err := repo.DueReminders(ctx, window)
+			if err != nil {
+				logger.Error("Failed to send due reminders", zap.Error(err))
+				continue
+			}
+			if len(reminded) > 0 {
+				logger.Info("Sent due reminders", zap.Int("count", len(reminded)))
+			}
+		}
+	}
+}
+
+// startGateway serves the HTTP/JSON gateway on cfg.GatewayPort, forwarding
+// to the gRPC server on this host so its interceptors apply to every request
+func startGateway(ctx context.Context, cfg *config.Config, logger *zap.Logger) *gateway.Gateway {
+	dialCreds := insecure.NewCredentials()
+	if cfg.TLSEnabled {
+		// The loopback connection trusts the server's own certificate, which
+		// must therefore be valid for localhost
+		creds, err := credentials.NewClientTLSFromFile(cfg.TLSCertFile, "localhost")
+		if err != nil {
+			logger.Fatal("Failed to load gateway TLS credentials", zap.Error(err))
+		}
+		dialCreds = creds
+	}
+
+	gw, err := gateway.New(ctx, gateway.Config{
+		Endpoint:       fmt.Sprintf("localhost:%d", cfg.Port),
+		DialOptions:    []grpc.DialOption{grpc.WithTransportCredentials(dialCreds)},
+		AllowedOrigins: cfg.GatewayAllowedOrigins,
+	}, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize gateway", zap.Error(err))
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GatewayPort))
+	if err != nil {
+		logger.Fatal("Failed to listen for gateway", zap.Error(err))
+	}
+
+	go func() {
+		logger.Info("Gateway starting", zap.Int("port", cfg.GatewayPort))
+		serve := func() error { return gw.Serve(lis) }
+		if cfg.TLSEnabled {
+			serve = func() error { return gw.ServeTLS(lis, cfg.TLSCertFile, cfg.TLSKeyFile) }
+		}
+		if err := serve(); err != nil {
+			logger.Fatal("Failed to serve gateway", zap.Error(err))
+		}
+	}()
+
+	return gw
+}
+
+// registerServices registers the todo service plus the health and reflection
+// services enabled in cfg. It returns the health server, or nil when disabled.
+func registerServices(grpcServer *grpc.Server, cfg *config.Config, todoService todov1.TodoServiceServer) *grpchealth.Server {
+	todov1.RegisterTodoServiceServer(grpcServer, todoService)
+
+	var healthServer *grpchealth.Server
+	if cfg.EnableHealthCheck {
+		healthServer = grpchealth.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	}
+
+	if cfg.EnableReflection {
+		reflection.Register(grpcServer)
+	}
+
+	return healthServer
+}
+
+func initGRPCServer(cfg *config.Config, logger *zap.Logger, keyFunc auth.Keyfunc, apiKeys auth.APIKeyStore, tenantIDs *auth.TenantIDValidator, drainer *interceptors.StreamDrainer) *grpc.Server {
 	opts := []grpc.ServerOption{
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     15 * time.Minute,
@@ -243,10 +612,18 @@ func initGRPCServer(cfg *config.Config, logger *zap.Logger) *grpc.Server {
 		grpc.ChainUnaryInterceptor(
 			interceptors.RecoveryInterceptor(logger),
 			interceptors.LoggingInterceptor(logger),
-			interceptors.MetricsInterceptor(),
-			interceptors.AuthInterceptor(cfg.JWTSecret),
+			interceptors.MetricsInterceptor(cfg.EnablePayloadMetrics),
+			interceptors.AuthInterceptor(keyFunc, apiKeys, tenantIDs),
+			interceptors.AuditInterceptor(interceptors.NewJSONAuditSink(os.Stdout), logger),
+			interceptors.ValidationInterceptor(),
 			// interceptors.RateLimitInterceptor(cfg.RateLimitRPS),
 		),
+		grpc.ChainStreamInterceptor(
+			interceptors.StreamRecoveryInterceptor(logger),
+			interceptors.StreamAuthInterceptor(keyFunc, apiKeys, tenantIDs),
+			interceptors.StreamValidationInterceptor(),
+			drainer.StreamInterceptor(),
+		),
 	}
 
 	// TLS configuration for production
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/config"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/memory"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/storage"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// withAttachment creates a todo in repo with one attachment stored in
+// objectStorage and returns the todo and the attachment's key
+func withAttachment(t *testing.T, repo domain.Repository, objectStorage *storage.MemoryStorage) (*domain.Todo, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	todo, err := domain.NewTodo("with attachment", "", "alice", "tenant-1", domain.PriorityMedium)
+	if err != nil {
+		t.Fatalf("NewTodo failed: %v", err)
+	}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	key, err := domain.NewAttachmentKey(todo.TenantID, todo.ID, "notes.txt")
+	if err != nil {
+		t.Fatalf("NewAttachmentKey failed: %v", err)
+	}
+	objectStorage.Put(key, "text/plain", 4)
+	err = repo.AddAttachment(ctx, &domain.Attachment{
+		ID:          domain.NewID(),
+		TodoID:      todo.ID,
+		TenantID:    todo.TenantID,
+		ObjectKey:   key,
+		Filename:    "notes.txt",
+		ContentType: "text/plain",
+		SizeBytes:   4,
+		UploadedBy:  "alice",
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	return todo, key
+}
+
+func TestPurgeJobDeletesAttachmentObjects(t *testing.T) {
+	repo := memory.NewInMemoryRepository()
+	objectStorage := storage.NewMemoryStorage()
+	todo, key := withAttachment(t, repo, objectStorage)
+	if err := repo.Delete(context.Background(), todo.ID, todo.TenantID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// A cancelled context runs the job once; a negative retention purges
+	// everything deleted so far
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runPurgeJob(ctx, repo, objectStorage, -time.Minute, zap.NewNop())
+
+	if _, err := repo.GetByIDIncludingDeleted(context.Background(), todo.ID, todo.TenantID); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the todo to be purged, got %v", err)
+	}
+	if _, err := objectStorage.Stat(context.Background(), key); !errors.Is(err, domain.ErrObjectNotFound) {
+		t.Fatalf("expected the attachment object to be deleted, got %v", err)
+	}
+}
+
+func TestArchivePurgeJobDeletesAttachmentObjects(t *testing.T) {
+	repo := memory.NewInMemoryRepository()
+	objectStorage := storage.NewMemoryStorage()
+	todo, key := withAttachment(t, repo, objectStorage)
+
+	completedAt := time.Now().UTC().Add(-time.Hour)
+	if _, err := repo.UpdateStatus(context.Background(), todo.ID, todo.TenantID, domain.StatusCompleted, &completedAt, nil, todo.Version); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if _, err := repo.ArchiveCompletedOlderThan(context.Background(), "", time.Minute); err != nil {
+		t.Fatalf("ArchiveCompletedOlderThan failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runArchivePurgeJob(ctx, repo, objectStorage, -time.Minute, zap.NewNop())
+
+	if _, err := repo.GetByIDIncludingDeleted(context.Background(), todo.ID, todo.TenantID); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the todo to be purged, got %v", err)
+	}
+	if _, err := objectStorage.Stat(context.Background(), key); !errors.Is(err, domain.ErrObjectNotFound) {
+		t.Fatalf("expected the attachment object to be deleted, got %v", err)
+	}
+}
+
+func TestRegisterServices(t *testing.T) {
+	const (
+		healthService     = "grpc.health.v1.Health"
+		reflectionService = "grpc.reflection.v1.ServerReflection"
+	)
+
+	tests := []struct {
+		name       string
+		health     bool
+		reflection bool
+	}{
+		{name: "todo only"},
+		{name: "health", health: true},
+		{name: "reflection", reflection: true},
+		{name: "all", health: true, reflection: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{EnableHealthCheck: tt.health, EnableReflection: tt.reflection}
+			grpcServer := grpc.NewServer()
+			healthServer := registerServices(grpcServer, cfg, todov1.UnimplementedTodoServiceServer{})
+
+			lis := bufconn.Listen(1 << 20)
+			go grpcServer.Serve(lis)
+			t.Cleanup(grpcServer.Stop)
+
+			services := grpcServer.GetServiceInfo()
+			if _, ok := services[todov1.TodoService_ServiceDesc.ServiceName]; !ok {
+				t.Error("todo service is not registered")
+			}
+			if _, ok := services[reflectionService]; ok != tt.reflection {
+				t.Errorf("reflection registered = %v, want %v", ok, tt.reflection)
+			}
+			if _, ok := services[healthService]; ok != tt.health {
+				t.Errorf("health registered = %v, want %v", ok, tt.health)
+			}
+			if (healthServer != nil) != tt.health {
+				t.Errorf("health server returned = %v, want %v", healthServer != nil, tt.health)
+			}
+
+			conn, err := grpc.NewClient("passthrough:///bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return lis.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+			defer conn.Close()
+
+			// A client probing health sees SERVING, or Unimplemented when disabled
+			resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if tt.health {
+				if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+					t.Fatalf("expected SERVING, got %v, %v", resp, err)
+				}
+			} else if status.Code(err) != codes.Unimplemented {
+				t.Fatalf("expected Unimplemented with health disabled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestInitTracer(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	tests := []struct {
+		name    string
+		cfg     config.ObservabilityConfig
+		wantSDK bool
+	}{
+		{name: "disabled", cfg: config.ObservabilityConfig{JaegerEndpoint: "localhost:4317", TraceSampleRatio: 1}},
+		{name: "enabled", cfg: config.ObservabilityConfig{EnableTracing: true, JaegerEndpoint: "localhost:4317", TraceSampleRatio: 0.5}, wantSDK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown := initTracer(tt.cfg, zap.NewNop())
+
+			_, isSDK := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+			_, isNoop := otel.GetTracerProvider().(noop.TracerProvider)
+			if isSDK != tt.wantSDK || isNoop == tt.wantSDK {
+				t.Fatalf("tracer provider = %T, want SDK %v", otel.GetTracerProvider(), tt.wantSDK)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				t.Fatalf("shutdown: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListArchived lists the caller's archived todos, most recently archived
+// first, with when each will be purged under the archive retention
+func (s *TodoServiceServer) ListArchived(ctx context.Context, req *todov1.ListArchivedRequest) (*todov1.ListArchivedResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ListArchived")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	filter := &domain.ListFilter{
+		TenantID: userCtx.TenantID,
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+		Statuses: []domain.TodoStatus{domain.StatusArchived},
+		Sort:     []domain.SortSpec{{Field: "archived_at", Ascending: false}},
+	}
+	s.restrictToReadable(userCtx, filter)
+
+	if err := filter.Validate(s.pageSizes); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	span.SetAttributes(attribute.String("tenant.id", userCtx.TenantID))
+
+	todos, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list archived todos",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to list archived todos")
+	}
+
+	resp := &todov1.ListArchivedResponse{
+		Todos:    make([]*todov1.ArchivedTodo, 0, len(todos)),
+		PageInfo: pageInfo(filter.Page, filter.PageSize, total),
+	}
+	if s.archiveRetention > 0 {
+		resp.Retention = durationpb.New(s.archiveRetention)
+	}
+
+	for _, todo := range todos {
+		archived := &todov1.ArchivedTodo{Todo: mapDomainToProto(todo)}
+		if s.archiveRetention > 0 && todo.ArchivedAt != nil {
+			archived.PurgeAt = timestamppb.New(todo.ArchivedAt.Add(s.archiveRetention))
+		}
+		resp.Todos = append(resp.Todos, archived)
+	}
+	return resp, nil
+}
@@ -0,0 +1,245 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultAttachmentContentType = "application/octet-stream"
+
+func (s *TodoServiceServer) CreateAttachmentUploadURL(ctx context.Context, req *todov1.CreateAttachmentUploadURLRequest) (*todov1.CreateAttachmentUploadURLResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "CreateAttachmentUploadURL")
+	defer span.End()
+
+	if s.storage == nil {
+		return nil, status.Error(codes.Unimplemented, "attachments are not enabled")
+	}
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if err := s.validateUploadRequest(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.updatableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	key, err := domain.NewAttachmentKey(userCtx.TenantID, req.TodoId, req.Filename)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = defaultAttachmentContentType
+	}
+
+	expiresAt := time.Now().UTC().Add(s.uploadURLExpiry)
+	url, err := s.storage.PresignPut(ctx, key, contentType, req.SizeBytes, s.uploadURLExpiry)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to presign attachment upload",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+		)
+		return nil, status.Error(codes.Internal, "failed to create upload URL")
+	}
+
+	return &todov1.CreateAttachmentUploadURLResponse{
+		UploadUrl: url,
+		ObjectKey: key,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+func (s *TodoServiceServer) ConfirmAttachmentUpload(ctx context.Context, req *todov1.ConfirmAttachmentUploadRequest) (*todov1.ConfirmAttachmentUploadResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ConfirmAttachmentUpload")
+	defer span.End()
+
+	if s.storage == nil {
+		return nil, status.Error(codes.Unimplemented, "attachments are not enabled")
+	}
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	// Only keys issued for this todo may be confirmed against it
+	prefix := domain.AttachmentKeyPrefix(userCtx.TenantID, req.TodoId)
+	if req.ObjectKey == "" || !strings.HasPrefix(req.ObjectKey, prefix) {
+		return nil, fieldError("object_key", "object key does not belong to this todo")
+	}
+
+	if _, err := s.updatableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	info, err := s.storage.Stat(ctx, req.ObjectKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrObjectNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, "attachment has not been uploaded")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to stat attachment",
+			zap.Error(err),
+			zap.String("object_key", req.ObjectKey),
+		)
+		return nil, status.Error(codes.Internal, "failed to verify upload")
+	}
+
+	if s.maxAttachmentSize > 0 && info.SizeBytes > s.maxAttachmentSize {
+		return nil, mapDomainError(domain.ErrAttachmentTooLarge)
+	}
+
+	attachment := &domain.Attachment{
+		ID:          domain.NewID(),
+		TodoID:      req.TodoId,
+		TenantID:    userCtx.TenantID,
+		ObjectKey:   req.ObjectKey,
+		Filename:    path.Base(req.ObjectKey),
+		ContentType: info.ContentType,
+		SizeBytes:   info.SizeBytes,
+		UploadedBy:  userCtx.UserID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.repo.AddAttachment(ctx, attachment); err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to record attachment",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+		)
+		return nil, status.Error(codes.Internal, "failed to record attachment")
+	}
+
+	return &todov1.ConfirmAttachmentUploadResponse{
+		Attachment: mapAttachmentToProto(attachment),
+	}, nil
+}
+
+func (s *TodoServiceServer) ListAttachments(ctx context.Context, req *todov1.ListAttachmentsRequest) (*todov1.ListAttachmentsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ListAttachments")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if _, err := s.readableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.ListAttachments(ctx, req.TodoId, userCtx.TenantID)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list attachments",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+		)
+		return nil, status.Error(codes.Internal, "failed to list attachments")
+	}
+
+	protoAttachments := make([]*todov1.TodoAttachment, len(attachments))
+	for i, attachment := range attachments {
+		protoAttachments[i] = mapAttachmentToProto(attachment)
+	}
+
+	return &todov1.ListAttachmentsResponse{
+		Attachments: protoAttachments,
+	}, nil
+}
+
+func (s *TodoServiceServer) validateUploadRequest(req *todov1.CreateAttachmentUploadURLRequest) error {
+	var violations fieldViolations
+
+	if err := domain.ValidateFilename(req.Filename); err != nil {
+		violations.addDomainError(err)
+	}
+	if req.SizeBytes <= 0 {
+		violations.add("size_bytes", "size must be positive")
+	} else if s.maxAttachmentSize > 0 && req.SizeBytes > s.maxAttachmentSize {
+		violations.addDomainError(domain.ErrAttachmentTooLarge)
+	}
+
+	return violations.err()
+}
+
+// updatableTodo loads a todo and checks the caller may modify it
+func (s *TodoServiceServer) updatableTodo(ctx context.Context, userCtx *auth.UserContext, todoID string) (*domain.Todo, error) {
+	if todoID == "" {
+		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+
+	// The todo is about to be written, so read it from the primary
+	ctx = domain.WithReadYourWrites(ctx)
+	todo, err := s.repo.GetByID(ctx, todoID, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	if !s.authz.CanUpdate(userCtx, todo) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	return todo, nil
+}
+
+func mapAttachmentToProto(attachment *domain.Attachment) *todov1.TodoAttachment {
+	return &todov1.TodoAttachment{
+		Id:          attachment.ID,
+		TodoId:      attachment.TodoID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		UploadedBy:  attachment.UploadedBy,
+		CreatedAt:   timestamppb.New(attachment.CreatedAt),
+	}
+}
+
+// deleteObjects removes the stored files of attachments whose todo was erased.
+// The todo is already gone, so a failure is logged rather than returned.
+func (s *TodoServiceServer) deleteObjects(ctx context.Context, keys []string) {
+	if s.storage == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			loggerFromContext(ctx, s.logger).Error("failed to delete attachment object",
+				zap.Error(err),
+				zap.String("object_key", key),
+			)
+		}
+	}
+}
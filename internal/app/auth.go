@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuthServiceServer issues tokens to users whose credentials the verifier
+// accepts. Its methods run without an authenticated caller.
+type AuthServiceServer struct {
+	todov1.UnimplementedAuthServiceServer
+	issuer   *auth.TokenIssuer
+	verifier auth.CredentialVerifier
+	logger   *zap.Logger
+	tracer   trace.Tracer
+}
+
+func NewAuthServiceServer(issuer *auth.TokenIssuer, verifier auth.CredentialVerifier, logger *zap.Logger) *AuthServiceServer {
+	return &AuthServiceServer{
+		issuer:   issuer,
+		verifier: verifier,
+		logger:   logger,
+		tracer:   otel.Tracer("auth-service"),
+	}
+}
+
+func (s *AuthServiceServer) Login(ctx context.Context, req *todov1.LoginRequest) (*todov1.TokenResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "Login")
+	defer span.End()
+
+	var violations fieldViolations
+	if req.TenantId == "" {
+		violations.add("tenant_id", "tenant ID is required")
+	}
+	if req.Username == "" {
+		violations.add("username", "username is required")
+	}
+	if req.Password == "" {
+		violations.add("password", "password is required")
+	}
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("tenant.id", req.TenantId))
+
+	userCtx, err := s.verifier.VerifyCredentials(ctx, req.TenantId, req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to verify credentials",
+			zap.Error(err),
+			zap.String("tenant_id", req.TenantId),
+		)
+		return nil, status.Error(codes.Internal, "failed to verify credentials")
+	}
+
+	pair, err := s.issuer.Issue(userCtx)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to issue tokens",
+			zap.Error(err),
+			zap.String("user_id", userCtx.UserID),
+		)
+		return nil, status.Error(codes.Internal, "failed to issue tokens")
+	}
+
+	loggerFromContext(ctx, s.logger).Info("user logged in",
+		zap.String("user_id", userCtx.UserID),
+		zap.String("tenant_id", userCtx.TenantID),
+	)
+
+	return mapTokenPairToProto(pair), nil
+}
+
+func (s *AuthServiceServer) RefreshToken(ctx context.Context, req *todov1.RefreshTokenRequest) (*todov1.TokenResponse, error) {
+	_, span := s.tracer.Start(ctx, "RefreshToken")
+	defer span.End()
+
+	if req.RefreshToken == "" {
+		return nil, fieldError("refresh_token", "refresh token is required")
+	}
+
+	pair, err := s.issuer.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidRefreshToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to refresh tokens", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to issue tokens")
+	}
+
+	return mapTokenPairToProto(pair), nil
+}
+
+func mapTokenPairToProto(pair *auth.TokenPair) *todov1.TokenResponse {
+	return &todov1.TokenResponse{
+		AccessToken:           pair.AccessToken,
+		TokenType:             "Bearer",
+		AccessTokenExpiresAt:  timestamppb.New(pair.AccessExpiresAt),
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(pair.RefreshExpiresAt),
+	}
+}
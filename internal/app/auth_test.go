@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVerifier accepts alice's password and fails every lookup with err
+type fakeVerifier struct {
+	err error
+}
+
+func (v *fakeVerifier) VerifyCredentials(ctx context.Context, tenantID, username, password string) (*auth.UserContext, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	if username != "alice" || password != "correct horse" {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return &auth.UserContext{UserID: "user-alice", TenantID: tenantID, Roles: []string{"user"}}, nil
+}
+
+func newTestAuthService(t *testing.T, verifier auth.CredentialVerifier) *AuthServiceServer {
+	t.Helper()
+	keyFunc, err := auth.NewKeyfunc(auth.KeyConfig{Algorithm: "HS256", Secrets: []string{"test-secret"}})
+	if err != nil {
+		t.Fatalf("NewKeyfunc: %v", err)
+	}
+	issuer, err := auth.NewTokenIssuer(auth.IssuerConfig{Secret: "test-secret", AccessTTL: time.Minute, RefreshTTL: time.Hour}, keyFunc)
+	if err != nil {
+		t.Fatalf("NewTokenIssuer: %v", err)
+	}
+	return NewAuthServiceServer(issuer, verifier, zap.NewNop())
+}
+
+func TestLogin(t *testing.T) {
+	s := newTestAuthService(t, &fakeVerifier{})
+
+	resp, err := s.Login(context.Background(), &todov1.LoginRequest{TenantId: testTenant, Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if resp.AccessToken == "" || resp.RefreshToken == "" || resp.TokenType != "Bearer" {
+		t.Fatalf("expected a bearer token pair, got %+v", resp)
+	}
+	if !resp.RefreshTokenExpiresAt.AsTime().After(resp.AccessTokenExpiresAt.AsTime()) {
+		t.Fatal("expected the refresh token to outlive the access token")
+	}
+
+	refreshed, err := s.RefreshToken(context.Background(), &todov1.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	if err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	if refreshed.AccessToken == "" || refreshed.RefreshToken == "" {
+		t.Fatalf("expected a new token pair, got %+v", refreshed)
+	}
+
+	if _, err := s.RefreshToken(context.Background(), &todov1.RefreshTokenRequest{RefreshToken: resp.AccessToken}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated refreshing with an access token, got %v", err)
+	}
+	_, err = s.RefreshToken(context.Background(), &todov1.RefreshTokenRequest{})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"refresh_token"}) {
+		t.Fatalf("violated fields = %v, want [refresh_token]", fields)
+	}
+}
+
+func TestLoginFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier *fakeVerifier
+		req      *todov1.LoginRequest
+		code     codes.Code
+	}{
+		{
+			name:     "wrong password",
+			verifier: &fakeVerifier{},
+			req:      &todov1.LoginRequest{TenantId: testTenant, Username: "alice", Password: "wrong"},
+			code:     codes.Unauthenticated,
+		},
+		{
+			name:     "verifier unavailable",
+			verifier: &fakeVerifier{err: errors.New("directory unreachable")},
+			req:      &todov1.LoginRequest{TenantId: testTenant, Username: "alice", Password: "correct horse"},
+			code:     codes.Internal,
+		},
+		{
+			name:     "missing fields",
+			verifier: &fakeVerifier{},
+			req:      &todov1.LoginRequest{Username: "alice"},
+			code:     codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestAuthService(t, tt.verifier).Login(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if tt.code == codes.InvalidArgument {
+				if fields := violatedFields(t, err); !slices.Equal(fields, []string{"tenant_id", "password"}) {
+					t.Fatalf("violated fields = %v, want [tenant_id password]", fields)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultCommentLimit = 50
+	maxCommentLimit     = 200
+)
+
+func (s *TodoServiceServer) AddComment(ctx context.Context, req *todov1.AddCommentRequest) (*todov1.AddCommentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "AddComment")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if _, err := s.readableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	comment, err := domain.NewComment(req.TodoId, userCtx.TenantID, userCtx.UserID, req.Body)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	if err := s.repo.AddComment(ctx, comment); err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to add comment",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+		)
+		return nil, status.Error(codes.Internal, "failed to add comment")
+	}
+
+	return &todov1.AddCommentResponse{
+		Comment: mapCommentToProto(comment),
+	}, nil
+}
+
+func (s *TodoServiceServer) ListComments(ctx context.Context, req *todov1.ListCommentsRequest) (*todov1.ListCommentsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ListComments")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if _, err := s.readableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit < 1 {
+		limit = defaultCommentLimit
+	}
+	if limit > maxCommentLimit {
+		limit = maxCommentLimit
+	}
+
+	comments, err := s.repo.ListComments(ctx, req.TodoId, userCtx.TenantID, limit)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list comments",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+		)
+		return nil, status.Error(codes.Internal, "failed to list comments")
+	}
+
+	protoComments := make([]*todov1.TodoComment, len(comments))
+	for i, comment := range comments {
+		protoComments[i] = mapCommentToProto(comment)
+	}
+
+	return &todov1.ListCommentsResponse{
+		Comments: protoComments,
+	}, nil
+}
+
+func (s *TodoServiceServer) DeleteComment(ctx context.Context, req *todov1.DeleteCommentRequest) (*todov1.DeleteCommentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "DeleteComment")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("comment.id", req.CommentId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.CommentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "comment ID is required")
+	}
+
+	if _, err := s.readableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.GetComment(ctx, req.CommentId, req.TodoId, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			return nil, status.Error(codes.NotFound, "comment not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve comment")
+	}
+
+	if !s.authz.CanDeleteComment(userCtx, comment) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	if err := s.repo.DeleteComment(ctx, req.CommentId, req.TodoId, userCtx.TenantID); err != nil {
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			return nil, status.Error(codes.NotFound, "comment not found")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to delete comment",
+			zap.Error(err),
+			zap.String("comment_id", req.CommentId),
+		)
+		return nil, status.Error(codes.Internal, "failed to delete comment")
+	}
+
+	return &todov1.DeleteCommentResponse{
+		Success: true,
+	}, nil
+}
+
+// readableTodo loads a todo and checks the caller may read it; comments
+// follow the same read authorization as their todo
+func (s *TodoServiceServer) readableTodo(ctx context.Context, userCtx *auth.UserContext, todoID string) (*domain.Todo, error) {
+	if todoID == "" {
+		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+
+	todo, err := s.repo.GetByID(ctx, todoID, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	if !s.authz.CanRead(userCtx, todo) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	return todo, nil
+}
+
+func mapCommentToProto(comment *domain.Comment) *todov1.TodoComment {
+	return &todov1.TodoComment{
+		Id:        comment.ID,
+		TodoId:    comment.TodoID,
+		AuthorId:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: timestamppb.New(comment.CreatedAt),
+	}
+}
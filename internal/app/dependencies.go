@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TodoServiceServer) AddDependency(ctx context.Context, req *todov1.AddDependencyRequest) (*todov1.AddDependencyResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "AddDependency")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("depends_on.id", req.DependsOnId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.DependsOnId == "" {
+		return nil, fieldError("depends_on_id", "depends_on_id is required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.updatableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+	if _, err := s.readableTodo(ctx, userCtx, req.DependsOnId); err != nil {
+		return nil, err
+	}
+
+	dep, err := domain.NewDependency(req.TodoId, req.DependsOnId, userCtx.TenantID, userCtx.UserID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	if err := s.repo.AddDependency(ctx, dep); err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) || errors.Is(err, domain.ErrDependencyCycle) || errors.Is(err, domain.ErrSelfDependency) {
+			return nil, mapDomainError(err)
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to add dependency",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+			zap.String("depends_on_id", req.DependsOnId),
+		)
+		return nil, status.Error(codes.Internal, "failed to add dependency")
+	}
+
+	loggerFromContext(ctx, s.logger).Info("dependency added",
+		zap.String("todo_id", req.TodoId),
+		zap.String("depends_on_id", req.DependsOnId),
+	)
+
+	return &todov1.AddDependencyResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *TodoServiceServer) RemoveDependency(ctx context.Context, req *todov1.RemoveDependencyRequest) (*todov1.RemoveDependencyResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "RemoveDependency")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("depends_on.id", req.DependsOnId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.DependsOnId == "" {
+		return nil, fieldError("depends_on_id", "depends_on_id is required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.updatableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveDependency(ctx, req.TodoId, req.DependsOnId, userCtx.TenantID); err != nil {
+		if errors.Is(err, domain.ErrDependencyNotFound) {
+			return nil, status.Error(codes.NotFound, "dependency not found")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to remove dependency",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+			zap.String("depends_on_id", req.DependsOnId),
+		)
+		return nil, status.Error(codes.Internal, "failed to remove dependency")
+	}
+
+	loggerFromContext(ctx, s.logger).Info("dependency removed",
+		zap.String("todo_id", req.TodoId),
+		zap.String("depends_on_id", req.DependsOnId),
+	)
+
+	return &todov1.RemoveDependencyResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *TodoServiceServer) ListDependencies(ctx context.Context, req *todov1.ListDependenciesRequest) (*todov1.ListDependenciesResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ListDependencies")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.TodoId),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.readableTodo(ctx, userCtx, req.TodoId); err != nil {
+		return nil, err
+	}
+
+	deps, err := s.repo.ListDependencies(ctx, req.TodoId, userCtx.TenantID)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list dependencies",
+			zap.Error(err),
+			zap.String("todo_id", req.TodoId),
+		)
+		return nil, status.Error(codes.Internal, "failed to list dependencies")
+	}
+
+	// Linked todos the caller may not read are left out
+	resp := &todov1.ListDependenciesResponse{
+		BlockedBy: make([]*todov1.Todo, 0, len(deps.BlockedBy)),
+		Blocks:    make([]*todov1.Todo, 0, len(deps.Blocks)),
+	}
+	for _, todo := range deps.BlockedBy {
+		if s.authz.CanRead(userCtx, todo) {
+			resp.BlockedBy = append(resp.BlockedBy, mapDomainToProto(todo))
+		}
+	}
+	for _, todo := range deps.Blocks {
+		if s.authz.CanRead(userCtx, todo) {
+			resp.Blocks = append(resp.Blocks, mapDomainToProto(todo))
+		}
+	}
+
+	return resp, nil
+}
+
+// checkDependenciesResolved rejects completing todo while dependency
+// blocking is enabled and a todo it depends on is still open
+func (s *TodoServiceServer) checkDependenciesResolved(ctx context.Context, todo *domain.Todo) error {
+	if !s.blockOnDependencies {
+		return nil
+	}
+
+	deps, err := s.repo.ListDependencies(ctx, todo.ID, todo.TenantID)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to check dependencies",
+			zap.Error(err),
+			zap.String("todo_id", todo.ID),
+		)
+		return status.Error(codes.Internal, "failed to check dependencies")
+	}
+
+	if err := domain.CheckDependenciesResolved(deps.BlockedBy); err != nil {
+		return mapDomainError(err)
+	}
+	return nil
+}
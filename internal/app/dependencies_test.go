@@ -0,0 +1,99 @@
+package app
+
+import (
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDependencies(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	design := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "design"})
+	build := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "build"})
+	bobs := createTodo(t, s, asUser("bob"), &todov1.CreateTodoRequest{Title: "bob's"})
+
+	if _, err := s.AddDependency(alice, &todov1.AddDependencyRequest{TodoId: build.Id, DependsOnId: design.Id}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *todov1.AddDependencyRequest
+		code codes.Code
+	}{
+		{name: "missing depends_on_id", req: &todov1.AddDependencyRequest{TodoId: build.Id}, code: codes.InvalidArgument},
+		{name: "self", req: &todov1.AddDependencyRequest{TodoId: build.Id, DependsOnId: build.Id}, code: codes.InvalidArgument},
+		{name: "cycle", req: &todov1.AddDependencyRequest{TodoId: design.Id, DependsOnId: build.Id}, code: codes.FailedPrecondition},
+		{name: "unreadable todo", req: &todov1.AddDependencyRequest{TodoId: build.Id, DependsOnId: bobs.Id}, code: codes.PermissionDenied},
+		{name: "missing todo", req: &todov1.AddDependencyRequest{TodoId: build.Id, DependsOnId: "missing"}, code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.AddDependency(alice, tt.req); status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	resp, err := s.ListDependencies(alice, &todov1.ListDependenciesRequest{TodoId: design.Id})
+	if err != nil {
+		t.Fatalf("ListDependencies failed: %v", err)
+	}
+	if len(resp.BlockedBy) != 0 || len(resp.Blocks) != 1 || resp.Blocks[0].Id != build.Id {
+		t.Fatalf("expected design to block build only, got %v and %v", todoIDs(resp.BlockedBy), todoIDs(resp.Blocks))
+	}
+
+	if _, err := s.RemoveDependency(alice, &todov1.RemoveDependencyRequest{TodoId: build.Id, DependsOnId: design.Id}); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if _, err := s.RemoveDependency(alice, &todov1.RemoveDependencyRequest{TodoId: build.Id, DependsOnId: design.Id}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound removing it again, got %v", err)
+	}
+}
+
+func TestDependencyBlocking(t *testing.T) {
+	for _, blocking := range []bool{false, true} {
+		s := newTestService(t, nil, WithDependencyBlocking(blocking))
+		alice := asUser("alice")
+		design := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "design"})
+		build := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "build"})
+		if _, err := s.AddDependency(alice, &todov1.AddDependencyRequest{TodoId: build.Id, DependsOnId: design.Id}); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+
+		setStatus := func(id string, newStatus todov1.TodoStatus) error {
+			_, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{Id: id, NewStatus: newStatus})
+			return err
+		}
+		complete := func(id string) error {
+			return setStatus(id, todov1.TodoStatus_TODO_STATUS_COMPLETED)
+		}
+		for _, id := range []string{design.Id, build.Id} {
+			if err := setStatus(id, todov1.TodoStatus_TODO_STATUS_IN_PROGRESS); err != nil {
+				t.Fatalf("starting %s failed: %v", id, err)
+			}
+		}
+
+		want := codes.OK
+		if blocking {
+			want = codes.FailedPrecondition
+		}
+		if err := complete(build.Id); status.Code(err) != want {
+			t.Fatalf("blocking %v: expected %v completing before the dependency, got %v", blocking, want, err)
+		}
+		if !blocking {
+			continue
+		}
+
+		if err := complete(design.Id); err != nil {
+			t.Fatalf("completing the dependency failed: %v", err)
+		}
+		if err := complete(build.Id); err != nil {
+			t.Fatalf("completing after the dependency failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultDueSoonWindow = 7 * 24 * time.Hour
+	maxDueSoonWindow     = 90 * 24 * time.Hour
+)
+
+func (s *TodoServiceServer) ListDueSoon(ctx context.Context, req *todov1.ListDueSoonRequest) (*todov1.ListDueSoonResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ListDueSoon")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	within := defaultDueSoonWindow
+	if req.Within != nil {
+		if err := req.Within.CheckValid(); err != nil || req.Within.AsDuration() <= 0 {
+			return nil, fieldError("within", "window must be a positive duration")
+		}
+		within = min(req.Within.AsDuration(), maxDueSoonWindow)
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant.id", userCtx.TenantID),
+		attribute.Int64("within_seconds", int64(within.Seconds())),
+	)
+
+	todos, err := s.repo.DueWithin(ctx, userCtx.TenantID, within)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list todos due soon",
+			zap.Error(err),
+			zap.Duration("within", within),
+		)
+		return nil, status.Error(codes.Internal, "failed to list todos due soon")
+	}
+
+	resp := &todov1.ListDueSoonResponse{Todos: make([]*todov1.Todo, 0, len(todos))}
+	for _, todo := range todos {
+		if s.authz.CanRead(userCtx, todo) {
+			resp.Todos = append(resp.Todos, mapDomainToProto(todo))
+		}
+	}
+	return resp, nil
+}
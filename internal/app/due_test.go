@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListDueSoon(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+	now := time.Now().UTC()
+	due := func(ctx context.Context, title string, in time.Duration) string {
+		return createTodo(t, s, ctx, &todov1.CreateTodoRequest{Title: title, DueDate: timestamppb.New(now.Add(in))}).Id
+	}
+
+	tomorrow := due(alice, "tomorrow", 24*time.Hour)
+	nextWeek := due(alice, "next week", 6*24*time.Hour)
+	nextMonth := due(alice, "next month", 30*24*time.Hour)
+	due(bob, "bob's", time.Hour)
+	createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "no due date"})
+
+	tests := []struct {
+		name   string
+		within *durationpb.Duration
+		want   []string
+	}{
+		{name: "default window", want: []string{tomorrow, nextWeek}},
+		{name: "narrow window", within: durationpb.New(48 * time.Hour), want: []string{tomorrow}},
+		{name: "capped window", within: durationpb.New(365 * 24 * time.Hour), want: []string{tomorrow, nextWeek, nextMonth}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListDueSoon(alice, &todov1.ListDueSoonRequest{Within: tt.within})
+			if err != nil {
+				t.Fatalf("ListDueSoon failed: %v", err)
+			}
+			ids := make([]string, len(resp.Todos))
+			for i, todo := range resp.Todos {
+				ids[i] = todo.Id
+			}
+			// Only alice's own todos are visible, soonest first
+			if !slices.Equal(ids, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+
+	for _, within := range []*durationpb.Duration{durationpb.New(0), durationpb.New(-time.Hour)} {
+		_, err := s.ListDueSoon(alice, &todov1.ListDueSoonRequest{Within: within})
+		if fields := violatedFields(t, err); !slices.Equal(fields, []string{"within"}) {
+			t.Fatalf("violated fields for %v = %v, want [within]", within.AsDuration(), fields)
+		}
+	}
+
+	if _, err := s.ListDueSoon(context.Background(), &todov1.ListDueSoonRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a user, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/dmehra2102/TaskForge/pkg/requestid"
+	"go.uber.org/zap"
+)
+
+// loggerFromContext returns logger with the request ID and caller of ctx
+// attached, so handler logs can be joined to the interceptor's request logs
+func loggerFromContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields := make([]zap.Field, 0, 3)
+	if id, ok := requestid.FromContext(ctx); ok {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if userCtx, err := auth.UserContextFromContext(ctx); err == nil {
+		fields = append(fields,
+			zap.String("user_id", userCtx.UserID),
+			zap.String("tenant_id", userCtx.TenantID),
+		)
+	}
+
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/memory"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/dmehra2102/TaskForge/pkg/requestid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	caller := &auth.UserContext{UserID: "alice", TenantID: testTenant}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]any
+	}{
+		{name: "bare context", ctx: context.Background(), want: map[string]any{}},
+		{
+			name: "request ID only",
+			ctx:  requestid.NewContext(context.Background(), "req-1"),
+			want: map[string]any{"request_id": "req-1"},
+		},
+		{
+			name: "request and caller",
+			ctx:  auth.ContextWithUserContext(requestid.NewContext(context.Background(), "req-1"), caller),
+			want: map[string]any{"request_id": "req-1", "user_id": "alice", "tenant_id": testTenant},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			loggerFromContext(tt.ctx, zap.New(core)).Info("handled")
+
+			if got := logs.All()[0].ContextMap(); !maps.Equal(got, tt.want) {
+				t.Fatalf("log fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerLogsCarryRequestContext(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s := NewTodoServiceServer(memory.NewInMemoryRepository(), zap.New(core), auth.NewAuthorizer())
+
+	ctx := requestid.NewContext(asUser("alice"), "req-7")
+	createTodo(t, s, ctx, &todov1.CreateTodoRequest{Title: "logged"})
+
+	created := logs.FilterMessage("todo created").All()
+	if len(created) != 1 {
+		t.Fatalf("expected one todo created log, got %d", len(created))
+	}
+	fields := created[0].ContextMap()
+	if fields["request_id"] != "req-7" || fields["user_id"] != "alice" || fields["tenant_id"] != testTenant {
+		t.Fatalf("log fields = %v, want the request ID and caller", fields)
+	}
+}
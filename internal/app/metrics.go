@@ -0,0 +1,90 @@
+package app
+
+import (
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// otherTenant labels tenants outside the allowlist, keeping the tenant label's
+// cardinality bounded however many tenants there are
+const otherTenant = "other"
+
+// defaultMetrics is registered with the default registry, served on /metrics
+var defaultMetrics = newServiceMetrics(prometheus.DefaultRegisterer)
+
+// serviceMetrics are business metrics recorded by the service itself, for
+// events the gRPC interceptors cannot tell apart
+type serviceMetrics struct {
+	completionSeconds *prometheus.HistogramVec
+	todosCreated      *prometheus.CounterVec
+	todosCompleted    *prometheus.CounterVec
+	todosDeleted      *prometheus.CounterVec
+}
+
+func newServiceMetrics(reg prometheus.Registerer) *serviceMetrics {
+	factory := promauto.With(reg)
+
+	return &serviceMetrics{
+		// Buckets span a minute up to about six months
+		completionSeconds: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "todo_completion_seconds",
+				Help:    "Histogram of the time from creating a todo to completing it",
+				Buckets: prometheus.ExponentialBuckets(60, 4, 10),
+			},
+			[]string{"priority"},
+		),
+		todosCreated: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "todos_created_total",
+				Help: "Total number of todos created",
+			},
+			[]string{"tenant"},
+		),
+		todosCompleted: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "todos_completed_total",
+				Help: "Total number of todos completed",
+			},
+			[]string{"tenant"},
+		),
+		todosDeleted: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "todos_deleted_total",
+				Help: "Total number of todos deleted",
+			},
+			[]string{"tenant"},
+		),
+	}
+}
+
+func (m *serviceMetrics) recordCreated(tenant string, count int) {
+	m.todosCreated.WithLabelValues(tenant).Add(float64(count))
+}
+
+func (m *serviceMetrics) recordCompleted(tenant string) {
+	m.todosCompleted.WithLabelValues(tenant).Inc()
+}
+
+func (m *serviceMetrics) recordDeleted(tenant string, count int64) {
+	m.todosDeleted.WithLabelValues(tenant).Add(float64(count))
+}
+
+// tenantLabel is the tenant label value for tenantID, otherTenant unless
+// the tenant is on the metrics allowlist
+func (s *TodoServiceServer) tenantLabel(tenantID string) string {
+	if s.metricsTenants[tenantID] {
+		return tenantID
+	}
+	return otherTenant
+}
+
+// observeCompletion records how long a just-completed todo took
+func (m *serviceMetrics) observeCompletion(todo *domain.Todo) {
+	if todo.Status != domain.StatusCompleted || todo.CompletedAt == nil {
+		return
+	}
+	elapsed := todo.CompletedAt.Sub(todo.CreatedAt).Seconds()
+	m.completionSeconds.WithLabelValues(priorityNames[todo.Priority]).Observe(elapsed)
+}
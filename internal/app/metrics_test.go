@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTenantCounters(t *testing.T) {
+	s := newTestService(t, nil, WithMetricsRegisterer(prometheus.NewRegistry()), WithMetricsTenants([]string{testTenant}))
+	alice := asUser("alice")
+	outsider := auth.ContextWithUserContext(context.Background(), &auth.UserContext{
+		UserID:   "carol",
+		TenantID: "tenant-2",
+		Roles:    []string{"user"},
+	})
+
+	done := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "done"})
+	removed := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "removed"})
+	if _, err := s.BatchCreateTodos(alice, &todov1.BatchCreateTodosRequest{Requests: []*todov1.CreateTodoRequest{
+		{Title: "batched 1", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+		{Title: "batched 2", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+	}}); err != nil {
+		t.Fatalf("BatchCreateTodos failed: %v", err)
+	}
+	createTodo(t, s, outsider, &todov1.CreateTodoRequest{Title: "elsewhere"})
+
+	for _, newStatus := range []todov1.TodoStatus{todov1.TodoStatus_TODO_STATUS_IN_PROGRESS, todov1.TodoStatus_TODO_STATUS_COMPLETED} {
+		if _, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{Id: done.Id, NewStatus: newStatus}); err != nil {
+			t.Fatalf("UpdateTodoStatus failed: %v", err)
+		}
+	}
+	if _, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Id: removed.Id}); err != nil {
+		t.Fatalf("DeleteTodo failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		counter *prometheus.CounterVec
+		tenant  string
+		want    float64
+	}{
+		{name: "created", counter: s.metrics.todosCreated, tenant: testTenant, want: 4},
+		{name: "completed", counter: s.metrics.todosCompleted, tenant: testTenant, want: 1},
+		{name: "deleted", counter: s.metrics.todosDeleted, tenant: testTenant, want: 1},
+		{name: "created outside the allowlist", counter: s.metrics.todosCreated, tenant: otherTenant, want: 1},
+		{name: "unlisted tenant unlabeled", counter: s.metrics.todosCreated, tenant: "tenant-2", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testutil.ToFloat64(tt.counter.WithLabelValues(tt.tenant)); got != tt.want {
+				t.Fatalf("%s{tenant=%q} = %v, want %v", tt.name, tt.tenant, got, tt.want)
+			}
+		})
+	}
+}
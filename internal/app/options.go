@@ -0,0 +1,136 @@
+package app
+
+import (
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures optional behavior of TodoServiceServer
+type Option func(*TodoServiceServer)
+
+// WithTenantQuota caps the number of active todos per tenant. A limit of 0
+// disables the quota; adminBypass lets admins create past it.
+func WithTenantQuota(maxTodos int, adminBypass bool) Option {
+	return func(s *TodoServiceServer) {
+		s.maxTodosPerTenant = maxTodos
+		s.adminBypassQuota = adminBypass
+	}
+}
+
+// WithTransitionPolicy sets the status workflow enforced on status changes
+func WithTransitionPolicy(policy *domain.TransitionPolicy) Option {
+	return func(s *TodoServiceServer) {
+		s.transitions = policy
+	}
+}
+
+// WithPriorityWeights ranks priorities by weights when todos are listed by
+// priority; nil sorts them by their value
+func WithPriorityWeights(weights *domain.PriorityWeights) Option {
+	return func(s *TodoServiceServer) {
+		s.priorityWeights = weights
+	}
+}
+
+// WithDependencyBlocking rejects completing a todo while any todo it depends
+// on is still open
+func WithDependencyBlocking(enabled bool) Option {
+	return func(s *TodoServiceServer) {
+		s.blockOnDependencies = enabled
+	}
+}
+
+// WithPageSizes sets the default and maximum page size of List requests
+func WithPageSizes(sizes domain.PageSizes) Option {
+	return func(s *TodoServiceServer) {
+		s.pageSizes = sizes
+	}
+}
+
+// WithMaxBatchSize caps the number of todos a BatchCreateTodos request may
+// carry
+func WithMaxBatchSize(size int) Option {
+	return func(s *TodoServiceServer) {
+		s.maxBatchSize = size
+	}
+}
+
+// WithQueryAnalysis enables AnalyzeListTodos, which executes the analyzed query
+func WithQueryAnalysis(enabled bool) Option {
+	return func(s *TodoServiceServer) {
+		s.queryAnalysis = enabled
+	}
+}
+
+// WithEventSubscriber enables WatchTodo, notifying watchers of changes
+// delivered by subscriber
+func WithEventSubscriber(subscriber domain.EventSubscriber) Option {
+	return func(s *TodoServiceServer) {
+		s.subscriber = subscriber
+	}
+}
+
+// WithUserDirectory checks assignees against users, rejecting assignments to
+// users it does not know
+func WithUserDirectory(users domain.UserDirectory) Option {
+	return func(s *TodoServiceServer) {
+		s.users = users
+	}
+}
+
+// WithArchiveRetention reports when archived todos will be purged, retention
+// after they were archived. It only informs ListArchived; the purge itself
+// is a background job.
+func WithArchiveRetention(retention time.Duration) Option {
+	return func(s *TodoServiceServer) {
+		s.archiveRetention = retention
+	}
+}
+
+// WithDeleteMode sets how DeleteTodo removes todos. With domain.DeleteHard
+// every delete erases the todo; with the default domain.DeleteSoft only
+// requests asking for a hard delete do.
+func WithDeleteMode(mode domain.DeleteMode) Option {
+	return func(s *TodoServiceServer) {
+		s.deleteMode = mode
+	}
+}
+
+// WithOwnerMapping remaps the owners of todos moved by MoveTodosToTenant
+// to users of the tenant they move to
+func WithOwnerMapping(mapping domain.OwnerMapping) Option {
+	return func(s *TodoServiceServer) {
+		s.ownerMapping = mapping
+	}
+}
+
+// WithObjectStorage enables attachments stored in storage. Upload URLs are
+// valid for urlExpiry and files may be at most maxSizeBytes.
+func WithObjectStorage(storage domain.ObjectStorage, urlExpiry time.Duration, maxSizeBytes int64) Option {
+	return func(s *TodoServiceServer) {
+		s.storage = storage
+		s.uploadURLExpiry = urlExpiry
+		s.maxAttachmentSize = maxSizeBytes
+	}
+}
+
+// WithMetricsRegisterer registers the service's business metrics with reg
+// instead of the default registry
+func WithMetricsRegisterer(reg prometheus.Registerer) Option {
+	return func(s *TodoServiceServer) {
+		s.metrics = newServiceMetrics(reg)
+	}
+}
+
+// WithMetricsTenants labels the business metrics of the given tenants with
+// their ID; all other tenants share a single label
+func WithMetricsTenants(tenantIDs []string) Option {
+	return func(s *TodoServiceServer) {
+		s.metricsTenants = make(map[string]bool, len(tenantIDs))
+		for _, id := range tenantIDs {
+			s.metricsTenants[id] = true
+		}
+	}
+}
@@ -1,13 +1,19 @@
 package app
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
+	"slices"
+	"strings"
+	"time"
 
 	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
 	"github.com/dmehra2102/TaskForge/internal/domain"
 	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -18,21 +24,70 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 200
+
+	maxBatchGetIDs = 100
+
+	defaultMaxBatchSize = 100
+)
+
 type TodoServiceServer struct {
 	todov1.UnimplementedTodoServiceServer
 	repo   domain.Repository
 	logger *zap.Logger
 	tracer trace.Tracer
 	authz  *auth.Authorizer
+
+	metrics        *serviceMetrics
+	metricsTenants map[string]bool
+
+	transitions         *domain.TransitionPolicy
+	priorityWeights     *domain.PriorityWeights
+	pageSizes           domain.PageSizes
+	maxBatchSize        int
+	queryAnalysis       bool
+	blockOnDependencies bool
+
+	maxTodosPerTenant int
+	adminBypassQuota  bool
+
+	subscriber domain.EventSubscriber
+	users      domain.UserDirectory
+	deleteMode domain.DeleteMode
+
+	// archiveRetention is how long archived todos are kept before they are
+	// purged; 0 keeps them indefinitely
+	archiveRetention time.Duration
+
+	// ownerMapping remaps owners of todos moved to another tenant; nil keeps them
+	ownerMapping domain.OwnerMapping
+
+	storage           domain.ObjectStorage
+	uploadURLExpiry   time.Duration
+	maxAttachmentSize int64
 }
 
-func NewTodoServiceServer(repo domain.Repository, logger *zap.Logger, authz *auth.Authorizer) *TodoServiceServer {
-	return &TodoServiceServer{
-		repo:   repo,
-		logger: logger,
-		tracer: otel.Tracer("todo-service"),
-		authz:  authz,
+func NewTodoServiceServer(repo domain.Repository, logger *zap.Logger, authz *auth.Authorizer, opts ...Option) *TodoServiceServer {
+	s := &TodoServiceServer{
+		repo:         repo,
+		logger:       logger,
+		tracer:       otel.Tracer("todo-service"),
+		authz:        authz,
+		metrics:      defaultMetrics,
+		transitions:  domain.DefaultTransitionPolicy(),
+		pageSizes:    domain.DefaultPageSizes(),
+		maxBatchSize: defaultMaxBatchSize,
+		users:        domain.PermissiveUserDirectory{},
+		deleteMode:   domain.DeleteSoft,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *TodoServiceServer) CreateTodo(ctx context.Context, req *todov1.CreateTodoRequest) (*todov1.CreateTodoResponse, error) {
@@ -50,7 +105,7 @@ func (s *TodoServiceServer) CreateTodo(ctx context.Context, req *todov1.CreateTo
 
 	// Validate Request
 	if err := validateCreateRequest(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	// check authorization
@@ -58,6 +113,46 @@ func (s *TodoServiceServer) CreateTodo(ctx context.Context, req *todov1.CreateTo
 		return nil, status.Error(codes.PermissionDenied, "Insufficient permissions")
 	}
 
+	// Optional fields
+	var opts []domain.TodoOption
+	if req.DueDate != nil {
+		dueDate := req.DueDate.AsTime()
+		opts = append(opts, domain.WithDueDate(&dueDate))
+	}
+
+	if req.DueDateTimezone != "" {
+		opts = append(opts, domain.WithDueDateTimezone(&req.DueDateTimezone))
+	}
+
+	if len(req.Tags) > 0 {
+		opts = append(opts, domain.WithTags(req.Tags))
+	}
+
+	if req.AssignedTo != "" {
+		opts = append(opts, domain.WithAssignee(&req.AssignedTo))
+	}
+
+	if req.EstimatedMinutes != nil {
+		opts = append(opts, domain.WithEstimate(req.EstimatedMinutes))
+	}
+
+	if req.RemindAt != nil {
+		remindAt := req.RemindAt.AsTime()
+		opts = append(opts, domain.WithReminder(&remindAt))
+	}
+
+	// Adding a subtask changes the parent's detail, so it takes the same
+	// permission as updating the parent
+	if req.ParentId != "" {
+		if _, err := s.updatableTodo(ctx, userCtx, req.ParentId); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, fieldError("parent_id", "parent todo not found")
+			}
+			return nil, err
+		}
+		opts = append(opts, domain.WithParent(&req.ParentId))
+	}
+
 	// create domain entity
 	todo, err := domain.NewTodo(
 		req.Title,
@@ -65,47 +160,37 @@ func (s *TodoServiceServer) CreateTodo(ctx context.Context, req *todov1.CreateTo
 		userCtx.UserID,
 		userCtx.TenantID,
 		mapProtoPriority(req.Priority),
+		opts...,
 	)
 	if err != nil {
-		s.logger.Error("failed to create todo entity",
+		loggerFromContext(ctx, s.logger).Error("failed to create todo entity",
 			zap.Error(err),
-			zap.String("user_id", userCtx.UserID),
 		)
 		return nil, mapDomainError(err)
 	}
 
-	// Optional fields
-	if req.DueDate != nil {
-		dueDate := req.DueDate.AsTime()
-		if err := todo.SetDueDate(&dueDate); err != nil {
-			return nil, mapDomainError(err)
-		}
-	}
-
-	if len(req.Tags) > 0 {
-		if err := todo.AddTags(req.Tags); err != nil {
-			return nil, mapDomainError(err)
-		}
+	if err := s.checkAssignee(ctx, todo, nil); err != nil {
+		return nil, err
 	}
 
-	if req.AssignedTo != "" {
-		if err := todo.AssignTo(&req.AssignedTo); err != nil {
-			return nil, mapDomainError(err)
-		}
+	if err := s.checkTenantQuota(ctx, userCtx, 1); err != nil {
+		return nil, err
 	}
 
 	if err := s.repo.Create(ctx, todo); err != nil {
-		s.logger.Error("failed to persist todo",
+		if errors.Is(err, domain.ErrDuplicateTitle) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to persist todo",
 			zap.Error(err),
 			zap.String("todo_id", todo.ID),
 		)
 		return nil, status.Error(codes.Internal, "failed to create todo")
 	}
 
-	s.logger.Info("todo created",
+	s.metrics.recordCreated(s.tenantLabel(userCtx.TenantID), 1)
+	loggerFromContext(ctx, s.logger).Info("todo created",
 		zap.String("todo_id", todo.ID),
-		zap.String("user_id", userCtx.UserID),
-		zap.String("tenant_id", userCtx.TenantID),
 	)
 
 	return &todov1.CreateTodoResponse{
@@ -131,12 +216,24 @@ func (s *TodoServiceServer) GetTodo(ctx context.Context, req *todov1.GetTodoRequ
 		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
 	}
 
-	todo, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	getByID := s.repo.GetByID
+	if req.IncludeDeleted {
+		if !s.authz.CanReadDeleted(userCtx) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+		getByID = s.repo.GetByIDIncludingDeleted
+	}
+
+	todo, err := getByID(ctx, req.Id, userCtx.TenantID)
 	if err != nil {
-		if err == domain.ErrTodoNotFound {
+		if errors.Is(err, domain.ErrTodoNotFound) {
 			return nil, status.Error(codes.NotFound, "todo not found")
 		}
-		s.logger.Error("failed to get todo",
+		loggerFromContext(ctx, s.logger).Error("failed to get todo",
 			zap.Error(err),
 			zap.String("todo_id", req.Id),
 		)
@@ -153,6 +250,188 @@ func (s *TodoServiceServer) GetTodo(ctx context.Context, req *todov1.GetTodoRequ
 	}, nil
 }
 
+// WatchTodo sends the todo's current state, then its new state after every
+// change. The stream ends when the todo is deleted or the client goes away.
+func (s *TodoServiceServer) WatchTodo(req *todov1.GetTodoRequest, stream todov1.TodoService_WatchTodoServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "WatchTodo")
+	defer span.End()
+
+	if s.subscriber == nil {
+		return status.Error(codes.Unimplemented, "watching todos is not enabled")
+	}
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.Id),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+
+	// Subscribe before the initial read so no change in between is missed
+	events, cancel := s.subscriber.Subscribe(req.Id)
+	defer cancel()
+
+	todo, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
+	if err != nil {
+		return mapDomainError(err)
+	}
+	if !s.authz.CanRead(userCtx, todo) {
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	if err := stream.Send(mapDomainToProto(todo)); err != nil {
+		return err
+	}
+
+	lastVersion := todo.Version
+	for {
+		select {
+		case <-ctx.Done():
+			// A server draining on shutdown cancels with a status as the cause
+			if _, ok := status.FromError(context.Cause(ctx)); ok {
+				return context.Cause(ctx)
+			}
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "server is shutting down")
+			}
+			if event.Tenant() != userCtx.TenantID {
+				continue
+			}
+			if event.EventName() == domain.EventTodoDeleted {
+				return nil
+			}
+
+			todo, err := s.repo.GetByID(domain.WithReadYourWrites(ctx), req.Id, userCtx.TenantID)
+			if errors.Is(err, domain.ErrTodoNotFound) {
+				return nil
+			}
+			if err != nil {
+				loggerFromContext(ctx, s.logger).Error("failed to reload watched todo",
+					zap.Error(err),
+					zap.String("todo_id", req.Id),
+				)
+				return status.Error(codes.Internal, "failed to retrieve todo")
+			}
+
+			// Outbox delivery is at-least-once; skip states already sent
+			if todo.Version <= lastVersion {
+				continue
+			}
+			if !s.authz.CanRead(userCtx, todo) {
+				return status.Error(codes.PermissionDenied, "insufficient permissions")
+			}
+			if err := stream.Send(mapDomainToProto(todo)); err != nil {
+				return err
+			}
+			lastVersion = todo.Version
+		}
+	}
+}
+
+func (s *TodoServiceServer) BatchGetTodos(ctx context.Context, req *todov1.BatchGetTodosRequest) (*todov1.BatchGetTodosResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "BatchGetTodos")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.Int("todo.count", len(req.Ids)),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if len(req.Ids) > maxBatchGetIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids may be requested", maxBatchGetIDs)
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	todos, err := s.repo.GetByIDs(ctx, req.Ids, userCtx.TenantID)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to batch get todos",
+			zap.Error(err),
+			zap.Int("count", len(req.Ids)),
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve todos")
+	}
+
+	// Only return the todos this user may read
+	protoTodos := make([]*todov1.Todo, 0, len(todos))
+	for _, todo := range todos {
+		if s.authz.CanRead(userCtx, todo) {
+			protoTodos = append(protoTodos, mapDomainToProto(todo))
+		}
+	}
+
+	return &todov1.BatchGetTodosResponse{
+		Todos: protoTodos,
+	}, nil
+}
+
+func (s *TodoServiceServer) GetTodoDetail(ctx context.Context, req *todov1.GetTodoDetailRequest) (*todov1.GetTodoDetailResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "GetTodoDetail")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.Id),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	detail, err := s.repo.GetDetail(ctx, req.Id, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to get todo detail",
+			zap.Error(err),
+			zap.String("todo_id", req.Id),
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	if !s.authz.CanRead(userCtx, detail.Todo) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	subtasks := make([]*todov1.Todo, 0, len(detail.Subtasks))
+	for _, subtask := range detail.Subtasks {
+		if s.authz.CanRead(userCtx, subtask) {
+			subtasks = append(subtasks, mapDomainToProto(subtask))
+		}
+	}
+
+	return &todov1.GetTodoDetailResponse{
+		Todo:         mapDomainToProto(detail.Todo),
+		CommentCount: detail.CommentCount,
+		Subtasks:     subtasks,
+	}, nil
+}
+
 func (s *TodoServiceServer) UpdateTodo(ctx context.Context, req *todov1.UpdateTodoRequest) (*todov1.UpdateTodoResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "UpdateTodo")
 	defer span.End()
@@ -162,36 +441,74 @@ func (s *TodoServiceServer) UpdateTodo(ctx context.Context, req *todov1.UpdateTo
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	// Both a masked and a full update read their values from todo
+	if req.Todo == nil {
+		return nil, fieldError("todo", "todo is required")
+	}
+
+	ctx = domain.WithReadYourWrites(ctx)
 	existing, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
 	if err != nil {
-		if err == domain.ErrTodoNotFound {
+		if errors.Is(err, domain.ErrTodoNotFound) {
 			return nil, status.Error(codes.NotFound, "todo not found")
 		}
 		return nil, status.Error(codes.Internal, "failed to retrieve todo")
 	}
 
-	if !s.authz.CanUpdate(userCtx, existing) {
+	// Reassigning has its own permission, which managers hold without being
+	// allowed to change anything else
+	if !s.authz.CanUpdate(userCtx, existing) && !(onlyReassigns(req.UpdateMask) && s.authz.CanReassign(userCtx, existing)) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	if maskIncludes(req.UpdateMask, "owner_id") && !s.authz.CanTransferOwnership(userCtx, existing) {
 		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 	}
 
-	if err := applyFieldMaskUpdates(existing, req.Todo, req.UpdateMask); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	// Reject stale edits before applying them; the repository re-checks the
+	// version in case the row changes between this read and the write
+	expectedVersion := existing.Version
+	if req.Version != 0 && req.Version != expectedVersion {
+		return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
 	}
 
-	if err := s.repo.Update(ctx, existing); err != nil {
-		if err == domain.ErrVersionMismatch {
+	wasCompleted := existing.Status == domain.StatusCompleted
+	previousAssignee := existing.AssignedTo
+	if err := applyFieldMaskUpdates(existing, req.Todo, req.UpdateMask, userCtx.UserID, s.transitions); err != nil {
+		return nil, mapDomainError(err)
+	}
+	if err := s.checkAssignee(ctx, existing, previousAssignee); err != nil {
+		return nil, err
+	}
+	if !wasCompleted && existing.Status == domain.StatusCompleted {
+		if err := s.checkDependenciesResolved(ctx, existing); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, existing, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
 			return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
 		}
-		s.logger.Error("failed to update todo",
+		if errors.Is(err, domain.ErrDuplicateTitle) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to update todo",
 			zap.Error(err),
 			zap.String("todo_id", req.Id),
 		)
 		return nil, status.Error(codes.Internal, "failed to update todo")
 	}
 
-	s.logger.Info("todo updated",
+	if !wasCompleted && existing.Status == domain.StatusCompleted {
+		s.metrics.recordCompleted(s.tenantLabel(userCtx.TenantID))
+	}
+
+	loggerFromContext(ctx, s.logger).Info("todo updated",
 		zap.String("todo_id", req.Id),
-		zap.String("user_id", userCtx.UserID),
 	)
 
 	return &todov1.UpdateTodoResponse{
@@ -199,6 +516,117 @@ func (s *TodoServiceServer) UpdateTodo(ctx context.Context, req *todov1.UpdateTo
 	}, nil
 }
 
+// UpsertTodo creates a todo under a client-generated id, or replaces the
+// stored todo when the request carries its current version. Offline clients
+// use it to sync without knowing whether the server has seen the todo yet.
+func (s *TodoServiceServer) UpsertTodo(ctx context.Context, req *todov1.UpsertTodoRequest) (*todov1.UpsertTodoResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "UpsertTodo")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if req.Todo == nil {
+		return nil, status.Error(codes.InvalidArgument, "todo is required")
+	}
+	if err := uuid.Validate(req.Todo.Id); err != nil {
+		return nil, fieldError("todo.id", "todo ID must be a UUID")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.Todo.Id),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	ctx = domain.WithReadYourWrites(ctx)
+	existing, err := s.repo.GetByID(ctx, req.Todo.Id, userCtx.TenantID)
+	if err != nil && !errors.Is(err, domain.ErrTodoNotFound) {
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	var todo *domain.Todo
+	var completing bool
+	var previousAssignee *string
+	if existing == nil {
+		if req.Version != 0 {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		if !s.authz.CanCreate(userCtx) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+		if err := s.checkTenantQuota(ctx, userCtx, 1); err != nil {
+			return nil, err
+		}
+
+		todo, err = newTodoFromProto(req.Todo, userCtx)
+		if err != nil {
+			return nil, mapDomainError(err)
+		}
+	} else {
+		if !s.authz.CanUpdate(userCtx, existing) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+		if req.Version != existing.Version {
+			return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
+		}
+
+		wasCompleted := existing.Status == domain.StatusCompleted
+		previousAssignee = existing.AssignedTo
+		if err := replaceTodoFields(existing, req.Todo, userCtx.UserID, s.transitions); err != nil {
+			return nil, mapDomainError(err)
+		}
+		completing = !wasCompleted && existing.Status == domain.StatusCompleted
+		if completing {
+			if err := s.checkDependenciesResolved(ctx, existing); err != nil {
+				return nil, err
+			}
+		}
+		todo = existing
+		todo.Version = req.Version + 1
+	}
+
+	if err := s.checkAssignee(ctx, todo, previousAssignee); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Upsert(ctx, todo)
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
+		}
+		if errors.Is(err, domain.ErrDuplicateTitle) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to upsert todo",
+			zap.Error(err),
+			zap.String("todo_id", todo.ID),
+		)
+		return nil, status.Error(codes.Internal, "failed to upsert todo")
+	}
+
+	if created {
+		s.metrics.recordCreated(s.tenantLabel(userCtx.TenantID), 1)
+	} else if completing {
+		s.metrics.recordCompleted(s.tenantLabel(userCtx.TenantID))
+	}
+
+	loggerFromContext(ctx, s.logger).Info("todo upserted",
+		zap.String("todo_id", todo.ID),
+		zap.Bool("created", created),
+	)
+
+	return &todov1.UpsertTodoResponse{
+		Todo:    mapDomainToProto(todo),
+		Created: created,
+	}, nil
+}
+
 func (s *TodoServiceServer) DeleteTodo(ctx context.Context, req *todov1.DeleteTodoRequest) (*todov1.DeleteTodoResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "DeleteTodo")
 	defer span.End()
@@ -208,9 +636,25 @@ func (s *TodoServiceServer) DeleteTodo(ctx context.Context, req *todov1.DeleteTo
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
-	todo, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	// Erasing a todo for good on request is reserved for admins
+	if req.Hard && !s.authz.CanHardDelete(userCtx) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	// An explicit hard delete may also erase a todo that is already soft-deleted
+	getTodo := s.repo.GetByID
+	if req.Hard {
+		getTodo = s.repo.GetByIDIncludingDeleted
+	}
+
+	ctx = domain.WithReadYourWrites(ctx)
+	todo, err := getTodo(ctx, req.Id, userCtx.TenantID)
 	if err != nil {
-		if err == domain.ErrTodoNotFound {
+		if errors.Is(err, domain.ErrTodoNotFound) {
 			return nil, status.Error(codes.NotFound, "todo not found")
 		}
 		return nil, status.Error(codes.Internal, "failed to retrieve todo")
@@ -220,17 +664,31 @@ func (s *TodoServiceServer) DeleteTodo(ctx context.Context, req *todov1.DeleteTo
 		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 	}
 
-	if err := s.repo.Delete(ctx, req.Id, userCtx.TenantID); err != nil {
-		s.logger.Error("failed to delete todo",
+	hard := req.Hard || s.deleteMode == domain.DeleteHard
+	var objectKeys []string
+	if hard {
+		objectKeys, err = s.repo.ForceDelete(ctx, req.Id, userCtx.TenantID)
+	} else {
+		err = s.repo.Delete(ctx, req.Id, userCtx.TenantID)
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to delete todo",
 			zap.Error(err),
 			zap.String("todo_id", req.Id),
+			zap.Bool("hard", hard),
 		)
 		return nil, status.Error(codes.Internal, "failed to delete todo")
 	}
+	s.deleteObjects(ctx, objectKeys)
 
-	s.logger.Info("todo deleted",
+	s.metrics.recordDeleted(s.tenantLabel(userCtx.TenantID), 1)
+	loggerFromContext(ctx, s.logger).Info("todo deleted",
 		zap.String("todo_id", req.Id),
-		zap.String("user_id", userCtx.UserID),
+		zap.Bool("hard", hard),
 	)
 
 	return &todov1.DeleteTodoResponse{
@@ -238,6 +696,75 @@ func (s *TodoServiceServer) DeleteTodo(ctx context.Context, req *todov1.DeleteTo
 	}, nil
 }
 
+func (s *TodoServiceServer) BulkDeleteTodos(ctx context.Context, req *todov1.BulkDeleteTodosRequest) (*todov1.BulkDeleteTodosResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "BulkDeleteTodos")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	// The filter means what it does for ListTodos, so build it the same way
+	filter, err := s.listFilterFromRequest(&todov1.ListTodosRequest{
+		StatusFilter:      req.StatusFilter,
+		PriorityFilter:    req.PriorityFilter,
+		TagsFilter:        req.TagsFilter,
+		ExcludeTagsFilter: req.ExcludeTagsFilter,
+		AssignedToFilter:  req.AssignedToFilter,
+		DueDateFrom:       req.DueDateFrom,
+		DueDateTo:         req.DueDateTo,
+		CreatedFrom:       req.CreatedFrom,
+		CreatedTo:         req.CreatedTo,
+		UpdatedFrom:       req.UpdatedFrom,
+		UpdatedTo:         req.UpdatedTo,
+		SearchQuery:       req.SearchQuery,
+		OverdueOnly:       req.OverdueOnly,
+		AssignedToIsNull:  req.AssignedToIsNull,
+		DueDateIsNull:     req.DueDateIsNull,
+		DueBucket:         req.DueBucket,
+		Timezone:          req.Timezone,
+	}, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filter.Validate(s.pageSizes); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	// Reject tenant-wide wipes before any implicit scoping is applied
+	if !filter.IsNarrowed() {
+		return nil, mapDomainError(domain.ErrFilterTooBroad)
+	}
+
+	// Like DeleteTodo, only admins may delete todos they do not own
+	if !s.authz.CanDeleteAll(userCtx) {
+		filter.OwnerID = &userCtx.UserID
+	}
+
+	deleted, err := s.repo.DeleteByFilter(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrFilterTooBroad) {
+			return nil, mapDomainError(err)
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to bulk delete todos",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to delete todos")
+	}
+
+	span.SetAttributes(attribute.Int64("deleted_count", deleted))
+	s.metrics.recordDeleted(s.tenantLabel(userCtx.TenantID), deleted)
+	loggerFromContext(ctx, s.logger).Info("todos bulk deleted",
+		zap.Int64("count", deleted),
+	)
+
+	return &todov1.BulkDeleteTodosResponse{
+		DeletedCount: deleted,
+	}, nil
+}
+
 func (s *TodoServiceServer) ListTodos(ctx context.Context, req *todov1.ListTodosRequest) (*todov1.ListTodosResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "ListTodos")
 	defer span.End()
@@ -247,16 +774,163 @@ func (s *TodoServiceServer) ListTodos(ctx context.Context, req *todov1.ListTodos
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
+	filter, err := s.listFilterFromRequest(req, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filter.Validate(s.pageSizes); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	var resp *todov1.ListTodosResponse
+	var totalCount int64
+	list := func() error {
+		resp = &todov1.ListTodosResponse{}
+		if req.IdsOnly {
+			refs, total, err := s.repo.ListRefs(ctx, filter)
+			for _, ref := range refs {
+				resp.Refs = append(resp.Refs, mapTodoRefToProto(ref))
+			}
+			totalCount = total
+			return err
+		}
+		todos, total, err := s.repo.List(ctx, filter)
+		for _, todo := range todos {
+			resp.Todos = append(resp.Todos, mapDomainToProto(todo))
+		}
+		totalCount = total
+		return err
+	}
+
+	err = list()
+	// Clients that change the page size mid-traversal can ask for a page past
+	// the end; serve the last page instead of an empty one
+	if lastPage := domain.TotalPages(totalCount, filter.PageSize); err == nil && lastPage > 0 && filter.Page > lastPage {
+		filter.Page = lastPage
+		err = list()
+	}
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list todos",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to list todos")
+	}
+
+	resp.PageInfo = pageInfo(filter.Page, filter.PageSize, totalCount)
+	return resp, nil
+}
+
+// pageInfo describes page of a listing, recomputing the page count from the
+// total rather than trusting anything the client carried over
+func pageInfo(page, pageSize int, totalCount int64) *todov1.PageInfo {
+	totalPages := domain.TotalPages(totalCount, pageSize)
+	return &todov1.PageInfo{
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		TotalItems: totalCount,
+		TotalPages: int32(totalPages),
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1 && totalPages > 0,
+	}
+}
+
+func (s *TodoServiceServer) DryRunListTodos(ctx context.Context, req *todov1.ListTodosRequest) (*todov1.DryRunListTodosResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "DryRunListTodos")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if !s.authz.CanInspectQueries(userCtx) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	filter, err := s.listFilterFromRequest(req, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filter.Validate(s.pageSizes); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	query, err := s.repo.DryRunList(ctx, filter)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to build list query",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to build list query")
+	}
+
+	return &todov1.DryRunListTodosResponse{
+		Sql:           query.SQL,
+		WhereClause:   query.Where,
+		OrderByClause: query.OrderBy,
+		ArgCount:      int32(query.ArgCount),
+	}, nil
+}
+
+func (s *TodoServiceServer) AnalyzeListTodos(ctx context.Context, req *todov1.ListTodosRequest) (*todov1.AnalyzeListTodosResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "AnalyzeListTodos")
+	defer span.End()
+
+	if !s.queryAnalysis {
+		return nil, status.Error(codes.FailedPrecondition, "query analysis is disabled")
+	}
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if !s.authz.CanInspectQueries(userCtx) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	filter, err := s.listFilterFromRequest(req, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filter.Validate(s.pageSizes); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	plan, err := s.repo.AnalyzeList(ctx, filter)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to analyze list query",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to analyze list query")
+	}
+
+	return &todov1.AnalyzeListTodosResponse{
+		PlanJson: string(plan),
+	}, nil
+}
+
+// listFilterFromRequest builds the filter of a ListTodos request, confined
+// to the caller's tenant and, for non-admins, to their own todos or those
+// assigned to them
+func (s *TodoServiceServer) listFilterFromRequest(req *todov1.ListTodosRequest, userCtx *auth.UserContext) (*domain.ListFilter, error) {
 	filter := &domain.ListFilter{
 		TenantID:      userCtx.TenantID,
 		Page:          int(req.Page),
 		PageSize:      int(req.PageSize),
 		SortBy:        req.SortBy,
 		SortAscending: req.SortOrder == todov1.SortOrder_SORT_ORDER_ASC,
+
+		PriorityWeights: s.priorityWeights,
 	}
 
-	if !s.authz.CanReadAll(userCtx) {
-		filter.OwnerID = &userCtx.UserID
+	for _, spec := range req.Sort {
+		filter.Sort = append(filter.Sort, domain.SortSpec{
+			Field:     spec.Field,
+			Ascending: spec.Order == todov1.SortOrder_SORT_ORDER_ASC,
+		})
 	}
 
 	if len(req.StatusFilter) > 0 {
@@ -274,12 +948,23 @@ func (s *TodoServiceServer) ListTodos(ctx context.Context, req *todov1.ListTodos
 	}
 
 	if len(req.TagsFilter) > 0 {
-		filter.Tags = req.TagsFilter
+		filter.Tags = canonicalTags(req.TagsFilter)
+	}
+
+	if len(req.ExcludeTagsFilter) > 0 {
+		filter.ExcludeTags = canonicalTags(req.ExcludeTagsFilter)
 	}
 
 	if req.AssignedToFilter != "" {
 		filter.AssignedTo = &req.AssignedToFilter
+	} else if req.AssignedToMe {
+		filter.AssignedTo = &userCtx.UserID
+	}
+
+	if req.OwnedByMe {
+		filter.OwnerID = &userCtx.UserID
 	}
+	s.restrictToReadable(userCtx, filter)
 
 	if req.DueDateFrom != nil {
 		from := req.DueDateFrom.AsTime()
@@ -291,58 +976,235 @@ func (s *TodoServiceServer) ListTodos(ctx context.Context, req *todov1.ListTodos
 		filter.DueDateTo = &to
 	}
 
+	if req.CreatedFrom != nil {
+		from := req.CreatedFrom.AsTime()
+		filter.CreatedFrom = &from
+	}
+
+	if req.CreatedTo != nil {
+		to := req.CreatedTo.AsTime()
+		filter.CreatedTo = &to
+	}
+
+	if req.UpdatedFrom != nil {
+		from := req.UpdatedFrom.AsTime()
+		filter.UpdatedFrom = &from
+	}
+
+	if req.UpdatedTo != nil {
+		to := req.UpdatedTo.AsTime()
+		filter.UpdatedTo = &to
+	}
+
 	if req.SearchQuery != "" {
 		filter.SearchQuery = &req.SearchQuery
 	}
 
-	if err := filter.Validate(); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	filter.OverdueOnly = req.OverdueOnly
+	filter.AssignedToIsNull = req.AssignedToIsNull
+	filter.DueDateIsNull = req.DueDateIsNull
+
+	if req.DueBucket != todov1.DueBucket_DUE_BUCKET_UNSPECIFIED {
+		loc := time.UTC
+		if req.Timezone != "" {
+			var err error
+			if loc, err = time.LoadLocation(req.Timezone); err != nil {
+				return nil, fieldError("timezone", domain.ErrInvalidTimezone.Error())
+			}
+		}
+		filter.ApplyDueBucket(mapProtoDueBucket(req.DueBucket), time.Now(), loc)
+	}
+
+	return filter, nil
+}
+
+func mapProtoDueBucket(b todov1.DueBucket) domain.DueBucket {
+	switch b {
+	case todov1.DueBucket_DUE_BUCKET_OVERDUE:
+		return domain.DueBucketOverdue
+	case todov1.DueBucket_DUE_BUCKET_TODAY:
+		return domain.DueBucketToday
+	case todov1.DueBucket_DUE_BUCKET_THIS_WEEK:
+		return domain.DueBucketThisWeek
+	case todov1.DueBucket_DUE_BUCKET_LATER:
+		return domain.DueBucketLater
+	case todov1.DueBucket_DUE_BUCKET_NONE:
+		return domain.DueBucketNone
+	default:
+		return domain.DueBucketAny
+	}
+}
+
+func (s *TodoServiceServer) UpdateTodoStatus(ctx context.Context, req *todov1.UpdateTodoStatusRequest) (*todov1.UpdateTodoStatusResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "UpdateTodoStatus")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	ctx = domain.WithReadYourWrites(ctx)
+	existing, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	if !s.authz.CanUpdate(userCtx, existing) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	// A zero version asks for an unconditional update against the version read
+	expectedVersion := existing.Version
+	if req.Version != 0 && req.Version != expectedVersion {
+		return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
 	}
 
-	todos, totalCount, err := s.repo.List(ctx, filter)
+	newStatus := mapProtoStatus(req.NewStatus)
+	wasCompleted := existing.Status == domain.StatusCompleted
+	if err := existing.UpdateStatus(newStatus, userCtx.UserID, s.transitions); err != nil {
+		return nil, mapDomainError(err)
+	}
+	if !wasCompleted && newStatus == domain.StatusCompleted {
+		if err := s.checkDependenciesResolved(ctx, existing); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := s.repo.UpdateStatus(ctx, req.Id, userCtx.TenantID, newStatus, existing.CompletedAt, existing.CompletedBy, expectedVersion)
 	if err != nil {
-		s.logger.Error("failed to list todos",
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to update status",
 			zap.Error(err),
-			zap.String("tenant_id", userCtx.TenantID),
+			zap.String("todo_id", req.Id),
 		)
-		return nil, status.Error(codes.Internal, "failed to list todos")
+		return nil, status.Error(codes.Internal, "failed to update status")
 	}
 
-	// Build response
-	protoTodos := make([]*todov1.Todo, len(todos))
-	for i, todo := range todos {
-		protoTodos[i] = mapDomainToProto(todo)
+	if newStatus == domain.StatusCompleted {
+		s.metrics.observeCompletion(updated)
+		if !wasCompleted {
+			s.metrics.recordCompleted(s.tenantLabel(userCtx.TenantID))
+		}
 	}
 
-	totalPages := int32(math.Ceil(float64(totalCount) / float64(filter.PageSize)))
+	loggerFromContext(ctx, s.logger).Info("todo status updated",
+		zap.String("todo_id", req.Id),
+		zap.Int("new_status", int(newStatus)),
+		zap.String("reason", req.Reason),
+	)
 
-	pageInfo := &todov1.PageInfo{
-		Page:       int32(filter.Page),
-		PageSize:   int32(filter.PageSize),
-		TotalItems: totalCount,
-		TotalPages: totalPages,
-		HasNext:    filter.Page < int(totalPages),
-		HasPrev:    filter.Page > 1,
+	return &todov1.UpdateTodoStatusResponse{
+		Todo: mapDomainToProto(updated),
+	}, nil
+}
+
+func (s *TodoServiceServer) SnoozeTodo(ctx context.Context, req *todov1.SnoozeTodoRequest) (*todov1.SnoozeTodoResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "SnoozeTodo")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.Id),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+	if req.Duration == nil {
+		return nil, fieldError("duration", "duration is required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	ctx = domain.WithReadYourWrites(ctx)
+	existing, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	if !s.authz.CanUpdate(userCtx, existing) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 	}
 
-	return &todov1.ListTodosResponse{
-		Todos:    protoTodos,
-		PageInfo: pageInfo,
+	expectedVersion := existing.Version
+	if req.Version != 0 && req.Version != expectedVersion {
+		return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
+	}
+
+	snooze := existing.Snooze
+	if req.FromNow {
+		snooze = existing.SnoozeFromNow
+	}
+	if err := snooze(req.Duration.AsDuration()); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	if err := s.repo.Update(ctx, existing, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to snooze todo",
+			zap.Error(err),
+			zap.String("todo_id", req.Id),
+		)
+		return nil, status.Error(codes.Internal, "failed to snooze todo")
+	}
+
+	loggerFromContext(ctx, s.logger).Info("todo snoozed",
+		zap.String("todo_id", req.Id),
+		zap.Duration("duration", req.Duration.AsDuration()),
+	)
+
+	return &todov1.SnoozeTodoResponse{
+		Todo: mapDomainToProto(existing),
 	}, nil
 }
 
-func (s *TodoServiceServer) UpdateTodoStatus(ctx context.Context, req *todov1.UpdateTodoStatusRequest) (*todov1.UpdateTodoStatusResponse, error) {
-	ctx, span := s.tracer.Start(ctx, "UpdateTodoStatus")
+func (s *TodoServiceServer) LogTime(ctx context.Context, req *todov1.LogTimeRequest) (*todov1.LogTimeResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "LogTime")
 	defer span.End()
 
-	userCtx, err := auth.UserContextFromContext(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.Id),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
 	}
 
+	ctx = domain.WithReadYourWrites(ctx)
 	existing, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
 	if err != nil {
-		if err == domain.ErrTodoNotFound {
+		if errors.Is(err, domain.ErrTodoNotFound) {
 			return nil, status.Error(codes.NotFound, "todo not found")
 		}
 		return nil, status.Error(codes.Internal, "failed to retrieve todo")
@@ -352,30 +1214,33 @@ func (s *TodoServiceServer) UpdateTodoStatus(ctx context.Context, req *todov1.Up
 		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 	}
 
-	newStatus := mapProtoStatus(req.NewStatus)
-	if err := existing.UpdateStatus(newStatus); err != nil {
+	expectedVersion := existing.Version
+	if req.Version != 0 && req.Version != expectedVersion {
+		return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
+	}
+
+	if err := existing.LogTime(req.Minutes); err != nil {
 		return nil, mapDomainError(err)
 	}
-	updated, err := s.repo.UpdateStatus(ctx, req.Id, userCtx.TenantID, newStatus, req.Version)
-	if err != nil {
-		if err == domain.ErrVersionMismatch {
+
+	if err := s.repo.Update(ctx, existing, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
 			return nil, status.Error(codes.Aborted, "concurrent update detected, please retry")
 		}
-		s.logger.Error("failed to update status",
+		loggerFromContext(ctx, s.logger).Error("failed to log time",
 			zap.Error(err),
 			zap.String("todo_id", req.Id),
 		)
-		return nil, status.Error(codes.Internal, "failed to update status")
+		return nil, status.Error(codes.Internal, "failed to log time")
 	}
 
-	s.logger.Info("todo status updated",
+	loggerFromContext(ctx, s.logger).Info("time logged",
 		zap.String("todo_id", req.Id),
-		zap.Int("new_status", int(newStatus)),
-		zap.String("reason", req.Reason),
+		zap.Int32("minutes", req.Minutes),
 	)
 
-	return &todov1.UpdateTodoStatusResponse{
-		Todo: mapDomainToProto(updated),
+	return &todov1.LogTimeResponse{
+		Todo: mapDomainToProto(existing),
 	}, nil
 }
 
@@ -392,8 +1257,12 @@ func (s *TodoServiceServer) BatchCreateTodos(ctx context.Context, req *todov1.Ba
 		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
 	}
 
+	if len(req.Requests) > s.maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d todos may be created in a batch", s.maxBatchSize)
+	}
+
 	todos := make([]*domain.Todo, 0, len(req.Requests))
-	errors := make([]*todov1.ErrorDetail, 0)
+	errs := make([]*todov1.ErrorDetail, 0)
 
 	// Create domain entities
 	for i, createReq := range req.Requests {
@@ -405,8 +1274,20 @@ func (s *TodoServiceServer) BatchCreateTodos(ctx context.Context, req *todov1.Ba
 			mapProtoPriority(createReq.Priority),
 		)
 		if err != nil {
-			errors = append(errors, &todov1.ErrorDetail{
-				Field:     fmt.Sprintf("requests[%d]", i),
+			prefix := fmt.Sprintf("requests[%d]", i)
+			var verrs domain.ValidationErrors
+			if errors.As(err, &verrs) {
+				for _, fe := range verrs {
+					errs = append(errs, &todov1.ErrorDetail{
+						Field:     prefix + "." + fe.Field,
+						Message:   fe.Err.Error(),
+						ErrorCode: "VALIDATION_ERROR",
+					})
+				}
+				continue
+			}
+			errs = append(errs, &todov1.ErrorDetail{
+				Field:     prefix,
 				Message:   err.Error(),
 				ErrorCode: "VALIDATION_ERROR",
 			})
@@ -417,13 +1298,21 @@ func (s *TodoServiceServer) BatchCreateTodos(ctx context.Context, req *todov1.Ba
 
 	// Batch create
 	if len(todos) > 0 {
+		if err := s.checkTenantQuota(ctx, userCtx, len(todos)); err != nil {
+			return nil, err
+		}
+
 		if err := s.repo.BatchCreate(ctx, todos); err != nil {
-			s.logger.Error("failed to batch create todos",
+			if errors.Is(err, domain.ErrDuplicateTitle) {
+				return nil, status.Error(codes.AlreadyExists, err.Error())
+			}
+			loggerFromContext(ctx, s.logger).Error("failed to batch create todos",
 				zap.Error(err),
 				zap.Int("count", len(todos)),
 			)
 			return nil, status.Error(codes.Internal, "failed to create todos")
 		}
+		s.metrics.recordCreated(s.tenantLabel(userCtx.TenantID), len(todos))
 	}
 
 	protoTodos := make([]*todov1.Todo, len(todos))
@@ -433,33 +1322,286 @@ func (s *TodoServiceServer) BatchCreateTodos(ctx context.Context, req *todov1.Ba
 
 	return &todov1.BatchCreateTodosResponse{
 		Todos:  protoTodos,
-		Errors: errors,
+		Errors: errs,
+	}, nil
+}
+
+func (s *TodoServiceServer) GetTodoHistory(ctx context.Context, req *todov1.GetTodoHistoryRequest) (*todov1.GetTodoHistoryResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "GetTodoHistory")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.String("todo.id", req.Id),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "todo ID is required")
+	}
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	todo, err := s.repo.GetByID(ctx, req.Id, userCtx.TenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil, status.Error(codes.NotFound, "todo not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve todo")
+	}
+
+	// History follows the same read authorization as the todo itself
+	if !s.authz.CanRead(userCtx, todo) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	limit := int(req.Limit)
+	if limit < 1 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	entries, err := s.repo.GetHistory(ctx, req.Id, userCtx.TenantID, limit)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to get todo history",
+			zap.Error(err),
+			zap.String("todo_id", req.Id),
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve todo history")
+	}
+
+	protoEntries := make([]*todov1.TodoHistoryEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = mapHistoryToProto(entry)
+	}
+
+	return &todov1.GetTodoHistoryResponse{
+		Entries: protoEntries,
+	}, nil
+}
+
+func (s *TodoServiceServer) GetTodoStats(ctx context.Context, req *todov1.GetTodoStatsRequest) (*todov1.GetTodoStatsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "GetTodoStats")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(attribute.String("tenant.id", userCtx.TenantID))
+
+	// Same scoping as ListTodos
+	filter := &domain.ListFilter{
+		TenantID: userCtx.TenantID,
+	}
+	s.restrictToReadable(userCtx, filter)
+
+	if err := filter.Validate(s.pageSizes); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	counts, err := s.repo.CountByStatus(ctx, filter)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to count todos by status",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get todo stats")
+	}
+
+	protoCounts, total := mapStatusCounts(counts)
+	return &todov1.GetTodoStatsResponse{
+		Counts: protoCounts,
+		Total:  total,
+	}, nil
+}
+
+// GetWorkload counts the tenant's todos per assignee, with unassigned todos
+// as a bucket of their own, so managers can balance work
+func (s *TodoServiceServer) GetWorkload(ctx context.Context, req *todov1.GetWorkloadRequest) (*todov1.GetWorkloadResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "GetWorkload")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(attribute.String("tenant.id", userCtx.TenantID))
+
+	if err := s.checkRequestTenant(userCtx, req.Metadata); err != nil {
+		return nil, err
+	}
+
+	if !s.authz.CanViewWorkload(userCtx) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	workload, err := s.repo.WorkloadByAssignee(ctx, userCtx.TenantID)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to count workload",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get workload")
+	}
+
+	assignees := make([]*todov1.AssigneeWorkload, 0, len(workload))
+	for assignee, stats := range workload {
+		counts, total := mapStatusCounts(stats.Counts)
+		assignees = append(assignees, &todov1.AssigneeWorkload{
+			AssigneeId: assignee,
+			Counts:     counts,
+			Total:      total,
+		})
+	}
+	slices.SortFunc(assignees, func(a, b *todov1.AssigneeWorkload) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return strings.Compare(a.AssigneeId, b.AssigneeId)
+	})
+
+	return &todov1.GetWorkloadResponse{
+		Assignees: assignees,
 	}, nil
 }
 
+// mapStatusCounts returns one count per status, including zero counts, and
+// their sum
+func mapStatusCounts(counts map[domain.TodoStatus]int64) ([]*todov1.StatusCount, int64) {
+	statuses := []domain.TodoStatus{
+		domain.StatusPending,
+		domain.StatusInProgress,
+		domain.StatusCompleted,
+		domain.StatusArchived,
+	}
+
+	var total int64
+	protoCounts := make([]*todov1.StatusCount, len(statuses))
+	for i, st := range statuses {
+		protoCounts[i] = &todov1.StatusCount{
+			Status: mapDomainStatus(st),
+			Count:  counts[st],
+		}
+		total += counts[st]
+	}
+	return protoCounts, total
+}
+
+// checkTenantQuota rejects creating n more todos when the tenant would exceed
+// its configured active-todo limit.
+func (s *TodoServiceServer) checkTenantQuota(ctx context.Context, userCtx *auth.UserContext, n int) error {
+	if s.maxTodosPerTenant <= 0 {
+		return nil
+	}
+	if s.adminBypassQuota && s.authz.CanBypassQuota(userCtx) {
+		return nil
+	}
+
+	active, err := s.repo.CountActive(ctx, userCtx.TenantID)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to count active todos",
+			zap.Error(err),
+		)
+		return status.Error(codes.Internal, "failed to check tenant quota")
+	}
+
+	if active+int64(n) > int64(s.maxTodosPerTenant) {
+		return status.Errorf(codes.ResourceExhausted,
+			"tenant todo limit reached (%d of %d active todos)", active, s.maxTodosPerTenant)
+	}
+
+	return nil
+}
+
+// checkAssignee rejects assigning todo to a user the directory does not know
+// in its tenant. previous is the assignee before the change; keeping it is
+// not checked again, so todos assigned before the directory existed stay
+// editable.
+func (s *TodoServiceServer) checkAssignee(ctx context.Context, todo *domain.Todo, previous *string) error {
+	if todo.AssignedTo == nil || (previous != nil && *previous == *todo.AssignedTo) {
+		return nil
+	}
+
+	exists, err := s.users.Exists(ctx, todo.TenantID, *todo.AssignedTo)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to look up assignee",
+			zap.Error(err),
+			zap.String("todo_id", todo.ID),
+		)
+		return status.Error(codes.Internal, "failed to check assignee")
+	}
+	if !exists {
+		return mapDomainError(domain.ErrUnknownAssignee)
+	}
+	return nil
+}
+
+// restrictToReadable confines filter to the todos the caller may read: the
+// whole tenant with read-all access, otherwise the todos they own or are
+// assigned to, matching Authorizer.CanRead
+func (s *TodoServiceServer) restrictToReadable(userCtx *auth.UserContext, filter *domain.ListFilter) {
+	if !s.authz.CanReadAll(userCtx) {
+		filter.VisibleTo = &userCtx.UserID
+	}
+}
+
+// checkRequestTenant refuses requests whose metadata names a tenant other
+// than the caller's, sparing the database a read that authorization would
+// reject anyway
+func (s *TodoServiceServer) checkRequestTenant(userCtx *auth.UserContext, metadata *todov1.RequestMetadata) error {
+	if tenantID := metadata.GetTenantId(); tenantID != "" && !s.authz.CanActOnTenant(userCtx, tenantID) {
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	return nil
+}
+
+// validateCreateRequest reports every invalid field of the request at once
 func validateCreateRequest(req *todov1.CreateTodoRequest) error {
+	var violations fieldViolations
+	limits := domain.CurrentLimits()
+
 	if req.Title == "" {
-		return fmt.Errorf("title is required")
+		violations.add("title", "title is required")
 	}
-	if len(req.Title) > 200 {
-		return fmt.Errorf("title exceeds maximum length")
+	if len(req.Title) > limits.MaxTitleLength {
+		violations.add("title", "exceeds maximum length")
 	}
-	return nil
+	if len(req.Description) > limits.MaxDescriptionLength {
+		violations.add("description", "exceeds maximum length")
+	}
+	if tags, err := domain.NormalizeTags(req.Tags); err != nil {
+		violations.add("tags", err.Error())
+	} else if len(tags) > limits.MaxTags {
+		violations.add("tags", fmt.Sprintf("maximum %d tags allowed", limits.MaxTags))
+	}
+
+	return violations.err()
 }
 
 func mapDomainToProto(todo *domain.Todo) *todov1.Todo {
 	proto := &todov1.Todo{
-		Id:          todo.ID,
-		Title:       todo.Title,
-		Description: todo.Description,
-		Status:      mapDomainStatus(todo.Status),
-		Priority:    mapDomainPriority(todo.Priority),
-		Tags:        todo.Tags,
-		OwnerId:     todo.OwnerID,
-		TenantId:    todo.TenantID,
-		CreatedAt:   timestamppb.New(todo.CreatedAt),
-		UpdatedAt:   timestamppb.New(todo.UpdatedAt),
-		Version:     todo.Version,
+		Id:            todo.ID,
+		Title:         todo.Title,
+		Description:   todo.Description,
+		Status:        mapDomainStatus(todo.Status),
+		Priority:      mapDomainPriority(todo.Priority),
+		Tags:          todo.Tags,
+		OwnerId:       todo.OwnerID,
+		TenantId:      todo.TenantID,
+		CreatedAt:     timestamppb.New(todo.CreatedAt),
+		UpdatedAt:     timestamppb.New(todo.UpdatedAt),
+		Version:       todo.Version,
+		Overdue:       todo.IsOverdue(time.Now().UTC()),
+		LoggedMinutes: todo.LoggedMinutes,
 	}
 
 	if todo.DueDate != nil {
@@ -470,9 +1612,68 @@ func mapDomainToProto(todo *domain.Todo) *todov1.Todo {
 		proto.AssignedTo = *todo.AssignedTo
 	}
 
+	if todo.DueDateTimezone != nil {
+		proto.DueDateTimezone = *todo.DueDateTimezone
+	}
+
+	if todo.DeletedAt != nil {
+		proto.DeletedAt = timestamppb.New(*todo.DeletedAt)
+	}
+
+	if todo.CompletedAt != nil {
+		proto.CompletedAt = timestamppb.New(*todo.CompletedAt)
+	}
+
+	if todo.CompletedBy != nil {
+		proto.CompletedBy = *todo.CompletedBy
+	}
+
+	if todo.ArchivedAt != nil {
+		proto.ArchivedAt = timestamppb.New(*todo.ArchivedAt)
+	}
+
+	if todo.ParentID != nil {
+		proto.ParentId = *todo.ParentID
+	}
+
+	if todo.EstimatedMinutes != nil {
+		estimate := *todo.EstimatedMinutes
+		proto.EstimatedMinutes = &estimate
+	}
+
+	if todo.RemindAt != nil {
+		proto.RemindAt = timestamppb.New(*todo.RemindAt)
+	}
+
+	if todo.RemindedAt != nil {
+		proto.RemindedAt = timestamppb.New(*todo.RemindedAt)
+	}
+
 	return proto
 }
 
+func mapTodoRefToProto(ref *domain.TodoRef) *todov1.TodoRef {
+	return &todov1.TodoRef{
+		Id:        ref.ID,
+		Version:   ref.Version,
+		UpdatedAt: timestamppb.New(ref.UpdatedAt),
+	}
+}
+
+func mapHistoryToProto(entry *domain.HistoryEntry) *todov1.TodoHistoryEntry {
+	// Changes round-tripped from JSON always re-encode
+	diff, _ := json.Marshal(entry.Changes)
+
+	return &todov1.TodoHistoryEntry{
+		Id:         entry.ID,
+		TodoId:     entry.TodoID,
+		ActorId:    entry.ActorID,
+		ChangeType: string(entry.ChangeType),
+		Diff:       string(diff),
+		CreatedAt:  timestamppb.New(entry.CreatedAt),
+	}
+}
+
 func mapDomainStatus(s domain.TodoStatus) todov1.TodoStatus {
 	switch s {
 	case domain.StatusPending:
@@ -499,7 +1700,9 @@ func mapProtoStatus(s todov1.TodoStatus) domain.TodoStatus {
 	case todov1.TodoStatus_TODO_STATUS_ARCHIVED:
 		return domain.StatusArchived
 	default:
-		return domain.StatusPending
+		// Unspecified and unknown values map to no status, which the domain
+		// rejects instead of silently picking one
+		return 0
 	}
 }
 
@@ -522,39 +1725,107 @@ func mapProtoPriority(p todov1.TodoPriority) domain.TodoPriority {
 	switch p {
 	case todov1.TodoPriority_TODO_PRIORITY_LOW:
 		return domain.PriorityLow
+	case todov1.TodoPriority_TODO_PRIORITY_MEDIUM:
+		return domain.PriorityMedium
 	case todov1.TodoPriority_TODO_PRIORITY_HIGH:
 		return domain.PriorityHigh
 	case todov1.TodoPriority_TODO_PRIORITY_CRITICAL:
 		return domain.PriorityCritical
 	default:
-		return domain.PriorityMedium
+		// Unspecified and unknown values map to no priority, which the
+		// domain rejects instead of silently picking one
+		return 0
 	}
 }
 
+// canonicalTags brings filter tags to their stored form so they match
+func canonicalTags(tags []string) []string {
+	canonical := make([]string, len(tags))
+	for i, tag := range tags {
+		canonical[i] = domain.CanonicalTag(tag)
+	}
+	return canonical
+}
+
 func mapDomainError(err error) error {
-	switch err {
-	case domain.ErrEmptyTitle, domain.ErrTitleTooLong, domain.ErrDescriptionTooLong,
-		domain.ErrInvalidPriority, domain.ErrDueDateInPast, domain.ErrTooManyTags:
-		return status.Error(codes.InvalidArgument, err.Error())
-	case domain.ErrInvalidStatusTransition:
-		return status.Error(codes.FailedPrecondition, err.Error())
-	case domain.ErrTodoNotFound:
-		return status.Error(codes.NotFound, err.Error())
-	case domain.ErrVersionMismatch:
-		return status.Error(codes.Aborted, err.Error())
-	case domain.ErrUnauthorized:
-		return status.Error(codes.Unauthenticated, err.Error())
-	case domain.ErrForbidden:
-		return status.Error(codes.PermissionDenied, err.Error())
-	default:
-		return status.Error(codes.Internal, "internal server error")
+	var errs domain.ValidationErrors
+	if errors.As(err, &errs) {
+		return validationError(errs)
+	}
+
+	// Errors may arrive wrapped with context, so match on the chain and
+	// report the domain error itself to the client
+	if target := matchError(err,
+		domain.ErrEmptyTitle, domain.ErrTitleTooLong, domain.ErrDescriptionTooLong,
+		domain.ErrInvalidPriority, domain.ErrInvalidStatus, domain.ErrDueDateInPast, domain.ErrTooManyTags,
+		domain.ErrInvalidOwnerId, domain.ErrUnknownAssignee, domain.ErrInvalidTenantID, domain.ErrInvalidTimezone,
+		domain.ErrEmptyCommentBody, domain.ErrCommentTooLong, domain.ErrInvalidFilename,
+		domain.ErrAttachmentTooLarge, domain.ErrInvalidSortField, domain.ErrInvalidPage,
+		domain.ErrInvalidPageSize, domain.ErrEmptyTag, domain.ErrTagTooLong,
+		domain.ErrInvalidSnooze, domain.ErrInvalidEstimate, domain.ErrInvalidTimeLog,
+		domain.ErrSelfDependency, domain.ErrPageTooLarge, domain.ErrInvalidDueRange,
+		domain.ErrInvalidCreatedRange, domain.ErrInvalidUpdatedRange,
+	); target != nil {
+		return fieldError(domainErrorFields[target], target.Error())
+	}
+
+	for _, m := range domainErrorCodes {
+		if target := matchError(err, m.errs...); target != nil {
+			return status.Error(m.code, target.Error())
+		}
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// domainErrorCodes maps domain errors that concern no single field to the
+// gRPC code reported for them
+var domainErrorCodes = []struct {
+	code codes.Code
+	errs []error
+}{
+	{codes.InvalidArgument, []error{domain.ErrFilterTooBroad}},
+	{codes.FailedPrecondition, []error{domain.ErrInvalidStatusTransition, domain.ErrNoDueDate,
+		domain.ErrBlockedByDependencies, domain.ErrDependencyCycle}},
+	{codes.NotFound, []error{domain.ErrTodoNotFound, domain.ErrCommentNotFound, domain.ErrDependencyNotFound}},
+	{codes.Aborted, []error{domain.ErrVersionMismatch}},
+	{codes.AlreadyExists, []error{domain.ErrDuplicateTitle}},
+	{codes.Unauthenticated, []error{domain.ErrUnauthorized}},
+	{codes.PermissionDenied, []error{domain.ErrForbidden}},
+}
+
+// matchError returns the first of targets found in err's chain, or nil
+func matchError(err error, targets ...error) error {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return target
+		}
+	}
+	return nil
+}
+
+// onlyReassigns reports whether mask updates the assignee and nothing else
+func onlyReassigns(mask *fieldmaskpb.FieldMask) bool {
+	if mask == nil || len(mask.Paths) == 0 {
+		return false
 	}
+	for _, path := range mask.Paths {
+		if path != "assigned_to" {
+			return false
+		}
+	}
+	return true
+}
+
+// maskIncludes reports whether mask explicitly updates path
+func maskIncludes(mask *fieldmaskpb.FieldMask, path string) bool {
+	return mask != nil && slices.Contains(mask.Paths, path)
 }
 
-func applyFieldMaskUpdates(existing *domain.Todo, updates *todov1.Todo, mask *fieldmaskpb.FieldMask) error {
+func applyFieldMaskUpdates(existing *domain.Todo, updates *todov1.Todo, mask *fieldmaskpb.FieldMask, actorID string, transitions *domain.TransitionPolicy) error {
 	if mask == nil || len(mask.Paths) == 0 {
-		// Update all fields if no mask
-		return updateAllFields(existing, updates)
+		// Without a mask the update is a full replace, so fields left unset
+		// in updates are cleared rather than silently kept
+		return replaceTodoFields(existing, updates, actorID, transitions)
 	}
 
 	for _, path := range mask.Paths {
@@ -572,7 +1843,7 @@ func applyFieldMaskUpdates(existing *domain.Todo, updates *todov1.Todo, mask *fi
 				return err
 			}
 		case "status":
-			if err := existing.UpdateStatus(mapProtoStatus(updates.Status)); err != nil {
+			if err := existing.UpdateStatus(mapProtoStatus(updates.Status), actorID, transitions); err != nil {
 				return err
 			}
 		case "due_date":
@@ -582,6 +1853,14 @@ func applyFieldMaskUpdates(existing *domain.Todo, updates *todov1.Todo, mask *fi
 					return err
 				}
 			}
+		case "due_date_timezone":
+			var tz *string
+			if updates.DueDateTimezone != "" {
+				tz = &updates.DueDateTimezone
+			}
+			if err := existing.SetDueDateTimezone(tz); err != nil {
+				return err
+			}
 		case "assigned_to":
 			if updates.AssignedTo != "" {
 				if err := existing.AssignTo(&updates.AssignedTo); err != nil {
@@ -594,21 +1873,136 @@ func applyFieldMaskUpdates(existing *domain.Todo, updates *todov1.Todo, mask *fi
 					return err
 				}
 			}
+		case "owner_id":
+			if err := existing.TransferOwnership(updates.OwnerId); err != nil {
+				return err
+			}
+		case "estimated_minutes":
+			if err := existing.SetEstimate(updates.EstimatedMinutes); err != nil {
+				return err
+			}
+		case "remind_at":
+			var remindAt *time.Time
+			if updates.RemindAt != nil {
+				r := updates.RemindAt.AsTime()
+				remindAt = &r
+			}
+			existing.SetReminder(remindAt)
 		}
 	}
 
 	return nil
 }
 
-func updateAllFields(existing *domain.Todo, updates *todov1.Todo) error {
-	if err := existing.UpdateTitle(updates.Title); err != nil {
+// newTodoFromProto builds a pending todo owned by the caller, keeping the
+// client-supplied id
+func newTodoFromProto(p *todov1.Todo, userCtx *auth.UserContext) (*domain.Todo, error) {
+	var opts []domain.TodoOption
+	if p.DueDate != nil {
+		dueDate := p.DueDate.AsTime()
+		opts = append(opts, domain.WithDueDate(&dueDate))
+	}
+	if p.DueDateTimezone != "" {
+		opts = append(opts, domain.WithDueDateTimezone(&p.DueDateTimezone))
+	}
+	if len(p.Tags) > 0 {
+		opts = append(opts, domain.WithTags(p.Tags))
+	}
+	if p.AssignedTo != "" {
+		opts = append(opts, domain.WithAssignee(&p.AssignedTo))
+	}
+	if p.EstimatedMinutes != nil {
+		opts = append(opts, domain.WithEstimate(p.EstimatedMinutes))
+	}
+	if p.RemindAt != nil {
+		remindAt := p.RemindAt.AsTime()
+		opts = append(opts, domain.WithReminder(&remindAt))
+	}
+
+	todo, err := domain.NewTodo(p.Title, p.Description, userCtx.UserID, userCtx.TenantID, mapProtoPriority(p.Priority), opts...)
+	if err != nil {
+		return nil, err
+	}
+	todo.ID = p.Id
+	return todo, nil
+}
+
+// replaceTodoFields overwrites the mutable fields of existing with those of
+// p. Unspecified enums keep their current value, and unchanged due dates are
+// not re-validated so todos that are already overdue can still sync.
+func replaceTodoFields(existing *domain.Todo, p *todov1.Todo, actorID string, transitions *domain.TransitionPolicy) error {
+	if err := existing.UpdateTitle(p.Title); err != nil {
 		return err
 	}
-	if err := existing.UpdateDescription(updates.Description); err != nil {
+	if err := existing.UpdateDescription(p.Description); err != nil {
 		return err
 	}
-	if err := existing.UpdatePriority(mapProtoPriority(updates.Priority)); err != nil {
+	if p.Priority != todov1.TodoPriority_TODO_PRIORITY_UNSPECIFIED {
+		if err := existing.UpdatePriority(mapProtoPriority(p.Priority)); err != nil {
+			return err
+		}
+	}
+	if p.Status != todov1.TodoStatus_TODO_STATUS_UNSPECIFIED && mapProtoStatus(p.Status) != existing.Status {
+		if err := existing.UpdateStatus(mapProtoStatus(p.Status), actorID, transitions); err != nil {
+			return err
+		}
+	}
+
+	var tz *string
+	if p.DueDateTimezone != "" {
+		tz = &p.DueDateTimezone
+	}
+	if err := existing.SetDueDateTimezone(tz); err != nil {
 		return err
 	}
-	return nil
+
+	var dueDate *time.Time
+	if p.DueDate != nil {
+		d := p.DueDate.AsTime()
+		dueDate = &d
+	}
+	if !equalTime(existing.DueDate, dueDate) {
+		if err := existing.SetDueDate(dueDate); err != nil {
+			return err
+		}
+	}
+
+	if err := existing.ReplaceTags(p.Tags); err != nil {
+		return err
+	}
+
+	if !equalInt32(existing.EstimatedMinutes, p.EstimatedMinutes) {
+		if err := existing.SetEstimate(p.EstimatedMinutes); err != nil {
+			return err
+		}
+	}
+
+	var remindAt *time.Time
+	if p.RemindAt != nil {
+		r := p.RemindAt.AsTime()
+		remindAt = &r
+	}
+	if !equalTime(existing.RemindAt, remindAt) {
+		existing.SetReminder(remindAt)
+	}
+
+	var assignee *string
+	if p.AssignedTo != "" {
+		assignee = &p.AssignedTo
+	}
+	return existing.AssignTo(assignee)
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalInt32(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
@@ -0,0 +1,1766 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/internal/events"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/memory"
+	"github.com/dmehra2102/TaskForge/internal/infrastructure/storage"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testTenant = "tenant-1"
+
+func newTestService(t *testing.T, repo domain.Repository, opts ...Option) *TodoServiceServer {
+	t.Helper()
+	if repo == nil {
+		repo = memory.NewInMemoryRepository()
+	}
+	return NewTodoServiceServer(repo, zap.NewNop(), auth.NewAuthorizer(), opts...)
+}
+
+// asUser returns a context authenticated as userID of the test tenant
+func asUser(userID string, roles ...string) context.Context {
+	if len(roles) == 0 {
+		roles = []string{"user"}
+	}
+	return auth.ContextWithUserContext(context.Background(), &auth.UserContext{
+		UserID:   userID,
+		TenantID: testTenant,
+		Roles:    roles,
+	})
+}
+
+func createTodo(t *testing.T, s *TodoServiceServer, ctx context.Context, req *todov1.CreateTodoRequest) *todov1.Todo {
+	t.Helper()
+	if req.Priority == todov1.TodoPriority_TODO_PRIORITY_UNSPECIFIED {
+		req.Priority = todov1.TodoPriority_TODO_PRIORITY_MEDIUM
+	}
+	resp, err := s.CreateTodo(ctx, req)
+	if err != nil {
+		t.Fatalf("failed to create todo %q: %v", req.Title, err)
+	}
+	return resp.Todo
+}
+
+func TestGetTodoDetailSubtasks(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+
+	parent := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "parent"})
+	first := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first", ParentId: parent.Id})
+	second := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "second", ParentId: parent.Id})
+	deleted := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "deleted", ParentId: parent.Id})
+	if _, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Id: deleted.Id}); err != nil {
+		t.Fatalf("failed to delete subtask: %v", err)
+	}
+	if _, err := s.AddComment(alice, &todov1.AddCommentRequest{TodoId: parent.Id, Body: "hello"}); err != nil {
+		t.Fatalf("failed to comment: %v", err)
+	}
+
+	if first.ParentId != parent.Id {
+		t.Fatalf("expected subtask parent %s, got %q", parent.Id, first.ParentId)
+	}
+
+	detail, err := s.GetTodoDetail(alice, &todov1.GetTodoDetailRequest{Id: parent.Id})
+	if err != nil {
+		t.Fatalf("GetTodoDetail failed: %v", err)
+	}
+	if detail.CommentCount != 1 {
+		t.Fatalf("expected 1 comment, got %d", detail.CommentCount)
+	}
+	if len(detail.Subtasks) != 2 || detail.Subtasks[0].Id != first.Id || detail.Subtasks[1].Id != second.Id {
+		t.Fatalf("expected live subtasks [first second] in creation order, got %v", detail.Subtasks)
+	}
+
+	// A todo with neither subtasks nor comments returns empty and zero
+	leaf, err := s.GetTodoDetail(alice, &todov1.GetTodoDetailRequest{Id: second.Id})
+	if err != nil {
+		t.Fatalf("GetTodoDetail failed: %v", err)
+	}
+	if leaf.CommentCount != 0 || len(leaf.Subtasks) != 0 {
+		t.Fatalf("expected no comments or subtasks, got %d and %v", leaf.CommentCount, leaf.Subtasks)
+	}
+
+	// Others may neither read the detail nor add subtasks to it
+	if _, err := s.GetTodoDetail(bob, &todov1.GetTodoDetailRequest{Id: parent.Id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied reading another user's detail, got %v", err)
+	}
+	if _, err := s.CreateTodo(bob, &todov1.CreateTodoRequest{Title: "sneaky", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW, ParentId: parent.Id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied adding a subtask to another user's todo, got %v", err)
+	}
+}
+
+func TestCreateTodoRejectsUnknownParent(t *testing.T) {
+	s := newTestService(t, nil)
+
+	_, err := s.CreateTodo(asUser("alice"), &todov1.CreateTodoRequest{
+		Title:    "orphan",
+		Priority: todov1.TodoPriority_TODO_PRIORITY_LOW,
+		ParentId: domain.NewID(),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unknown parent, got %v", err)
+	}
+}
+
+func todoIDs(todos []*todov1.Todo) map[string]bool {
+	ids := make(map[string]bool, len(todos))
+	for _, todo := range todos {
+		ids[todo.Id] = true
+	}
+	return ids
+}
+
+func TestListTodosVisibility(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+
+	aliceOwn := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "alice own"})
+	aliceForBob := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "alice for bob", AssignedTo: "bob"})
+	bobForAlice := createTodo(t, s, bob, &todov1.CreateTodoRequest{Title: "bob for alice", AssignedTo: "alice"})
+	bobOwn := createTodo(t, s, bob, &todov1.CreateTodoRequest{Title: "bob own"})
+	bobForCarol := createTodo(t, s, bob, &todov1.CreateTodoRequest{Title: "bob for carol", AssignedTo: "carol"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *todov1.ListTodosRequest
+		want []string
+	}{
+		{
+			name: "no filter shows owned and assigned todos",
+			ctx:  alice,
+			req:  &todov1.ListTodosRequest{},
+			want: []string{aliceOwn.Id, aliceForBob.Id, bobForAlice.Id},
+		},
+		{
+			name: "assigned to self",
+			ctx:  alice,
+			req:  &todov1.ListTodosRequest{AssignedToMe: true},
+			want: []string{bobForAlice.Id},
+		},
+		{
+			name: "assigned to self by id",
+			ctx:  alice,
+			req:  &todov1.ListTodosRequest{AssignedToFilter: "alice"},
+			want: []string{bobForAlice.Id},
+		},
+		{
+			name: "assigned to someone else stays within what the caller can read",
+			ctx:  alice,
+			req:  &todov1.ListTodosRequest{AssignedToFilter: "bob"},
+			want: []string{aliceForBob.Id},
+		},
+		{
+			name: "assigned to a user the caller shares nothing with",
+			ctx:  alice,
+			req:  &todov1.ListTodosRequest{AssignedToFilter: "carol"},
+			want: nil,
+		},
+		{
+			name: "owned by me",
+			ctx:  alice,
+			req:  &todov1.ListTodosRequest{OwnedByMe: true},
+			want: []string{aliceOwn.Id, aliceForBob.Id},
+		},
+		{
+			name: "admins read the whole tenant",
+			ctx:  asUser("root", "admin"),
+			req:  &todov1.ListTodosRequest{},
+			want: []string{aliceOwn.Id, aliceForBob.Id, bobForAlice.Id, bobOwn.Id, bobForCarol.Id},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListTodos(tt.ctx, tt.req)
+			if err != nil {
+				t.Fatalf("ListTodos failed: %v", err)
+			}
+			got := todoIDs(resp.Todos)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d todos, got %d", len(tt.want), len(got))
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Fatalf("expected todo %s in the results", id)
+				}
+			}
+		})
+	}
+
+	// Stats count what ListTodos shows
+	stats, err := s.GetTodoStats(alice, &todov1.GetTodoStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetTodoStats failed: %v", err)
+	}
+	if stats.Total != 3 {
+		t.Fatalf("expected stats over 3 readable todos, got %d", stats.Total)
+	}
+}
+
+func TestBatchCreateTodosReportsFieldErrors(t *testing.T) {
+	s := newTestService(t, nil)
+
+	resp, err := s.BatchCreateTodos(asUser("alice"), &todov1.BatchCreateTodosRequest{
+		Requests: []*todov1.CreateTodoRequest{
+			{Title: "valid", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+			{Title: "", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreateTodos failed: %v", err)
+	}
+	if len(resp.Todos) != 1 || resp.Todos[0].Title != "valid" {
+		t.Fatalf("expected only the valid todo to be created, got %v", resp.Todos)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Field != "requests[1].title" {
+		t.Fatalf("expected a field error for requests[1].title, got %v", resp.Errors)
+	}
+}
+
+func TestBatchCreateTodosDuplicateTitle(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "taken"})
+
+	tests := []struct {
+		name   string
+		titles []string
+	}{
+		{name: "existing title", titles: []string{"new", "taken"}},
+		{name: "repeated within the batch", titles: []string{"twice", "twice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &todov1.BatchCreateTodosRequest{}
+			for _, title := range tt.titles {
+				req.Requests = append(req.Requests, &todov1.CreateTodoRequest{Title: title, Priority: todov1.TodoPriority_TODO_PRIORITY_LOW})
+			}
+			if _, err := s.BatchCreateTodos(alice, req); status.Code(err) != codes.AlreadyExists {
+				t.Fatalf("expected AlreadyExists, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHardDeleteRemovesAttachmentObjects(t *testing.T) {
+	objects := storage.NewMemoryStorage()
+	s := newTestService(t, nil, WithObjectStorage(objects, time.Minute, 0))
+	admin := asUser("root", "admin")
+
+	attach := func(todoID, filename string) string {
+		t.Helper()
+		upload, err := s.CreateAttachmentUploadURL(admin, &todov1.CreateAttachmentUploadURLRequest{
+			TodoId:    todoID,
+			Filename:  filename,
+			SizeBytes: 4,
+		})
+		if err != nil {
+			t.Fatalf("CreateAttachmentUploadURL failed: %v", err)
+		}
+		objects.Put(upload.ObjectKey, "text/plain", 4)
+		if _, err := s.ConfirmAttachmentUpload(admin, &todov1.ConfirmAttachmentUploadRequest{TodoId: todoID, ObjectKey: upload.ObjectKey}); err != nil {
+			t.Fatalf("ConfirmAttachmentUpload failed: %v", err)
+		}
+		return upload.ObjectKey
+	}
+
+	erased := createTodo(t, s, admin, &todov1.CreateTodoRequest{Title: "erased"})
+	softDeleted := createTodo(t, s, admin, &todov1.CreateTodoRequest{Title: "soft deleted"})
+	erasedKeys := []string{attach(erased.Id, "a.txt"), attach(erased.Id, "b.txt")}
+	keptKey := attach(softDeleted.Id, "c.txt")
+
+	if _, err := s.DeleteTodo(admin, &todov1.DeleteTodoRequest{Id: erased.Id, Hard: true}); err != nil {
+		t.Fatalf("hard DeleteTodo failed: %v", err)
+	}
+	if _, err := s.DeleteTodo(admin, &todov1.DeleteTodoRequest{Id: softDeleted.Id}); err != nil {
+		t.Fatalf("DeleteTodo failed: %v", err)
+	}
+
+	for _, key := range erasedKeys {
+		if _, err := objects.Stat(context.Background(), key); !errors.Is(err, domain.ErrObjectNotFound) {
+			t.Fatalf("expected object %s removed with its todo, got %v", key, err)
+		}
+	}
+	// A soft-deleted todo can still be restored, so its files stay
+	if _, err := objects.Stat(context.Background(), keptKey); err != nil {
+		t.Fatalf("expected object %s kept for a soft-deleted todo, got %v", keptKey, err)
+	}
+
+	// until it is erased for good
+	if _, err := s.DeleteTodo(admin, &todov1.DeleteTodoRequest{Id: softDeleted.Id, Hard: true}); err != nil {
+		t.Fatalf("hard DeleteTodo of a soft-deleted todo failed: %v", err)
+	}
+	if _, err := objects.Stat(context.Background(), keptKey); !errors.Is(err, domain.ErrObjectNotFound) {
+		t.Fatalf("expected object %s removed once its todo was erased, got %v", keptKey, err)
+	}
+}
+
+func TestHardDeleteModeRemovesAttachmentObjects(t *testing.T) {
+	objects := storage.NewMemoryStorage()
+	s := newTestService(t, nil, WithObjectStorage(objects, time.Minute, 0), WithDeleteMode(domain.DeleteHard))
+	admin := asUser("root", "admin")
+
+	todo := createTodo(t, s, admin, &todov1.CreateTodoRequest{Title: "erased"})
+	upload, err := s.CreateAttachmentUploadURL(admin, &todov1.CreateAttachmentUploadURLRequest{
+		TodoId:    todo.Id,
+		Filename:  "a.txt",
+		SizeBytes: 4,
+	})
+	if err != nil {
+		t.Fatalf("CreateAttachmentUploadURL failed: %v", err)
+	}
+	objects.Put(upload.ObjectKey, "text/plain", 4)
+	if _, err := s.ConfirmAttachmentUpload(admin, &todov1.ConfirmAttachmentUploadRequest{TodoId: todo.Id, ObjectKey: upload.ObjectKey}); err != nil {
+		t.Fatalf("ConfirmAttachmentUpload failed: %v", err)
+	}
+
+	// Without Hard set, the configured mode erases the todo
+	if _, err := s.DeleteTodo(admin, &todov1.DeleteTodoRequest{Id: todo.Id}); err != nil {
+		t.Fatalf("DeleteTodo failed: %v", err)
+	}
+	if _, err := objects.Stat(context.Background(), upload.ObjectKey); !errors.Is(err, domain.ErrObjectNotFound) {
+		t.Fatalf("expected object %s removed with its todo, got %v", upload.ObjectKey, err)
+	}
+}
+
+func TestBulkDeleteTodosFilters(t *testing.T) {
+	alice := asUser("alice")
+	bob := "bob"
+	yes := true
+
+	tests := []struct {
+		name    string
+		req     *todov1.BulkDeleteTodosRequest
+		deleted []string // titles
+		code    codes.Code
+	}{
+		{
+			name: "no filter",
+			req:  &todov1.BulkDeleteTodosRequest{},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unspecified status",
+			req:  &todov1.BulkDeleteTodosRequest{StatusFilter: []todov1.TodoStatus{todov1.TodoStatus_TODO_STATUS_UNSPECIFIED}},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "reversed created range",
+			req: &todov1.BulkDeleteTodosRequest{
+				CreatedFrom: timestamppb.New(time.Now().Add(time.Hour)),
+				CreatedTo:   timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown timezone",
+			req: &todov1.BulkDeleteTodosRequest{
+				DueBucket: todov1.DueBucket_DUE_BUCKET_TODAY,
+				Timezone:  "Mars/Olympus_Mons",
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "exclude tags",
+			req:     &todov1.BulkDeleteTodosRequest{ExcludeTagsFilter: []string{"keep"}},
+			deleted: []string{"plain", "assigned"},
+		},
+		{
+			name:    "unassigned",
+			req:     &todov1.BulkDeleteTodosRequest{AssignedToIsNull: &yes},
+			deleted: []string{"plain", "kept"},
+		},
+		{
+			name:    "updated range",
+			req:     &todov1.BulkDeleteTodosRequest{UpdatedFrom: timestamppb.New(time.Now().Add(-time.Hour))},
+			deleted: []string{"plain", "kept", "assigned"},
+		},
+		{
+			name:    "no due date bucket",
+			req:     &todov1.BulkDeleteTodosRequest{DueBucket: todov1.DueBucket_DUE_BUCKET_NONE},
+			deleted: []string{"plain", "kept", "assigned"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, nil)
+			ids := map[string]string{
+				createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "plain"}).Id:                        "plain",
+				createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "kept", Tags: []string{"keep"}}).Id: "kept",
+				createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "assigned", AssignedTo: bob}).Id:    "assigned",
+			}
+
+			resp, err := s.BulkDeleteTodos(alice, tt.req)
+			if tt.code != codes.OK {
+				if status.Code(err) != tt.code {
+					t.Fatalf("expected %v, got %v", tt.code, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BulkDeleteTodos failed: %v", err)
+			}
+			if resp.DeletedCount != int64(len(tt.deleted)) {
+				t.Fatalf("expected %d deleted, got %d", len(tt.deleted), resp.DeletedCount)
+			}
+
+			list, err := s.ListTodos(alice, &todov1.ListTodosRequest{})
+			if err != nil {
+				t.Fatalf("ListTodos failed: %v", err)
+			}
+			remaining := todoIDs(list.Todos)
+			for id, title := range ids {
+				if remaining[id] == slices.Contains(tt.deleted, title) {
+					t.Errorf("todo %q: expected deleted %v", title, slices.Contains(tt.deleted, title))
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateTodoStatusVersion(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "status"})
+
+	// Zero skips the version check
+	resp, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{
+		Id:        todo.Id,
+		NewStatus: todov1.TodoStatus_TODO_STATUS_IN_PROGRESS,
+	})
+	if err != nil {
+		t.Fatalf("UpdateTodoStatus without a version failed: %v", err)
+	}
+	if resp.Todo.Version != todo.Version+1 {
+		t.Fatalf("expected version %d, got %d", todo.Version+1, resp.Todo.Version)
+	}
+
+	_, err = s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{
+		Id:        todo.Id,
+		NewStatus: todov1.TodoStatus_TODO_STATUS_COMPLETED,
+		Version:   todo.Version,
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted for a stale version, got %v", err)
+	}
+
+	if _, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{
+		Id:        todo.Id,
+		NewStatus: todov1.TodoStatus_TODO_STATUS_COMPLETED,
+		Version:   resp.Todo.Version,
+	}); err != nil {
+		t.Fatalf("UpdateTodoStatus with the current version failed: %v", err)
+	}
+}
+
+func TestUpdateTodoRequiresTodo(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "update"})
+
+	tests := []struct {
+		name string
+		mask *fieldmaskpb.FieldMask
+	}{
+		{name: "masked", mask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}},
+		{name: "full replace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{Id: todo.Id, UpdateMask: tt.mask})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected InvalidArgument without a todo, got %v", err)
+			}
+		})
+	}
+}
+
+// watchStream records the todos WatchTodo sends to a client whose context is ctx
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *todov1.Todo
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(todo *todov1.Todo) error {
+	s.sent <- todo
+	return nil
+}
+
+func TestWatchTodoReleasesSubscriptionOnExit(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(cancel context.CancelCauseFunc, broker *events.Broker)
+		code codes.Code
+	}{
+		{
+			name: "client goes away",
+			stop: func(cancel context.CancelCauseFunc, broker *events.Broker) { cancel(nil) },
+			code: codes.OK,
+		},
+		{
+			name: "server shuts down",
+			stop: func(cancel context.CancelCauseFunc, broker *events.Broker) { broker.Close() },
+			code: codes.Unavailable,
+		},
+		{
+			name: "server drains streams",
+			stop: func(cancel context.CancelCauseFunc, broker *events.Broker) {
+				cancel(status.Error(codes.Unavailable, "server is shutting down"))
+			},
+			code: codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := events.NewBroker()
+			s := newTestService(t, nil, WithEventSubscriber(broker))
+			alice := asUser("alice")
+			todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "watched"})
+
+			ctx, cancel := context.WithCancelCause(alice)
+			defer cancel(nil)
+			stream := &watchStream{ctx: ctx, sent: make(chan *todov1.Todo, 1)}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- s.WatchTodo(&todov1.GetTodoRequest{Id: todo.Id}, stream)
+			}()
+
+			select {
+			case <-stream.sent:
+			case <-time.After(5 * time.Second):
+				t.Fatal("WatchTodo did not send the current state")
+			}
+			if n := broker.Subscribers(); n != 1 {
+				t.Fatalf("expected 1 subscription while watching, got %d", n)
+			}
+
+			tt.stop(cancel, broker)
+
+			select {
+			case err := <-done:
+				if status.Code(err) != tt.code {
+					t.Fatalf("expected %v, got %v", tt.code, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("WatchTodo kept running after the stream ended")
+			}
+			if n := broker.Subscribers(); n != 0 {
+				t.Fatalf("expected the subscription released, got %d left", n)
+			}
+		})
+	}
+}
+
+func TestGetTodoStats(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+
+	createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "pending"})
+	done := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "done"})
+	createTodo(t, s, bob, &todov1.CreateTodoRequest{Title: "bob's"})
+	for _, st := range []todov1.TodoStatus{todov1.TodoStatus_TODO_STATUS_IN_PROGRESS, todov1.TodoStatus_TODO_STATUS_COMPLETED} {
+		if _, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{Id: done.Id, NewStatus: st}); err != nil {
+			t.Fatalf("UpdateTodoStatus failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		want  map[todov1.TodoStatus]int64
+		total int64
+	}{
+		{
+			name:  "own todos",
+			ctx:   alice,
+			want:  map[todov1.TodoStatus]int64{todov1.TodoStatus_TODO_STATUS_PENDING: 1, todov1.TodoStatus_TODO_STATUS_COMPLETED: 1},
+			total: 2,
+		},
+		{
+			name:  "whole tenant",
+			ctx:   asUser("root", "admin"),
+			want:  map[todov1.TodoStatus]int64{todov1.TodoStatus_TODO_STATUS_PENDING: 2, todov1.TodoStatus_TODO_STATUS_COMPLETED: 1},
+			total: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetTodoStats(tt.ctx, &todov1.GetTodoStatsRequest{})
+			if err != nil {
+				t.Fatalf("GetTodoStats failed: %v", err)
+			}
+			// Every status is reported, including those with no todos
+			if len(resp.Counts) != 4 {
+				t.Fatalf("expected a count per status, got %v", resp.Counts)
+			}
+			for _, count := range resp.Counts {
+				if count.Count != tt.want[count.Status] {
+					t.Fatalf("expected %d %v todos, got %d", tt.want[count.Status], count.Status, count.Count)
+				}
+			}
+			if resp.Total != tt.total {
+				t.Fatalf("expected total %d, got %d", tt.total, resp.Total)
+			}
+		})
+	}
+}
+
+func TestTodoOverdue(t *testing.T) {
+	repo := memory.NewInMemoryRepository()
+	s := newTestService(t, repo)
+	alice := asUser("alice")
+
+	// Past due dates are rejected on creation, so store one directly
+	past := time.Now().UTC().Add(-time.Hour)
+	late, err := domain.NewTodo("late", "", "alice", testTenant, domain.PriorityMedium)
+	if err != nil {
+		t.Fatalf("NewTodo failed: %v", err)
+	}
+	late.DueDate = &past
+	if err := repo.Create(context.Background(), late); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	future := time.Now().UTC().Add(time.Hour)
+	onTime := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "on time", DueDate: timestamppb.New(future)})
+
+	got, err := s.GetTodo(alice, &todov1.GetTodoRequest{Id: late.ID})
+	if err != nil {
+		t.Fatalf("GetTodo failed: %v", err)
+	}
+	if !got.Todo.Overdue || onTime.Overdue {
+		t.Fatalf("expected only the late todo overdue, got %v and %v", got.Todo.Overdue, onTime.Overdue)
+	}
+
+	resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{OverdueOnly: true})
+	if err != nil {
+		t.Fatalf("ListTodos failed: %v", err)
+	}
+	if ids := todoIDs(resp.Todos); len(ids) != 1 || !ids[late.ID] {
+		t.Fatalf("expected only the late todo, got %v", ids)
+	}
+}
+
+func TestTenantQuota(t *testing.T) {
+	tests := []struct {
+		name        string
+		adminBypass bool
+		ctx         context.Context
+		code        codes.Code
+	}{
+		{name: "user at the limit", ctx: asUser("alice"), code: codes.ResourceExhausted},
+		{name: "admin without bypass", ctx: asUser("root", "admin"), code: codes.ResourceExhausted},
+		{name: "admin with bypass", adminBypass: true, ctx: asUser("root", "admin"), code: codes.OK},
+		{name: "user despite bypass", adminBypass: true, ctx: asUser("alice"), code: codes.ResourceExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, nil, WithTenantQuota(2, tt.adminBypass))
+			alice := asUser("alice")
+			createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first"})
+			createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "second"})
+
+			_, err := s.CreateTodo(tt.ctx, &todov1.CreateTodoRequest{Title: "third", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestTenantQuotaCountsActiveTodos(t *testing.T) {
+	s := newTestService(t, nil, WithTenantQuota(2, false))
+	alice := asUser("alice")
+	first := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first"})
+
+	// A batch that would overshoot is rejected whole
+	_, err := s.BatchCreateTodos(alice, &todov1.BatchCreateTodosRequest{Requests: []*todov1.CreateTodoRequest{
+		{Title: "second", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+		{Title: "third", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+	}})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted for an oversized batch, got %v", err)
+	}
+
+	// Deleted todos and other tenants' todos do not count
+	if _, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Id: first.Id}); err != nil {
+		t.Fatalf("DeleteTodo failed: %v", err)
+	}
+	other := auth.ContextWithUserContext(context.Background(), &auth.UserContext{UserID: "zoe", TenantID: "tenant-2", Roles: []string{"user"}})
+	createTodo(t, s, other, &todov1.CreateTodoRequest{Title: "elsewhere"})
+	createTodo(t, s, other, &todov1.CreateTodoRequest{Title: "elsewhere too"})
+
+	_, err = s.BatchCreateTodos(alice, &todov1.BatchCreateTodosRequest{Requests: []*todov1.CreateTodoRequest{
+		{Title: "second", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+		{Title: "third", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+	}})
+	if err != nil {
+		t.Fatalf("expected the batch to fit after the delete, got %v", err)
+	}
+}
+
+func TestAssignmentEmitsTodoAssigned(t *testing.T) {
+	repo := memory.NewInMemoryRepository()
+	s := newTestService(t, repo)
+	publisher := events.NewInMemoryPublisher()
+	relay := events.NewRelay(repo, publisher, zap.NewNop(), time.Minute)
+	alice := asUser("alice")
+
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "assign me"})
+	update := func(changes *todov1.Todo, paths ...string) {
+		t.Helper()
+		changes.Id = todo.Id
+		if _, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+			Id:         todo.Id,
+			Todo:       changes,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		}); err != nil {
+			t.Fatalf("UpdateTodo failed: %v", err)
+		}
+	}
+	// assigned returns the TodoAssigned events relayed since the last call
+	assigned := func() []domain.TodoAssigned {
+		t.Helper()
+		publisher.Reset()
+		if _, err := relay.RelayOnce(context.Background()); err != nil {
+			t.Fatalf("RelayOnce failed: %v", err)
+		}
+		var found []domain.TodoAssigned
+		for _, event := range publisher.Events() {
+			if event.EventName() != domain.EventTodoAssigned {
+				continue
+			}
+			var e domain.TodoAssigned
+			if err := json.Unmarshal(event.(*domain.OutboxMessage).Payload, &e); err != nil {
+				t.Fatalf("failed to decode %s: %v", event.EventName(), err)
+			}
+			found = append(found, e)
+		}
+		return found
+	}
+
+	if got := assigned(); len(got) != 0 {
+		t.Fatalf("expected no assignment event for an unassigned todo, got %+v", got)
+	}
+
+	update(&todov1.Todo{AssignedTo: "bob"}, "assigned_to")
+	got := assigned()
+	if len(got) != 1 || got[0].OldAssignee != nil || got[0].NewAssignee == nil || *got[0].NewAssignee != "bob" {
+		t.Fatalf("expected one assignment to bob, got %+v", got)
+	}
+	if got[0].TodoID != todo.Id || got[0].ActorID != "alice" {
+		t.Fatalf("expected alice assigning %s, got %+v", todo.Id, got[0])
+	}
+
+	update(&todov1.Todo{Title: "renamed"}, "title")
+	if got := assigned(); len(got) != 0 {
+		t.Fatalf("expected no assignment event for a rename, got %+v", got)
+	}
+}
+
+func TestBatchGetTodos(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+
+	first := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first"})
+	second := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "second"})
+	hidden := createTodo(t, s, bob, &todov1.CreateTodoRequest{Title: "bob's"})
+
+	// Todos alice may not read are left out like missing ones
+	resp, err := s.BatchGetTodos(alice, &todov1.BatchGetTodosRequest{Ids: []string{second.Id, hidden.Id, domain.NewID(), first.Id}})
+	if err != nil {
+		t.Fatalf("BatchGetTodos failed: %v", err)
+	}
+	if len(resp.Todos) != 2 || resp.Todos[0].Id != second.Id || resp.Todos[1].Id != first.Id {
+		t.Fatalf("expected [second first], got %v", resp.Todos)
+	}
+
+	ids := make([]string, maxBatchGetIDs+1)
+	for i := range ids {
+		ids[i] = domain.NewID()
+	}
+	if _, err := s.BatchGetTodos(alice, &todov1.BatchGetTodosRequest{Ids: ids}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument past %d ids, got %v", maxBatchGetIDs, err)
+	}
+}
+
+func TestUpsertTodo(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+	id := domain.NewID()
+
+	upsert := func(ctx context.Context, title string, version int64) (*todov1.UpsertTodoResponse, error) {
+		return s.UpsertTodo(ctx, &todov1.UpsertTodoRequest{
+			Todo:    &todov1.Todo{Id: id, Title: title, Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+			Version: version,
+		})
+	}
+
+	resp, err := upsert(alice, "offline draft", 0)
+	if err != nil {
+		t.Fatalf("UpsertTodo failed: %v", err)
+	}
+	if !resp.Created || resp.Todo.Id != id || resp.Todo.OwnerId != "alice" || resp.Todo.Version != 1 {
+		t.Fatalf("expected todo %s created by alice at version 1, got created=%v %+v", id, resp.Created, resp.Todo)
+	}
+
+	// Retrying the create does not duplicate the todo
+	if _, err := upsert(alice, "offline draft", 0); status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted replaying the create, got %v", err)
+	}
+
+	resp, err = upsert(alice, "synced", 1)
+	if err != nil {
+		t.Fatalf("UpsertTodo failed: %v", err)
+	}
+	if resp.Created || resp.Todo.Title != "synced" || resp.Todo.Version != 2 {
+		t.Fatalf("expected synced replaced at version 2, got created=%v %+v", resp.Created, resp.Todo)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{
+			name: "stale version",
+			call: func() error { _, err := upsert(alice, "lost update", 1); return err },
+			code: codes.Aborted,
+		},
+		{
+			name: "not the owner",
+			call: func() error { _, err := upsert(bob, "hijacked", 2); return err },
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "version for an unknown todo",
+			call: func() error {
+				_, err := s.UpsertTodo(alice, &todov1.UpsertTodoRequest{Todo: &todov1.Todo{Id: domain.NewID(), Title: "gone"}, Version: 3})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "id is not a UUID",
+			call: func() error {
+				_, err := s.UpsertTodo(alice, &todov1.UpsertTodoRequest{Todo: &todov1.Todo{Id: "todo-1", Title: "bad id"}})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unauthenticated",
+			call: func() error { _, err := upsert(context.Background(), "anonymous", 2); return err },
+			code: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	got, err := s.GetTodo(alice, &todov1.GetTodoRequest{Id: id})
+	if err != nil {
+		t.Fatalf("GetTodo failed: %v", err)
+	}
+	if got.Todo.Title != "synced" || got.Todo.Version != 2 {
+		t.Fatalf("expected the rejected upserts to leave synced at version 2, got %q at %d", got.Todo.Title, got.Todo.Version)
+	}
+}
+
+func TestUpsertTodoKeepsPastDueDate(t *testing.T) {
+	repo := memory.NewInMemoryRepository()
+	s := newTestService(t, repo)
+
+	todo, err := domain.NewTodo("overdue", "", "alice", testTenant, domain.PriorityMedium)
+	if err != nil {
+		t.Fatalf("NewTodo failed: %v", err)
+	}
+	past := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+	todo.DueDate = &past
+	if err := repo.Create(context.Background(), todo); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// An unchanged past due date syncs; moving it into the past does not
+	req := &todov1.UpsertTodoRequest{
+		Todo:    &todov1.Todo{Id: todo.ID, Title: "still overdue", DueDate: timestamppb.New(past)},
+		Version: 1,
+	}
+	if _, err := s.UpsertTodo(asUser("alice"), req); err != nil {
+		t.Fatalf("UpsertTodo with the stored due date failed: %v", err)
+	}
+
+	req.Todo.DueDate = timestamppb.New(past.Add(-time.Hour))
+	req.Version = 2
+	if _, err := s.UpsertTodo(asUser("alice"), req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument moving the due date into the past, got %v", err)
+	}
+}
+
+func TestAllowPastDueDate(t *testing.T) {
+	t.Cleanup(func() { domain.SetAllowPastDueDate(false) })
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	past := timestamppb.New(time.Now().Add(-48 * time.Hour))
+
+	create := func() error {
+		_, err := s.CreateTodo(alice, &todov1.CreateTodoRequest{Title: "imported", DueDate: past, Priority: todov1.TodoPriority_TODO_PRIORITY_LOW})
+		return err
+	}
+	if err := create(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a past due date, got %v", err)
+	}
+
+	domain.SetAllowPastDueDate(true)
+	if err := create(); err != nil {
+		t.Fatalf("CreateTodo with past due dates allowed failed: %v", err)
+	}
+
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "rescheduled"})
+	_, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+		Id:         todo.Id,
+		Todo:       &todov1.Todo{Id: todo.Id, DueDate: past},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"due_date"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTodo into the past with past due dates allowed failed: %v", err)
+	}
+}
+
+func TestSnoozeTodo(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	due := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "snoozable", DueDate: timestamppb.New(due)})
+	undated := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "undated"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *todov1.SnoozeTodoRequest
+		code codes.Code
+	}{
+		{name: "missing duration", ctx: alice, req: &todov1.SnoozeTodoRequest{Id: todo.Id}, code: codes.InvalidArgument},
+		{name: "negative duration", ctx: alice, req: &todov1.SnoozeTodoRequest{Id: todo.Id, Duration: durationpb.New(-time.Hour)}, code: codes.InvalidArgument},
+		{name: "no due date", ctx: alice, req: &todov1.SnoozeTodoRequest{Id: undated.Id, Duration: durationpb.New(time.Hour)}, code: codes.FailedPrecondition},
+		{name: "stale version", ctx: alice, req: &todov1.SnoozeTodoRequest{Id: todo.Id, Duration: durationpb.New(time.Hour), Version: 7}, code: codes.Aborted},
+		{name: "not the owner", ctx: asUser("bob"), req: &todov1.SnoozeTodoRequest{Id: todo.Id, Duration: durationpb.New(time.Hour)}, code: codes.PermissionDenied},
+		{name: "missing todo", ctx: alice, req: &todov1.SnoozeTodoRequest{Id: "missing", Duration: durationpb.New(time.Hour)}, code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.SnoozeTodo(tt.ctx, tt.req); status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	resp, err := s.SnoozeTodo(alice, &todov1.SnoozeTodoRequest{Id: todo.Id, Duration: durationpb.New(2 * time.Hour), Version: todo.Version})
+	if err != nil {
+		t.Fatalf("SnoozeTodo failed: %v", err)
+	}
+	if got := resp.Todo.DueDate.AsTime(); !got.Equal(due.Add(2 * time.Hour)) {
+		t.Fatalf("due date = %v, want %v", got, due.Add(2*time.Hour))
+	}
+	if resp.Todo.Version != todo.Version+1 {
+		t.Fatalf("version = %d, want %d", resp.Todo.Version, todo.Version+1)
+	}
+}
+
+func TestTrackEffort(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	estimate := int32(120)
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "estimated", EstimatedMinutes: &estimate})
+	if todo.EstimatedMinutes == nil || *todo.EstimatedMinutes != 120 {
+		t.Fatalf("estimated minutes = %v, want 120", todo.EstimatedMinutes)
+	}
+
+	for _, minutes := range []int32{30, 45} {
+		if _, err := s.LogTime(alice, &todov1.LogTimeRequest{Id: todo.Id, Minutes: minutes}); err != nil {
+			t.Fatalf("LogTime failed: %v", err)
+		}
+	}
+
+	over := int32(domain.MaxEstimatedMinutes + 1)
+	tests := []struct {
+		name   string
+		call   func() error
+		fields []string
+	}{
+		{
+			name: "no time logged",
+			call: func() error {
+				_, err := s.LogTime(alice, &todov1.LogTimeRequest{Id: todo.Id})
+				return err
+			},
+			fields: []string{"minutes"},
+		},
+		{
+			name: "estimate over one year",
+			call: func() error {
+				_, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+					Id:         todo.Id,
+					Todo:       &todov1.Todo{Id: todo.Id, EstimatedMinutes: &over},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"estimated_minutes"}},
+				})
+				return err
+			},
+			fields: []string{"estimated_minutes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fields := violatedFields(t, tt.call()); !slices.Equal(fields, tt.fields) {
+				t.Fatalf("violated fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+
+	if _, err := s.LogTime(asUser("bob"), &todov1.LogTimeRequest{Id: todo.Id, Minutes: 10}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied logging time on another user's todo, got %v", err)
+	}
+
+	resp, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+		Id:         todo.Id,
+		Todo:       &todov1.Todo{Id: todo.Id},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"estimated_minutes"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTodo failed: %v", err)
+	}
+	if resp.Todo.EstimatedMinutes != nil || resp.Todo.LoggedMinutes != 75 {
+		t.Fatalf("expected the estimate cleared with 75 minutes logged, got %v and %d", resp.Todo.EstimatedMinutes, resp.Todo.LoggedMinutes)
+	}
+}
+
+func TestReminders(t *testing.T) {
+	repo := memory.NewInMemoryRepository()
+	s := newTestService(t, repo)
+	alice := asUser("alice")
+	remindAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "call back", RemindAt: timestamppb.New(remindAt)})
+	if todo.RemindAt == nil || !todo.RemindAt.AsTime().Equal(remindAt) || todo.RemindedAt != nil {
+		t.Fatalf("expected an unsent reminder at %v, got %v sent %v", remindAt, todo.RemindAt, todo.RemindedAt)
+	}
+
+	if _, err := repo.DueReminders(context.Background(), 0); err != nil {
+		t.Fatalf("DueReminders failed: %v", err)
+	}
+	got, err := s.GetTodo(alice, &todov1.GetTodoRequest{Id: todo.Id})
+	if err != nil {
+		t.Fatalf("GetTodo failed: %v", err)
+	}
+	if got.Todo.RemindedAt == nil {
+		t.Fatal("expected the sent reminder reported")
+	}
+
+	later := remindAt.Add(time.Hour)
+	resp, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+		Id:         todo.Id,
+		Todo:       &todov1.Todo{Id: todo.Id, RemindAt: timestamppb.New(later)},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"remind_at"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTodo failed: %v", err)
+	}
+	if !resp.Todo.RemindAt.AsTime().Equal(later) || resp.Todo.RemindedAt != nil {
+		t.Fatalf("expected the reminder moved to %v and re-armed, got %v sent %v", later, resp.Todo.RemindAt.AsTime(), resp.Todo.RemindedAt)
+	}
+}
+
+func TestListTodosMultiColumnSort(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+
+	create := func(title string, priority todov1.TodoPriority) string {
+		return createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: title, Priority: priority}).Id
+	}
+	lowB := create("b low", todov1.TodoPriority_TODO_PRIORITY_LOW)
+	highB := create("b high", todov1.TodoPriority_TODO_PRIORITY_HIGH)
+	lowA := create("a low", todov1.TodoPriority_TODO_PRIORITY_LOW)
+	highA := create("a high", todov1.TodoPriority_TODO_PRIORITY_HIGH)
+
+	resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{
+		SortBy: "created_at",
+		Sort: []*todov1.SortSpec{
+			{Field: "priority", Order: todov1.SortOrder_SORT_ORDER_DESC},
+			{Field: "title", Order: todov1.SortOrder_SORT_ORDER_ASC},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ListTodos failed: %v", err)
+	}
+	var got []string
+	for _, todo := range resp.Todos {
+		got = append(got, todo.Id)
+	}
+	if want := []string{highA, highB, lowA, lowB}; !slices.Equal(got, want) {
+		t.Fatalf("expected priority then title order %v, got %v", want, got)
+	}
+
+	_, err = s.ListTodos(alice, &todov1.ListTodosRequest{
+		Sort: []*todov1.SortSpec{{Field: "priority"}, {Field: "owner_id"}},
+	})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"sort"}) {
+		t.Fatalf("violated fields = %v, want [sort]", fields)
+	}
+}
+
+func TestGetTodoIncludeDeleted(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, admin := asUser("alice"), asUser("root", "admin")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "removed"})
+	if _, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Id: todo.Id}); err != nil {
+		t.Fatalf("DeleteTodo failed: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		includeDeleted bool
+		code           codes.Code
+	}{
+		{name: "admin including deleted", ctx: admin, includeDeleted: true, code: codes.OK},
+		{name: "admin without the flag", ctx: admin, code: codes.NotFound},
+		{name: "owner including deleted", ctx: alice, includeDeleted: true, code: codes.PermissionDenied},
+		{name: "owner without the flag", ctx: alice, code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetTodo(tt.ctx, &todov1.GetTodoRequest{Id: todo.Id, IncludeDeleted: tt.includeDeleted})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if err == nil && (resp.Todo.Id != todo.Id || resp.Todo.DeletedAt == nil) {
+				t.Fatalf("expected the deleted todo with deleted_at set, got %+v", resp.Todo)
+			}
+		})
+	}
+}
+
+func TestListTodosIdsOnly(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	first := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first"})
+	second := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "second"})
+	createTodo(t, s, asUser("bob"), &todov1.CreateTodoRequest{Title: "not alice's"})
+
+	resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{
+		IdsOnly:   true,
+		SortBy:    "title",
+		SortOrder: todov1.SortOrder_SORT_ORDER_ASC,
+	})
+	if err != nil {
+		t.Fatalf("ListTodos failed: %v", err)
+	}
+	if len(resp.Todos) != 0 {
+		t.Fatalf("expected no todo bodies, got %d", len(resp.Todos))
+	}
+	if resp.PageInfo.GetTotalItems() != 2 || len(resp.Refs) != 2 {
+		t.Fatalf("expected 2 of 2 refs, got %d of %d", len(resp.Refs), resp.PageInfo.GetTotalItems())
+	}
+	for i, want := range []*todov1.Todo{first, second} {
+		ref := resp.Refs[i]
+		if ref.Id != want.Id || ref.Version != want.Version || !ref.UpdatedAt.AsTime().Equal(want.UpdatedAt.AsTime()) {
+			t.Fatalf("ref %d = %v, want %s at version %d", i, ref, want.Id, want.Version)
+		}
+	}
+}
+
+func TestListTodosPageSizes(t *testing.T) {
+	s := newTestService(t, nil, WithPageSizes(domain.PageSizes{Default: 2, Max: 3}))
+	alice := asUser("alice")
+	for _, title := range []string{"one", "two", "three", "four"} {
+		createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: title})
+	}
+
+	tests := []struct {
+		name     string
+		pageSize int32
+		want     int32
+	}{
+		{name: "unset uses the default", pageSize: 0, want: 2},
+		{name: "within the max", pageSize: 3, want: 3},
+		{name: "above the max is clamped", pageSize: 50, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{PageSize: tt.pageSize})
+			if err != nil {
+				t.Fatalf("ListTodos failed: %v", err)
+			}
+			if resp.PageInfo.PageSize != tt.want || int32(len(resp.Todos)) != tt.want {
+				t.Fatalf("expected %d todos per page, got %d with page size %d", tt.want, len(resp.Todos), resp.PageInfo.PageSize)
+			}
+		})
+	}
+
+	_, err := s.ListTodos(alice, &todov1.ListTodosRequest{Page: -1})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"page"}) {
+		t.Fatalf("violated fields = %v, want [page]", fields)
+	}
+	_, err = s.ListTodos(alice, &todov1.ListTodosRequest{PageSize: -1})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"page_size"}) {
+		t.Fatalf("violated fields = %v, want [page_size]", fields)
+	}
+}
+
+func TestListTodosClampsPastLastPage(t *testing.T) {
+	s := newTestService(t, nil)
+
+	tests := []struct {
+		name     string
+		todos    int
+		idsOnly  bool
+		page     int32
+		wantPage int32
+		wantLen  int
+		hasNext  bool
+		hasPrev  bool
+	}{
+		{name: "within range", todos: 5, page: 2, wantPage: 2, wantLen: 2, hasNext: true, hasPrev: true},
+		{name: "past the end", todos: 5, page: 9, wantPage: 3, wantLen: 1, hasPrev: true},
+		{name: "past the end with ids only", todos: 5, idsOnly: true, page: 9, wantPage: 3, wantLen: 1, hasPrev: true},
+		{name: "empty listing", page: 4, wantPage: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := asUser(tt.name)
+			for i := range tt.todos {
+				createTodo(t, s, owner, &todov1.CreateTodoRequest{Title: fmt.Sprint("todo ", i)})
+			}
+
+			resp, err := s.ListTodos(owner, &todov1.ListTodosRequest{Page: tt.page, PageSize: 2, IdsOnly: tt.idsOnly})
+			if err != nil {
+				t.Fatalf("ListTodos failed: %v", err)
+			}
+			if got := len(resp.Todos) + len(resp.Refs); got != tt.wantLen {
+				t.Fatalf("expected %d results, got %d", tt.wantLen, got)
+			}
+			info := resp.PageInfo
+			if info.Page != tt.wantPage || info.HasNext != tt.hasNext || info.HasPrev != tt.hasPrev {
+				t.Fatalf("page info = %+v, want page %d, next %v, prev %v", info, tt.wantPage, tt.hasNext, tt.hasPrev)
+			}
+		})
+	}
+}
+
+func TestTagsAreNormalized(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "tagged", Tags: []string{" Backend ", "backend", "Hot  Fix"}})
+	if want := []string{"backend", "hot fix"}; !slices.Equal(todo.Tags, want) {
+		t.Fatalf("stored tags = %q, want %q", todo.Tags, want)
+	}
+	createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "untagged"})
+
+	// Filters match regardless of how the tag is spelled
+	resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{TagsFilter: []string{"BACKEND"}})
+	if err != nil {
+		t.Fatalf("ListTodos failed: %v", err)
+	}
+	if len(resp.Todos) != 1 || resp.Todos[0].Id != todo.Id {
+		t.Fatalf("expected the tagged todo, got %v", todoIDs(resp.Todos))
+	}
+	resp, err = s.ListTodos(alice, &todov1.ListTodosRequest{ExcludeTagsFilter: []string{" hot   FIX"}})
+	if err != nil {
+		t.Fatalf("ListTodos failed: %v", err)
+	}
+	if len(resp.Todos) != 1 || resp.Todos[0].Id == todo.Id {
+		t.Fatalf("expected only the untagged todo, got %v", todoIDs(resp.Todos))
+	}
+
+	_, err = s.CreateTodo(alice, &todov1.CreateTodoRequest{Title: "too long", Tags: []string{strings.Repeat("x", 51)}, Priority: todov1.TodoPriority_TODO_PRIORITY_LOW})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"tags"}) {
+		t.Fatalf("violated fields = %v, want [tags]", fields)
+	}
+}
+
+func TestManagerReassignsOnly(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, manager := asUser("alice"), asUser("mia", "manager")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "to hand over"})
+
+	update := func(ctx context.Context, changes *todov1.Todo, paths ...string) (*todov1.UpdateTodoResponse, error) {
+		changes.Id = todo.Id
+		return s.UpdateTodo(ctx, &todov1.UpdateTodoRequest{
+			Id:         todo.Id,
+			Todo:       changes,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+	}
+
+	resp, err := update(manager, &todov1.Todo{AssignedTo: "bob"}, "assigned_to")
+	if err != nil {
+		t.Fatalf("manager reassignment failed: %v", err)
+	}
+	if resp.Todo.AssignedTo != "bob" {
+		t.Fatalf("expected the todo assigned to bob, got %q", resp.Todo.AssignedTo)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		changes *todov1.Todo
+		paths   []string
+	}{
+		{name: "manager editing the title", ctx: manager, changes: &todov1.Todo{Title: "renamed"}, paths: []string{"title"}},
+		{name: "manager reassigning alongside an edit", ctx: manager, changes: &todov1.Todo{Title: "renamed", AssignedTo: "carol"}, paths: []string{"assigned_to", "title"}},
+		{name: "manager without a mask", ctx: manager, changes: &todov1.Todo{Title: "renamed", AssignedTo: "carol"}},
+		{name: "other user reassigning", ctx: asUser("eve"), changes: &todov1.Todo{AssignedTo: "eve"}, paths: []string{"assigned_to"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := update(tt.ctx, tt.changes, tt.paths...); status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("expected PermissionDenied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, carol := asUser("alice"), asUser("carol")
+
+	transfer := func(ctx context.Context, todo *todov1.Todo, owner string) (*todov1.UpdateTodoResponse, error) {
+		return s.UpdateTodo(ctx, &todov1.UpdateTodoRequest{
+			Id:         todo.Id,
+			Todo:       &todov1.Todo{Id: todo.Id, OwnerId: owner},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"owner_id"}},
+		})
+	}
+
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "handed over", AssignedTo: "carol"})
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		owner string
+		code  codes.Code
+	}{
+		{name: "assignee", ctx: carol, owner: "carol", code: codes.PermissionDenied},
+		{name: "empty owner", ctx: alice, owner: "", code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := transfer(tt.ctx, todo, tt.owner); status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	resp, err := transfer(alice, todo, "bob")
+	if err != nil {
+		t.Fatalf("owner transfer failed: %v", err)
+	}
+	if resp.Todo.OwnerId != "bob" {
+		t.Fatalf("expected bob to own the todo, got %s", resp.Todo.OwnerId)
+	}
+	if _, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Id: todo.Id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected the previous owner to lose the todo, got %v", err)
+	}
+
+	// Admins may transfer todos they do not own
+	resp, err = transfer(asUser("root", "admin"), resp.Todo, "alice")
+	if err != nil {
+		t.Fatalf("admin transfer failed: %v", err)
+	}
+	if resp.Todo.OwnerId != "alice" {
+		t.Fatalf("expected alice to own the todo again, got %s", resp.Todo.OwnerId)
+	}
+}
+
+// readCountingRepository counts the single-todo reads made through it
+type readCountingRepository struct {
+	domain.Repository
+	reads int
+}
+
+func (r *readCountingRepository) GetByID(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	r.reads++
+	return r.Repository.GetByID(ctx, id, tenantID)
+}
+
+func (r *readCountingRepository) GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*domain.Todo, error) {
+	r.reads++
+	return r.Repository.GetByIDs(ctx, ids, tenantID)
+}
+
+func (r *readCountingRepository) GetDetail(ctx context.Context, id, tenantID string) (*domain.TodoDetail, error) {
+	r.reads++
+	return r.Repository.GetDetail(ctx, id, tenantID)
+}
+
+func TestCrossTenantRequestsRefusedBeforeReading(t *testing.T) {
+	repo := &readCountingRepository{Repository: memory.NewInMemoryRepository()}
+	s := newTestService(t, repo)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "tenant bound"})
+
+	calls := map[string]func(md *todov1.RequestMetadata) error{
+		"GetTodo": func(md *todov1.RequestMetadata) error {
+			_, err := s.GetTodo(alice, &todov1.GetTodoRequest{Metadata: md, Id: todo.Id})
+			return err
+		},
+		"BatchGetTodos": func(md *todov1.RequestMetadata) error {
+			_, err := s.BatchGetTodos(alice, &todov1.BatchGetTodosRequest{Metadata: md, Ids: []string{todo.Id}})
+			return err
+		},
+		"GetTodoDetail": func(md *todov1.RequestMetadata) error {
+			_, err := s.GetTodoDetail(alice, &todov1.GetTodoDetailRequest{Metadata: md, Id: todo.Id})
+			return err
+		},
+		"UpdateTodo": func(md *todov1.RequestMetadata) error {
+			_, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+				Metadata:   md,
+				Id:         todo.Id,
+				Todo:       &todov1.Todo{Id: todo.Id, Priority: todov1.TodoPriority_TODO_PRIORITY_HIGH},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"priority"}},
+			})
+			return err
+		},
+		"UpdateTodoStatus": func(md *todov1.RequestMetadata) error {
+			_, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{Metadata: md, Id: todo.Id, NewStatus: todov1.TodoStatus_TODO_STATUS_IN_PROGRESS})
+			return err
+		},
+		"GetTodoHistory": func(md *todov1.RequestMetadata) error {
+			_, err := s.GetTodoHistory(alice, &todov1.GetTodoHistoryRequest{Metadata: md, Id: todo.Id})
+			return err
+		},
+		"DeleteTodo": func(md *todov1.RequestMetadata) error {
+			_, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Metadata: md, Id: todo.Id})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			repo.reads = 0
+			if err := call(&todov1.RequestMetadata{TenantId: "tenant-2"}); status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("expected PermissionDenied for another tenant, got %v", err)
+			}
+			if repo.reads != 0 {
+				t.Fatalf("expected no read for another tenant, got %d", repo.reads)
+			}
+		})
+	}
+
+	// Naming the caller's own tenant is allowed
+	for _, name := range []string{"GetTodo", "UpdateTodo", "DeleteTodo"} {
+		if err := calls[name](&todov1.RequestMetadata{TenantId: testTenant}); err != nil {
+			t.Fatalf("%s in the caller's tenant failed: %v", name, err)
+		}
+	}
+}
+
+// consistencyRecordingRepository records whether each single-todo read
+// asked for read-your-writes
+type consistencyRecordingRepository struct {
+	domain.Repository
+	readYourWrites []bool
+}
+
+func (r *consistencyRecordingRepository) GetByID(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	r.readYourWrites = append(r.readYourWrites, domain.ReadYourWrites(ctx))
+	return r.Repository.GetByID(ctx, id, tenantID)
+}
+
+func (r *consistencyRecordingRepository) GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*domain.Todo, error) {
+	r.readYourWrites = append(r.readYourWrites, domain.ReadYourWrites(ctx))
+	return r.Repository.GetByIDs(ctx, ids, tenantID)
+}
+
+func TestReadsDecidingWritesUsePrimary(t *testing.T) {
+	repo := &consistencyRecordingRepository{Repository: memory.NewInMemoryRepository()}
+	s := newTestService(t, repo)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "consistent"})
+
+	tests := []struct {
+		name           string
+		call           func() error
+		readYourWrites bool
+	}{
+		{
+			name: "GetTodo",
+			call: func() error {
+				_, err := s.GetTodo(alice, &todov1.GetTodoRequest{Id: todo.Id})
+				return err
+			},
+		},
+		{
+			name: "BatchGetTodos",
+			call: func() error {
+				_, err := s.BatchGetTodos(alice, &todov1.BatchGetTodosRequest{Ids: []string{todo.Id}})
+				return err
+			},
+		},
+		{
+			name: "UpdateTodo",
+			call: func() error {
+				_, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+					Id:         todo.Id,
+					Todo:       &todov1.Todo{Id: todo.Id, Priority: todov1.TodoPriority_TODO_PRIORITY_HIGH},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"priority"}},
+				})
+				return err
+			},
+			readYourWrites: true,
+		},
+		{
+			name: "UpdateTodoStatus",
+			call: func() error {
+				_, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{Id: todo.Id, NewStatus: todov1.TodoStatus_TODO_STATUS_IN_PROGRESS})
+				return err
+			},
+			readYourWrites: true,
+		},
+		{
+			name: "AddTagToTodos",
+			call: func() error {
+				_, err := s.AddTagToTodos(alice, &todov1.AddTagToTodosRequest{Ids: []string{todo.Id}, Tag: "primary"})
+				return err
+			},
+			readYourWrites: true,
+		},
+		{
+			name: "DeleteTodo",
+			call: func() error {
+				_, err := s.DeleteTodo(alice, &todov1.DeleteTodoRequest{Id: todo.Id})
+				return err
+			},
+			readYourWrites: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.readYourWrites = nil
+			if err := tt.call(); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if len(repo.readYourWrites) == 0 {
+				t.Fatal("expected the todo to be read")
+			}
+			for i, got := range repo.readYourWrites {
+				if got != tt.readYourWrites {
+					t.Fatalf("read %d: read-your-writes = %v, want %v", i, got, tt.readYourWrites)
+				}
+			}
+		})
+	}
+}
+
+// fakeUserDirectory knows the users of the test tenant listed in users
+type fakeUserDirectory struct {
+	users map[string]bool
+	err   error
+}
+
+func (d *fakeUserDirectory) Exists(ctx context.Context, tenantID, userID string) (bool, error) {
+	if d.err != nil {
+		return false, d.err
+	}
+	return tenantID == testTenant && d.users[userID], nil
+}
+
+func TestAssigneesCheckedAgainstUserDirectory(t *testing.T) {
+	users := &fakeUserDirectory{users: map[string]bool{"bob": true}}
+	s := newTestService(t, nil, WithUserDirectory(users))
+	alice := asUser("alice")
+
+	_, err := s.CreateTodo(alice, &todov1.CreateTodoRequest{Title: "for a stranger", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW, AssignedTo: "mallory"})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"assigned_to"}) {
+		t.Fatalf("violated fields = %v, want [assigned_to]", fields)
+	}
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "for bob", AssignedTo: "bob"})
+
+	update := func(changes *todov1.Todo, paths ...string) (*todov1.UpdateTodoResponse, error) {
+		changes.Id = todo.Id
+		return s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+			Id:         todo.Id,
+			Todo:       changes,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+	}
+
+	_, err = update(&todov1.Todo{AssignedTo: "mallory"}, "assigned_to")
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"assigned_to"}) {
+		t.Fatalf("violated fields = %v, want [assigned_to]", fields)
+	}
+	_, err = s.UpsertTodo(alice, &todov1.UpsertTodoRequest{Todo: &todov1.Todo{Id: domain.NewID(), Title: "upserted", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW, AssignedTo: "mallory"}})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"assigned_to"}) {
+		t.Fatalf("violated fields = %v, want [assigned_to]", fields)
+	}
+
+	// Keeping an assignee the directory no longer knows is not checked again
+	delete(users.users, "bob")
+	if _, err := update(&todov1.Todo{Title: "still for bob", AssignedTo: "bob"}, "title", "assigned_to"); err != nil {
+		t.Fatalf("UpdateTodo keeping the assignee failed: %v", err)
+	}
+
+	users.err = errors.New("directory unavailable")
+	_, err = s.CreateTodo(alice, &todov1.CreateTodoRequest{Title: "during outage", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW, AssignedTo: "bob"})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal when the directory fails, got %v", err)
+	}
+	// Unassigned todos do not consult the directory
+	createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "unassigned"})
+}
+
+func TestGetWorkload(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, manager := asUser("alice"), asUser("mia", "manager")
+	for i, assignee := range []string{"bob", "carol", "carol", ""} {
+		createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: fmt.Sprintf("task %d", i), AssignedTo: assignee})
+	}
+
+	if _, err := s.GetWorkload(alice, &todov1.GetWorkloadRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a user, got %v", err)
+	}
+
+	resp, err := s.GetWorkload(manager, &todov1.GetWorkloadRequest{})
+	if err != nil {
+		t.Fatalf("GetWorkload failed: %v", err)
+	}
+
+	// Busiest first, ties by assignee, with the unassigned bucket keyed by ""
+	var got []string
+	for _, assignee := range resp.Assignees {
+		got = append(got, fmt.Sprintf("%s:%d", assignee.AssigneeId, assignee.Total))
+		if len(assignee.Counts) != 4 {
+			t.Fatalf("expected a count per status for %q, got %v", assignee.AssigneeId, assignee.Counts)
+		}
+	}
+	if want := []string{"carol:2", ":1", "bob:1"}; !slices.Equal(got, want) {
+		t.Fatalf("workload = %v, want %v", got, want)
+	}
+}
+
+func TestListTodosPriorityWeights(t *testing.T) {
+	weights, err := domain.ParsePriorityWeights("low=10")
+	if err != nil {
+		t.Fatalf("ParsePriorityWeights failed: %v", err)
+	}
+	s := newTestService(t, nil, WithPriorityWeights(weights))
+	alice := asUser("alice")
+
+	low := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "low", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW}).Id
+	critical := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "critical", Priority: todov1.TodoPriority_TODO_PRIORITY_CRITICAL}).Id
+
+	resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{SortBy: "priority", SortOrder: todov1.SortOrder_SORT_ORDER_DESC})
+	if err != nil {
+		t.Fatalf("ListTodos failed: %v", err)
+	}
+	var got []string
+	for _, todo := range resp.Todos {
+		got = append(got, todo.Id)
+	}
+	if want := []string{low, critical}; !slices.Equal(got, want) {
+		t.Fatalf("expected the weighted low priority first %v, got %v", want, got)
+	}
+}
+
+func TestListTodosDueBucket(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	nextMonth := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "next month", DueDate: timestamppb.New(time.Now().AddDate(0, 1, 0))})
+	undated := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "undated"})
+
+	tests := []struct {
+		name     string
+		bucket   todov1.DueBucket
+		timezone string
+		want     []string
+	}{
+		{name: "later", bucket: todov1.DueBucket_DUE_BUCKET_LATER, want: []string{nextMonth.Id}},
+		{name: "later in a timezone", bucket: todov1.DueBucket_DUE_BUCKET_LATER, timezone: "Pacific/Auckland", want: []string{nextMonth.Id}},
+		{name: "none", bucket: todov1.DueBucket_DUE_BUCKET_NONE, want: []string{undated.Id}},
+		{name: "overdue", bucket: todov1.DueBucket_DUE_BUCKET_OVERDUE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListTodos(alice, &todov1.ListTodosRequest{DueBucket: tt.bucket, Timezone: tt.timezone})
+			if err != nil {
+				t.Fatalf("ListTodos failed: %v", err)
+			}
+			var got []string
+			for _, todo := range resp.Todos {
+				got = append(got, todo.Id)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	_, err := s.ListTodos(alice, &todov1.ListTodosRequest{DueBucket: todov1.DueBucket_DUE_BUCKET_TODAY, Timezone: "Mars/Olympus"})
+	if fields := violatedFields(t, err); !slices.Equal(fields, []string{"timezone"}) {
+		t.Fatalf("violated fields = %v, want [timezone]", fields)
+	}
+}
+
+func TestCompletedByReported(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, admin := asUser("alice"), asUser("root", "admin")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "finish"})
+
+	setStatus := func(ctx context.Context, st todov1.TodoStatus) *todov1.Todo {
+		t.Helper()
+		resp, err := s.UpdateTodoStatus(ctx, &todov1.UpdateTodoStatusRequest{Id: todo.Id, NewStatus: st})
+		if err != nil {
+			t.Fatalf("UpdateTodoStatus(%v) failed: %v", st, err)
+		}
+		return resp.Todo
+	}
+
+	if got := setStatus(alice, todov1.TodoStatus_TODO_STATUS_IN_PROGRESS); got.CompletedBy != "" {
+		t.Fatalf("expected no completer before completion, got %q", got.CompletedBy)
+	}
+	// The caller completing the todo is recorded, not its owner
+	if got := setStatus(admin, todov1.TodoStatus_TODO_STATUS_COMPLETED); got.CompletedBy != "root" {
+		t.Fatalf("expected the todo completed by root, got %q", got.CompletedBy)
+	}
+	if got := setStatus(alice, todov1.TodoStatus_TODO_STATUS_PENDING); got.CompletedBy != "" || got.CompletedAt != nil {
+		t.Fatalf("expected reopening to clear the completion, got %q at %v", got.CompletedBy, got.CompletedAt)
+	}
+}
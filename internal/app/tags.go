@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTagLimit = 20
+	maxTagLimit     = 100
+
+	maxBulkTagIDs = 100
+)
+
+func (s *TodoServiceServer) ListTags(ctx context.Context, req *todov1.ListTagsRequest) (*todov1.ListTagsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "ListTags")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(attribute.String("tenant.id", userCtx.TenantID))
+
+	limit := int(req.Limit)
+	if limit < 1 {
+		limit = defaultTagLimit
+	}
+	if limit > maxTagLimit {
+		limit = maxTagLimit
+	}
+
+	tags, err := s.repo.ListTags(ctx, userCtx.TenantID, req.Prefix, limit)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Error("failed to list tags",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to list tags")
+	}
+
+	return &todov1.ListTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+func (s *TodoServiceServer) AddTagToTodos(ctx context.Context, req *todov1.AddTagToTodosRequest) (*todov1.AddTagToTodosResponse, error) {
+	updated, err := s.tagTodos(ctx, "AddTagToTodos", req.Metadata, req.Ids, req.Tag, s.repo.AddTagToMany)
+	if err != nil {
+		return nil, err
+	}
+	return &todov1.AddTagToTodosResponse{UpdatedCount: updated}, nil
+}
+
+func (s *TodoServiceServer) RemoveTagFromTodos(ctx context.Context, req *todov1.RemoveTagFromTodosRequest) (*todov1.RemoveTagFromTodosResponse, error) {
+	updated, err := s.tagTodos(ctx, "RemoveTagFromTodos", req.Metadata, req.Ids, req.Tag, s.repo.RemoveTagFromMany)
+	if err != nil {
+		return nil, err
+	}
+	return &todov1.RemoveTagFromTodosResponse{UpdatedCount: updated}, nil
+}
+
+// tagTodos applies a bulk tag change to the todos among ids the caller may
+// update. Missing and deleted ids are skipped; any todo the caller may not
+// update fails the whole request.
+func (s *TodoServiceServer) tagTodos(
+	ctx context.Context,
+	op string,
+	metadata *todov1.RequestMetadata,
+	ids []string,
+	tag string,
+	apply func(ctx context.Context, tenantID string, ids []string, tag string) (int64, error),
+) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, op)
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return 0, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	span.SetAttributes(
+		attribute.Int("todo.count", len(ids)),
+		attribute.String("tenant.id", userCtx.TenantID),
+	)
+
+	if len(ids) == 0 {
+		return 0, fieldError("ids", "at least one id is required")
+	}
+	if len(ids) > maxBulkTagIDs {
+		return 0, status.Errorf(codes.InvalidArgument, "at most %d ids may be given", maxBulkTagIDs)
+	}
+	for _, id := range ids {
+		if err := uuid.Validate(id); err != nil {
+			return 0, fieldError("ids", "todo IDs must be UUIDs")
+		}
+	}
+
+	tags, err := domain.NormalizeTags([]string{tag})
+	if err != nil {
+		return 0, mapDomainError(err)
+	}
+
+	if err := s.checkRequestTenant(userCtx, metadata); err != nil {
+		return 0, err
+	}
+
+	ctx = domain.WithReadYourWrites(ctx)
+	todos, err := s.repo.GetByIDs(ctx, ids, userCtx.TenantID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "failed to retrieve todos")
+	}
+
+	found := make([]string, 0, len(todos))
+	for _, todo := range todos {
+		if !s.authz.CanUpdate(userCtx, todo) {
+			return 0, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+		found = append(found, todo.ID)
+	}
+	if len(found) == 0 {
+		return 0, nil
+	}
+
+	updated, err := apply(ctx, userCtx.TenantID, found, tags[0])
+	if err != nil {
+		if errors.Is(err, domain.ErrTooManyTags) {
+			return 0, mapDomainError(err)
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to update tags",
+			zap.Error(err),
+			zap.String("op", op),
+			zap.Int("count", len(found)),
+		)
+		return 0, status.Error(codes.Internal, "failed to update tags")
+	}
+
+	span.SetAttributes(attribute.Int64("updated_count", updated))
+	loggerFromContext(ctx, s.logger).Info("todos tagged",
+		zap.String("op", op),
+		zap.String("tag", tags[0]),
+		zap.Int64("count", updated),
+	)
+	return updated, nil
+}
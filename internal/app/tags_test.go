@@ -0,0 +1,75 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBulkTags(t *testing.T) {
+	s := newTestService(t, nil)
+	alice, bob := asUser("alice"), asUser("bob")
+	first := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first"})
+	second := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "second", Tags: []string{"backend"}})
+	bobs := createTodo(t, s, bob, &todov1.CreateTodoRequest{Title: "bob's"})
+
+	tagsOf := func(id string) []string {
+		t.Helper()
+		resp, err := s.GetTodo(alice, &todov1.GetTodoRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetTodo failed: %v", err)
+		}
+		return resp.Todo.Tags
+	}
+
+	// The tag is normalized and missing ids are skipped
+	added, err := s.AddTagToTodos(alice, &todov1.AddTagToTodosRequest{Ids: []string{first.Id, second.Id, domain.NewID()}, Tag: " Backend "})
+	if err != nil {
+		t.Fatalf("AddTagToTodos failed: %v", err)
+	}
+	if added.UpdatedCount != 1 || !slices.Equal(tagsOf(first.Id), []string{"backend"}) {
+		t.Fatalf("expected only first tagged backend, got %d updated and %v", added.UpdatedCount, tagsOf(first.Id))
+	}
+
+	// A todo the caller may not update fails the whole request
+	_, err = s.AddTagToTodos(alice, &todov1.AddTagToTodosRequest{Ids: []string{second.Id, bobs.Id}, Tag: "shared"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied tagging another user's todo, got %v", err)
+	}
+	if tags := tagsOf(second.Id); slices.Contains(tags, "shared") {
+		t.Fatalf("expected no todo tagged after the refusal, got %v", tags)
+	}
+
+	removed, err := s.RemoveTagFromTodos(alice, &todov1.RemoveTagFromTodosRequest{Ids: []string{first.Id, second.Id}, Tag: "BACKEND"})
+	if err != nil {
+		t.Fatalf("RemoveTagFromTodos failed: %v", err)
+	}
+	if removed.UpdatedCount != 2 || len(tagsOf(first.Id)) != 0 || len(tagsOf(second.Id)) != 0 {
+		t.Fatalf("expected both untagged, got %d updated", removed.UpdatedCount)
+	}
+
+	tooMany := make([]string, maxBulkTagIDs+1)
+	for i := range tooMany {
+		tooMany[i] = domain.NewID()
+	}
+	tests := []struct {
+		name string
+		req  *todov1.AddTagToTodosRequest
+	}{
+		{name: "no ids", req: &todov1.AddTagToTodosRequest{Tag: "x"}},
+		{name: "invalid id", req: &todov1.AddTagToTodosRequest{Ids: []string{"not-a-uuid"}, Tag: "x"}},
+		{name: "too many ids", req: &todov1.AddTagToTodosRequest{Ids: tooMany, Tag: "x"}},
+		{name: "empty tag", req: &todov1.AddTagToTodosRequest{Ids: []string{first.Id}, Tag: "  "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.AddTagToTodos(alice, tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxMoveIDs = 100
+
+// MoveTodosToTenant moves todos between tenants for an account merge. It is
+// reserved for platform admins, so unlike the other handlers it is not
+// confined to the caller's tenant.
+func (s *TodoServiceServer) MoveTodosToTenant(ctx context.Context, req *todov1.MoveTodosToTenantRequest) (*todov1.MoveTodosToTenantResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "MoveTodosToTenant")
+	defer span.End()
+
+	userCtx, err := auth.UserContextFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if !s.authz.CanMoveTenants(userCtx) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	span.SetAttributes(
+		attribute.Int("todo.count", len(req.Ids)),
+		attribute.String("from_tenant.id", req.FromTenantId),
+		attribute.String("to_tenant.id", req.ToTenantId),
+	)
+
+	if len(req.Ids) == 0 {
+		return nil, fieldError("ids", "at least one id is required")
+	}
+	if len(req.Ids) > maxMoveIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids may be given", maxMoveIDs)
+	}
+	for _, id := range req.Ids {
+		if err := uuid.Validate(id); err != nil {
+			return nil, fieldError("ids", "todo IDs must be UUIDs")
+		}
+	}
+	if req.FromTenantId == "" {
+		return nil, fieldError("from_tenant_id", "from tenant is required")
+	}
+	if req.ToTenantId == "" {
+		return nil, fieldError("to_tenant_id", "to tenant is required")
+	}
+	if req.FromTenantId == req.ToTenantId {
+		return nil, fieldError("to_tenant_id", "to tenant must differ from from tenant")
+	}
+
+	moved, err := s.repo.MoveToTenant(ctx, req.Ids, req.FromTenantId, req.ToTenantId, s.ownerMapping)
+	if err != nil {
+		if errors.Is(err, domain.ErrDuplicateTitle) {
+			return nil, mapDomainError(err)
+		}
+		loggerFromContext(ctx, s.logger).Error("failed to move todos",
+			zap.Error(err),
+			zap.String("from_tenant_id", req.FromTenantId),
+			zap.String("to_tenant_id", req.ToTenantId),
+		)
+		return nil, status.Error(codes.Internal, "failed to move todos")
+	}
+
+	loggerFromContext(ctx, s.logger).Info("todos moved to tenant",
+		zap.Int64("moved_count", moved),
+		zap.String("from_tenant_id", req.FromTenantId),
+		zap.String("to_tenant_id", req.ToTenantId),
+	)
+
+	return &todov1.MoveTodosToTenantResponse{
+		MovedCount: moved,
+	}, nil
+}
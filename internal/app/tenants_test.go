@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMoveTodosToTenant(t *testing.T) {
+	s := newTestService(t, nil, WithOwnerMapping(func(ownerID string) string { return strings.ToUpper(ownerID) }))
+	alice := asUser("alice")
+	first := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "first"})
+	second := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "second"})
+
+	platformAdmin := auth.ContextWithUserContext(context.Background(), &auth.UserContext{
+		UserID:   "root",
+		TenantID: "platform",
+		Roles:    []string{"platform_admin"},
+	})
+
+	// Only platform admins may move todos, not even admins of the tenant
+	for _, ctx := range []context.Context{alice, asUser("root", "admin")} {
+		_, err := s.MoveTodosToTenant(ctx, &todov1.MoveTodosToTenantRequest{Ids: []string{first.Id}, FromTenantId: testTenant, ToTenantId: "tenant-2"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected PermissionDenied, got %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		req   *todov1.MoveTodosToTenantRequest
+		field string
+	}{
+		{name: "no ids", req: &todov1.MoveTodosToTenantRequest{FromTenantId: testTenant, ToTenantId: "tenant-2"}, field: "ids"},
+		{name: "invalid id", req: &todov1.MoveTodosToTenantRequest{Ids: []string{"nope"}, FromTenantId: testTenant, ToTenantId: "tenant-2"}, field: "ids"},
+		{name: "no from tenant", req: &todov1.MoveTodosToTenantRequest{Ids: []string{first.Id}, ToTenantId: "tenant-2"}, field: "from_tenant_id"},
+		{name: "no to tenant", req: &todov1.MoveTodosToTenantRequest{Ids: []string{first.Id}, FromTenantId: testTenant}, field: "to_tenant_id"},
+		{name: "same tenant", req: &todov1.MoveTodosToTenantRequest{Ids: []string{first.Id}, FromTenantId: testTenant, ToTenantId: testTenant}, field: "to_tenant_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.MoveTodosToTenant(platformAdmin, tt.req)
+			if fields := violatedFields(t, err); !slices.Equal(fields, []string{tt.field}) {
+				t.Fatalf("violated fields = %v, want [%s]", fields, tt.field)
+			}
+		})
+	}
+
+	resp, err := s.MoveTodosToTenant(platformAdmin, &todov1.MoveTodosToTenantRequest{
+		Ids:          []string{first.Id, second.Id, domain.NewID()},
+		FromTenantId: testTenant,
+		ToTenantId:   "tenant-2",
+	})
+	if err != nil {
+		t.Fatalf("MoveTodosToTenant failed: %v", err)
+	}
+	if resp.MovedCount != 2 {
+		t.Fatalf("expected 2 todos moved, got %d", resp.MovedCount)
+	}
+
+	if _, err := s.GetTodo(alice, &todov1.GetTodoRequest{Id: first.Id}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected the moved todo gone from the old tenant, got %v", err)
+	}
+	moved := auth.ContextWithUserContext(context.Background(), &auth.UserContext{UserID: "ALICE", TenantID: "tenant-2", Roles: []string{"user"}})
+	got, err := s.GetTodo(moved, &todov1.GetTodoRequest{Id: first.Id})
+	if err != nil {
+		t.Fatalf("GetTodo in the new tenant failed: %v", err)
+	}
+	if got.Todo.OwnerId != "ALICE" {
+		t.Fatalf("expected the owner mapped to ALICE, got %q", got.Todo.OwnerId)
+	}
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// domainErrorFields maps domain validation errors to the request field they concern
+var domainErrorFields = map[error]string{
+	domain.ErrEmptyTitle:          "title",
+	domain.ErrTitleTooLong:        "title",
+	domain.ErrDescriptionTooLong:  "description",
+	domain.ErrInvalidPriority:     "priority",
+	domain.ErrInvalidStatus:       "status",
+	domain.ErrDueDateInPast:       "due_date",
+	domain.ErrInvalidTimezone:     "due_date_timezone",
+	domain.ErrTooManyTags:         "tags",
+	domain.ErrEmptyTag:            "tags",
+	domain.ErrTagTooLong:          "tags",
+	domain.ErrInvalidOwnerId:      "owner_id",
+	domain.ErrUnknownAssignee:     "assigned_to",
+	domain.ErrInvalidTenantID:     "tenant_id",
+	domain.ErrEmptyCommentBody:    "body",
+	domain.ErrCommentTooLong:      "body",
+	domain.ErrInvalidFilename:     "filename",
+	domain.ErrAttachmentTooLarge:  "size_bytes",
+	domain.ErrInvalidSortField:    "sort",
+	domain.ErrInvalidPage:         "page",
+	domain.ErrInvalidPageSize:     "page_size",
+	domain.ErrPageTooLarge:        "page",
+	domain.ErrInvalidDueRange:     "due_date_from",
+	domain.ErrInvalidCreatedRange: "created_from",
+	domain.ErrInvalidUpdatedRange: "updated_from",
+	domain.ErrInvalidSnooze:       "duration",
+	domain.ErrInvalidEstimate:     "estimated_minutes",
+	domain.ErrInvalidTimeLog:      "minutes",
+	domain.ErrSelfDependency:      "depends_on_id",
+}
+
+// fieldViolations collects field-level validation failures so they can be
+// returned together as a google.rpc.BadRequest detail
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+func (v *fieldViolations) add(field, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+// addDomainError records a domain validation error against its field
+func (v *fieldViolations) addDomainError(err error) {
+	for target, field := range domainErrorFields {
+		if errors.Is(err, target) {
+			v.add(field, target.Error())
+			return
+		}
+	}
+	v.add("", err.Error())
+}
+
+// addValidationErrors records every error of an aggregated domain validation
+func (v *fieldViolations) addValidationErrors(errs domain.ValidationErrors) {
+	for _, fe := range errs {
+		v.add(fe.Field, fe.Err.Error())
+	}
+}
+
+// err returns an InvalidArgument status carrying every violation, or nil when there are none
+func (v fieldViolations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Field + ": " + violation.Description
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(messages, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// validationError converts aggregated domain validation errors into a single
+// InvalidArgument status carrying every violation
+func validationError(errs domain.ValidationErrors) error {
+	var v fieldViolations
+	v.addValidationErrors(errs)
+	return v.err()
+}
+
+// fieldError returns an InvalidArgument status for a single field violation
+func fieldError(field, description string) error {
+	var v fieldViolations
+	v.add(field, description)
+	return v.err()
+}
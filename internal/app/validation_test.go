@@ -0,0 +1,242 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// violatedFields returns the fields named by the BadRequest detail of an
+// InvalidArgument error
+func violatedFields(t *testing.T, err error) []string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		t.Fatalf("expected a BadRequest detail on %v", err)
+	}
+	return fields
+}
+
+func TestValidationErrorsNameFields(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "valid"})
+
+	early := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		call   func() error
+		fields []string
+	}{
+		{
+			name: "create reports every field",
+			call: func() error {
+				_, err := s.CreateTodo(alice, &todov1.CreateTodoRequest{
+					Description: strings.Repeat("x", 2001),
+					Tags:        []string{"ok", " "},
+					Priority:    todov1.TodoPriority_TODO_PRIORITY_LOW,
+				})
+				return err
+			},
+			fields: []string{"title", "description", "tags"},
+		},
+		{
+			name: "update",
+			call: func() error {
+				_, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{
+					Id:         todo.Id,
+					Todo:       &todov1.Todo{Id: todo.Id},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+				})
+				return err
+			},
+			fields: []string{"title"},
+		},
+		{
+			name: "list",
+			call: func() error {
+				_, err := s.ListTodos(alice, &todov1.ListTodosRequest{
+					CreatedFrom: timestamppb.New(late),
+					CreatedTo:   timestamppb.New(early),
+				})
+				return err
+			},
+			fields: []string{"created_from"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fields := violatedFields(t, tt.call()); !slices.Equal(fields, tt.fields) {
+				t.Fatalf("violated fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestBatchCreateTodosNamesFieldsPerRequest(t *testing.T) {
+	s := newTestService(t, nil)
+
+	resp, err := s.BatchCreateTodos(asUser("alice"), &todov1.BatchCreateTodosRequest{Requests: []*todov1.CreateTodoRequest{
+		{Title: "valid", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+		{Description: strings.Repeat("x", 2001), Priority: todov1.TodoPriority_TODO_PRIORITY_LOW},
+	}})
+	if err != nil {
+		t.Fatalf("BatchCreateTodos failed: %v", err)
+	}
+
+	var fields []string
+	for _, detail := range resp.Errors {
+		fields = append(fields, detail.Field)
+		if detail.ErrorCode != "VALIDATION_ERROR" {
+			t.Errorf("error code = %q, want VALIDATION_ERROR", detail.ErrorCode)
+		}
+	}
+	if want := []string{"requests[1].title", "requests[1].description"}; !slices.Equal(fields, want) {
+		t.Fatalf("error fields = %v, want %v", fields, want)
+	}
+	if len(resp.Todos) != 1 {
+		t.Fatalf("expected the valid todo created, got %d", len(resp.Todos))
+	}
+}
+
+func TestListTodosRejectsInvalidRanges(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	early := timestamppb.New(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	late := timestamppb.New(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name  string
+		req   *todov1.ListTodosRequest
+		field string
+	}{
+		{name: "page too large", req: &todov1.ListTodosRequest{Page: domain.MaxPage + 1}, field: "page"},
+		{name: "reversed due range", req: &todov1.ListTodosRequest{DueDateFrom: late, DueDateTo: early}, field: "due_date_from"},
+		{name: "reversed created range", req: &todov1.ListTodosRequest{CreatedFrom: late, CreatedTo: early}, field: "created_from"},
+		{name: "reversed updated range", req: &todov1.ListTodosRequest{UpdatedFrom: late, UpdatedTo: early}, field: "updated_from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ListTodos(alice, tt.req)
+			if fields := violatedFields(t, err); !slices.Equal(fields, []string{tt.field}) {
+				t.Fatalf("violated fields = %v, want [%s]", fields, tt.field)
+			}
+		})
+	}
+
+	// Equal bounds select a single instant rather than being rejected
+	if _, err := s.ListTodos(alice, &todov1.ListTodosRequest{Page: domain.MaxPage, CreatedFrom: early, CreatedTo: early}); err != nil {
+		t.Fatalf("ListTodos with equal bounds on the last page failed: %v", err)
+	}
+}
+
+func TestProtoEnumMapping(t *testing.T) {
+	for value := range todov1.TodoStatus_name {
+		status := todov1.TodoStatus(value)
+		if status == todov1.TodoStatus_TODO_STATUS_UNSPECIFIED {
+			continue
+		}
+		if got := mapDomainStatus(mapProtoStatus(status)); got != status {
+			t.Errorf("status %v round-trips to %v", status, got)
+		}
+	}
+	for value := range todov1.TodoPriority_name {
+		priority := todov1.TodoPriority(value)
+		if priority == todov1.TodoPriority_TODO_PRIORITY_UNSPECIFIED {
+			continue
+		}
+		if got := mapDomainPriority(mapProtoPriority(priority)); got != priority {
+			t.Errorf("priority %v round-trips to %v", priority, got)
+		}
+	}
+
+	// Unspecified and unknown values map to nothing rather than a default
+	for _, status := range []todov1.TodoStatus{todov1.TodoStatus_TODO_STATUS_UNSPECIFIED, 99} {
+		if got := mapProtoStatus(status); got != 0 {
+			t.Errorf("mapProtoStatus(%v) = %v, want 0", status, got)
+		}
+	}
+	for _, priority := range []todov1.TodoPriority{todov1.TodoPriority_TODO_PRIORITY_UNSPECIFIED, 99} {
+		if got := mapProtoPriority(priority); got != 0 {
+			t.Errorf("mapProtoPriority(%v) = %v, want 0", priority, got)
+		}
+	}
+}
+
+func TestUnspecifiedEnumsAreRejected(t *testing.T) {
+	s := newTestService(t, nil)
+	alice := asUser("alice")
+	todo := createTodo(t, s, alice, &todov1.CreateTodoRequest{Title: "enums", Priority: todov1.TodoPriority_TODO_PRIORITY_HIGH})
+
+	tests := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{
+			name: "create without priority",
+			call: func() error {
+				_, err := s.CreateTodo(alice, &todov1.CreateTodoRequest{Title: "no priority"})
+				return err
+			},
+			field: "priority",
+		},
+		{
+			name: "unknown priority",
+			call: func() error {
+				_, err := s.CreateTodo(alice, &todov1.CreateTodoRequest{Title: "bad priority", Priority: 99})
+				return err
+			},
+			field: "priority",
+		},
+		{
+			name: "status update without status",
+			call: func() error {
+				_, err := s.UpdateTodoStatus(alice, &todov1.UpdateTodoStatusRequest{Id: todo.Id})
+				return err
+			},
+			field: "status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fields := violatedFields(t, tt.call()); !slices.Equal(fields, []string{tt.field}) {
+				t.Fatalf("violated fields = %v, want [%s]", fields, tt.field)
+			}
+		})
+	}
+
+	// A full update leaves the priority alone when none is given
+	resp, err := s.UpdateTodo(alice, &todov1.UpdateTodoRequest{Id: todo.Id, Todo: &todov1.Todo{Id: todo.Id, Title: "renamed"}})
+	if err != nil {
+		t.Fatalf("UpdateTodo failed: %v", err)
+	}
+	if resp.Todo.Title != "renamed" || resp.Todo.Priority != todov1.TodoPriority_TODO_PRIORITY_HIGH {
+		t.Fatalf("expected renamed with high priority kept, got %q at %v", resp.Todo.Title, resp.Todo.Priority)
+	}
+}
@@ -0,0 +1,217 @@
+// Package backup exports a tenant's todos as newline-delimited JSON and
+// imports them back, into the same or another tenant.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+const (
+	// exportPageSize is how many todos are read per query while exporting
+	exportPageSize = 500
+
+	// importBatchSize is how many todos are inserted per transaction while importing
+	importBatchSize = 1000
+)
+
+// Record is the exported form of a todo, one JSON object per line
+type Record struct {
+	ID               string     `json:"id"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description,omitempty"`
+	Status           int        `json:"status"`
+	Priority         int        `json:"priority"`
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	DueDateTimezone  *string    `json:"due_date_timezone,omitempty"`
+	Tags             []string   `json:"tags,omitempty"`
+	OwnerID          string     `json:"owner_id"`
+	AssignedTo       *string    `json:"assigned_to,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	CompletedBy      *string    `json:"completed_by,omitempty"`
+	ArchivedAt       *time.Time `json:"archived_at,omitempty"`
+	EstimatedMinutes *int32     `json:"estimated_minutes,omitempty"`
+	LoggedMinutes    int32      `json:"logged_minutes,omitempty"`
+	RemindAt         *time.Time `json:"remind_at,omitempty"`
+	RemindedAt       *time.Time `json:"reminded_at,omitempty"`
+}
+
+// ExportTodos streams every todo of tenantID to w as newline-delimited JSON,
+// oldest first. Soft-deleted todos are included when includeDeleted is set.
+func ExportTodos(ctx context.Context, repo domain.Repository, tenantID string, includeDeleted bool, w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	filter := &domain.ListFilter{
+		TenantID:       tenantID,
+		IncludeDeleted: includeDeleted,
+		PageSize:       exportPageSize,
+		Sort:           []domain.SortSpec{{Field: "created_at", Ascending: true}},
+	}
+
+	for page := 1; ; page++ {
+		filter.Page = page
+
+		todos, _, err := repo.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+
+		for _, todo := range todos {
+			if err := enc.Encode(toRecord(todo)); err != nil {
+				return fmt.Errorf("failed to write todo %s: %w", todo.ID, err)
+			}
+		}
+
+		if len(todos) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// ImportTodos reads todos exported by ExportTodos from r and creates them in
+// tenantID under new ids, so an export can be restored next to the todos it
+// came from. Soft-deleted records are skipped. Records are validated as they
+// are read and inserted in batches; on error, the batches already inserted
+// remain and their count is returned.
+func ImportTodos(ctx context.Context, repo domain.Repository, tenantID string, r io.Reader) (int, error) {
+	dec := json.NewDecoder(r)
+
+	imported := 0
+	batch := make([]*domain.Todo, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := repo.BatchCreate(ctx, batch); err != nil {
+			return err
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for line := 1; ; line++ {
+		var record Record
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, fmt.Errorf("record %d: %w", line, err)
+		}
+
+		if record.DeletedAt != nil {
+			continue
+		}
+
+		todo, err := fromRecord(&record, tenantID)
+		if err != nil {
+			return imported, fmt.Errorf("record %d (%s): %w", line, record.ID, err)
+		}
+
+		batch = append(batch, todo)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return imported, err
+	}
+	return imported, nil
+}
+
+func toRecord(todo *domain.Todo) *Record {
+	return &Record{
+		ID:               todo.ID,
+		Title:            todo.Title,
+		Description:      todo.Description,
+		Status:           int(todo.Status),
+		Priority:         int(todo.Priority),
+		DueDate:          todo.DueDate,
+		DueDateTimezone:  todo.DueDateTimezone,
+		Tags:             todo.Tags,
+		OwnerID:          todo.OwnerID,
+		AssignedTo:       todo.AssignedTo,
+		CreatedAt:        todo.CreatedAt,
+		UpdatedAt:        todo.UpdatedAt,
+		DeletedAt:        todo.DeletedAt,
+		CompletedAt:      todo.CompletedAt,
+		CompletedBy:      todo.CompletedBy,
+		ArchivedAt:       todo.ArchivedAt,
+		EstimatedMinutes: todo.EstimatedMinutes,
+		LoggedMinutes:    todo.LoggedMinutes,
+		RemindAt:         todo.RemindAt,
+		RemindedAt:       todo.RemindedAt,
+	}
+}
+
+func fromRecord(record *Record, tenantID string) (*domain.Todo, error) {
+	status := domain.TodoStatus(record.Status)
+	if status < domain.StatusPending || status > domain.StatusArchived {
+		return nil, fmt.Errorf("invalid status: %d", record.Status)
+	}
+
+	todo := &domain.Todo{
+		ID:               domain.NewID(),
+		Title:            record.Title,
+		Description:      record.Description,
+		Status:           status,
+		Priority:         domain.TodoPriority(record.Priority),
+		DueDate:          record.DueDate,
+		DueDateTimezone:  record.DueDateTimezone,
+		Tags:             append(make([]string, 0, len(record.Tags)), record.Tags...),
+		OwnerID:          record.OwnerID,
+		AssignedTo:       record.AssignedTo,
+		TenantID:         tenantID,
+		CreatedAt:        record.CreatedAt,
+		UpdatedAt:        record.UpdatedAt,
+		CompletedAt:      record.CompletedAt,
+		CompletedBy:      record.CompletedBy,
+		ArchivedAt:       record.ArchivedAt,
+		EstimatedMinutes: record.EstimatedMinutes,
+		LoggedMinutes:    record.LoggedMinutes,
+		RemindAt:         record.RemindAt,
+		RemindedAt:       record.RemindedAt,
+		Version:          1,
+	}
+
+	if err := validate(todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// validate applies the creation rules except the due date check, since a
+// restored todo may legitimately be overdue
+func validate(todo *domain.Todo) error {
+	err := domain.ValidateTodo(todo)
+
+	var errs domain.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	remaining := make(domain.ValidationErrors, 0, len(errs))
+	for _, fe := range errs {
+		if !errors.Is(fe.Err, domain.ErrDueDateInPast) {
+			remaining = append(remaining, fe)
+		}
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	return remaining
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxFilenameLength bounds the name of an attached file
+const MaxFilenameLength = 255
+
+// Attachment is metadata for a file stored in object storage and attached to a todo
+type Attachment struct {
+	ID          string
+	TodoID      string
+	TenantID    string
+	ObjectKey   string
+	Filename    string
+	ContentType string
+	SizeBytes   int64
+	UploadedBy  string
+	CreatedAt   time.Time
+}
+
+// ObjectInfo describes an object that exists in storage
+type ObjectInfo struct {
+	ContentType string
+	SizeBytes   int64
+}
+
+// ObjectStorage stores attachment contents outside the database. Clients
+// upload directly to presigned URLs so file bytes never pass through the service.
+type ObjectStorage interface {
+	// PresignPut returns a URL that accepts a single PUT of the object until expiry
+	PresignPut(ctx context.Context, key, contentType string, sizeBytes int64, expiry time.Duration) (string, error)
+
+	// Stat reports the stored object, or ErrObjectNotFound if it was never uploaded
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// Delete removes the object; deleting a missing object is not an error.
+	// Every hard delete of a todo, by ForceDelete or the purge jobs, deletes
+	// the objects of its attachments through it.
+	Delete(ctx context.Context, key string) error
+}
+
+// AttachmentKeyPrefix is the storage prefix under which every attachment of a todo lives
+func AttachmentKeyPrefix(tenantID, todoID string) string {
+	return "tenants/" + tenantID + "/todos/" + todoID + "/attachments/"
+}
+
+// NewAttachmentKey returns a fresh storage key for a file attached to a todo
+func NewAttachmentKey(tenantID, todoID, filename string) (string, error) {
+	if err := ValidateFilename(filename); err != nil {
+		return "", err
+	}
+	return AttachmentKeyPrefix(tenantID, todoID) + uuid.New().String() + "/" + filename, nil
+}
+
+// ValidateFilename rejects names that are empty, too long or carry a path
+func ValidateFilename(filename string) error {
+	if filename == "" || len(filename) > MaxFilenameLength {
+		return ErrInvalidFilename
+	}
+	if strings.ContainsAny(filename, "/\\") || path.Clean(filename) != filename || filename == ".." {
+		return ErrInvalidFilename
+	}
+	return nil
+}
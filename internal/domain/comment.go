@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxCommentLength bounds the body of a comment
+const MaxCommentLength = 5000
+
+// Comment is a note left on a todo by a collaborator
+type Comment struct {
+	ID        string
+	TodoID    string
+	TenantID  string
+	AuthorID  string
+	Body      string
+	CreatedAt time.Time
+}
+
+// NewComment creates a comment with validation
+func NewComment(todoID, tenantID, authorID, body string) (*Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyCommentBody
+	}
+	if len(body) > MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+
+	return &Comment{
+		ID:        NewID(),
+		TodoID:    todoID,
+		TenantID:  tenantID,
+		AuthorID:  authorID,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
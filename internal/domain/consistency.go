@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+type readYourWritesKey struct{}
+
+// WithReadYourWrites marks ctx so repository reads see every write committed
+// before them, bypassing read replicas that may lag behind the primary. Use it
+// for reads that decide a write or follow one within the same request.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// ReadYourWrites reports whether ctx asks for reads from the primary
+func ReadYourWrites(ctx context.Context) bool {
+	ok, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return ok
+}
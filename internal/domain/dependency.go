@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// Dependency records that a todo is blocked by another todo of the same tenant
+type Dependency struct {
+	TodoID      string
+	DependsOnID string
+	TenantID    string
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// TodoDependencies lists the todos a todo waits on and the todos waiting on it
+type TodoDependencies struct {
+	BlockedBy []*Todo
+	Blocks    []*Todo
+}
+
+// NewDependency creates a dependency of todoID on dependsOnID
+func NewDependency(todoID, dependsOnID, tenantID, createdBy string) (*Dependency, error) {
+	if todoID == dependsOnID {
+		return nil, ErrSelfDependency
+	}
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+
+	return &Dependency{
+		TodoID:      todoID,
+		DependsOnID: dependsOnID,
+		TenantID:    tenantID,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// DependencyGraph maps each todo ID to the IDs of the todos it depends on
+type DependencyGraph map[string][]string
+
+// CheckCycle returns ErrDependencyCycle when adding dep to the graph would
+// close a loop, i.e. when dep.TodoID is already reachable from dep.DependsOnID
+func (g DependencyGraph) CheckCycle(dep *Dependency) error {
+	if dep.TodoID == dep.DependsOnID {
+		return ErrSelfDependency
+	}
+
+	visited := map[string]bool{dep.DependsOnID: true}
+	queue := []string{dep.DependsOnID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g[id] {
+			if next == dep.TodoID {
+				return ErrDependencyCycle
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckDependenciesResolved returns ErrBlockedByDependencies unless every
+// todo in blockedBy is completed or archived
+func CheckDependenciesResolved(blockedBy []*Todo) error {
+	for _, dep := range blockedBy {
+		if dep.Status != StatusCompleted && dep.Status != StatusArchived {
+			return ErrBlockedByDependencies
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewDependency(t *testing.T) {
+	tests := []struct {
+		name                string
+		todoID, dependsOnID string
+		tenantID            string
+		err                 error
+	}{
+		{name: "valid", todoID: "a", dependsOnID: "b", tenantID: "tenant-a"},
+		{name: "self", todoID: "a", dependsOnID: "a", tenantID: "tenant-a", err: ErrSelfDependency},
+		{name: "no tenant", todoID: "a", dependsOnID: "b", err: ErrInvalidTenantID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep, err := NewDependency(tt.todoID, tt.dependsOnID, tt.tenantID, "alice")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NewDependency() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (dep.TodoID != "a" || dep.DependsOnID != "b" || dep.CreatedBy != "alice" || dep.CreatedAt.IsZero()) {
+				t.Errorf("dependency = %+v", dep)
+			}
+		})
+	}
+}
+
+func TestDependencyGraphCheckCycle(t *testing.T) {
+	// a depends on b, b on c and d
+	graph := DependencyGraph{"a": {"b"}, "b": {"c", "d"}}
+
+	tests := []struct {
+		name                string
+		todoID, dependsOnID string
+		err                 error
+	}{
+		{name: "new branch", todoID: "c", dependsOnID: "d"},
+		{name: "existing edge", todoID: "a", dependsOnID: "b"},
+		{name: "shortcut", todoID: "a", dependsOnID: "c"},
+		{name: "direct cycle", todoID: "b", dependsOnID: "a", err: ErrDependencyCycle},
+		{name: "transitive cycle", todoID: "d", dependsOnID: "a", err: ErrDependencyCycle},
+		{name: "self", todoID: "c", dependsOnID: "c", err: ErrSelfDependency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := graph.CheckCycle(&Dependency{TodoID: tt.todoID, DependsOnID: tt.dependsOnID})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("CheckCycle() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckDependenciesResolved(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []TodoStatus
+		err      error
+	}{
+		{name: "none"},
+		{name: "completed and archived", statuses: []TodoStatus{StatusCompleted, StatusArchived}},
+		{name: "pending", statuses: []TodoStatus{StatusCompleted, StatusPending}, err: ErrBlockedByDependencies},
+		{name: "in progress", statuses: []TodoStatus{StatusInProgress}, err: ErrBlockedByDependencies},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blockedBy []*Todo
+			for _, status := range tt.statuses {
+				blockedBy = append(blockedBy, &Todo{Status: status})
+			}
+			if err := CheckDependenciesResolved(blockedBy); !errors.Is(err, tt.err) {
+				t.Fatalf("CheckDependenciesResolved() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -4,19 +4,47 @@ import "errors"
 
 var (
 	// Validation Errors
-	ErrEmptyTitle         = errors.New("title cannot be empty")
-	ErrTitleTooLong       = errors.New("title exceeds 200 characters")
-	ErrDescriptionTooLong = errors.New("description exceeds 2000 characters")
-	ErrInvalidOwnerId     = errors.New("owner ID is required")
-	ErrInvalidTenantID    = errors.New("tenant ID is required")
-	ErrInvalidPriority    = errors.New("invalid priority value")
-	ErrDueDateInPast      = errors.New("due date cannot be in the past")
-	ErrTooManyTags        = errors.New("maximum 20 tags allowed")
+	ErrEmptyTitle          = errors.New("title cannot be empty")
+	ErrTitleTooLong        = errors.New("title exceeds maximum length")
+	ErrDescriptionTooLong  = errors.New("description exceeds maximum length")
+	ErrInvalidOwnerId      = errors.New("owner ID is required")
+	ErrInvalidTenantID     = errors.New("tenant ID is required")
+	ErrInvalidPriority     = errors.New("invalid priority value")
+	ErrInvalidStatus       = errors.New("invalid status value")
+	ErrDueDateInPast       = errors.New("due date cannot be in the past")
+	ErrInvalidTimezone     = errors.New("invalid IANA timezone")
+	ErrTooManyTags         = errors.New("too many tags")
+	ErrEmptyTag            = errors.New("tag cannot be empty")
+	ErrTagTooLong          = errors.New("tag exceeds maximum length")
+	ErrEmptyCommentBody    = errors.New("comment body cannot be empty")
+	ErrCommentTooLong      = errors.New("comment exceeds maximum length")
+	ErrInvalidFilename     = errors.New("invalid filename")
+	ErrAttachmentTooLarge  = errors.New("attachment exceeds maximum size")
+	ErrInvalidSortField    = errors.New("unsupported sort field")
+	ErrInvalidPage         = errors.New("page cannot be negative")
+	ErrInvalidPageSize     = errors.New("page size cannot be negative")
+	ErrPageTooLarge        = errors.New("page exceeds the maximum page number")
+	ErrInvalidDueRange     = errors.New("due_date_from must not be after due_date_to")
+	ErrInvalidCreatedRange = errors.New("created_from must not be after created_to")
+	ErrInvalidUpdatedRange = errors.New("updated_from must not be after updated_to")
+	ErrInvalidSnooze       = errors.New("snooze duration must be positive")
+	ErrInvalidEstimate     = errors.New("estimate must be between 0 and one year of minutes")
+	ErrInvalidTimeLog      = errors.New("logged time must be a positive number of minutes")
+	ErrUnknownAssignee     = errors.New("assignee is not a user of the tenant")
 
 	// Business logic errors
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
 	ErrTodoNotFound            = errors.New("todo not found")
 	ErrVersionMismatch         = errors.New("version mismatch - concurrent update detected")
+	ErrDuplicateTitle          = errors.New("a todo with this title already exists")
+	ErrCommentNotFound         = errors.New("comment not found")
+	ErrObjectNotFound          = errors.New("object not found in storage")
+	ErrFilterTooBroad          = errors.New("filter must narrow beyond the tenant")
+	ErrNoDueDate               = errors.New("todo has no due date")
+	ErrSelfDependency          = errors.New("todo cannot depend on itself")
+	ErrDependencyCycle         = errors.New("dependency would create a cycle")
+	ErrDependencyNotFound      = errors.New("dependency not found")
+	ErrBlockedByDependencies   = errors.New("todo has incomplete dependencies")
 
 	// Authorization errors
 	ErrUnauthorized = errors.New("unauthorized access")
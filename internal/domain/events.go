@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventTodoCreated  = "todo.created"
+	EventTodoUpdated  = "todo.updated"
+	EventTodoDeleted  = "todo.deleted"
+	EventTodoAssigned = "todo.assigned"
+	EventTodoReminder = "todo.reminder"
+)
+
+// DomainEvent is something that happened to a todo that other systems may react to
+type DomainEvent interface {
+	EventName() string
+	AggregateID() string
+	Tenant() string
+	OccurredAt() time.Time
+}
+
+// EventPublisher delivers domain events to an external transport
+type EventPublisher interface {
+	Publish(ctx context.Context, event DomainEvent) error
+}
+
+// EventSubscriber delivers events concerning a single todo as they are
+// published. The returned function cancels the subscription.
+type EventSubscriber interface {
+	Subscribe(todoID string) (<-chan DomainEvent, func())
+}
+
+// TodoChanged is emitted for every create, update and delete of a todo
+type TodoChanged struct {
+	Name      string                 `json:"name"`
+	TodoID    string                 `json:"todo_id"`
+	TenantID  string                 `json:"tenant_id"`
+	ActorID   string                 `json:"actor_id"`
+	Changes   map[string]FieldChange `json:"changes"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+func (e TodoChanged) EventName() string     { return e.Name }
+func (e TodoChanged) AggregateID() string   { return e.TodoID }
+func (e TodoChanged) Tenant() string        { return e.TenantID }
+func (e TodoChanged) OccurredAt() time.Time { return e.Timestamp }
+
+// TodoAssigned is emitted when a todo's assignee changes
+type TodoAssigned struct {
+	TodoID      string    `json:"todo_id"`
+	TenantID    string    `json:"tenant_id"`
+	OldAssignee *string   `json:"old_assignee,omitempty"`
+	NewAssignee *string   `json:"new_assignee,omitempty"`
+	ActorID     string    `json:"actor_id"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (e TodoAssigned) EventName() string     { return EventTodoAssigned }
+func (e TodoAssigned) AggregateID() string   { return e.TodoID }
+func (e TodoAssigned) Tenant() string        { return e.TenantID }
+func (e TodoAssigned) OccurredAt() time.Time { return e.Timestamp }
+
+// TodoReminder is emitted when the reminder time of a todo comes up
+type TodoReminder struct {
+	TodoID     string     `json:"todo_id"`
+	TenantID   string     `json:"tenant_id"`
+	Title      string     `json:"title"`
+	OwnerID    string     `json:"owner_id"`
+	AssignedTo *string    `json:"assigned_to,omitempty"`
+	DueDate    *time.Time `json:"due_date,omitempty"`
+	RemindAt   time.Time  `json:"remind_at"`
+	Timestamp  time.Time  `json:"timestamp"`
+}
+
+func (e TodoReminder) EventName() string     { return EventTodoReminder }
+func (e TodoReminder) AggregateID() string   { return e.TodoID }
+func (e TodoReminder) Tenant() string        { return e.TenantID }
+func (e TodoReminder) OccurredAt() time.Time { return e.Timestamp }
+
+// ReminderFor builds the reminder event of a todo whose reminder is due
+func ReminderFor(t *Todo, at time.Time) TodoReminder {
+	event := TodoReminder{
+		TodoID:     t.ID,
+		TenantID:   t.TenantID,
+		Title:      t.Title,
+		OwnerID:    t.OwnerID,
+		AssignedTo: t.AssignedTo,
+		DueDate:    t.DueDate,
+		Timestamp:  at,
+	}
+	if t.RemindAt != nil {
+		event.RemindAt = *t.RemindAt
+	}
+	return event
+}
+
+// OutboxMessage is a persisted event awaiting delivery. Payload holds the
+// JSON encoding of the original event.
+type OutboxMessage struct {
+	ID        int64
+	Name      string
+	TodoID    string
+	TenantID  string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+	Attempts  int
+}
+
+func (m *OutboxMessage) EventName() string     { return m.Name }
+func (m *OutboxMessage) AggregateID() string   { return m.TodoID }
+func (m *OutboxMessage) Tenant() string        { return m.TenantID }
+func (m *OutboxMessage) OccurredAt() time.Time { return m.CreatedAt }
+
+// EventsForChange derives the domain events produced by a recorded change
+func EventsForChange(todoID, tenantID, actorID string, changeType ChangeType, changes map[string]FieldChange, at time.Time) []DomainEvent {
+	name := EventTodoUpdated
+	switch changeType {
+	case ChangeCreated:
+		name = EventTodoCreated
+	case ChangeDeleted:
+		name = EventTodoDeleted
+	}
+
+	events := []DomainEvent{
+		TodoChanged{
+			Name:      name,
+			TodoID:    todoID,
+			TenantID:  tenantID,
+			ActorID:   actorID,
+			Changes:   changes,
+			Timestamp: at,
+		},
+	}
+
+	if change, ok := changes["assigned_to"]; ok {
+		oldAssignee, _ := change.Old.(*string)
+		newAssignee, _ := change.New.(*string)
+		events = append(events, TodoAssigned{
+			TodoID:      todoID,
+			TenantID:    tenantID,
+			OldAssignee: oldAssignee,
+			NewAssignee: newAssignee,
+			ActorID:     actorID,
+			Timestamp:   at,
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEventsForChange(t *testing.T) {
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	bob := "bob"
+
+	tests := []struct {
+		name       string
+		changeType ChangeType
+		changes    map[string]FieldChange
+		events     []string
+		assigned   *TodoAssigned
+	}{
+		{name: "created", changeType: ChangeCreated, events: []string{EventTodoCreated}},
+		{name: "deleted", changeType: ChangeDeleted, events: []string{EventTodoDeleted}},
+		{
+			name:       "updated",
+			changeType: ChangeUpdated,
+			changes:    map[string]FieldChange{"title": {Old: "draft", New: "final"}},
+			events:     []string{EventTodoUpdated},
+		},
+		{
+			name:       "assigned",
+			changeType: ChangeUpdated,
+			changes:    map[string]FieldChange{"assigned_to": {Old: (*string)(nil), New: &bob}},
+			events:     []string{EventTodoUpdated, EventTodoAssigned},
+			assigned:   &TodoAssigned{NewAssignee: &bob},
+		},
+		{
+			name:       "unassigned",
+			changeType: ChangeUpdated,
+			changes:    map[string]FieldChange{"assigned_to": {Old: &bob, New: (*string)(nil)}},
+			events:     []string{EventTodoUpdated, EventTodoAssigned},
+			assigned:   &TodoAssigned{OldAssignee: &bob},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := EventsForChange("todo-1", "tenant-a", "alice", tt.changeType, tt.changes, at)
+
+			var names []string
+			for _, event := range events {
+				names = append(names, event.EventName())
+				if event.AggregateID() != "todo-1" || event.Tenant() != "tenant-a" || !event.OccurredAt().Equal(at) {
+					t.Errorf("event %s = %+v, want todo-1 of tenant-a at %v", event.EventName(), event, at)
+				}
+			}
+			if !slices.Equal(names, tt.events) {
+				t.Fatalf("events = %v, want %v", names, tt.events)
+			}
+
+			if tt.assigned == nil {
+				return
+			}
+			assigned := events[len(events)-1].(TodoAssigned)
+			if !sameAssignee(assigned.OldAssignee, tt.assigned.OldAssignee) || !sameAssignee(assigned.NewAssignee, tt.assigned.NewAssignee) {
+				t.Errorf("assignee change = %v -> %v, want %v -> %v", assigned.OldAssignee, assigned.NewAssignee, tt.assigned.OldAssignee, tt.assigned.NewAssignee)
+			}
+			if assigned.ActorID != "alice" {
+				t.Errorf("actor = %q, want alice", assigned.ActorID)
+			}
+		})
+	}
+}
+
+func sameAssignee(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestReminderFor(t *testing.T) {
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	remindAt := at.Add(-time.Minute)
+	bob := "bob"
+	todo := &Todo{ID: "todo-1", TenantID: "tenant-a", Title: "call back", OwnerID: "alice", AssignedTo: &bob, RemindAt: &remindAt}
+
+	event := ReminderFor(todo, at)
+	want := TodoReminder{TodoID: "todo-1", TenantID: "tenant-a", Title: "call back", OwnerID: "alice", AssignedTo: &bob, RemindAt: remindAt, Timestamp: at}
+	if event != want {
+		t.Fatalf("ReminderFor() = %+v, want %+v", event, want)
+	}
+	if event.EventName() != EventTodoReminder || event.AggregateID() != "todo-1" || event.Tenant() != "tenant-a" || !event.OccurredAt().Equal(at) {
+		t.Errorf("event metadata = %s, %s, %s, %v", event.EventName(), event.AggregateID(), event.Tenant(), event.OccurredAt())
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type ChangeType string
+
+const (
+	ChangeCreated       ChangeType = "CREATED"
+	ChangeUpdated       ChangeType = "UPDATED"
+	ChangeStatusChanged ChangeType = "STATUS_CHANGED"
+	ChangeDeleted       ChangeType = "DELETED"
+)
+
+// FieldChange holds the before and after value of a single field
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// HistoryEntry is an append-only record of a change made to a todo
+type HistoryEntry struct {
+	ID         int64
+	TodoID     string
+	TenantID   string
+	ActorID    string
+	ChangeType ChangeType
+	Changes    map[string]FieldChange
+	CreatedAt  time.Time
+}
+
+// DiffTodos returns the fields that differ between before and after. A nil
+// before yields every tracked field as newly set.
+func DiffTodos(before, after *Todo) map[string]FieldChange {
+	if before == nil {
+		before = &Todo{}
+	}
+
+	changes := make(map[string]FieldChange)
+
+	if before.Title != after.Title {
+		changes["title"] = FieldChange{Old: before.Title, New: after.Title}
+	}
+	if before.Description != after.Description {
+		changes["description"] = FieldChange{Old: before.Description, New: after.Description}
+	}
+	if before.Status != after.Status {
+		changes["status"] = FieldChange{Old: before.Status, New: after.Status}
+	}
+	if before.Priority != after.Priority {
+		changes["priority"] = FieldChange{Old: before.Priority, New: after.Priority}
+	}
+	if !equalTimePtr(before.DueDate, after.DueDate) {
+		changes["due_date"] = FieldChange{Old: before.DueDate, New: after.DueDate}
+	}
+	if !equalStringPtr(before.DueDateTimezone, after.DueDateTimezone) {
+		changes["due_date_timezone"] = FieldChange{Old: before.DueDateTimezone, New: after.DueDateTimezone}
+	}
+	if !slices.Equal(before.Tags, after.Tags) {
+		changes["tags"] = FieldChange{Old: before.Tags, New: after.Tags}
+	}
+	if before.OwnerID != after.OwnerID {
+		changes["owner_id"] = FieldChange{Old: before.OwnerID, New: after.OwnerID}
+	}
+	if !equalStringPtr(before.AssignedTo, after.AssignedTo) {
+		changes["assigned_to"] = FieldChange{Old: before.AssignedTo, New: after.AssignedTo}
+	}
+	if !equalStringPtr(before.ParentID, after.ParentID) {
+		changes["parent_id"] = FieldChange{Old: before.ParentID, New: after.ParentID}
+	}
+	if !equalTimePtr(before.CompletedAt, after.CompletedAt) {
+		changes["completed_at"] = FieldChange{Old: before.CompletedAt, New: after.CompletedAt}
+	}
+	if !equalStringPtr(before.CompletedBy, after.CompletedBy) {
+		changes["completed_by"] = FieldChange{Old: before.CompletedBy, New: after.CompletedBy}
+	}
+	if !equalTimePtr(before.ArchivedAt, after.ArchivedAt) {
+		changes["archived_at"] = FieldChange{Old: before.ArchivedAt, New: after.ArchivedAt}
+	}
+	if !equalInt32Ptr(before.EstimatedMinutes, after.EstimatedMinutes) {
+		changes["estimated_minutes"] = FieldChange{Old: before.EstimatedMinutes, New: after.EstimatedMinutes}
+	}
+	if !equalTimePtr(before.RemindAt, after.RemindAt) {
+		changes["remind_at"] = FieldChange{Old: before.RemindAt, New: after.RemindAt}
+	}
+	if before.LoggedMinutes != after.LoggedMinutes {
+		changes["logged_minutes"] = FieldChange{Old: before.LoggedMinutes, New: after.LoggedMinutes}
+	}
+
+	return changes
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalInt32Ptr(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// IDScheme selects the UUID version new records are identified with
+type IDScheme string
+
+const (
+	// IDSchemeV7 generates time-ordered UUIDv7 ids, so new rows land next to
+	// each other in primary key indexes and ids sort by creation time
+	IDSchemeV7 IDScheme = "v7"
+	// IDSchemeV4 generates random UUIDv4 ids
+	IDSchemeV4 IDScheme = "v4"
+)
+
+// Validate rejects unknown schemes
+func (s IDScheme) Validate() error {
+	if s != IDSchemeV7 && s != IDSchemeV4 {
+		return fmt.Errorf("invalid id scheme: %q, must be %q or %q", s, IDSchemeV7, IDSchemeV4)
+	}
+	return nil
+}
+
+var currentIDScheme atomic.Value
+
+func init() {
+	currentIDScheme.Store(IDSchemeV7)
+}
+
+// SetIDScheme replaces the scheme NewID generates ids with, typically once at startup
+func SetIDScheme(scheme IDScheme) error {
+	if err := scheme.Validate(); err != nil {
+		return err
+	}
+	currentIDScheme.Store(scheme)
+	return nil
+}
+
+// NewID returns a new record id under the current scheme. UUIDv7 ids
+// generated by one process are strictly increasing.
+func NewID() string {
+	if currentIDScheme.Load().(IDScheme) == IDSchemeV4 {
+		return uuid.New().String()
+	}
+	return uuid.Must(uuid.NewV7()).String()
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewIDScheme(t *testing.T) {
+	t.Cleanup(func() { currentIDScheme.Store(IDSchemeV7) })
+
+	tests := []struct {
+		scheme  IDScheme
+		version uuid.Version
+	}{
+		{scheme: IDSchemeV7, version: 7},
+		{scheme: IDSchemeV4, version: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.scheme), func(t *testing.T) {
+			if err := SetIDScheme(tt.scheme); err != nil {
+				t.Fatalf("SetIDScheme failed: %v", err)
+			}
+			id, err := uuid.Parse(NewID())
+			if err != nil {
+				t.Fatalf("NewID returned an invalid uuid: %v", err)
+			}
+			if id.Version() != tt.version {
+				t.Fatalf("expected a version %d id, got %d", tt.version, id.Version())
+			}
+		})
+	}
+
+	// An unknown scheme is refused and leaves the current one in place
+	if err := SetIDScheme("v1"); err == nil {
+		t.Fatal("expected an unknown scheme to be rejected")
+	}
+	if id := uuid.MustParse(NewID()); id.Version() != 4 {
+		t.Fatalf("expected the v4 scheme kept, got version %d", id.Version())
+	}
+}
+
+func TestNewIDV7IsIncreasing(t *testing.T) {
+	previous := NewID()
+	for range 1000 {
+		id := NewID()
+		if id <= previous {
+			t.Fatalf("expected %s to sort after %s", id, previous)
+		}
+		previous = id
+	}
+}
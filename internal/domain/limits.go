@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Limits bounds the size of todo fields
+type Limits struct {
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	MaxTags              int
+	MaxTagLength         int
+}
+
+// DefaultLimits returns the limits applied when none are configured
+func DefaultLimits() Limits {
+	return Limits{
+		MaxTitleLength:       200,
+		MaxDescriptionLength: 2000,
+		MaxTags:              20,
+		MaxTagLength:         50,
+	}
+}
+
+// Validate reports a limit that is not positive
+func (l Limits) Validate() error {
+	if l.MaxTitleLength <= 0 {
+		return fmt.Errorf("invalid max title length: %d", l.MaxTitleLength)
+	}
+	if l.MaxDescriptionLength <= 0 {
+		return fmt.Errorf("invalid max description length: %d", l.MaxDescriptionLength)
+	}
+	if l.MaxTags <= 0 {
+		return fmt.Errorf("invalid max tags: %d", l.MaxTags)
+	}
+	if l.MaxTagLength <= 0 {
+		return fmt.Errorf("invalid max tag length: %d", l.MaxTagLength)
+	}
+	return nil
+}
+
+// PageSizes bounds the page size of List requests
+type PageSizes struct {
+	Default int // used when a request leaves the page size unset
+	Max     int // larger requested page sizes are clamped to this
+}
+
+// DefaultPageSizes returns the page sizes applied when none are configured
+func DefaultPageSizes() PageSizes {
+	return PageSizes{
+		Default: 20,
+		Max:     100,
+	}
+}
+
+// Validate reports a page size that is not positive or a default above the max
+func (p PageSizes) Validate() error {
+	if p.Default <= 0 {
+		return fmt.Errorf("invalid default page size: %d", p.Default)
+	}
+	if p.Max < p.Default {
+		return fmt.Errorf("max page size (%d) must be >= default page size (%d)", p.Max, p.Default)
+	}
+	return nil
+}
+
+var currentLimits atomic.Pointer[Limits]
+
+func init() {
+	limits := DefaultLimits()
+	currentLimits.Store(&limits)
+}
+
+// SetLimits replaces the limits used by todo validation, typically once at startup
+func SetLimits(limits Limits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	currentLimits.Store(&limits)
+	return nil
+}
+
+// CurrentLimits returns the limits used by todo validation
+func CurrentLimits() Limits {
+	return *currentLimits.Load()
+}
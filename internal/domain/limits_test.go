@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Limits)
+		wantErr bool
+	}{
+		{name: "defaults", mutate: func(*Limits) {}},
+		{name: "zero title length", mutate: func(l *Limits) { l.MaxTitleLength = 0 }, wantErr: true},
+		{name: "negative description length", mutate: func(l *Limits) { l.MaxDescriptionLength = -1 }, wantErr: true},
+		{name: "zero tags", mutate: func(l *Limits) { l.MaxTags = 0 }, wantErr: true},
+		{name: "zero tag length", mutate: func(l *Limits) { l.MaxTagLength = 0 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := DefaultLimits()
+			tt.mutate(&limits)
+			if err := limits.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetLimitsAppliesToValidation(t *testing.T) {
+	t.Cleanup(func() { SetLimits(DefaultLimits()) })
+
+	if err := SetLimits(Limits{}); err == nil {
+		t.Fatal("SetLimits accepted zero limits")
+	}
+	if CurrentLimits() != DefaultLimits() {
+		t.Fatalf("rejected limits were installed: %+v", CurrentLimits())
+	}
+
+	if err := SetLimits(Limits{MaxTitleLength: 5, MaxDescriptionLength: 10, MaxTags: 1, MaxTagLength: 3}); err != nil {
+		t.Fatalf("SetLimits: %v", err)
+	}
+
+	if _, err := NewTodo("short", strings.Repeat("x", 10), "alice", "tenant-a", PriorityLow, WithTags([]string{"abc"})); err != nil {
+		t.Fatalf("NewTodo at the limits: %v", err)
+	}
+	_, err := NewTodo("longer", strings.Repeat("x", 11), "alice", "tenant-a", PriorityLow, WithTags([]string{"abc", "de"}))
+	for _, target := range []error{ErrTitleTooLong, ErrDescriptionTooLong, ErrTooManyTags} {
+		if !errors.Is(err, target) {
+			t.Errorf("NewTodo past the limits = %v, want %v", err, target)
+		}
+	}
+	if _, err := NormalizeTags([]string{"abcd"}); !errors.Is(err, ErrTagTooLong) {
+		t.Errorf("NormalizeTags past the tag length = %v, want %v", err, ErrTagTooLong)
+	}
+}
+
+func TestPageSizesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sizes   PageSizes
+		wantErr bool
+	}{
+		{name: "defaults", sizes: DefaultPageSizes()},
+		{name: "default equals max", sizes: PageSizes{Default: 50, Max: 50}},
+		{name: "zero default", sizes: PageSizes{Default: 0, Max: 100}, wantErr: true},
+		{name: "max below default", sizes: PageSizes{Default: 50, Max: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sizes.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TransitionPolicy defines which status transitions are allowed
+type TransitionPolicy struct {
+	allowed map[TodoStatus][]TodoStatus
+}
+
+// NewTransitionPolicy builds a policy from a map of allowed target statuses per source status
+func NewTransitionPolicy(allowed map[TodoStatus][]TodoStatus) *TransitionPolicy {
+	copied := make(map[TodoStatus][]TodoStatus, len(allowed))
+	for from, to := range allowed {
+		copied[from] = slices.Clone(to)
+	}
+	return &TransitionPolicy{allowed: copied}
+}
+
+// DefaultTransitionPolicy returns the standard todo workflow
+func DefaultTransitionPolicy() *TransitionPolicy {
+	return NewTransitionPolicy(map[TodoStatus][]TodoStatus{
+		StatusPending: {
+			StatusInProgress,
+			StatusArchived,
+		},
+		StatusInProgress: {
+			StatusArchived,
+			StatusCompleted,
+			StatusPending,
+		},
+		StatusCompleted: {
+			StatusArchived,
+			StatusPending,
+		},
+		StatusArchived: {
+			StatusPending,
+		},
+	})
+}
+
+// ParseTransitionPolicy parses a policy of the form
+// "pending=in_progress,archived;in_progress=completed". An empty spec yields
+// the default policy; otherwise statuses without a rule allow no transitions.
+func ParseTransitionPolicy(spec string) (*TransitionPolicy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return DefaultTransitionPolicy(), nil
+	}
+
+	allowed := make(map[TodoStatus][]TodoStatus)
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		fromName, toNames, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid transition rule %q: expected from=to[,to...]", rule)
+		}
+
+		from, err := ParseStatus(fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, toName := range strings.Split(toNames, ",") {
+			if strings.TrimSpace(toName) == "" {
+				continue
+			}
+			to, err := ParseStatus(toName)
+			if err != nil {
+				return nil, err
+			}
+			allowed[from] = append(allowed[from], to)
+		}
+	}
+
+	return NewTransitionPolicy(allowed), nil
+}
+
+// Allows reports whether a transition from one status to another is permitted
+func (p *TransitionPolicy) Allows(from, to TodoStatus) bool {
+	return slices.Contains(p.allowed[from], to)
+}
+
+// PriorityWeights ranks priorities when todos are sorted by priority, for
+// tenants whose priorities are not evenly spaced. Priorities without a weight
+// rank by their own value.
+type PriorityWeights struct {
+	weights map[TodoPriority]int
+}
+
+// NewPriorityWeights builds weights from a map of weight per priority
+func NewPriorityWeights(weights map[TodoPriority]int) *PriorityWeights {
+	copied := make(map[TodoPriority]int, len(weights))
+	for priority, weight := range weights {
+		copied[priority] = weight
+	}
+	return &PriorityWeights{weights: copied}
+}
+
+// ParsePriorityWeights parses weights of the form "low=1,high=5,critical=100".
+// An empty spec yields nil, which sorts priorities by their value.
+func ParsePriorityWeights(spec string) (*PriorityWeights, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	weights := make(map[TodoPriority]int)
+	for _, rule := range strings.Split(spec, ",") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid priority weight %q: expected priority=weight", rule)
+		}
+
+		priority, err := ParsePriority(name)
+		if err != nil {
+			return nil, err
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for priority %q: %w", name, err)
+		}
+		weights[priority] = weight
+	}
+
+	return NewPriorityWeights(weights), nil
+}
+
+// Weight returns the rank of priority; a nil PriorityWeights ranks every
+// priority by its value
+func (w *PriorityWeights) Weight(priority TodoPriority) int {
+	if w != nil {
+		if weight, ok := w.weights[priority]; ok {
+			return weight
+		}
+	}
+	return int(priority)
+}
+
+// Priorities returns the priorities that have a weight, lowest value first
+func (w *PriorityWeights) Priorities() []TodoPriority {
+	if w == nil {
+		return nil
+	}
+	priorities := make([]TodoPriority, 0, len(w.weights))
+	for priority := range w.weights {
+		priorities = append(priorities, priority)
+	}
+	slices.Sort(priorities)
+	return priorities
+}
+
+// ParsePriority maps a priority name such as "high" to its TodoPriority
+func ParsePriority(name string) (TodoPriority, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "low":
+		return PriorityLow, nil
+	case "medium":
+		return PriorityMedium, nil
+	case "high":
+		return PriorityHigh, nil
+	case "critical":
+		return PriorityCritical, nil
+	default:
+		return 0, fmt.Errorf("unknown priority: %q", name)
+	}
+}
+
+// ParseStatus maps a status name such as "in_progress" to its TodoStatus
+func ParseStatus(name string) (TodoStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "pending":
+		return StatusPending, nil
+	case "in_progress":
+		return StatusInProgress, nil
+	case "completed":
+		return StatusCompleted, nil
+	case "archived":
+		return StatusArchived, nil
+	default:
+		return 0, fmt.Errorf("unknown status: %q", name)
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestUpdateStatusWithCustomPolicy(t *testing.T) {
+	// A stricter workflow: work must start before it completes, and nothing
+	// leaves the archive
+	policy := NewTransitionPolicy(map[TodoStatus][]TodoStatus{
+		StatusPending:    {StatusInProgress},
+		StatusInProgress: {StatusCompleted},
+		StatusCompleted:  {StatusArchived},
+	})
+
+	tests := []struct {
+		name   string
+		policy *TransitionPolicy
+		from   TodoStatus
+		to     TodoStatus
+		err    error
+	}{
+		{name: "allowed", policy: policy, from: StatusPending, to: StatusInProgress},
+		{name: "allowed onward", policy: policy, from: StatusInProgress, to: StatusCompleted},
+		{name: "skipping a step", policy: policy, from: StatusPending, to: StatusCompleted, err: ErrInvalidStatusTransition},
+		{name: "left out of the policy", policy: policy, from: StatusArchived, to: StatusPending, err: ErrInvalidStatusTransition},
+		{name: "nil applies the default", from: StatusArchived, to: StatusPending},
+		{name: "invalid status", policy: policy, from: StatusPending, to: StatusArchived + 1, err: ErrInvalidStatus},
+		{name: "no status", from: StatusPending, to: 0, err: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{Status: tt.from, Version: 1}
+
+			err := todo.UpdateStatus(tt.to, "alice", tt.policy)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("UpdateStatus() error = %v, want %v", err, tt.err)
+			}
+			want, version := tt.to, int64(2)
+			if err != nil {
+				want, version = tt.from, 1
+			}
+			if todo.Status != want || todo.Version != version {
+				t.Errorf("status, version = %v, %d, want %v, %d", todo.Status, todo.Version, want, version)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusRecordsCompletion(t *testing.T) {
+	todo := &Todo{Status: StatusInProgress, Version: 1}
+
+	if err := todo.UpdateStatus(StatusCompleted, "alice", nil); err != nil {
+		t.Fatalf("UpdateStatus(completed): %v", err)
+	}
+	if todo.CompletedAt == nil || todo.CompletedBy == nil || *todo.CompletedBy != "alice" {
+		t.Fatalf("completion = %v by %v, want now by alice", todo.CompletedAt, todo.CompletedBy)
+	}
+
+	if err := todo.UpdateStatus(StatusArchived, "bob", nil); err != nil {
+		t.Fatalf("UpdateStatus(archived): %v", err)
+	}
+	if todo.ArchivedAt == nil || *todo.CompletedBy != "alice" {
+		t.Fatalf("archiving lost the completion or set no archive time: %+v", todo)
+	}
+
+	if err := todo.UpdateStatus(StatusPending, "bob", nil); err != nil {
+		t.Fatalf("UpdateStatus(pending): %v", err)
+	}
+	if todo.ArchivedAt != nil || todo.CompletedAt != nil || todo.CompletedBy != nil {
+		t.Fatalf("leaving the archive kept its timestamps: %+v", todo)
+	}
+}
+
+func TestParseTransitionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		allowed [][2]TodoStatus
+		denied  [][2]TodoStatus
+		wantErr bool
+	}{
+		{
+			name:    "empty is the default",
+			spec:    " ",
+			allowed: [][2]TodoStatus{{StatusPending, StatusInProgress}, {StatusArchived, StatusPending}},
+			denied:  [][2]TodoStatus{{StatusPending, StatusCompleted}},
+		},
+		{
+			name:    "rules",
+			spec:    "pending=in_progress, completed ; in_progress=completed;",
+			allowed: [][2]TodoStatus{{StatusPending, StatusCompleted}, {StatusInProgress, StatusCompleted}},
+			denied:  [][2]TodoStatus{{StatusPending, StatusArchived}, {StatusCompleted, StatusPending}},
+		},
+		{name: "missing targets", spec: "pending", wantErr: true},
+		{name: "unknown source", spec: "done=pending", wantErr: true},
+		{name: "unknown target", spec: "pending=done", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParseTransitionPolicy(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTransitionPolicy(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			for _, pair := range tt.allowed {
+				if !policy.Allows(pair[0], pair[1]) {
+					t.Errorf("expected %v -> %v allowed", pair[0], pair[1])
+				}
+			}
+			for _, pair := range tt.denied {
+				if policy.Allows(pair[0], pair[1]) {
+					t.Errorf("expected %v -> %v denied", pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestParsePriorityWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[TodoPriority]int
+		wantErr bool
+	}{
+		{name: "empty ranks by value", spec: " ", want: map[TodoPriority]int{PriorityLow: 1, PriorityCritical: 4}},
+		{
+			name: "weights",
+			spec: " High=5 ,critical=100,,",
+			want: map[TodoPriority]int{PriorityLow: 1, PriorityMedium: 2, PriorityHigh: 5, PriorityCritical: 100},
+		},
+		{name: "negative weight", spec: "low=-1", want: map[TodoPriority]int{PriorityLow: -1}},
+		{name: "missing weight", spec: "high", wantErr: true},
+		{name: "unknown priority", spec: "urgent=5", wantErr: true},
+		{name: "not a number", spec: "high=lots", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights, err := ParsePriorityWeights(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriorityWeights(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			for priority, want := range tt.want {
+				if got := weights.Weight(priority); got != want {
+					t.Errorf("Weight(%v) = %d, want %d", priority, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityWeightsPriorities(t *testing.T) {
+	var none *PriorityWeights
+	if got := none.Priorities(); len(got) != 0 {
+		t.Fatalf("expected no weighted priorities, got %v", got)
+	}
+
+	weights := NewPriorityWeights(map[TodoPriority]int{PriorityCritical: 100, PriorityLow: 0})
+	if got := weights.Priorities(); !slices.Equal(got, []TodoPriority{PriorityLow, PriorityCritical}) {
+		t.Fatalf("Priorities() = %v, want [low critical]", got)
+	}
+}
@@ -1,29 +1,191 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the contract for todo persistence
 type Repository interface {
 	// Create persists a new todo
 	Create(ctx context.Context, todo *Todo) error
 
+	// Upsert creates the todo with its ID, or replaces the existing one when
+	// todo.Version is exactly one ahead of it. It reports whether it created.
+	Upsert(ctx context.Context, todo *Todo) (bool, error)
+
 	// GetByID retrieves a todo by ID
 	GetByID(ctx context.Context, id, tenantID string) (*Todo, error)
 
-	// Update updates an existing todo with optimistic locking
-	Update(ctx context.Context, todo *Todo) error
+	// GetByIDIncludingDeleted retrieves a todo by ID even when it is soft-deleted
+	GetByIDIncludingDeleted(ctx context.Context, id, tenantID string) (*Todo, error)
+
+	// GetDetail retrieves a todo together with its subtasks and the number of
+	// its comments
+	GetDetail(ctx context.Context, id, tenantID string) (*TodoDetail, error)
+
+	// GetByIDs retrieves todos by ID in input order, skipping missing ones
+	GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*Todo, error)
+
+	// Update updates an existing todo if its stored version is still
+	// expectedVersion, then sets todo.Version to the new version
+	Update(ctx context.Context, todo *Todo, expectedVersion int64) error
 
 	// Delete soft-deletes a todo
 	Delete(ctx context.Context, id, tenantID string) error
 
+	// ForceDelete permanently removes a todo, soft-deleted or not, with its
+	// history. It returns the storage keys of the attachments it removed, for
+	// the caller to delete from object storage.
+	ForceDelete(ctx context.Context, id, tenantID string) ([]string, error)
+
+	// DeleteByFilter soft-deletes every todo matching a narrowed filter and returns the count
+	DeleteByFilter(ctx context.Context, filter *ListFilter) (int64, error)
+
 	// List retrieves todos with filtering and pagination
 	List(ctx context.Context, filter *ListFilter) ([]*Todo, int64, error)
 
-	// UpdateStatus updates only the status field
-	UpdateStatus(ctx context.Context, id, tenantID string, status TodoStatus, version int64) (*Todo, error)
+	// DryRunList returns the query List would run for a filter, without running it
+	DryRunList(ctx context.Context, filter *ListFilter) (*ListQuery, error)
+
+	// AnalyzeList runs the query List would run under EXPLAIN ANALYZE and returns its JSON plan
+	AnalyzeList(ctx context.Context, filter *ListFilter) ([]byte, error)
+
+	// ListRefs is List returning only the id, version and update time of each todo
+	ListRefs(ctx context.Context, filter *ListFilter) ([]*TodoRef, int64, error)
+
+	// CountActive counts the todos of a tenant that are not soft-deleted
+	CountActive(ctx context.Context, tenantID string) (int64, error)
+
+	// CountByStatus counts todos matching the filter grouped by status
+	CountByStatus(ctx context.Context, filter *ListFilter) (map[TodoStatus]int64, error)
+
+	// WorkloadByAssignee counts the tenant's non-deleted todos by status for
+	// each assignee; unassigned todos are keyed by UnassignedWorkload
+	WorkloadByAssignee(ctx context.Context, tenantID string) (map[string]WorkloadStats, error)
+
+	// UpdateStatus updates only the status field and the completion time that goes with it
+	UpdateStatus(ctx context.Context, id, tenantID string, status TodoStatus, completedAt *time.Time, completedBy *string, version int64) (*Todo, error)
 
 	// BatchCreate creates multiple todos in a transaction
 	BatchCreate(ctx context.Context, todos []*Todo) error
+
+	// PurgeDeleted hard-deletes todos soft-deleted before olderThan. It
+	// returns how many it removed and the storage keys of their attachments,
+	// for the caller to delete from object storage.
+	PurgeDeleted(ctx context.Context, olderThan time.Time) (int64, []string, error)
+
+	// PurgeArchived hard-deletes todos archived before olderThan, returning
+	// the count and attachment keys like PurgeDeleted
+	PurgeArchived(ctx context.Context, olderThan time.Time) (int64, []string, error)
+
+	// EscalateOverdue raises overdue open todos below Critical by one priority level
+	EscalateOverdue(ctx context.Context, tenantID string) (int64, error)
+
+	// ArchiveCompletedOlderThan archives todos completed more than age ago
+	ArchiveCompletedOlderThan(ctx context.Context, tenantID string, age time.Duration) (int64, error)
+
+	// DueReminders marks as sent the unsent reminders of open todos falling
+	// before now+window, across every tenant, and returns those todos
+	DueReminders(ctx context.Context, window time.Duration) ([]*Todo, error)
+
+	// DueWithin returns a tenant's open todos that fall due between now and
+	// now+within, soonest first
+	DueWithin(ctx context.Context, tenantID string, within time.Duration) ([]*Todo, error)
+
+	// ListTags returns distinct tags of a tenant's todos starting with prefix, most used first
+	ListTags(ctx context.Context, tenantID, prefix string, limit int) ([]string, error)
+
+	// AddTagToMany adds tag to the tenant's todos among ids that lack it and
+	// returns how many changed; no todo may end up with too many tags
+	AddTagToMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error)
+
+	// RemoveTagFromMany removes tag from the tenant's todos among ids and
+	// returns how many changed
+	RemoveTagFromMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error)
+
+	// GetHistory retrieves the most recent history entries of a todo, newest first
+	GetHistory(ctx context.Context, id, tenantID string, limit int) ([]*HistoryEntry, error)
+
+	// AddComment persists a new comment on a todo
+	AddComment(ctx context.Context, comment *Comment) error
+
+	// GetComment retrieves a comment of a todo by ID
+	GetComment(ctx context.Context, id, todoID, tenantID string) (*Comment, error)
+
+	// ListComments retrieves the comments of a todo, oldest first
+	ListComments(ctx context.Context, todoID, tenantID string, limit int) ([]*Comment, error)
+
+	// DeleteComment removes a comment
+	DeleteComment(ctx context.Context, id, todoID, tenantID string) error
+
+	// AddAttachment records metadata of an uploaded attachment
+	AddAttachment(ctx context.Context, attachment *Attachment) error
+
+	// ListAttachments retrieves the attachments of a todo, oldest first
+	ListAttachments(ctx context.Context, todoID, tenantID string) ([]*Attachment, error)
+
+	// AddDependency records that a todo is blocked by another, rejecting cycles
+	AddDependency(ctx context.Context, dep *Dependency) error
+
+	// RemoveDependency removes a dependency between two todos
+	RemoveDependency(ctx context.Context, todoID, dependsOnID, tenantID string) error
+
+	// ListDependencies retrieves the todos a todo is blocked by and the todos it blocks
+	ListDependencies(ctx context.Context, todoID, tenantID string) (*TodoDependencies, error)
+
+	// MoveToTenant moves the fromTenant todos among ids, deleted or not, to
+	// toTenant together with everything recorded about them and returns how
+	// many moved. mapOwner, when set, gives each moved todo its owner in toTenant.
+	MoveToTenant(ctx context.Context, ids []string, fromTenant, toTenant string, mapOwner OwnerMapping) (int64, error)
+}
+
+// OwnerMapping returns the user that owns a todo after it moves to another
+// tenant, given its owner before the move
+type OwnerMapping func(ownerID string) string
+
+// DeleteMode selects whether deleting a todo keeps it as soft-deleted or
+// removes it for good
+type DeleteMode string
+
+const (
+	DeleteSoft DeleteMode = "soft"
+	DeleteHard DeleteMode = "hard"
+)
+
+// UnassignedWorkload is the WorkloadByAssignee key of todos nobody is assigned
+const UnassignedWorkload = ""
+
+// WorkloadStats is the number of an assignee's todos in each status
+type WorkloadStats struct {
+	Counts map[TodoStatus]int64
+	Total  int64
+}
+
+// TodoDetail is a todo with its subtasks and the related counts shown on its
+// detail view
+type TodoDetail struct {
+	Todo         *Todo
+	CommentCount int64
+
+	// Subtasks are the live todos whose parent is Todo, oldest first
+	Subtasks []*Todo
+}
+
+// ListQuery is the SQL generated for a ListFilter. Argument values are not
+// included; the query refers to ArgCount placeholders $1..$ArgCount.
+type ListQuery struct {
+	SQL      string
+	Where    string
+	OrderBy  string
+	ArgCount int
+}
+
+// TodoRef identifies a todo revision without its content
+type TodoRef struct {
+	ID        string
+	Version   int64
+	UpdatedAt time.Time
 }
 
 // PageResult contains paginated results
@@ -0,0 +1,182 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// OutboxRepository is a repository that also relays its outbox
+type OutboxRepository interface {
+	domain.Repository
+	ProcessOutbox(ctx context.Context, limit int, fn func(ctx context.Context, msg *domain.OutboxMessage) error) (int, error)
+}
+
+// RunOutboxTests runs the outbox conformance suite against the repositories
+// newRepo returns. Each case gets its own repository, whose outbox must start
+// empty.
+func RunOutboxTests(t *testing.T, newRepo func() OutboxRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo OutboxRepository)
+	}{
+		{"attempt counting", testOutboxAttempts},
+		{"concurrent workers", testOutboxConcurrentWorkers},
+		{"poison message", testOutboxPoisonMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo())
+		})
+	}
+}
+
+var errPublish = errors.New("publish failed")
+
+func testOutboxAttempts(t *testing.T, repo OutboxRepository) {
+	ctx := context.Background()
+	todo := create(t, repo, newTodo(t, "relayed", tenantA, "alice"))
+
+	// Each failed delivery is counted, and the next one sees the count
+	for want := range 3 {
+		sent, err := repo.ProcessOutbox(ctx, 10, func(ctx context.Context, msg *domain.OutboxMessage) error {
+			if msg.TodoID != todo.ID || msg.Name != domain.EventTodoCreated {
+				t.Fatalf("unexpected message %s for %s", msg.Name, msg.TodoID)
+			}
+			if msg.Attempts != want {
+				t.Fatalf("expected %d previous attempts, got %d", want, msg.Attempts)
+			}
+			return errPublish
+		})
+		if err != nil || sent != 0 {
+			t.Fatalf("expected a failed delivery to send nothing, got %d, %v", sent, err)
+		}
+	}
+
+	sent, err := repo.ProcessOutbox(ctx, 10, func(ctx context.Context, msg *domain.OutboxMessage) error {
+		if msg.Attempts != 3 {
+			t.Fatalf("expected 3 previous attempts, got %d", msg.Attempts)
+		}
+		return nil
+	})
+	if err != nil || sent != 1 {
+		t.Fatalf("expected the message to be sent, got %d, %v", sent, err)
+	}
+
+	sent, err = repo.ProcessOutbox(ctx, 10, func(ctx context.Context, msg *domain.OutboxMessage) error {
+		t.Fatalf("expected a sent message not to be delivered again, got %s", msg.Name)
+		return nil
+	})
+	if err != nil || sent != 0 {
+		t.Fatalf("expected nothing left to send, got %d, %v", sent, err)
+	}
+}
+
+func testOutboxConcurrentWorkers(t *testing.T, repo OutboxRepository) {
+	const (
+		todos   = 40
+		workers = 4
+	)
+	for i := range todos {
+		create(t, repo, newTodo(t, fmt.Sprintf("todo %d", i), tenantA, "alice"))
+	}
+
+	var (
+		mu         sync.Mutex
+		deliveries = make(map[int64]int)
+	)
+	delivered := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(deliveries)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A worker finding every unsent row locked by the others gets an
+			// empty batch, so keep going until everything is delivered
+			for delivered() < todos && ctx.Err() == nil {
+				_, err := repo.ProcessOutbox(ctx, 3, func(ctx context.Context, msg *domain.OutboxMessage) error {
+					mu.Lock()
+					deliveries[msg.ID]++
+					mu.Unlock()
+					// Hold the batch so the workers overlap
+					time.Sleep(time.Millisecond)
+					return nil
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("ProcessOutbox failed: %v", err)
+	}
+	if n := delivered(); n != todos {
+		t.Fatalf("expected %d messages delivered, got %d", todos, n)
+	}
+	for id, n := range deliveries {
+		if n != 1 {
+			t.Fatalf("expected message %d delivered once, got %d", id, n)
+		}
+	}
+}
+
+func testOutboxPoisonMessage(t *testing.T, repo OutboxRepository) {
+	ctx := context.Background()
+	poison := create(t, repo, newTodo(t, "poison", tenantA, "alice"))
+	healthy := []*domain.Todo{
+		create(t, repo, newTodo(t, "first", tenantA, "alice")),
+		create(t, repo, newTodo(t, "second", tenantA, "alice")),
+	}
+
+	deliver := func(ctx context.Context, msg *domain.OutboxMessage) error {
+		if msg.TodoID == poison.ID {
+			return errPublish
+		}
+		return nil
+	}
+
+	// The failing message neither stops the ones queued after it nor is
+	// dropped; it is retried on every call
+	sent, err := repo.ProcessOutbox(ctx, 10, deliver)
+	if err != nil || sent != len(healthy) {
+		t.Fatalf("expected the %d healthy messages sent past the poison one, got %d, %v", len(healthy), sent, err)
+	}
+
+	for range 2 {
+		if sent, err := repo.ProcessOutbox(ctx, 10, deliver); err != nil || sent != 0 {
+			t.Fatalf("expected only the poison message left, got %d sent, %v", sent, err)
+		}
+	}
+
+	var seen []*domain.OutboxMessage
+	_, err = repo.ProcessOutbox(ctx, 10, func(ctx context.Context, msg *domain.OutboxMessage) error {
+		seen = append(seen, msg)
+		return errPublish
+	})
+	if err != nil {
+		t.Fatalf("ProcessOutbox failed: %v", err)
+	}
+	if len(seen) != 1 || seen[0].TodoID != poison.ID || seen[0].Attempts != 3 {
+		t.Fatalf("expected the poison message alone after 3 attempts, got %+v", seen)
+	}
+}
@@ -0,0 +1,1520 @@
+// Package repositorytest holds the conformance suite every domain.Repository
+// implementation must pass, so the in-memory and Postgres repositories
+// cannot drift apart.
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+)
+
+const (
+	tenantA = "tenant-a"
+	tenantB = "tenant-b"
+)
+
+// RunRepositoryTests runs the conformance suite against the repositories
+// newRepo returns. Each case gets its own repository, which must start empty.
+func RunRepositoryTests(t *testing.T, newRepo func() domain.Repository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo domain.Repository)
+	}{
+		{"create and get", testCreateAndGet},
+		{"version conflict", testVersionConflict},
+		{"completed by", testCompletedBy},
+		{"soft delete", testSoftDelete},
+		{"force delete", testForceDelete},
+		{"purge deleted", testPurgeDeleted},
+		{"purge archived", testPurgeArchived},
+		{"archive completed", testArchiveCompleted},
+		{"list filters", testListFilters},
+		{"list pagination", testListPagination},
+		{"list sort ties", testListSortTies},
+		{"list multi-column sort", testListMultiColumnSort},
+		{"list priority weights", testListPriorityWeights},
+		{"list refs", testListRefs},
+		{"batch create", testBatchCreate},
+		{"history", testHistory},
+		{"tenant isolation", testTenantIsolation},
+		{"count by status", testCountByStatus},
+		{"overdue filter", testOverdueFilter},
+		{"due before filter", testDueBeforeFilter},
+		{"count active", testCountActive},
+		{"get by ids", testGetByIDs},
+		{"upsert", testUpsert},
+		{"transfer ownership", testTransferOwnership},
+		{"effort", testEffort},
+		{"dependencies", testDependencies},
+		{"due reminders", testDueReminders},
+		{"due within", testDueWithin},
+		{"bulk tags", testBulkTags},
+		{"move to tenant", testMoveToTenant},
+		{"workload by assignee", testWorkloadByAssignee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo())
+		})
+	}
+}
+
+func newTodo(t *testing.T, title, tenantID, ownerID string, opts ...domain.TodoOption) *domain.Todo {
+	t.Helper()
+	todo, err := domain.NewTodo(title, "", ownerID, tenantID, domain.PriorityMedium, opts...)
+	if err != nil {
+		t.Fatalf("failed to build todo %q: %v", title, err)
+	}
+	return todo
+}
+
+func create(t *testing.T, repo domain.Repository, todo *domain.Todo) *domain.Todo {
+	t.Helper()
+	if err := repo.Create(context.Background(), todo); err != nil {
+		t.Fatalf("failed to create todo %q: %v", todo.Title, err)
+	}
+	return todo
+}
+
+// attach records an attachment on todo for each filename and returns their
+// storage keys
+func attach(t *testing.T, repo domain.Repository, todo *domain.Todo, filenames ...string) []string {
+	t.Helper()
+	var keys []string
+	for _, filename := range filenames {
+		key, err := domain.NewAttachmentKey(todo.TenantID, todo.ID, filename)
+		if err != nil {
+			t.Fatalf("NewAttachmentKey failed: %v", err)
+		}
+		err = repo.AddAttachment(context.Background(), &domain.Attachment{
+			ID:          domain.NewID(),
+			TodoID:      todo.ID,
+			TenantID:    todo.TenantID,
+			ObjectKey:   key,
+			Filename:    filename,
+			ContentType: "text/plain",
+			SizeBytes:   4,
+			UploadedBy:  todo.OwnerID,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatalf("AddAttachment failed: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// list returns the ids filter matches along with the reported total
+func list(t *testing.T, repo domain.Repository, filter domain.ListFilter) ([]string, int64) {
+	t.Helper()
+	if filter.Page == 0 {
+		filter.Page, filter.PageSize = 1, 50
+	}
+	todos, total, err := repo.List(context.Background(), &filter)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	ids := make([]string, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	return ids, total
+}
+
+func sameIDs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]bool, len(got))
+	for _, id := range got {
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func testCreateAndGet(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	due := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Microsecond)
+	assignee := "bob"
+	todo := create(t, repo, newTodo(t, "write tests", tenantA, "alice",
+		domain.WithTags([]string{"backend", "db"}),
+		domain.WithDueDate(&due),
+		domain.WithAssignee(&assignee),
+	))
+
+	got, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "write tests" || got.OwnerID != "alice" || got.Status != domain.StatusPending || got.Version != 1 {
+		t.Fatalf("unexpected todo: %+v", got)
+	}
+	if len(got.Tags) != 2 || got.Tags[0] != "backend" || got.Tags[1] != "db" {
+		t.Fatalf("expected tags [backend db], got %v", got.Tags)
+	}
+	if got.DueDate == nil || !got.DueDate.Equal(due) {
+		t.Fatalf("expected due date %v, got %v", due, got.DueDate)
+	}
+	if got.AssignedTo == nil || *got.AssignedTo != assignee {
+		t.Fatalf("expected assignee %s, got %v", assignee, got.AssignedTo)
+	}
+
+	if _, err := repo.GetByID(ctx, domain.NewID(), tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound for an unknown id, got %v", err)
+	}
+	if err := repo.Create(ctx, newTodo(t, "write tests", tenantA, "alice")); !errors.Is(err, domain.ErrDuplicateTitle) {
+		t.Fatalf("expected ErrDuplicateTitle for a second active todo with the title, got %v", err)
+	}
+}
+
+func testVersionConflict(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	todo := create(t, repo, newTodo(t, "draft", tenantA, "alice"))
+	stale := *todo
+
+	if err := todo.UpdateTitle("final"); err != nil {
+		t.Fatalf("UpdateTitle failed: %v", err)
+	}
+	if err := repo.Update(ctx, todo, 1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "final" || got.Version != 2 {
+		t.Fatalf("expected title final at version 2, got %q at %d", got.Title, got.Version)
+	}
+
+	if err := stale.UpdateTitle("lost update"); err != nil {
+		t.Fatalf("UpdateTitle failed: %v", err)
+	}
+	if err := repo.Update(ctx, &stale, 1); !errors.Is(err, domain.ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch updating a stale version, got %v", err)
+	}
+
+	completedBy := "alice"
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, todo.ID, tenantA, domain.StatusCompleted, &completedAt, &completedBy, 1); !errors.Is(err, domain.ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch changing status at a stale version, got %v", err)
+	}
+	updated, err := repo.UpdateStatus(ctx, todo.ID, tenantA, domain.StatusCompleted, &completedAt, &completedBy, 2)
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if updated.Status != domain.StatusCompleted || updated.Version != 3 {
+		t.Fatalf("expected completed at version 3, got %v at %d", updated.Status, updated.Version)
+	}
+}
+
+func testCompletedBy(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	todo := create(t, repo, newTodo(t, "finish", tenantA, "alice"))
+
+	completedBy := "bob"
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, todo.ID, tenantA, domain.StatusCompleted, &completedAt, &completedBy, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	got, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.CompletedBy == nil || *got.CompletedBy != "bob" {
+		t.Fatalf("expected the todo completed by bob, got %v", got.CompletedBy)
+	}
+	entries, err := repo.GetHistory(ctx, todo.ID, tenantA, 1)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if change, ok := entries[0].Changes["completed_by"]; !ok || jsonOf(t, change.New) != jsonOf(t, "bob") {
+		t.Fatalf("expected the completer recorded in history, got %v", entries[0].Changes)
+	}
+
+	// Reopening clears who completed it
+	reopened, err := repo.UpdateStatus(ctx, todo.ID, tenantA, domain.StatusPending, nil, nil, 2)
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if reopened.CompletedBy != nil || reopened.CompletedAt != nil {
+		t.Fatalf("expected the completion cleared, got %v at %v", reopened.CompletedBy, reopened.CompletedAt)
+	}
+}
+
+func testSoftDelete(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	kept := create(t, repo, newTodo(t, "kept", tenantA, "alice"))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, deleted.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound for a deleted todo, got %v", err)
+	}
+	got, err := repo.GetByIDIncludingDeleted(ctx, deleted.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByIDIncludingDeleted failed: %v", err)
+	}
+	if got.DeletedAt == nil {
+		t.Fatal("expected deleted_at to be set")
+	}
+	if got, err := repo.GetByIDIncludingDeleted(ctx, kept.ID, tenantA); err != nil || got.DeletedAt != nil {
+		t.Fatalf("expected the live todo without deleted_at, got %v, %v", got, err)
+	}
+	if _, err := repo.GetByIDIncludingDeleted(ctx, deleted.ID, tenantB); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound reading a deleted todo from another tenant, got %v", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound deleting twice, got %v", err)
+	}
+
+	if ids, total := list(t, repo, domain.ListFilter{TenantID: tenantA}); total != 1 || !sameIDs(ids, []string{kept.ID}) {
+		t.Fatalf("expected only the kept todo, got %v of %d", ids, total)
+	}
+	if ids, _ := list(t, repo, domain.ListFilter{TenantID: tenantA, IncludeDeleted: true}); !sameIDs(ids, []string{kept.ID, deleted.ID}) {
+		t.Fatalf("expected both todos when including deleted, got %v", ids)
+	}
+
+	// The title of a deleted todo is free again
+	create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+}
+
+func testForceDelete(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	todo := create(t, repo, newTodo(t, "erased", tenantA, "alice"))
+	bare := create(t, repo, newTodo(t, "bare", tenantA, "alice"))
+
+	want := attach(t, repo, todo, "a.txt", "b.txt")
+
+	if _, err := repo.ForceDelete(ctx, todo.ID, tenantB); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound force-deleting from another tenant, got %v", err)
+	}
+
+	keys, err := repo.ForceDelete(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("ForceDelete failed: %v", err)
+	}
+	if !sameIDs(keys, want) {
+		t.Fatalf("expected the removed attachment keys %v, got %v", want, keys)
+	}
+	if _, err := repo.GetByIDIncludingDeleted(ctx, todo.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the todo to be gone, got %v", err)
+	}
+	if attachments, err := repo.ListAttachments(ctx, todo.ID, tenantA); err != nil || len(attachments) != 0 {
+		t.Fatalf("expected no attachments left, got %v, %v", attachments, err)
+	}
+
+	// A soft-deleted todo may be erased too; without attachments there are no keys
+	if err := repo.Delete(ctx, bare.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if keys, err := repo.ForceDelete(ctx, bare.ID, tenantA); err != nil || len(keys) != 0 {
+		t.Fatalf("expected no keys force-deleting a todo without attachments, got %v, %v", keys, err)
+	}
+}
+
+func testPurgeDeleted(t *testing.T, repo domain.Repository) {
+	// Purging is a maintenance job spanning every tenant
+	ctx := domain.WithCrossTenant(context.Background())
+
+	stale := create(t, repo, newTodo(t, "stale", tenantA, "alice"))
+	other := create(t, repo, newTodo(t, "other tenant", tenantB, "bob"))
+	live := create(t, repo, newTodo(t, "live", tenantA, "alice"))
+	want := append(attach(t, repo, stale, "a.txt"), attach(t, repo, other, "b.txt", "c.txt")...)
+	attach(t, repo, live, "kept.txt")
+
+	for _, todo := range []*domain.Todo{stale, other} {
+		if err := repo.Delete(ctx, todo.ID, todo.TenantID); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+
+	if purged, keys, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour)); err != nil || purged != 0 || len(keys) != 0 {
+		t.Fatalf("expected nothing deleted before the cutoff to be purged, got %d, %v, %v", purged, keys, err)
+	}
+
+	purged, keys, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("PurgeDeleted failed: %v", err)
+	}
+	if purged != 2 {
+		t.Fatalf("expected 2 todos purged, got %d", purged)
+	}
+	if !sameIDs(keys, want) {
+		t.Fatalf("expected the purged attachment keys %v, got %v", want, keys)
+	}
+	if _, err := repo.GetByIDIncludingDeleted(ctx, stale.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the purged todo to be gone, got %v", err)
+	}
+	if attachments, err := repo.ListAttachments(ctx, live.ID, tenantA); err != nil || len(attachments) != 1 {
+		t.Fatalf("expected the live todo to keep its attachment, got %v, %v", attachments, err)
+	}
+}
+
+func testPurgeArchived(t *testing.T, repo domain.Repository) {
+	// Purging is a maintenance job spanning every tenant
+	ctx := domain.WithCrossTenant(context.Background())
+
+	archived := func(title, tenantID string, age time.Duration) *domain.Todo {
+		todo := newTodo(t, title, tenantID, "alice")
+		archivedAt := time.Now().UTC().Add(-age)
+		todo.Status = domain.StatusArchived
+		todo.ArchivedAt = &archivedAt
+		return create(t, repo, todo)
+	}
+	old := archived("old", tenantA, 48*time.Hour)
+	other := archived("other tenant", tenantB, 48*time.Hour)
+	recent := archived("recent", tenantA, time.Minute)
+	open := create(t, repo, newTodo(t, "open", tenantA, "alice"))
+	want := append(attach(t, repo, old, "a.txt"), attach(t, repo, other, "b.txt")...)
+	attach(t, repo, recent, "recent.txt")
+	attach(t, repo, open, "open.txt")
+
+	purged, keys, err := repo.PurgeArchived(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeArchived failed: %v", err)
+	}
+	if purged != 2 {
+		t.Fatalf("expected 2 todos purged, got %d", purged)
+	}
+	if !sameIDs(keys, want) {
+		t.Fatalf("expected the purged attachment keys %v, got %v", want, keys)
+	}
+	if _, err := repo.GetByIDIncludingDeleted(ctx, old.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the purged todo to be gone, got %v", err)
+	}
+	for _, todo := range []*domain.Todo{recent, open} {
+		if _, err := repo.GetByID(ctx, todo.ID, tenantA); err != nil {
+			t.Fatalf("expected %q to survive the purge, got %v", todo.Title, err)
+		}
+	}
+}
+
+func testArchiveCompleted(t *testing.T, repo domain.Repository) {
+	// Archiving is a maintenance job spanning every tenant
+	ctx := domain.WithCrossTenant(context.Background())
+
+	completed := func(title, tenantID string, age time.Duration) *domain.Todo {
+		todo := newTodo(t, title, tenantID, "alice")
+		completedAt := time.Now().UTC().Add(-age)
+		todo.Status = domain.StatusCompleted
+		todo.CompletedAt = &completedAt
+		return create(t, repo, todo)
+	}
+	old := completed("old", tenantA, 48*time.Hour)
+	other := completed("other tenant", tenantB, 48*time.Hour)
+	recent := completed("recent", tenantA, time.Minute)
+	open := create(t, repo, newTodo(t, "open", tenantA, "alice"))
+	deleted := completed("deleted", tenantA, 48*time.Hour)
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	archived, err := repo.ArchiveCompletedOlderThan(ctx, tenantA, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("ArchiveCompletedOlderThan failed: %v", err)
+	}
+	if archived != 1 {
+		t.Fatalf("expected 1 todo archived in %s, got %d", tenantA, archived)
+	}
+
+	got, err := repo.GetByID(ctx, old.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Status != domain.StatusArchived || got.ArchivedAt == nil || got.Version != old.Version+1 {
+		t.Fatalf("expected old archived at version %d, got %v at %d", old.Version+1, got.Status, got.Version)
+	}
+	entries, err := repo.GetHistory(ctx, old.ID, tenantA, 1)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ChangeType != domain.ChangeStatusChanged {
+		t.Fatalf("expected the archival recorded in history, got %d entries", len(entries))
+	}
+	status := entries[0].Changes["status"]
+	if jsonOf(t, status.Old) != jsonOf(t, domain.StatusCompleted) || jsonOf(t, status.New) != jsonOf(t, domain.StatusArchived) {
+		t.Fatalf("expected a completed to archived change, got %v", entries[0].Changes)
+	}
+
+	for _, todo := range []*domain.Todo{recent, open} {
+		got, err := repo.GetByID(ctx, todo.ID, tenantA)
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		if got.Status == domain.StatusArchived {
+			t.Fatalf("expected %q left alone", todo.Title)
+		}
+	}
+
+	// An empty tenant sweeps the rest
+	archived, err = repo.ArchiveCompletedOlderThan(ctx, "", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("ArchiveCompletedOlderThan failed: %v", err)
+	}
+	if archived != 1 {
+		t.Fatalf("expected only the other tenant's todo archived, got %d", archived)
+	}
+	if got, err := repo.GetByID(ctx, other.ID, tenantB); err != nil || got.Status != domain.StatusArchived {
+		t.Fatalf("expected the other tenant's todo archived, got %v", err)
+	}
+}
+
+func testListFilters(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	bob := "bob"
+	backend := create(t, repo, newTodo(t, "backend", tenantA, "alice", domain.WithTags([]string{"backend"})))
+	urgent := create(t, repo, newTodo(t, "urgent", tenantA, "alice", domain.WithTags([]string{"backend", "urgent"}), domain.WithAssignee(&bob)))
+	frontend := create(t, repo, newTodo(t, "frontend", tenantA, "bob", domain.WithTags([]string{"frontend"})))
+	done := create(t, repo, newTodo(t, "done", tenantA, "carol"))
+
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, done.ID, tenantA, domain.StatusCompleted, &completedAt, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.ListFilter
+		want   []string
+	}{
+		{
+			name:   "whole tenant",
+			filter: domain.ListFilter{},
+			want:   []string{backend.ID, urgent.ID, frontend.ID, done.ID},
+		},
+		{
+			name:   "status",
+			filter: domain.ListFilter{Statuses: []domain.TodoStatus{domain.StatusCompleted}},
+			want:   []string{done.ID},
+		},
+		{
+			name:   "any of the tags",
+			filter: domain.ListFilter{Tags: []string{"urgent", "frontend"}},
+			want:   []string{urgent.ID, frontend.ID},
+		},
+		{
+			name:   "excluding a tag",
+			filter: domain.ListFilter{Tags: []string{"backend"}, ExcludeTags: []string{"urgent"}},
+			want:   []string{backend.ID},
+		},
+		{
+			name:   "owner",
+			filter: domain.ListFilter{OwnerID: &bob},
+			want:   []string{frontend.ID},
+		},
+		{
+			name:   "assignee",
+			filter: domain.ListFilter{AssignedTo: &bob},
+			want:   []string{urgent.ID},
+		},
+		{
+			name:   "visible to owner or assignee",
+			filter: domain.ListFilter{VisibleTo: &bob},
+			want:   []string{urgent.ID, frontend.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			filter.TenantID = tenantA
+			ids, total := list(t, repo, filter)
+			if int(total) != len(tt.want) || !sameIDs(ids, tt.want) {
+				t.Fatalf("expected %v, got %v of %d", tt.want, ids, total)
+			}
+		})
+	}
+}
+
+func testListPagination(t *testing.T, repo domain.Repository) {
+	var want []string
+	for i := range 5 {
+		want = append(want, create(t, repo, newTodo(t, fmt.Sprintf("todo %d", i), tenantA, "alice")).ID)
+		time.Sleep(time.Millisecond) // keep created_at distinct for a stable order
+	}
+
+	var got []string
+	for page, size := range []int{2, 2, 1, 0} {
+		ids, total := list(t, repo, domain.ListFilter{
+			TenantID:      tenantA,
+			Page:          page + 1,
+			PageSize:      2,
+			SortBy:        "created_at",
+			SortAscending: true,
+		})
+		if total != 5 || len(ids) != size {
+			t.Fatalf("expected %d of 5 todos on page %d, got %d of %d", size, page+1, len(ids), total)
+		}
+		got = append(got, ids...)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected todos in creation order across pages, got %v", got)
+		}
+	}
+}
+
+func testListSortTies(t *testing.T, repo domain.Repository) {
+	var want []string
+	for i := range 5 {
+		want = append(want, create(t, repo, newTodo(t, fmt.Sprintf("todo %d", i), tenantA, "alice")).ID)
+	}
+	// Every todo shares the sort value, so id alone decides the order
+	slices.Sort(want)
+
+	for _, ascending := range []bool{true, false} {
+		var got []string
+		for page := 1; page <= 3; page++ {
+			ids, _ := list(t, repo, domain.ListFilter{
+				TenantID:      tenantA,
+				Page:          page,
+				PageSize:      2,
+				SortBy:        "priority",
+				SortAscending: ascending,
+			})
+			got = append(got, ids...)
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("expected ties in id order across pages (ascending=%v), got %v, want %v", ascending, got, want)
+		}
+	}
+}
+
+func testListMultiColumnSort(t *testing.T, repo domain.Repository) {
+	soon := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Microsecond)
+	later := soon.Add(24 * time.Hour)
+	build := func(title string, priority domain.TodoPriority, due *time.Time) string {
+		todo := newTodo(t, title, tenantA, "alice", domain.WithDueDate(due))
+		todo.Priority = priority
+		return create(t, repo, todo).ID
+	}
+	highLater := build("high later", domain.PriorityHigh, &later)
+	lowSoon := build("low soon", domain.PriorityLow, &soon)
+	highSoon := build("high soon", domain.PriorityHigh, &soon)
+	lowLater := build("low later", domain.PriorityLow, &later)
+
+	// Sort wins over SortBy
+	ids, _ := list(t, repo, domain.ListFilter{
+		TenantID: tenantA,
+		SortBy:   "title",
+		Sort: []domain.SortSpec{
+			{Field: "priority"},
+			{Field: "due_date", Ascending: true},
+		},
+	})
+	if want := []string{highSoon, highLater, lowSoon, lowLater}; !slices.Equal(ids, want) {
+		t.Fatalf("expected priority then due date order %v, got %v", want, ids)
+	}
+}
+
+func testListRefs(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	edited := create(t, repo, newTodo(t, "edited", tenantA, "alice"))
+	create(t, repo, newTodo(t, "untouched", tenantA, "alice"))
+	create(t, repo, newTodo(t, "someone else's", tenantA, "bob"))
+	create(t, repo, newTodo(t, "other tenant", tenantB, "alice"))
+
+	if err := edited.UpdateTitle("edited twice"); err != nil {
+		t.Fatalf("UpdateTitle failed: %v", err)
+	}
+	if err := repo.Update(ctx, edited, 1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// Refs match List on the same filter, row for row
+	owner := "alice"
+	filter := domain.ListFilter{TenantID: tenantA, OwnerID: &owner, Page: 1, PageSize: 50, SortBy: "title", SortAscending: true}
+	todos, total, err := repo.List(ctx, &filter)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	refs, refTotal, err := repo.ListRefs(ctx, &filter)
+	if err != nil {
+		t.Fatalf("ListRefs failed: %v", err)
+	}
+	if total != 2 || refTotal != total || len(refs) != len(todos) {
+		t.Fatalf("expected 2 refs like List, got %d of %d against %d of %d", len(refs), refTotal, len(todos), total)
+	}
+	for i, todo := range todos {
+		ref := refs[i]
+		if ref.ID != todo.ID || ref.Version != todo.Version || !ref.UpdatedAt.Equal(todo.UpdatedAt) {
+			t.Fatalf("ref %d = %+v, want %s at version %d updated %v", i, ref, todo.ID, todo.Version, todo.UpdatedAt)
+		}
+	}
+	if refs[0].ID != edited.ID || refs[0].Version != 2 {
+		t.Fatalf("expected the edited todo first at version 2, got %+v", refs[0])
+	}
+
+	filter.PageSize, filter.Page = 1, 2
+	if refs, total, err := repo.ListRefs(ctx, &filter); err != nil || total != 2 || len(refs) != 1 || refs[0].ID == edited.ID {
+		t.Fatalf("expected the second ref on page 2, got %v of %d, %v", refs, total, err)
+	}
+}
+
+func testBatchCreate(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	todos := []*domain.Todo{
+		newTodo(t, "first", tenantA, "alice"),
+		newTodo(t, "second", tenantA, "alice"),
+		newTodo(t, "third", tenantA, "alice"),
+	}
+	if err := repo.BatchCreate(ctx, todos); err != nil {
+		t.Fatalf("BatchCreate failed: %v", err)
+	}
+	for _, todo := range todos {
+		if _, err := repo.GetByID(ctx, todo.ID, tenantA); err != nil {
+			t.Fatalf("expected batch-created todo %q, got %v", todo.Title, err)
+		}
+	}
+
+	// A duplicate title rolls back the whole batch
+	failed := []*domain.Todo{newTodo(t, "fourth", tenantA, "alice"), newTodo(t, "first", tenantA, "alice")}
+	if err := repo.BatchCreate(ctx, failed); !errors.Is(err, domain.ErrDuplicateTitle) {
+		t.Fatalf("expected ErrDuplicateTitle for a title already taken, got %v", err)
+	}
+	within := []*domain.Todo{newTodo(t, "fifth", tenantA, "alice"), newTodo(t, "fifth", tenantA, "alice")}
+	if err := repo.BatchCreate(ctx, within); !errors.Is(err, domain.ErrDuplicateTitle) {
+		t.Fatalf("expected ErrDuplicateTitle for a title repeated within the batch, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, failed[0].ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the failed batch to leave nothing behind, got %v", err)
+	}
+	if _, total := list(t, repo, domain.ListFilter{TenantID: tenantA}); total != 3 {
+		t.Fatalf("expected 3 todos, got %d", total)
+	}
+}
+
+func testHistory(t *testing.T, repo domain.Repository) {
+	ctx := auth.ContextWithUserContext(context.Background(), &auth.UserContext{UserID: "alice", TenantID: tenantA})
+	todo := newTodo(t, "draft", tenantA, "alice")
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := todo.UpdateTitle("final"); err != nil {
+		t.Fatalf("UpdateTitle failed: %v", err)
+	}
+	if err := todo.UpdatePriority(domain.PriorityHigh); err != nil {
+		t.Fatalf("UpdatePriority failed: %v", err)
+	}
+	if err := repo.Update(ctx, todo, 1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, todo.ID, tenantA, domain.StatusCompleted, &completedAt, nil, 2); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	entries, err := repo.GetHistory(ctx, todo.ID, tenantA, 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+
+	// Newest first; values are compared as JSON since a stored diff decodes
+	// into plain JSON types
+	want := []struct {
+		changeType domain.ChangeType
+		changes    map[string]domain.FieldChange
+	}{
+		{domain.ChangeStatusChanged, map[string]domain.FieldChange{
+			"status": {Old: domain.StatusPending, New: domain.StatusCompleted},
+		}},
+		{domain.ChangeUpdated, map[string]domain.FieldChange{
+			"title":    {Old: "draft", New: "final"},
+			"priority": {Old: domain.PriorityMedium, New: domain.PriorityHigh},
+		}},
+		{domain.ChangeCreated, map[string]domain.FieldChange{
+			"title": {Old: "", New: "draft"},
+		}},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d history entries, got %d", len(want), len(entries))
+	}
+	for i, w := range want {
+		entry := entries[i]
+		if entry.ChangeType != w.changeType || entry.ActorID != "alice" || entry.TodoID != todo.ID || entry.TenantID != tenantA {
+			t.Fatalf("entry %d: expected %s by alice on %s, got %s by %s on %s",
+				i, w.changeType, todo.ID, entry.ChangeType, entry.ActorID, entry.TodoID)
+		}
+		for field, change := range w.changes {
+			got, ok := entry.Changes[field]
+			if !ok {
+				t.Fatalf("entry %d: expected a %s change, got %v", i, field, entry.Changes)
+			}
+			if jsonOf(t, got.Old) != jsonOf(t, change.Old) || jsonOf(t, got.New) != jsonOf(t, change.New) {
+				t.Fatalf("entry %d: expected %s to change from %s to %s, got %s to %s", i, field,
+					jsonOf(t, change.Old), jsonOf(t, change.New), jsonOf(t, got.Old), jsonOf(t, got.New))
+			}
+		}
+	}
+	if _, ok := entries[1].Changes["status"]; ok {
+		t.Fatalf("expected the edit to leave status out of its diff, got %v", entries[1].Changes)
+	}
+
+	if entries, err := repo.GetHistory(ctx, todo.ID, tenantB, 10); err != nil || len(entries) != 0 {
+		t.Fatalf("expected no history from another tenant, got %v, %v", entries, err)
+	}
+}
+
+func jsonOf(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %v: %v", v, err)
+	}
+	return string(b)
+}
+
+func testTenantIsolation(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	ours := create(t, repo, newTodo(t, "shared title", tenantA, "alice"))
+	theirs := create(t, repo, newTodo(t, "shared title", tenantB, "alice"))
+
+	if _, err := repo.GetByID(ctx, theirs.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound reading another tenant's todo, got %v", err)
+	}
+	if ids, total := list(t, repo, domain.ListFilter{TenantID: tenantA}); total != 1 || !sameIDs(ids, []string{ours.ID}) {
+		t.Fatalf("expected only our todo, got %v of %d", ids, total)
+	}
+
+	if err := theirs.UpdateTitle("hijacked"); err != nil {
+		t.Fatalf("UpdateTitle failed: %v", err)
+	}
+	theirs.TenantID = tenantA
+	if err := repo.Update(ctx, theirs, 1); err == nil {
+		t.Fatal("expected updating another tenant's todo to fail")
+	}
+	if err := repo.Delete(ctx, theirs.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound deleting another tenant's todo, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, theirs.ID, tenantB)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "shared title" || got.Version != 1 {
+		t.Fatalf("expected the other tenant's todo untouched, got %q at %d", got.Title, got.Version)
+	}
+}
+
+func testCountByStatus(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	bob := "bob"
+	create(t, repo, newTodo(t, "pending", tenantA, "alice"))
+	started := create(t, repo, newTodo(t, "started", tenantA, "alice"))
+	done := create(t, repo, newTodo(t, "done", tenantA, "bob"))
+	create(t, repo, newTodo(t, "assigned", tenantA, "carol", domain.WithAssignee(&bob)))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+	create(t, repo, newTodo(t, "other tenant", tenantB, "alice"))
+
+	if _, err := repo.UpdateStatus(ctx, started.ID, tenantA, domain.StatusInProgress, nil, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, done.ID, tenantA, domain.StatusCompleted, &completedAt, &bob, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.ListFilter
+		want   map[domain.TodoStatus]int64
+	}{
+		{
+			name:   "whole tenant",
+			filter: domain.ListFilter{},
+			want:   map[domain.TodoStatus]int64{domain.StatusPending: 2, domain.StatusInProgress: 1, domain.StatusCompleted: 1},
+		},
+		{
+			name:   "visible to owner or assignee",
+			filter: domain.ListFilter{VisibleTo: &bob},
+			want:   map[domain.TodoStatus]int64{domain.StatusPending: 1, domain.StatusCompleted: 1},
+		},
+		{
+			name:   "status",
+			filter: domain.ListFilter{Statuses: []domain.TodoStatus{domain.StatusPending}},
+			want:   map[domain.TodoStatus]int64{domain.StatusPending: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			filter.TenantID = tenantA
+			counts, err := repo.CountByStatus(ctx, &filter)
+			if err != nil {
+				t.Fatalf("CountByStatus failed: %v", err)
+			}
+			// Statuses without todos may be reported as zero or left out
+			for _, st := range []domain.TodoStatus{domain.StatusPending, domain.StatusInProgress, domain.StatusCompleted, domain.StatusArchived} {
+				if counts[st] != tt.want[st] {
+					t.Fatalf("expected counts %v, got %v", tt.want, counts)
+				}
+			}
+		})
+	}
+}
+
+func testOverdueFilter(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	past := time.Now().UTC().Add(-time.Hour)
+	future := time.Now().UTC().Add(time.Hour)
+
+	// Past due dates are rejected on creation, so set them afterwards
+	pastDue := func(title string) *domain.Todo {
+		todo := newTodo(t, title, tenantA, "alice")
+		todo.DueDate = &past
+		return create(t, repo, todo)
+	}
+	overdue := pastDue("overdue")
+	started := pastDue("started")
+	done := pastDue("done")
+	create(t, repo, newTodo(t, "due later", tenantA, "alice", domain.WithDueDate(&future)))
+	create(t, repo, newTodo(t, "no due date", tenantA, "alice"))
+
+	if _, err := repo.UpdateStatus(ctx, started.ID, tenantA, domain.StatusInProgress, nil, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, done.ID, tenantA, domain.StatusCompleted, &completedAt, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	want := []string{overdue.ID, started.ID}
+	ids, total := list(t, repo, domain.ListFilter{TenantID: tenantA, OverdueOnly: true})
+	if int(total) != len(want) || !sameIDs(ids, want) {
+		t.Fatalf("expected overdue %v, got %v of %d", want, ids, total)
+	}
+}
+
+func testCountActive(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	create(t, repo, newTodo(t, "open", tenantA, "alice"))
+	done := create(t, repo, newTodo(t, "done", tenantA, "alice"))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+	create(t, repo, newTodo(t, "other tenant", tenantB, "alice"))
+
+	// Completed todos still count against the quota; deleted ones do not
+	completedAt := time.Now().UTC()
+	if _, err := repo.UpdateStatus(ctx, done.ID, tenantA, domain.StatusCompleted, &completedAt, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	count, err := repo.CountActive(ctx, tenantA)
+	if err != nil {
+		t.Fatalf("CountActive failed: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 active todos, got %d", count)
+	}
+}
+
+func testGetByIDs(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	first := create(t, repo, newTodo(t, "first", tenantA, "alice"))
+	second := create(t, repo, newTodo(t, "second", tenantA, "alice"))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+	foreign := create(t, repo, newTodo(t, "foreign", tenantB, "alice"))
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// Input order is kept; missing, deleted and other tenants' todos are skipped
+	ids := []string{second.ID, domain.NewID(), deleted.ID, foreign.ID, first.ID}
+	todos, err := repo.GetByIDs(ctx, ids, tenantA)
+	if err != nil {
+		t.Fatalf("GetByIDs failed: %v", err)
+	}
+	got := make([]string, len(todos))
+	for i, todo := range todos {
+		got[i] = todo.ID
+	}
+	if want := []string{second.ID, first.ID}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if todos, err := repo.GetByIDs(ctx, nil, tenantA); err != nil || len(todos) != 0 {
+		t.Fatalf("expected nothing for no ids, got %d, %v", len(todos), err)
+	}
+}
+
+func testUpsert(t *testing.T, repo domain.Repository) {
+	ctx := auth.ContextWithUserContext(context.Background(), &auth.UserContext{UserID: "alice", TenantID: tenantA})
+	todo := newTodo(t, "synced", tenantA, "alice")
+
+	created, err := repo.Upsert(ctx, todo)
+	if err != nil || !created {
+		t.Fatalf("expected the first upsert to create, got %v, %v", created, err)
+	}
+
+	// The replacement keeps the stored owner
+	replacement, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	replacement.Title = "synced again"
+	replacement.OwnerID = "mallory"
+	replacement.Version = 2
+	created, err = repo.Upsert(ctx, replacement)
+	if err != nil || created {
+		t.Fatalf("expected the second upsert to replace, got %v, %v", created, err)
+	}
+	got, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "synced again" || got.Version != 2 || got.OwnerID != "alice" {
+		t.Fatalf("expected synced again at version 2 owned by alice, got %q at %d owned by %s", got.Title, got.Version, got.OwnerID)
+	}
+
+	// Replaying the same version, or skipping one, does not overwrite
+	for _, version := range []int64{2, 4} {
+		stale := *got
+		stale.Title = "lost update"
+		stale.Version = version
+		if _, err := repo.Upsert(ctx, &stale); !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Fatalf("expected ErrVersionMismatch upserting version %d, got %v", version, err)
+		}
+	}
+
+	foreign := *got
+	foreign.TenantID = tenantB
+	foreign.Title = "foreign"
+	foreign.Version = 3
+	if _, err := repo.Upsert(ctx, &foreign); err == nil {
+		t.Fatal("expected another tenant's upsert of the id to fail")
+	}
+
+	entries, err := repo.GetHistory(ctx, todo.ID, tenantA, 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	var changes []domain.ChangeType
+	for _, entry := range entries {
+		changes = append(changes, entry.ChangeType)
+	}
+	if want := []domain.ChangeType{domain.ChangeUpdated, domain.ChangeCreated}; !slices.Equal(changes, want) {
+		t.Fatalf("expected history %v, got %v", want, changes)
+	}
+
+	if err := repo.Delete(ctx, todo.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	deleted := *got
+	deleted.Version = 3
+	if _, err := repo.Upsert(ctx, &deleted); !errors.Is(err, domain.ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch upserting a deleted todo, got %v", err)
+	}
+}
+
+func testTransferOwnership(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	todo := create(t, repo, newTodo(t, "handed over", tenantA, "alice"))
+
+	if err := todo.TransferOwnership("bob"); err != nil {
+		t.Fatalf("TransferOwnership failed: %v", err)
+	}
+	if err := repo.Update(ctx, todo, 1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.OwnerID != "bob" || got.Version != 2 {
+		t.Fatalf("expected bob to own the todo at version 2, got %s at %d", got.OwnerID, got.Version)
+	}
+
+	bob := "bob"
+	if ids, _ := list(t, repo, domain.ListFilter{TenantID: tenantA, OwnerID: &bob}); !sameIDs(ids, []string{todo.ID}) {
+		t.Fatalf("expected the todo listed under its new owner, got %v", ids)
+	}
+}
+
+func testEffort(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	estimate := int32(90)
+	todo := create(t, repo, newTodo(t, "estimated", tenantA, "alice", domain.WithEstimate(&estimate)))
+
+	got, err := repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.EstimatedMinutes == nil || *got.EstimatedMinutes != 90 || got.LoggedMinutes != 0 {
+		t.Fatalf("expected a 90 minute estimate with nothing logged, got %v and %d", got.EstimatedMinutes, got.LoggedMinutes)
+	}
+
+	if err := got.LogTime(45); err != nil {
+		t.Fatalf("LogTime failed: %v", err)
+	}
+	if err := repo.Update(ctx, got, 1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := got.SetEstimate(nil); err != nil {
+		t.Fatalf("SetEstimate failed: %v", err)
+	}
+	if err := repo.Update(ctx, got, 2); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, err = repo.GetByID(ctx, todo.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.EstimatedMinutes != nil || got.LoggedMinutes != 45 {
+		t.Fatalf("expected the estimate cleared with 45 minutes logged, got %v and %d", got.EstimatedMinutes, got.LoggedMinutes)
+	}
+}
+
+func testDependencies(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	design := create(t, repo, newTodo(t, "design", tenantA, "alice"))
+	build := create(t, repo, newTodo(t, "build", tenantA, "alice"))
+	ship := create(t, repo, newTodo(t, "ship", tenantA, "alice"))
+	foreign := create(t, repo, newTodo(t, "foreign", tenantB, "alice"))
+
+	depend := func(todo, dependsOn *domain.Todo) error {
+		dep, err := domain.NewDependency(todo.ID, dependsOn.ID, tenantA, "alice")
+		if err != nil {
+			t.Fatalf("NewDependency failed: %v", err)
+		}
+		return repo.AddDependency(ctx, dep)
+	}
+	idsOf := func(todos []*domain.Todo) []string {
+		ids := make([]string, len(todos))
+		for i, todo := range todos {
+			ids[i] = todo.ID
+		}
+		return ids
+	}
+
+	// ship waits on build, which waits on design; adding an edge twice is a no-op
+	for _, edge := range [][2]*domain.Todo{{build, design}, {ship, build}, {ship, build}} {
+		if err := depend(edge[0], edge[1]); err != nil {
+			t.Fatalf("AddDependency(%s, %s) failed: %v", edge[0].Title, edge[1].Title, err)
+		}
+	}
+
+	if err := depend(design, ship); !errors.Is(err, domain.ErrDependencyCycle) {
+		t.Fatalf("expected ErrDependencyCycle closing a loop, got %v", err)
+	}
+	if err := depend(build, foreign); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected ErrTodoNotFound depending on another tenant's todo, got %v", err)
+	}
+
+	deps, err := repo.ListDependencies(ctx, build.ID, tenantA)
+	if err != nil {
+		t.Fatalf("ListDependencies failed: %v", err)
+	}
+	if !sameIDs(idsOf(deps.BlockedBy), []string{design.ID}) || !sameIDs(idsOf(deps.Blocks), []string{ship.ID}) {
+		t.Fatalf("expected build blocked by design and blocking ship, got %v and %v", idsOf(deps.BlockedBy), idsOf(deps.Blocks))
+	}
+	if deps, err := repo.ListDependencies(ctx, build.ID, tenantB); err != nil || len(deps.BlockedBy)+len(deps.Blocks) != 0 {
+		t.Fatalf("expected no dependencies across tenants, got %+v, %v", deps, err)
+	}
+
+	if err := repo.RemoveDependency(ctx, ship.ID, build.ID, tenantA); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if err := repo.RemoveDependency(ctx, ship.ID, build.ID, tenantA); !errors.Is(err, domain.ErrDependencyNotFound) {
+		t.Fatalf("expected ErrDependencyNotFound removing it again, got %v", err)
+	}
+	if err := repo.RemoveDependency(ctx, build.ID, design.ID, tenantB); !errors.Is(err, domain.ErrDependencyNotFound) {
+		t.Fatalf("expected ErrDependencyNotFound removing from another tenant, got %v", err)
+	}
+
+	// With the loop gone, design may now wait on ship
+	if err := depend(design, ship); err != nil {
+		t.Fatalf("AddDependency after removal failed: %v", err)
+	}
+}
+
+func testDueReminders(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	remind := func(d time.Duration) domain.TodoOption {
+		at := now.Add(d)
+		return domain.WithReminder(&at)
+	}
+
+	missed := create(t, repo, newTodo(t, "missed", tenantA, "alice", remind(-time.Hour)))
+	soon := create(t, repo, newTodo(t, "soon", tenantB, "bob", remind(30*time.Second)))
+	create(t, repo, newTodo(t, "later", tenantA, "alice", remind(time.Hour)))
+	create(t, repo, newTodo(t, "no reminder", tenantA, "alice"))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice", remind(-time.Minute)))
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	completed := create(t, repo, newTodo(t, "completed", tenantA, "alice", remind(-time.Minute)))
+	if _, err := repo.UpdateStatus(ctx, completed.ID, tenantA, domain.StatusCompleted, &now, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	due := func() []string {
+		t.Helper()
+		todos, err := repo.DueReminders(ctx, time.Minute)
+		if err != nil {
+			t.Fatalf("DueReminders failed: %v", err)
+		}
+		ids := make([]string, len(todos))
+		for i, todo := range todos {
+			if todo.RemindedAt == nil {
+				t.Errorf("reminded todo %q has no RemindedAt", todo.Title)
+			}
+			ids[i] = todo.ID
+		}
+		return ids
+	}
+
+	// Reminders of every tenant are claimed once
+	if ids := due(); !sameIDs(ids, []string{missed.ID, soon.ID}) {
+		t.Fatalf("expected the missed and upcoming reminders, got %v", ids)
+	}
+	if ids := due(); len(ids) != 0 {
+		t.Fatalf("expected sent reminders not to be claimed again, got %v", ids)
+	}
+
+	// Moving the reminder re-arms it
+	got, err := repo.GetByID(ctx, missed.ID, tenantA)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.RemindedAt == nil {
+		t.Fatal("expected the sent reminder recorded on the todo")
+	}
+	at := now.Add(-time.Minute)
+	got.SetReminder(&at)
+	if err := repo.Update(ctx, got, got.Version-1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if ids := due(); !sameIDs(ids, []string{missed.ID}) {
+		t.Fatalf("expected the re-armed reminder, got %v", ids)
+	}
+}
+
+func testDueWithin(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	due := func(d time.Duration) domain.TodoOption {
+		at := now.Add(d)
+		return domain.WithDueDate(&at)
+	}
+
+	later := create(t, repo, newTodo(t, "later", tenantA, "alice", due(2*time.Hour)))
+	soon := create(t, repo, newTodo(t, "soon", tenantA, "bob", due(time.Hour)))
+	create(t, repo, newTodo(t, "next month", tenantA, "alice", due(30*24*time.Hour)))
+	create(t, repo, newTodo(t, "no due date", tenantA, "alice"))
+	create(t, repo, newTodo(t, "other tenant", tenantB, "alice", due(time.Hour)))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice", due(time.Hour)))
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	completed := create(t, repo, newTodo(t, "completed", tenantA, "alice", due(time.Hour)))
+	if _, err := repo.UpdateStatus(ctx, completed.ID, tenantA, domain.StatusCompleted, &now, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	// Past due dates are rejected on creation, so set them afterwards. A todo
+	// due earlier today in a zone is open until the day ends there.
+	past := now.Add(-time.Hour)
+	overdue := newTodo(t, "overdue", tenantA, "alice")
+	overdue.DueDate = &past
+	create(t, repo, overdue)
+	midnight := now.Truncate(24 * time.Hour)
+	utc := "UTC"
+	today := newTodo(t, "today", tenantA, "alice", domain.WithDueDateTimezone(&utc))
+	today.DueDate = &midnight
+	today = create(t, repo, today)
+
+	todos, err := repo.DueWithin(ctx, tenantA, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("DueWithin failed: %v", err)
+	}
+	ids := make([]string, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	if want := []string{today.ID, soon.ID, later.ID}; !slices.Equal(ids, want) {
+		t.Fatalf("expected todos due soonest first %v, got %v", want, ids)
+	}
+}
+
+func testBulkTags(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	plain := create(t, repo, newTodo(t, "plain", tenantA, "alice"))
+	tagged := create(t, repo, newTodo(t, "tagged", tenantA, "alice", domain.WithTags([]string{"urgent"})))
+	foreign := create(t, repo, newTodo(t, "foreign", tenantB, "alice"))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	ids := []string{plain.ID, tagged.ID, foreign.ID, deleted.ID, domain.NewID()}
+
+	tagsOf := func(todo *domain.Todo) ([]string, int64) {
+		t.Helper()
+		got, err := repo.GetByID(ctx, todo.ID, todo.TenantID)
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		return got.Tags, got.Version
+	}
+
+	// Only live todos of the tenant that lack the tag change
+	updated, err := repo.AddTagToMany(ctx, tenantA, ids, "urgent")
+	if err != nil {
+		t.Fatalf("AddTagToMany failed: %v", err)
+	}
+	if updated != 1 {
+		t.Fatalf("expected 1 todo tagged, got %d", updated)
+	}
+	if tags, version := tagsOf(plain); !slices.Equal(tags, []string{"urgent"}) || version != plain.Version+1 {
+		t.Fatalf("expected plain tagged urgent at version %d, got %v at %d", plain.Version+1, tags, version)
+	}
+	if _, version := tagsOf(tagged); version != tagged.Version {
+		t.Fatalf("expected the already tagged todo left at version %d, got %d", tagged.Version, version)
+	}
+	if tags, _ := tagsOf(foreign); len(tags) != 0 {
+		t.Fatalf("expected another tenant's todo untouched, got %v", tags)
+	}
+
+	entries, err := repo.GetHistory(ctx, plain.ID, tenantA, 1)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("GetHistory = %v, %v, want one entry", entries, err)
+	}
+	change, ok := entries[0].Changes["tags"]
+	if entries[0].ChangeType != domain.ChangeUpdated || !ok || jsonOf(t, change.New) != `["urgent"]` {
+		t.Fatalf("expected the tag change recorded, got %s %v", entries[0].ChangeType, entries[0].Changes)
+	}
+
+	// A todo that would exceed the limit fails the whole change
+	full := make([]string, domain.CurrentLimits().MaxTags)
+	for i := range full {
+		full[i] = fmt.Sprintf("tag-%d", i)
+	}
+	crowded := create(t, repo, newTodo(t, "crowded", tenantA, "alice", domain.WithTags(full)))
+	if _, err := repo.AddTagToMany(ctx, tenantA, []string{plain.ID, crowded.ID}, "extra"); !errors.Is(err, domain.ErrTooManyTags) {
+		t.Fatalf("expected ErrTooManyTags, got %v", err)
+	}
+	if tags, _ := tagsOf(plain); !slices.Equal(tags, []string{"urgent"}) {
+		t.Fatalf("expected plain unchanged by the failed change, got %v", tags)
+	}
+
+	updated, err = repo.RemoveTagFromMany(ctx, tenantA, ids, "urgent")
+	if err != nil {
+		t.Fatalf("RemoveTagFromMany failed: %v", err)
+	}
+	if updated != 2 {
+		t.Fatalf("expected 2 todos untagged, got %d", updated)
+	}
+	for _, todo := range []*domain.Todo{plain, tagged} {
+		if tags, _ := tagsOf(todo); len(tags) != 0 {
+			t.Fatalf("expected %q untagged, got %v", todo.Title, tags)
+		}
+	}
+}
+
+func testMoveToTenant(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	design := create(t, repo, newTodo(t, "design", tenantA, "alice"))
+	build := create(t, repo, newTodo(t, "build", tenantA, "alice"))
+	stays := create(t, repo, newTodo(t, "stays", tenantA, "alice"))
+	deleted := create(t, repo, newTodo(t, "deleted", tenantA, "alice"))
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	foreign := create(t, repo, newTodo(t, "foreign", tenantB, "bob"))
+
+	comment, err := domain.NewComment(design.ID, tenantA, "alice", "moving soon")
+	if err != nil {
+		t.Fatalf("NewComment failed: %v", err)
+	}
+	if err := repo.AddComment(ctx, comment); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	attach(t, repo, design, "spec.txt")
+	for _, edge := range [][2]*domain.Todo{{build, design}, {stays, build}} {
+		dep, err := domain.NewDependency(edge[0].ID, edge[1].ID, tenantA, "alice")
+		if err != nil {
+			t.Fatalf("NewDependency failed: %v", err)
+		}
+		if err := repo.AddDependency(ctx, dep); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	// Ids of other tenants are skipped; deleted todos move too
+	mapOwner := func(ownerID string) string { return ownerID + "-b" }
+	moved, err := repo.MoveToTenant(ctx, []string{design.ID, build.ID, deleted.ID, foreign.ID}, tenantA, tenantB, mapOwner)
+	if err != nil {
+		t.Fatalf("MoveToTenant failed: %v", err)
+	}
+	if moved != 3 {
+		t.Fatalf("expected 3 todos moved, got %d", moved)
+	}
+
+	if _, err := repo.GetByID(ctx, design.ID, tenantA); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("expected the moved todo gone from its old tenant, got %v", err)
+	}
+	got, err := repo.GetByID(ctx, design.ID, tenantB)
+	if err != nil {
+		t.Fatalf("GetByID in the new tenant failed: %v", err)
+	}
+	if got.TenantID != tenantB || got.OwnerID != "alice-b" {
+		t.Fatalf("expected design in %s owned by alice-b, got %s owned by %s", tenantB, got.TenantID, got.OwnerID)
+	}
+	if got, err := repo.GetByID(ctx, foreign.ID, tenantB); err != nil || got.OwnerID != "bob" {
+		t.Fatalf("expected the other tenant's todo untouched, got %v, %v", got, err)
+	}
+
+	// Comments, attachments and history follow the todo
+	if comments, err := repo.ListComments(ctx, design.ID, tenantB, 10); err != nil || len(comments) != 1 {
+		t.Fatalf("expected the comment moved, got %v, %v", comments, err)
+	}
+	if attachments, err := repo.ListAttachments(ctx, design.ID, tenantB); err != nil || len(attachments) != 1 {
+		t.Fatalf("expected the attachment moved, got %v, %v", attachments, err)
+	}
+	entries, err := repo.GetHistory(ctx, design.ID, tenantB, 10)
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("expected the history moved, got %v, %v", entries, err)
+	}
+	if change, ok := entries[0].Changes["tenant_id"]; !ok || jsonOf(t, change.New) != jsonOf(t, tenantB) {
+		t.Fatalf("expected the move recorded, got %v", entries[0].Changes)
+	}
+
+	// The dependency between moved todos moves; the one reaching back is dropped
+	deps, err := repo.ListDependencies(ctx, build.ID, tenantB)
+	if err != nil {
+		t.Fatalf("ListDependencies failed: %v", err)
+	}
+	if len(deps.BlockedBy) != 1 || deps.BlockedBy[0].ID != design.ID || len(deps.Blocks) != 0 {
+		t.Fatalf("expected build blocked by design only, got %+v", deps)
+	}
+	if deps, err := repo.ListDependencies(ctx, stays.ID, tenantA); err != nil || len(deps.BlockedBy) != 0 {
+		t.Fatalf("expected the cross-tenant dependency dropped, got %+v, %v", deps, err)
+	}
+
+	// A move that would duplicate an active title changes nothing
+	create(t, repo, newTodo(t, "Stays", tenantB, "alice-b"))
+	if _, err := repo.MoveToTenant(ctx, []string{stays.ID}, tenantA, tenantB, mapOwner); !errors.Is(err, domain.ErrDuplicateTitle) {
+		t.Fatalf("expected ErrDuplicateTitle, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, stays.ID, tenantA); err != nil {
+		t.Fatalf("expected the todo left in its tenant, got %v", err)
+	}
+}
+
+func testWorkloadByAssignee(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	bob, carol := "bob", "carol"
+	create(t, repo, newTodo(t, "bob open", tenantA, "alice", domain.WithAssignee(&bob)))
+	started := create(t, repo, newTodo(t, "bob started", tenantA, "alice", domain.WithAssignee(&bob)))
+	create(t, repo, newTodo(t, "carol open", tenantA, "alice", domain.WithAssignee(&carol)))
+	create(t, repo, newTodo(t, "unassigned", tenantA, "alice"))
+	deleted := create(t, repo, newTodo(t, "carol deleted", tenantA, "alice", domain.WithAssignee(&carol)))
+	create(t, repo, newTodo(t, "other tenant", tenantB, "alice", domain.WithAssignee(&bob)))
+
+	if _, err := repo.UpdateStatus(ctx, started.ID, tenantA, domain.StatusInProgress, nil, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID, tenantA); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	workload, err := repo.WorkloadByAssignee(ctx, tenantA)
+	if err != nil {
+		t.Fatalf("WorkloadByAssignee failed: %v", err)
+	}
+
+	want := map[string]map[domain.TodoStatus]int64{
+		"bob":                     {domain.StatusPending: 1, domain.StatusInProgress: 1},
+		"carol":                   {domain.StatusPending: 1},
+		domain.UnassignedWorkload: {domain.StatusPending: 1},
+	}
+	if len(workload) != len(want) {
+		t.Fatalf("expected workload of %d assignees, got %v", len(want), workload)
+	}
+	for assignee, counts := range want {
+		stats := workload[assignee]
+		var total int64
+		for st, n := range counts {
+			if stats.Counts[st] != n {
+				t.Fatalf("%q: expected counts %v, got %v", assignee, counts, stats.Counts)
+			}
+			total += n
+		}
+		if stats.Total != total {
+			t.Fatalf("%q: expected total %d, got %d", assignee, total, stats.Total)
+		}
+	}
+}
+
+func testListPriorityWeights(t *testing.T, repo domain.Repository) {
+	build := func(title string, priority domain.TodoPriority) string {
+		todo := newTodo(t, title, tenantA, "alice")
+		todo.Priority = priority
+		return create(t, repo, todo).ID
+	}
+	low := build("low", domain.PriorityLow)
+	medium := build("medium", domain.PriorityMedium)
+	high := build("high", domain.PriorityHigh)
+	critical := build("critical", domain.PriorityCritical)
+
+	// Low outranks high and medium; unweighted priorities keep their value
+	weights := domain.NewPriorityWeights(map[domain.TodoPriority]int{domain.PriorityLow: 10, domain.PriorityCritical: 20})
+	ids, _ := list(t, repo, domain.ListFilter{
+		TenantID:        tenantA,
+		Sort:            []domain.SortSpec{{Field: "priority"}},
+		PriorityWeights: weights,
+	})
+	if want := []string{critical, low, high, medium}; !slices.Equal(ids, want) {
+		t.Fatalf("expected weighted priority order %v, got %v", want, ids)
+	}
+
+	ids, _ = list(t, repo, domain.ListFilter{TenantID: tenantA, SortBy: "priority"})
+	if want := []string{critical, high, medium, low}; !slices.Equal(ids, want) {
+		t.Fatalf("expected priority order by value %v, got %v", want, ids)
+	}
+}
+
+func testDueBeforeFilter(t *testing.T, repo domain.Repository) {
+	cutoff := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+	due := func(title string, at time.Time) string {
+		return create(t, repo, newTodo(t, title, tenantA, "alice", domain.WithDueDate(&at))).ID
+	}
+	early := due("early", cutoff.Add(-time.Hour))
+	due("at cutoff", cutoff)
+	due("late", cutoff.Add(time.Hour))
+	create(t, repo, newTodo(t, "no due date", tenantA, "alice"))
+
+	// The bound is exclusive and todos without a due date never match
+	ids, total := list(t, repo, domain.ListFilter{TenantID: tenantA, DueBefore: &cutoff})
+	if total != 1 || !sameIDs(ids, []string{early}) {
+		t.Fatalf("expected only the todo due before the cutoff, got %v of %d", ids, total)
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+type crossTenantKey struct{}
+
+// WithCrossTenant marks ctx as a maintenance job allowed to span tenants.
+// Repositories enforcing tenant isolation see no rows outside a tenant scope
+// unless the context opts in through this.
+func WithCrossTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crossTenantKey{}, true)
+}
+
+// CrossTenant reports whether ctx opted in to spanning tenants
+func CrossTenant(ctx context.Context) bool {
+	ok, _ := ctx.Value(crossTenantKey{}).(bool)
+	return ok
+}
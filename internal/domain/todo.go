@@ -1,13 +1,16 @@
 package domain
 
 import (
+	"math"
+	"slices"
+	"strings"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 type TodoStatus int
 
+var defaultTransitionPolicy = DefaultTransitionPolicy()
+
 const (
 	StatusPending TodoStatus = iota + 1
 	StatusInProgress
@@ -39,26 +42,95 @@ type Todo struct {
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
 	Version     int64
+
+	// DueDateTimezone is an optional IANA zone; when set the todo is due at
+	// the end of DueDate's day in that zone rather than at the exact instant
+	DueDateTimezone *string
+
+	// CompletedAt is when the todo last became completed and CompletedBy who
+	// completed it; reopening clears both
+	CompletedAt *time.Time
+	CompletedBy *string
+
+	// ArchivedAt is when the todo was archived; leaving the archive clears it
+	ArchivedAt *time.Time
+
+	// EstimatedMinutes is the optional expected effort, LoggedMinutes the
+	// effort spent so far
+	EstimatedMinutes *int32
+	LoggedMinutes    int32
+
+	// RemindAt is when to remind about the todo; RemindedAt is set once that
+	// reminder has been sent and cleared whenever RemindAt changes
+	RemindAt   *time.Time
+	RemindedAt *time.Time
+
+	// ParentID is the todo this one is a subtask of; it is fixed at creation
+	ParentID *string
 }
 
-// NewTodo creates a new todo with validation
-func NewTodo(title, description, ownerID, tenantID string, priority TodoPriority) (*Todo, error) {
-	if err := validateTitle(title); err != nil {
-		return nil, err
+// MaxEstimatedMinutes bounds effort estimates to one year
+const MaxEstimatedMinutes = 365 * 24 * 60
+
+// TodoOption sets an optional field on a todo being created
+type TodoOption func(*Todo)
+
+// WithDueDate sets the due date of a new todo
+func WithDueDate(dueDate *time.Time) TodoOption {
+	return func(t *Todo) {
+		t.DueDate = dueDate
+	}
+}
+
+// WithDueDateTimezone sets the IANA timezone the due date is interpreted in
+func WithDueDateTimezone(tz *string) TodoOption {
+	return func(t *Todo) {
+		t.DueDateTimezone = tz
+	}
+}
+
+// WithEstimate sets the estimated effort of a new todo
+func WithEstimate(minutes *int32) TodoOption {
+	return func(t *Todo) {
+		t.EstimatedMinutes = minutes
+	}
+}
+
+// WithReminder sets the reminder time of a new todo
+func WithReminder(remindAt *time.Time) TodoOption {
+	return func(t *Todo) {
+		t.RemindAt = remindAt
+	}
+}
+
+// WithTags sets the tags of a new todo
+func WithTags(tags []string) TodoOption {
+	return func(t *Todo) {
+		t.Tags = append(t.Tags, tags...)
 	}
+}
 
-	if ownerID == "" {
-		return nil, ErrInvalidOwnerId
+// WithParent makes a new todo a subtask of parentID
+func WithParent(parentID *string) TodoOption {
+	return func(t *Todo) {
+		t.ParentID = parentID
 	}
+}
 
-	if tenantID == "" {
-		return nil, ErrInvalidTenantID
+// WithAssignee assigns a new todo to a user
+func WithAssignee(userID *string) TodoOption {
+	return func(t *Todo) {
+		t.AssignedTo = userID
 	}
+}
 
+// NewTodo creates a new todo with validation. Every invalid field is reported
+// together as ValidationErrors.
+func NewTodo(title, description, ownerID, tenantID string, priority TodoPriority, opts ...TodoOption) (*Todo, error) {
 	now := time.Now().UTC()
 
-	return &Todo{
-		ID:          uuid.New().String(),
+	todo := &Todo{
+		ID:          NewID(),
 		Title:       title,
 		Description: description,
 		Status:      StatusPending,
@@ -69,7 +141,22 @@ func NewTodo(title, description, ownerID, tenantID string, priority TodoPriority
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Version:     1,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(todo)
+	}
+
+	// Invalid tags are left as given for ValidateTodo to report
+	if tags, err := NormalizeTags(todo.Tags); err == nil {
+		todo.Tags = tags
+	}
+
+	if err := ValidateTodo(todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
 }
 
 // UpdateTitle updates the title with validation
@@ -85,7 +172,7 @@ func (t *Todo) UpdateTitle(title string) error {
 
 // UpdateDescription updates the description
 func (t *Todo) UpdateDescription(description string) error {
-	if len(description) > 2000 {
+	if len(description) > CurrentLimits().MaxDescriptionLength {
 		return ErrDescriptionTooLong
 	}
 	t.Description = description
@@ -94,17 +181,49 @@ func (t *Todo) UpdateDescription(description string) error {
 	return nil
 }
 
-// UpdateStatus transitions the todo to a new status
-func (t *Todo) UpdateStatus(newStatus TodoStatus) error {
-	if !isValidStatusTransition(t.Status, newStatus) {
+// UpdateStatus transitions the todo to a new status as permitted by policy.
+// A nil policy applies DefaultTransitionPolicy.
+func (t *Todo) UpdateStatus(newStatus TodoStatus, actorID string, policy *TransitionPolicy) error {
+	if !isValidStatus(newStatus) {
+		return ErrInvalidStatus
+	}
+	if policy == nil {
+		policy = defaultTransitionPolicy
+	}
+	if !policy.Allows(t.Status, newStatus) {
 		return ErrInvalidStatusTransition
 	}
+
+	now := time.Now().UTC()
+	switch newStatus {
+	case StatusCompleted:
+		t.CompletedAt = &now
+		t.CompletedBy = &actorID
+	case StatusPending, StatusInProgress:
+		t.CompletedAt = nil
+		t.CompletedBy = nil
+	}
+	if newStatus == StatusArchived {
+		t.ArchivedAt = &now
+	} else {
+		t.ArchivedAt = nil
+	}
+
 	t.Status = newStatus
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = now
 	t.Version++
 	return nil
 }
 
+// Reopen moves a completed todo back to pending as permitted by policy,
+// clearing its completion time. A nil policy applies DefaultTransitionPolicy.
+func (t *Todo) Reopen(policy *TransitionPolicy) error {
+	if t.Status != StatusCompleted {
+		return ErrInvalidStatusTransition
+	}
+	return t.UpdateStatus(StatusPending, "", policy)
+}
+
 // UpdatePriority changes the priority level
 func (t *Todo) UpdatePriority(priority TodoPriority) error {
 	if !isValidPriority(priority) {
@@ -118,7 +237,7 @@ func (t *Todo) UpdatePriority(priority TodoPriority) error {
 
 // SetDueDate sets or updates the due date
 func (t *Todo) SetDueDate(dueDate *time.Time) error {
-	if dueDate != nil && dueDate.Before(time.Now().UTC()) {
+	if isPastDue(dueDeadline(dueDate, t.DueDateTimezone)) {
 		return ErrDueDateInPast
 	}
 	t.DueDate = dueDate
@@ -127,6 +246,79 @@ func (t *Todo) SetDueDate(dueDate *time.Time) error {
 	return nil
 }
 
+// Snooze moves the due date forward by d
+func (t *Todo) Snooze(d time.Duration) error {
+	if t.DueDate == nil {
+		return ErrNoDueDate
+	}
+	return t.snoozeFrom(*t.DueDate, d)
+}
+
+// SnoozeFromNow is Snooze, except that an overdue todo is moved to d from
+// now so it lands in the future rather than possibly still in the past
+func (t *Todo) SnoozeFromNow(d time.Duration) error {
+	if t.DueDate == nil {
+		return ErrNoDueDate
+	}
+	if deadline, _ := t.Deadline(); deadline.Before(time.Now().UTC()) {
+		return t.snoozeFrom(time.Now().UTC(), d)
+	}
+	return t.snoozeFrom(*t.DueDate, d)
+}
+
+func (t *Todo) snoozeFrom(from time.Time, d time.Duration) error {
+	if d <= 0 {
+		return ErrInvalidSnooze
+	}
+	dueDate := from.Add(d)
+	t.DueDate = &dueDate
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+	return nil
+}
+
+// SetReminder sets or clears the reminder time, re-arming a reminder that was
+// already sent
+func (t *Todo) SetReminder(remindAt *time.Time) {
+	t.RemindAt = remindAt
+	t.RemindedAt = nil
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+}
+
+// SetEstimate sets or clears the estimated effort
+func (t *Todo) SetEstimate(minutes *int32) error {
+	if !isValidEstimate(minutes) {
+		return ErrInvalidEstimate
+	}
+	t.EstimatedMinutes = minutes
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+	return nil
+}
+
+// LogTime adds minutes of spent effort to the todo
+func (t *Todo) LogTime(minutes int32) error {
+	if minutes <= 0 || minutes > math.MaxInt32-t.LoggedMinutes {
+		return ErrInvalidTimeLog
+	}
+	t.LoggedMinutes += minutes
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+	return nil
+}
+
+// SetDueDateTimezone sets or clears the IANA timezone of the due date
+func (t *Todo) SetDueDateTimezone(tz *string) error {
+	if err := validateTimezone(tz); err != nil {
+		return err
+	}
+	t.DueDateTimezone = tz
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+	return nil
+}
+
 // AssignTo assigns the todo to a user
 func (t *Todo) AssignTo(userID *string) error {
 	t.AssignedTo = userID
@@ -135,66 +327,164 @@ func (t *Todo) AssignTo(userID *string) error {
 	return nil
 }
 
+// TransferOwnership makes newOwnerID the owner of the todo
+func (t *Todo) TransferOwnership(newOwnerID string) error {
+	if newOwnerID == "" {
+		return ErrInvalidOwnerId
+	}
+	t.OwnerID = newOwnerID
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+	return nil
+}
+
 // AddTags adds tags to the todo
 func (t *Todo) AddTags(tags []string) error {
-	if len(t.Tags)+len(tags) > 20 {
+	merged, err := NormalizeTags(append(slices.Clone(t.Tags), tags...))
+	if err != nil {
+		return err
+	}
+	if len(merged) > CurrentLimits().MaxTags {
+		return ErrTooManyTags
+	}
+	t.Tags = merged
+	t.UpdatedAt = time.Now().UTC()
+	t.Version++
+	return nil
+}
+
+// ReplaceTags replaces all tags of the todo
+func (t *Todo) ReplaceTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if len(normalized) > CurrentLimits().MaxTags {
 		return ErrTooManyTags
 	}
-	t.Tags = append(t.Tags, tags...)
+	t.Tags = normalized
 	t.UpdatedAt = time.Now().UTC()
 	t.Version++
 	return nil
 }
 
-// isValidStatusTransition checks if a status transition is allowed
-func isValidStatusTransition(from, to TodoStatus) bool {
-	validTransitions := map[TodoStatus][]TodoStatus{
-		StatusPending: {
-			StatusInProgress,
-			StatusArchived,
-		},
-		StatusInProgress: {
-			StatusArchived,
-			StatusCompleted,
-			StatusPending,
-		},
-		StatusCompleted: {
-			StatusArchived,
-			StatusPending,
-		},
-		StatusArchived: {
-			StatusPending,
-		},
-	}
-
-	allowed, exists := validTransitions[from]
-	if !exists {
+// Deadline returns the instant after which the todo is overdue. With a
+// DueDateTimezone this is the end of the due day in that zone.
+func (t *Todo) Deadline() (time.Time, bool) {
+	return dueDeadline(t.DueDate, t.DueDateTimezone)
+}
+
+// IsOverdue reports whether the todo is past its deadline and still open.
+// A todo due exactly at now is not yet overdue.
+func (t *Todo) IsOverdue(now time.Time) bool {
+	deadline, ok := t.Deadline()
+	if !ok {
 		return false
 	}
+	if t.Status == StatusCompleted || t.Status == StatusArchived {
+		return false
+	}
+	return deadline.Before(now)
+}
 
-	for _, status := range allowed {
-		if status == to {
-			return true
-		}
+func dueDeadline(dueDate *time.Time, tz *string) (time.Time, bool) {
+	if dueDate == nil {
+		return time.Time{}, false
+	}
+	if tz == nil {
+		return *dueDate, true
 	}
 
-	return false
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return *dueDate, true
+	}
+
+	local := dueDate.In(loc)
+	endOfDay := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+	return endOfDay.UTC(), true
+}
+
+func validateTimezone(tz *string) error {
+	if tz == nil {
+		return nil
+	}
+	if *tz == "" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(*tz); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
 }
 
 func validateTitle(title string) error {
 	if title == "" {
 		return ErrEmptyTitle
 	}
-	if len(title) > 200 {
+	if len(title) > CurrentLimits().MaxTitleLength {
 		return ErrTitleTooLong
 	}
 	return nil
 }
 
+// CanonicalTag returns the stored form of tag: lowercase, trimmed, with
+// runs of internal whitespace collapsed to a single space
+func CanonicalTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// NormalizeTags canonicalizes tags and drops duplicates, keeping the first
+// occurrence. It rejects tags that are empty or too long once canonicalized.
+func NormalizeTags(tags []string) ([]string, error) {
+	maxLength := CurrentLimits().MaxTagLength
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = CanonicalTag(tag)
+		if tag == "" {
+			return nil, ErrEmptyTag
+		}
+		if len(tag) > maxLength {
+			return nil, ErrTagTooLong
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
 func isValidPriority(p TodoPriority) bool {
 	return p >= PriorityLow && p <= PriorityCritical
 }
 
+func isValidStatus(s TodoStatus) bool {
+	return s >= StatusPending && s <= StatusArchived
+}
+
+// sortableFields lists the todo fields List results may be ordered by
+var sortableFields = map[string]bool{
+	"created_at":  true,
+	"updated_at":  true,
+	"due_date":    true,
+	"priority":    true,
+	"status":      true,
+	"title":       true,
+	"archived_at": true,
+}
+
+// IsSortableField reports whether List results may be ordered by field
+func IsSortableField(field string) bool {
+	return sortableFields[field]
+}
+
+// SortSpec orders List results by a single field
+type SortSpec struct {
+	Field     string
+	Ascending bool
+}
+
 type ListFilter struct {
 	TenantID      string
 	OwnerID       *string
@@ -202,25 +492,179 @@ type ListFilter struct {
 	Statuses      []TodoStatus
 	Priorities    []TodoPriority
 	Tags          []string
+	ExcludeTags   []string
 	DueDateFrom   *time.Time
 	DueDateTo     *time.Time
+	DueBefore     *time.Time // exclusive, unlike DueDateTo
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	UpdatedFrom   *time.Time
+	UpdatedTo     *time.Time
 	SearchQuery   *string
+	OverdueOnly   bool
 	Page          int
 	PageSize      int
 	SortBy        string
 	SortAscending bool
+
+	// Sort takes precedence over SortBy/SortAscending when non-empty
+	Sort []SortSpec
+
+	// PriorityWeights ranks priorities when sorting by priority; nil sorts
+	// them by their value
+	PriorityWeights *PriorityWeights
+
+	// IncludeDeleted also matches soft-deleted todos
+	IncludeDeleted bool
+
+	// VisibleTo confines matches to the todos this user may read without
+	// tenant-wide access: those they own or are assigned to
+	VisibleTo *string
+
+	// When set, match only todos whose assignee or due date is unset (true)
+	// or set (false)
+	AssignedToIsNull *bool
+	DueDateIsNull    *bool
+}
+
+// IsNarrowed reports whether the filter has any predicate beyond the tenant
+func (f *ListFilter) IsNarrowed() bool {
+	return f.OwnerID != nil ||
+		f.AssignedTo != nil ||
+		len(f.Statuses) > 0 ||
+		len(f.Priorities) > 0 ||
+		len(f.Tags) > 0 ||
+		len(f.ExcludeTags) > 0 ||
+		f.DueDateFrom != nil ||
+		f.DueDateTo != nil ||
+		f.DueBefore != nil ||
+		f.CreatedFrom != nil ||
+		f.CreatedTo != nil ||
+		f.UpdatedFrom != nil ||
+		f.UpdatedTo != nil ||
+		f.SearchQuery != nil ||
+		f.OverdueOnly ||
+		f.AssignedToIsNull != nil ||
+		f.DueDateIsNull != nil
+}
+
+// DueBucket groups todos by when they fall due relative to now
+type DueBucket int
+
+const (
+	DueBucketAny DueBucket = iota
+	DueBucketOverdue
+	DueBucketToday
+	DueBucketThisWeek
+	DueBucketLater
+	DueBucketNone
+)
+
+// ApplyDueBucket narrows the filter to the todos in bucket, taking days and
+// weeks, which start on Monday, in loc. Overdue todos are those past their
+// deadline and still open, as with OverdueOnly; the other buckets split the
+// due dates into today, the rest of this week and later, so they do not
+// overlap.
+func (f *ListFilter) ApplyDueBucket(bucket DueBucket, now time.Time, loc *time.Location) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+	daysToMonday := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+	if daysToMonday == 0 {
+		daysToMonday = 7
+	}
+	nextWeek := today.AddDate(0, 0, daysToMonday)
+
+	switch bucket {
+	case DueBucketOverdue:
+		f.OverdueOnly = true
+	case DueBucketToday:
+		f.narrowDueRange(today, tomorrow)
+	case DueBucketThisWeek:
+		f.narrowDueRange(tomorrow, nextWeek)
+	case DueBucketLater:
+		f.narrowDueRange(nextWeek, time.Time{})
+	case DueBucketNone:
+		isNull := true
+		f.DueDateIsNull = &isNull
+	}
 }
 
-// Validates the filter
-func (f *ListFilter) Validate() error {
+// narrowDueRange intersects the due date range with [from, before); a zero
+// before leaves the range open-ended
+func (f *ListFilter) narrowDueRange(from, before time.Time) {
+	if f.DueDateFrom == nil || f.DueDateFrom.Before(from) {
+		f.DueDateFrom = &from
+	}
+	if !before.IsZero() && (f.DueBefore == nil || before.Before(*f.DueBefore)) {
+		f.DueBefore = &before
+	}
+}
+
+// MaxPage bounds the page number of List requests, keeping offsets cheap
+const MaxPage = 10000
+
+// Validate checks the filter and normalizes its pagination: an unset page
+// becomes the first, an unset page size the default and an oversized one the max
+func (f *ListFilter) Validate(sizes PageSizes) error {
 	if f.TenantID == "" {
 		return ErrInvalidTenantID
 	}
-	if f.Page < 1 {
+	if f.Page < 0 {
+		return ErrInvalidPage
+	}
+	if f.Page > MaxPage {
+		return ErrPageTooLarge
+	}
+	if f.PageSize < 0 {
+		return ErrInvalidPageSize
+	}
+	if f.Page == 0 {
 		f.Page = 1
 	}
-	if f.PageSize < 1 || f.PageSize > 100 {
-		f.PageSize = 20
+	if f.PageSize == 0 {
+		f.PageSize = sizes.Default
+	}
+	if f.PageSize > sizes.Max {
+		f.PageSize = sizes.Max
+	}
+	for _, spec := range f.Sort {
+		if !IsSortableField(spec.Field) {
+			return ErrInvalidSortField
+		}
+	}
+	for _, s := range f.Statuses {
+		if !isValidStatus(s) {
+			return ErrInvalidStatus
+		}
+	}
+	for _, p := range f.Priorities {
+		if !isValidPriority(p) {
+			return ErrInvalidPriority
+		}
+	}
+
+	// Equal bounds are a valid single-instant range
+	if reversedRange(f.DueDateFrom, f.DueDateTo) {
+		return ErrInvalidDueRange
+	}
+	if reversedRange(f.CreatedFrom, f.CreatedTo) {
+		return ErrInvalidCreatedRange
+	}
+	if reversedRange(f.UpdatedFrom, f.UpdatedTo) {
+		return ErrInvalidUpdatedRange
 	}
 	return nil
 }
+
+func reversedRange(from, to *time.Time) bool {
+	return from != nil && to != nil && from.After(*to)
+}
+
+// TotalPages is the number of pages of pageSize needed for total items
+func TotalPages(total int64, pageSize int) int {
+	if total <= 0 || pageSize <= 0 {
+		return 0
+	}
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
@@ -0,0 +1,522 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestDeadlineEndsDueDayInTimezone(t *testing.T) {
+	// Kolkata is UTC+05:30, so its days start at 18:30 UTC the evening before
+	kolkata := ptr("Asia/Kolkata")
+
+	tests := []struct {
+		name     string
+		due      time.Time
+		deadline time.Time
+	}{
+		{
+			name:     "last second of the local day",
+			due:      time.Date(2026, 3, 10, 18, 29, 59, 0, time.UTC),
+			deadline: time.Date(2026, 3, 10, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "first second of the next local day",
+			due:      time.Date(2026, 3, 10, 18, 30, 0, 0, time.UTC),
+			deadline: time.Date(2026, 3, 11, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "UTC day behind the local day",
+			due:      time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC),
+			deadline: time.Date(2026, 3, 11, 18, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{Status: StatusPending, DueDate: &tt.due, DueDateTimezone: kolkata}
+
+			deadline, ok := todo.Deadline()
+			if !ok || !deadline.Equal(tt.deadline) {
+				t.Fatalf("Deadline() = %v, %v, want %v", deadline, ok, tt.deadline)
+			}
+			if todo.IsOverdue(tt.deadline) {
+				t.Error("todo is overdue at its deadline")
+			}
+			if !todo.IsOverdue(tt.deadline.Add(time.Nanosecond)) {
+				t.Error("todo is not overdue just past its deadline")
+			}
+		})
+	}
+}
+
+func TestReopen(t *testing.T) {
+	noReopen := NewTransitionPolicy(map[TodoStatus][]TodoStatus{
+		StatusCompleted: {StatusArchived},
+	})
+
+	tests := []struct {
+		name   string
+		status TodoStatus
+		policy *TransitionPolicy
+		err    error
+	}{
+		{name: "completed", status: StatusCompleted},
+		{name: "completed under an explicit policy", status: StatusCompleted, policy: DefaultTransitionPolicy()},
+		{name: "pending", status: StatusPending, err: ErrInvalidStatusTransition},
+		{name: "archived", status: StatusArchived, err: ErrInvalidStatusTransition},
+		{name: "disallowed by policy", status: StatusCompleted, policy: noReopen, err: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completedAt := time.Now().UTC()
+			todo := &Todo{
+				Status:      tt.status,
+				Version:     1,
+				CompletedAt: &completedAt,
+				CompletedBy: ptr("alice"),
+			}
+
+			err := todo.Reopen(tt.policy)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Reopen() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				if todo.Status != tt.status || todo.Version != 1 || todo.CompletedAt == nil {
+					t.Errorf("failed Reopen changed the todo: %+v", todo)
+				}
+				return
+			}
+			if todo.Status != StatusPending || todo.Version != 2 {
+				t.Errorf("status, version = %v, %d, want pending, 2", todo.Status, todo.Version)
+			}
+			if todo.CompletedAt != nil || todo.CompletedBy != nil {
+				t.Error("Reopen kept the completion")
+			}
+		})
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	due := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		due  *time.Time
+		d    time.Duration
+		want time.Time
+		err  error
+	}{
+		{name: "one day", due: &due, d: 24 * time.Hour, want: due.Add(24 * time.Hour)},
+		{name: "no due date", d: time.Hour, err: ErrNoDueDate},
+		{name: "zero duration", due: &due, err: ErrInvalidSnooze},
+		{name: "negative duration", due: &due, d: -time.Hour, err: ErrInvalidSnooze},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{DueDate: tt.due, Version: 1}
+
+			err := todo.Snooze(tt.d)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Snooze() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				if todo.DueDate != tt.due || todo.Version != 1 {
+					t.Errorf("failed Snooze changed the todo: %+v", todo)
+				}
+				return
+			}
+			if !todo.DueDate.Equal(tt.want) || todo.Version != 2 {
+				t.Errorf("due date, version = %v, %d, want %v, 2", todo.DueDate, todo.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnoozeFromNow(t *testing.T) {
+	now := time.Now().UTC()
+	overdue := now.Add(-48 * time.Hour)
+	upcoming := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		name string
+		due  time.Time
+		want time.Time // approximate for overdue todos, which move from now
+	}{
+		{name: "upcoming moves from its due date", due: upcoming, want: upcoming.Add(time.Hour)},
+		{name: "overdue moves from now", due: overdue, want: now.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due := tt.due
+			todo := &Todo{DueDate: &due, Version: 1}
+
+			if err := todo.SnoozeFromNow(time.Hour); err != nil {
+				t.Fatalf("SnoozeFromNow: %v", err)
+			}
+			if diff := todo.DueDate.Sub(tt.want); diff < 0 || diff > time.Minute {
+				t.Errorf("due date = %v, want about %v", todo.DueDate, tt.want)
+			}
+			if todo.Version != 2 {
+				t.Errorf("version = %d, want 2", todo.Version)
+			}
+		})
+	}
+
+	if err := (&Todo{}).SnoozeFromNow(time.Hour); !errors.Is(err, ErrNoDueDate) {
+		t.Errorf("SnoozeFromNow without a due date = %v, want %v", err, ErrNoDueDate)
+	}
+}
+
+func TestSetReminder(t *testing.T) {
+	sent := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	later := sent.Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		remindAt *time.Time
+	}{
+		{name: "moved", remindAt: &later},
+		{name: "cleared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{RemindAt: &sent, RemindedAt: &sent, Version: 1}
+
+			todo.SetReminder(tt.remindAt)
+			if todo.RemindAt != tt.remindAt || todo.RemindedAt != nil || todo.Version != 2 {
+				t.Errorf("reminder, reminded, version = %v, %v, %d, want %v, nil, 2", todo.RemindAt, todo.RemindedAt, todo.Version, tt.remindAt)
+			}
+		})
+	}
+}
+
+func TestSetEstimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes *int32
+		err     error
+	}{
+		{name: "cleared"},
+		{name: "zero", minutes: ptr[int32](0)},
+		{name: "one year", minutes: ptr[int32](MaxEstimatedMinutes)},
+		{name: "negative", minutes: ptr[int32](-1), err: ErrInvalidEstimate},
+		{name: "over one year", minutes: ptr[int32](MaxEstimatedMinutes + 1), err: ErrInvalidEstimate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{EstimatedMinutes: ptr[int32](30), Version: 1}
+
+			err := todo.SetEstimate(tt.minutes)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SetEstimate() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				if *todo.EstimatedMinutes != 30 || todo.Version != 1 {
+					t.Errorf("failed SetEstimate changed the todo: %+v", todo)
+				}
+				return
+			}
+			if todo.EstimatedMinutes != tt.minutes || todo.Version != 2 {
+				t.Errorf("estimate, version = %v, %d, want %v, 2", todo.EstimatedMinutes, todo.Version, tt.minutes)
+			}
+		})
+	}
+}
+
+func TestLogTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		logged int32
+		add    int32
+		want   int32
+		err    error
+	}{
+		{name: "first entry", add: 30, want: 30},
+		{name: "accumulates", logged: 30, add: 15, want: 45},
+		{name: "zero", add: 0, err: ErrInvalidTimeLog},
+		{name: "negative", logged: 30, add: -10, err: ErrInvalidTimeLog},
+		{name: "overflow", logged: math.MaxInt32 - 5, add: 6, err: ErrInvalidTimeLog},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{LoggedMinutes: tt.logged, Version: 1}
+
+			err := todo.LogTime(tt.add)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("LogTime() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				if todo.LoggedMinutes != tt.logged || todo.Version != 1 {
+					t.Errorf("failed LogTime changed the todo: %+v", todo)
+				}
+				return
+			}
+			if todo.LoggedMinutes != tt.want || todo.Version != 2 {
+				t.Errorf("logged, version = %d, %d, want %d, 2", todo.LoggedMinutes, todo.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	maxLength := DefaultLimits().MaxTagLength
+
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+		err  error
+	}{
+		{name: "none", tags: nil, want: []string{}},
+		{name: "canonical", tags: []string{"  Backend ", "Hot\t  Fix"}, want: []string{"backend", "hot fix"}},
+		{name: "duplicates keep first", tags: []string{"b", "A", "B", "a"}, want: []string{"b", "a"}},
+		{name: "longest allowed", tags: []string{strings.Repeat("x", maxLength)}, want: []string{strings.Repeat("x", maxLength)}},
+		{name: "too long", tags: []string{strings.Repeat("x", maxLength+1)}, err: ErrTagTooLong},
+		{name: "empty", tags: []string{"ok", ""}, err: ErrEmptyTag},
+		{name: "whitespace only", tags: []string{" \t "}, err: ErrEmptyTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NormalizeTags() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagsAreNormalizedOnWrite(t *testing.T) {
+	todo, err := NewTodo("tagged", "", "alice", "tenant-a", PriorityLow, WithTags([]string{" Backend", "backend ", "API"}))
+	if err != nil {
+		t.Fatalf("NewTodo: %v", err)
+	}
+	if want := []string{"backend", "api"}; !slices.Equal(todo.Tags, want) {
+		t.Fatalf("NewTodo tags = %q, want %q", todo.Tags, want)
+	}
+
+	if err := todo.AddTags([]string{"API", "Hot  Fix"}); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+	if want := []string{"backend", "api", "hot fix"}; !slices.Equal(todo.Tags, want) {
+		t.Fatalf("AddTags tags = %q, want %q", todo.Tags, want)
+	}
+
+	if err := todo.ReplaceTags([]string{"DB", "db"}); err != nil {
+		t.Fatalf("ReplaceTags: %v", err)
+	}
+	if want := []string{"db"}; !slices.Equal(todo.Tags, want) {
+		t.Fatalf("ReplaceTags tags = %q, want %q", todo.Tags, want)
+	}
+
+	version := todo.Version
+	if err := todo.AddTags([]string{" "}); !errors.Is(err, ErrEmptyTag) {
+		t.Fatalf("AddTags with an empty tag = %v, want %v", err, ErrEmptyTag)
+	}
+	if !slices.Equal(todo.Tags, []string{"db"}) || todo.Version != version {
+		t.Fatalf("rejected tags changed the todo: %q at version %d", todo.Tags, todo.Version)
+	}
+}
+
+func TestListFilterValidate(t *testing.T) {
+	sizes := PageSizes{Default: 20, Max: 100}
+	early := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		filter   ListFilter
+		page     int
+		pageSize int
+		err      error
+	}{
+		{name: "defaults", filter: ListFilter{}, page: 1, pageSize: 20},
+		{name: "explicit", filter: ListFilter{Page: 3, PageSize: 50}, page: 3, pageSize: 50},
+		{name: "clamped page size", filter: ListFilter{PageSize: 500}, page: 1, pageSize: 100},
+		{name: "last page", filter: ListFilter{Page: MaxPage}, page: MaxPage, pageSize: 20},
+		{name: "sortable fields", filter: ListFilter{Sort: []SortSpec{{Field: "priority"}, {Field: "due_date", Ascending: true}}}, page: 1, pageSize: 20},
+		{name: "single instant range", filter: ListFilter{DueDateFrom: &early, DueDateTo: &early}, page: 1, pageSize: 20},
+		{name: "missing tenant", filter: ListFilter{}, err: ErrInvalidTenantID},
+		{name: "negative page", filter: ListFilter{Page: -1}, err: ErrInvalidPage},
+		{name: "page too large", filter: ListFilter{Page: MaxPage + 1}, err: ErrPageTooLarge},
+		{name: "negative page size", filter: ListFilter{PageSize: -1}, err: ErrInvalidPageSize},
+		{name: "unsortable field", filter: ListFilter{Sort: []SortSpec{{Field: "owner_id"}}}, err: ErrInvalidSortField},
+		{name: "invalid status", filter: ListFilter{Statuses: []TodoStatus{StatusArchived + 1}}, err: ErrInvalidStatus},
+		{name: "invalid priority", filter: ListFilter{Priorities: []TodoPriority{0}}, err: ErrInvalidPriority},
+		{name: "reversed due range", filter: ListFilter{DueDateFrom: &late, DueDateTo: &early}, err: ErrInvalidDueRange},
+		{name: "reversed created range", filter: ListFilter{CreatedFrom: &late, CreatedTo: &early}, err: ErrInvalidCreatedRange},
+		{name: "reversed updated range", filter: ListFilter{UpdatedFrom: &late, UpdatedTo: &early}, err: ErrInvalidUpdatedRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if tt.err != ErrInvalidTenantID {
+				filter.TenantID = "tenant-a"
+			}
+
+			err := filter.Validate(sizes)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (filter.Page != tt.page || filter.PageSize != tt.pageSize) {
+				t.Errorf("page, page size = %d, %d, want %d, %d", filter.Page, filter.PageSize, tt.page, tt.pageSize)
+			}
+		})
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	tests := []struct {
+		total    int64
+		pageSize int
+		want     int
+	}{
+		{total: 0, pageSize: 20, want: 0},
+		{total: 1, pageSize: 20, want: 1},
+		{total: 20, pageSize: 20, want: 1},
+		{total: 21, pageSize: 20, want: 2},
+		{total: 100, pageSize: 1, want: 100},
+		{total: -1, pageSize: 20, want: 0},
+		{total: 10, pageSize: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := TotalPages(tt.total, tt.pageSize); got != tt.want {
+			t.Errorf("TotalPages(%d, %d) = %d, want %d", tt.total, tt.pageSize, got, tt.want)
+		}
+	}
+}
+
+func TestApplyDueBucket(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	// Wednesday 11 March in Kolkata, still Tuesday evening in UTC
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, kolkata) }
+
+	tests := []struct {
+		name    string
+		bucket  DueBucket
+		from    *time.Time
+		before  *time.Time
+		overdue bool
+		isNull  bool
+	}{
+		{name: "any", bucket: DueBucketAny},
+		{name: "overdue", bucket: DueBucketOverdue, overdue: true},
+		{name: "today", bucket: DueBucketToday, from: ptr(day(11)), before: ptr(day(12))},
+		{name: "this week", bucket: DueBucketThisWeek, from: ptr(day(12)), before: ptr(day(16))},
+		{name: "later", bucket: DueBucketLater, from: ptr(day(16))},
+		{name: "none", bucket: DueBucketNone, isNull: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f ListFilter
+			f.ApplyDueBucket(tt.bucket, now, kolkata)
+
+			if !sameTime(f.DueDateFrom, tt.from) || !sameTime(f.DueBefore, tt.before) {
+				t.Errorf("due range = [%v, %v), want [%v, %v)", f.DueDateFrom, f.DueBefore, tt.from, tt.before)
+			}
+			if f.OverdueOnly != tt.overdue {
+				t.Errorf("OverdueOnly = %v, want %v", f.OverdueOnly, tt.overdue)
+			}
+			if (f.DueDateIsNull != nil && *f.DueDateIsNull) != tt.isNull {
+				t.Errorf("DueDateIsNull = %v, want %v", f.DueDateIsNull, tt.isNull)
+			}
+		})
+	}
+
+	t.Run("sunday", func(t *testing.T) {
+		// The week ends at the coming midnight, so this week holds no days
+		sunday := time.Date(2026, 3, 15, 12, 0, 0, 0, kolkata)
+		var f ListFilter
+		f.ApplyDueBucket(DueBucketThisWeek, sunday, kolkata)
+		if !sameTime(f.DueDateFrom, ptr(day(16))) || !sameTime(f.DueBefore, ptr(day(16))) {
+			t.Errorf("due range = [%v, %v), want empty at %v", f.DueDateFrom, f.DueBefore, day(16))
+		}
+	})
+
+	t.Run("intersects an existing range", func(t *testing.T) {
+		from, before := day(1), day(13)
+		f := ListFilter{DueDateFrom: &from, DueBefore: &before}
+		f.ApplyDueBucket(DueBucketThisWeek, now, kolkata)
+		if !sameTime(f.DueDateFrom, ptr(day(12))) || !sameTime(f.DueBefore, ptr(day(13))) {
+			t.Errorf("due range = [%v, %v), want [%v, %v)", f.DueDateFrom, f.DueBefore, day(12), day(13))
+		}
+	})
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	tests := []struct {
+		name   string
+		due    *time.Time
+		status TodoStatus
+		want   bool
+	}{
+		{name: "no due date", status: StatusPending},
+		{name: "due later", due: &future, status: StatusPending},
+		{name: "due now", due: &now, status: StatusPending},
+		{name: "past due", due: &past, status: StatusPending, want: true},
+		{name: "past due in progress", due: &past, status: StatusInProgress, want: true},
+		{name: "past due completed", due: &past, status: StatusCompleted},
+		{name: "past due archived", due: &past, status: StatusArchived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{DueDate: tt.due, Status: tt.status}
+			if got := todo.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	todo := &Todo{OwnerID: "alice", Version: 1}
+
+	if err := todo.TransferOwnership(""); !errors.Is(err, ErrInvalidOwnerId) {
+		t.Fatalf("TransferOwnership(\"\") = %v, want %v", err, ErrInvalidOwnerId)
+	}
+	if todo.OwnerID != "alice" || todo.Version != 1 {
+		t.Fatalf("failed TransferOwnership changed the todo: %+v", todo)
+	}
+
+	if err := todo.TransferOwnership("bob"); err != nil {
+		t.Fatalf("TransferOwnership: %v", err)
+	}
+	if todo.OwnerID != "bob" || todo.Version != 2 {
+		t.Errorf("owner, version = %s, %d, want bob, 2", todo.OwnerID, todo.Version)
+	}
+}
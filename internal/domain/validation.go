@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var allowPastDueDate atomic.Bool
+
+// SetAllowPastDueDate permits due dates in the past on create and update,
+// e.g. to import historical data. Past due dates are rejected by default.
+func SetAllowPastDueDate(allow bool) {
+	allowPastDueDate.Store(allow)
+}
+
+// AllowsPastDueDate reports whether due dates in the past are permitted
+func AllowsPastDueDate() bool {
+	return allowPastDueDate.Load()
+}
+
+// isPastDue reports whether a deadline is rejected for lying in the past
+func isPastDue(deadline time.Time, ok bool) bool {
+	return ok && !AllowsPastDueDate() && deadline.Before(time.Now().UTC())
+}
+
+// FieldError ties a validation error to the field it concerns
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors collects every validation failure found on a todo
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, fe := range v {
+		messages[i] = fe.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v))
+	for i, fe := range v {
+		errs[i] = fe.Err
+	}
+	return errs
+}
+
+func (v *ValidationErrors) add(field string, err error) {
+	*v = append(*v, FieldError{Field: field, Err: err})
+}
+
+// ValidateTodo checks a todo about to be created and reports every violation
+// together rather than stopping at the first. It returns nil when the todo is valid.
+func ValidateTodo(t *Todo) error {
+	var errs ValidationErrors
+	limits := CurrentLimits()
+
+	if err := validateTitle(t.Title); err != nil {
+		errs.add("title", err)
+	}
+	if len(t.Description) > limits.MaxDescriptionLength {
+		errs.add("description", ErrDescriptionTooLong)
+	}
+	if !isValidPriority(t.Priority) {
+		errs.add("priority", ErrInvalidPriority)
+	}
+	if err := validateTimezone(t.DueDateTimezone); err != nil {
+		errs.add("due_date_timezone", err)
+	} else if isPastDue(t.Deadline()) {
+		errs.add("due_date", ErrDueDateInPast)
+	}
+	if tags, err := NormalizeTags(t.Tags); err != nil {
+		errs.add("tags", err)
+	} else if len(tags) > limits.MaxTags {
+		errs.add("tags", ErrTooManyTags)
+	}
+	if !isValidEstimate(t.EstimatedMinutes) {
+		errs.add("estimated_minutes", ErrInvalidEstimate)
+	}
+	if t.LoggedMinutes < 0 {
+		errs.add("logged_minutes", ErrInvalidTimeLog)
+	}
+	if t.OwnerID == "" {
+		errs.add("owner_id", ErrInvalidOwnerId)
+	}
+	if t.TenantID == "" {
+		errs.add("tenant_id", ErrInvalidTenantID)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func isValidEstimate(minutes *int32) bool {
+	return minutes == nil || (*minutes >= 0 && *minutes <= MaxEstimatedMinutes)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateTodoReportsEveryViolation(t *testing.T) {
+	past := time.Now().UTC().Add(-time.Hour)
+	future := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		todo   Todo
+		fields []string
+	}{
+		{
+			name: "valid",
+			todo: Todo{Title: "ok", Priority: PriorityLow, OwnerID: "alice", TenantID: "tenant-a", DueDate: &future},
+		},
+		{
+			name: "everything wrong",
+			todo: Todo{
+				Description:      strings.Repeat("x", DefaultLimits().MaxDescriptionLength+1),
+				Tags:             []string{""},
+				EstimatedMinutes: ptr(int32(-1)),
+				LoggedMinutes:    -1,
+			},
+			fields: []string{"title", "description", "priority", "tags", "estimated_minutes", "logged_minutes", "owner_id", "tenant_id"},
+		},
+		{
+			name:   "past due",
+			todo:   Todo{Title: "ok", Priority: PriorityLow, OwnerID: "alice", TenantID: "tenant-a", DueDate: &past},
+			fields: []string{"due_date"},
+		},
+		{
+			// An unknown zone leaves the deadline unknown, so it is the only report
+			name:   "unknown timezone",
+			todo:   Todo{Title: "ok", Priority: PriorityLow, OwnerID: "alice", TenantID: "tenant-a", DueDate: &past, DueDateTimezone: ptr("Mars/Olympus")},
+			fields: []string{"due_date_timezone"},
+		},
+		{
+			name:   "too many tags",
+			todo:   Todo{Title: "ok", Priority: PriorityLow, OwnerID: "alice", TenantID: "tenant-a", Tags: make([]string, DefaultLimits().MaxTags+1)},
+			fields: []string{"tags"},
+		},
+	}
+	for i := range tests[len(tests)-1].todo.Tags {
+		tests[len(tests)-1].todo.Tags[i] = strings.Repeat("t", i+1)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTodo(&tt.todo)
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("ValidateTodo() = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("ValidateTodo() = %v, want ValidationErrors", err)
+			}
+			var fields []string
+			for _, fe := range errs {
+				fields = append(fields, fe.Field)
+			}
+			if !slices.Equal(fields, tt.fields) {
+				t.Fatalf("fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestNewTodoValidationErrorsUnwrap(t *testing.T) {
+	_, err := NewTodo("", "", "alice", "tenant-a", 0)
+
+	for _, target := range []error{ErrEmptyTitle, ErrInvalidPriority} {
+		if !errors.Is(err, target) {
+			t.Errorf("errors.Is(%v, %v) = false", err, target)
+		}
+	}
+	if errors.Is(err, ErrInvalidOwnerId) {
+		t.Errorf("errors.Is(%v, %v) = true for a valid owner", err, ErrInvalidOwnerId)
+	}
+	if want := "title: title cannot be empty; priority: invalid priority value"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestSetAllowPastDueDate(t *testing.T) {
+	t.Cleanup(func() { SetAllowPastDueDate(false) })
+	past := time.Now().UTC().AddDate(0, 0, -2)
+
+	tests := []struct {
+		allow   bool
+		wantErr error
+	}{
+		{allow: false, wantErr: ErrDueDateInPast},
+		{allow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint("allow=", tt.allow), func(t *testing.T) {
+			SetAllowPastDueDate(tt.allow)
+			if AllowsPastDueDate() != tt.allow {
+				t.Fatalf("AllowsPastDueDate() = %v, want %v", AllowsPastDueDate(), tt.allow)
+			}
+
+			if _, err := NewTodo("todo", "", "alice", "tenant-a", PriorityLow, WithDueDate(&past)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewTodo with a past due date = %v, want %v", err, tt.wantErr)
+			}
+
+			todo, err := NewTodo("todo", "", "alice", "tenant-a", PriorityLow)
+			if err != nil {
+				t.Fatalf("NewTodo: %v", err)
+			}
+			if err := todo.SetDueDate(&past); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetDueDate in the past = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
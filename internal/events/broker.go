@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// Broker fans published events out to in-process subscribers watching a
+// single todo. It never blocks the publisher: a subscriber that has not
+// consumed its previous notification simply keeps that one, so watchers must
+// treat a notification as "reload the todo" rather than a full change log.
+type Broker struct {
+	mu     sync.RWMutex
+	subs   map[string]map[*subscription]struct{}
+	closed bool
+}
+
+type subscription struct {
+	ch chan domain.DomainEvent
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subs: make(map[string]map[*subscription]struct{}),
+	}
+}
+
+func (b *Broker) Publish(ctx context.Context, event domain.DomainEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs[event.AggregateID()] {
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe registers interest in events for todoID. The returned cancel
+// function unsubscribes and closes the channel; it is safe to call twice.
+func (b *Broker) Subscribe(todoID string) (<-chan domain.DomainEvent, func()) {
+	sub := &subscription{ch: make(chan domain.DomainEvent, 1)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+
+	if b.subs[todoID] == nil {
+		b.subs[todoID] = make(map[*subscription]struct{})
+	}
+	b.subs[todoID][sub] = struct{}{}
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() { b.unsubscribe(todoID, sub) })
+	}
+}
+
+func (b *Broker) unsubscribe(todoID string, sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs, ok := b.subs[todoID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subs, todoID)
+	}
+	close(sub.ch)
+}
+
+// Close closes every subscriber channel and rejects new subscriptions
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subs := range b.subs {
+		for sub := range subs {
+			close(sub.ch)
+		}
+	}
+	b.subs = make(map[string]map[*subscription]struct{})
+	b.closed = true
+}
+
+// Subscribers returns how many subscriptions are active across all todos
+func (b *Broker) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := 0
+	for _, subs := range b.subs {
+		n += len(subs)
+	}
+	return n
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// NoopPublisher discards every event
+type NoopPublisher struct{}
+
+func NewNoopPublisher() *NoopPublisher {
+	return &NoopPublisher{}
+}
+
+func (p *NoopPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	return nil
+}
+
+// InMemoryPublisher records published events, for tests and local development
+type InMemoryPublisher struct {
+	mu     sync.Mutex
+	events []domain.DomainEvent
+}
+
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{}
+}
+
+func (p *InMemoryPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns a copy of the events published so far
+func (p *InMemoryPublisher) Events() []domain.DomainEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]domain.DomainEvent, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+// Reset discards all recorded events
+func (p *InMemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = nil
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.uber.org/zap"
+)
+
+const defaultRelayBatchSize = 100
+
+// OutboxStore hands unsent outbox messages to a callback and marks the ones
+// it accepts as sent
+type OutboxStore interface {
+	ProcessOutbox(ctx context.Context, limit int, fn func(ctx context.Context, msg *domain.OutboxMessage) error) (int, error)
+}
+
+// Relay periodically delivers outbox messages through an EventPublisher,
+// giving at-least-once delivery for events written with their mutation.
+type Relay struct {
+	store     OutboxStore
+	publisher domain.EventPublisher
+	logger    *zap.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRelay(store OutboxStore, publisher domain.EventPublisher, logger *zap.Logger, interval time.Duration) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		logger:    logger,
+		interval:  interval,
+		batchSize: defaultRelayBatchSize,
+	}
+}
+
+// Run relays messages every interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Drain full batches before waiting for the next tick
+			for {
+				sent, err := r.RelayOnce(ctx)
+				if err != nil {
+					r.logger.Error("failed to relay outbox", zap.Error(err))
+					break
+				}
+				if sent < r.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RelayOnce delivers a single batch and returns how many messages were sent
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	return r.store.ProcessOutbox(ctx, r.batchSize, func(ctx context.Context, msg *domain.OutboxMessage) error {
+		if err := r.publisher.Publish(ctx, msg); err != nil {
+			r.logger.Warn("failed to publish outbox message",
+				zap.Error(err),
+				zap.Int64("outbox_id", msg.ID),
+				zap.String("event", msg.Name),
+				zap.Int("attempts", msg.Attempts+1),
+			)
+			return err
+		}
+		return nil
+	})
+}
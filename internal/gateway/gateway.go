@@ -0,0 +1,148 @@
+// Package gateway serves the gRPC API as HTTP/JSON so browsers can call it
+// without a separate proxy. Requests are forwarded to the gRPC server over a
+// client connection, so they pass through the same interceptors as native
+// gRPC calls.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Headers forwarded to the gRPC server as metadata besides Authorization,
+// which the gateway forwards by default
+var forwardedHeaders = []string{"x-api-key", "x-request-id"}
+
+const corsMaxAge = 10 * time.Minute
+
+// Config describes where the gateway listens and which server it fronts
+type Config struct {
+	// Endpoint is the address of the gRPC server, e.g. localhost:8080
+	Endpoint    string
+	DialOptions []grpc.DialOption
+
+	// AllowedOrigins lists the origins browsers may call from; "*" allows any
+	AllowedOrigins []string
+}
+
+// Gateway is an HTTP server translating JSON requests into gRPC calls
+type Gateway struct {
+	server *http.Server
+	conn   *grpc.ClientConn
+	logger *zap.Logger
+}
+
+func New(ctx context.Context, cfg Config, logger *zap.Logger) (*Gateway, error) {
+	conn, err := grpc.NewClient(cfg.Endpoint, cfg.DialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC endpoint: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(matchHeader),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+
+	if err := todov1.RegisterTodoServiceHandler(ctx, mux, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register todo service: %w", err)
+	}
+	if err := todov1.RegisterAuthServiceHandler(ctx, mux, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register auth service: %w", err)
+	}
+
+	return &Gateway{
+		server: &http.Server{
+			Handler:           withCORS(mux, cfg.AllowedOrigins),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		conn:   conn,
+		logger: logger,
+	}, nil
+}
+
+// Serve accepts HTTP connections on lis until Shutdown is called
+func (g *Gateway) Serve(lis net.Listener) error {
+	if err := g.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// ServeTLS is Serve over TLS with the given certificate and key files
+func (g *Gateway) ServeTLS(lis net.Listener, certFile, keyFile string) error {
+	if err := g.server.ServeTLS(lis, certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting requests, waits for in-flight ones until ctx is
+// done and closes the connection to the gRPC server
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	err := g.server.Shutdown(ctx)
+	if closeErr := g.conn.Close(); closeErr != nil {
+		g.logger.Warn("Failed to close gateway connection", zap.Error(closeErr))
+	}
+	return err
+}
+
+func matchHeader(key string) (string, bool) {
+	if lower := strings.ToLower(key); slices.Contains(forwardedHeaders, lower) {
+		return lower, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// withCORS answers preflight requests and marks responses readable by the
+// allowed origins
+func withCORS(next http.Handler, allowedOrigins []string) http.Handler {
+	allowHeaders := strings.Join(append([]string{"Authorization", "Content-Type"}, forwardedHeaders...), ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !originAllowed(origin, allowedOrigins) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(corsMaxAge.Seconds())))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func originAllowed(origin string, allowedOrigins []string) bool {
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
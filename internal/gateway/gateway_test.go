@@ -0,0 +1,208 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// todoServer answers GetTodo and CreateTodo and records the metadata of the
+// last call
+type todoServer struct {
+	todov1.UnimplementedTodoServiceServer
+	md metadata.MD
+}
+
+func (s *todoServer) GetTodo(ctx context.Context, req *todov1.GetTodoRequest) (*todov1.GetTodoResponse, error) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	if req.Id != "todo-1" {
+		return nil, status.Error(codes.NotFound, "todo not found")
+	}
+	return &todov1.GetTodoResponse{Todo: &todov1.Todo{Id: req.Id, Title: "write tests"}}, nil
+}
+
+func (s *todoServer) CreateTodo(ctx context.Context, req *todov1.CreateTodoRequest) (*todov1.CreateTodoResponse, error) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	return &todov1.CreateTodoResponse{Todo: &todov1.Todo{Id: "todo-2", Title: req.Title, Priority: req.Priority}}, nil
+}
+
+// newTestGateway serves the gateway over HTTP in front of an in-process gRPC
+// server backed by todos
+func newTestGateway(t *testing.T, todos *todoServer) *httptest.Server {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	todov1.RegisterTodoServiceServer(grpcServer, todos)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	gw, err := New(context.Background(), Config{
+		Endpoint: "passthrough:///bufnet",
+		DialOptions: []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		},
+		AllowedOrigins: []string{"https://app.example.com"},
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { gw.conn.Close() })
+
+	server := httptest.NewServer(gw.server.Handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGatewayRoundTrip(t *testing.T) {
+	todos := &todoServer{}
+	server := newTestGateway(t, todos)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/todos/todo-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-Id", "req-1")
+	req.Header.Set("X-Unrelated", "dropped")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var body map[string]map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["todo"]["id"] != "todo-1" || body["todo"]["title"] != "write tests" {
+		t.Errorf("todo = %v, want todo-1 titled write tests", body["todo"])
+	}
+	// Unpopulated fields are emitted under their proto names
+	if _, ok := body["todo"]["owner_id"]; !ok {
+		t.Errorf("todo = %v, want owner_id emitted", body["todo"])
+	}
+
+	if got := todos.md.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("authorization metadata = %v", got)
+	}
+	if got := todos.md.Get("x-request-id"); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("x-request-id metadata = %v", got)
+	}
+	if got := todos.md.Get("x-unrelated"); len(got) != 0 {
+		t.Errorf("unlisted header forwarded as %v", got)
+	}
+}
+
+func TestGatewayMapsRequestsAndErrors(t *testing.T) {
+	server := newTestGateway(t, &todoServer{})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		title  string
+	}{
+		{name: "not found", method: http.MethodGet, path: "/v1/todos/missing", status: http.StatusNotFound},
+		{
+			name:   "create with unknown field",
+			method: http.MethodPost,
+			path:   "/v1/todos",
+			body:   `{"title": "from json", "priority": "TODO_PRIORITY_HIGH", "unknown": 1}`,
+			status: http.StatusOK,
+			title:  "from json",
+		},
+		{name: "malformed body", method: http.MethodPost, path: "/v1/todos", body: `{"title":`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.title == "" {
+				return
+			}
+			var body map[string]map[string]any
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body["todo"]["title"] != tt.title || body["todo"]["priority"] != "TODO_PRIORITY_HIGH" {
+				t.Errorf("todo = %v, want title %q and high priority", body["todo"], tt.title)
+			}
+		})
+	}
+}
+
+func TestGatewayCORS(t *testing.T) {
+	server := newTestGateway(t, &todoServer{})
+
+	tests := []struct {
+		name   string
+		origin string
+		status int
+		allow  string
+	}{
+		{name: "allowed origin", origin: "https://app.example.com", status: http.StatusNoContent, allow: "https://app.example.com"},
+		{name: "other origin", origin: "https://evil.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodOptions, server.URL+"/v1/todos/todo-1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("OPTIONS: %v", err)
+			}
+			resp.Body.Close()
+
+			if got := resp.Header.Get("Access-Control-Allow-Origin"); got != tt.allow {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allow)
+			}
+			if tt.status != 0 && resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.allow != "" && !strings.Contains(resp.Header.Get("Access-Control-Allow-Headers"), "x-request-id") {
+				t.Errorf("Access-Control-Allow-Headers = %q, want x-request-id listed", resp.Header.Get("Access-Control-Allow-Headers"))
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const defaultPingTimeout = 2 * time.Second
+
+// Pinger checks that a dependency is reachable; *sql.DB satisfies it
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// StatusSetter receives serving status changes; *health.Server satisfies it
+type StatusSetter interface {
+	SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// DatabaseHealthChecker pings the database every interval and reports the
+// server NOT_SERVING after failureThreshold consecutive failures, and
+// SERVING again on the first successful ping.
+type DatabaseHealthChecker struct {
+	pinger           Pinger
+	status           StatusSetter
+	logger           *zap.Logger
+	interval         time.Duration
+	failureThreshold int
+	pingTimeout      time.Duration
+
+	failures int
+	serving  bool
+}
+
+func NewDatabaseHealthChecker(pinger Pinger, status StatusSetter, logger *zap.Logger, interval time.Duration, failureThreshold int) *DatabaseHealthChecker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	return &DatabaseHealthChecker{
+		pinger:           pinger,
+		status:           status,
+		logger:           logger,
+		interval:         interval,
+		failureThreshold: failureThreshold,
+		pingTimeout:      defaultPingTimeout,
+		serving:          true,
+	}
+}
+
+// Run checks every interval until ctx is cancelled
+func (c *DatabaseHealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
+
+// Check pings once and updates the serving status if it changed
+func (c *DatabaseHealthChecker) Check(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, c.pingTimeout)
+	defer cancel()
+
+	if err := c.pinger.PingContext(pingCtx); err != nil {
+		c.failures++
+		if c.serving && c.failures >= c.failureThreshold {
+			c.serving = false
+			c.status.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+			c.logger.Error("Database unreachable, reporting NOT_SERVING",
+				zap.Error(err),
+				zap.Int("consecutive_failures", c.failures),
+			)
+		}
+		return
+	}
+
+	c.failures = 0
+	if !c.serving {
+		c.serving = true
+		c.status.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		c.logger.Info("Database reachable again, reporting SERVING")
+	}
+}
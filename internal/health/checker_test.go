@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakePinger fails while err is set, and hangs until the ping is cut off
+// while hang is set
+type fakePinger struct {
+	err  error
+	hang bool
+}
+
+func (p *fakePinger) PingContext(ctx context.Context) error {
+	if p.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return p.err
+}
+
+// recordingStatus records every serving status change
+type recordingStatus struct {
+	changes []healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (s *recordingStatus) SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	s.changes = append(s.changes, servingStatus)
+}
+
+func TestDatabaseHealthChecker(t *testing.T) {
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	errDown := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		threshold int
+		pings     []fakePinger
+		changes   []healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:      "healthy",
+			threshold: 1,
+			pings:     []fakePinger{{}, {}, {}},
+		},
+		{
+			name:      "failing",
+			threshold: 2,
+			pings:     []fakePinger{{err: errDown}, {err: errDown}, {err: errDown}},
+			changes:   []healthpb.HealthCheckResponse_ServingStatus{notServing},
+		},
+		{
+			name:      "timeout",
+			threshold: 1,
+			pings:     []fakePinger{{hang: true}},
+			changes:   []healthpb.HealthCheckResponse_ServingStatus{notServing},
+		},
+		{
+			name:      "failure below the threshold",
+			threshold: 2,
+			pings:     []fakePinger{{err: errDown}, {}, {err: errDown}},
+		},
+		{
+			name:      "recovery",
+			threshold: 1,
+			pings:     []fakePinger{{err: errDown}, {}, {}},
+			changes:   []healthpb.HealthCheckResponse_ServingStatus{notServing, serving},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinger := &fakePinger{}
+			status := &recordingStatus{}
+			checker := NewDatabaseHealthChecker(pinger, status, zap.NewNop(), time.Second, tt.threshold)
+			checker.pingTimeout = 10 * time.Millisecond
+
+			for _, ping := range tt.pings {
+				*pinger = ping
+				checker.Check(context.Background())
+			}
+
+			if !slices.Equal(status.changes, tt.changes) {
+				t.Errorf("status changes = %v, want %v", status.changes, tt.changes)
+			}
+		})
+	}
+}
@@ -4,9 +4,31 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
 	"github.com/joho/godotenv"
+	"go.uber.org/zap/zapcore"
+)
+
+// Repository backends selectable with REPOSITORY
+const (
+	RepositoryPostgres = "postgres"
+	RepositoryMemory   = "memory"
+)
+
+// Delete modes selectable with DELETE_MODE
+const (
+	DeleteModeSoft = "soft"
+	DeleteModeHard = "hard"
+)
+
+// User directories selectable with USER_DIRECTORY
+const (
+	UserDirectoryNone     = "none"
+	UserDirectoryPostgres = "postgres"
 )
 
 type Config struct {
@@ -15,17 +37,43 @@ type Config struct {
 	Port        int
 	MetricsPort int
 
+	// HTTP/JSON gateway in front of the gRPC server
+	EnableGateway         bool
+	GatewayPort           int
+	GatewayAllowedOrigins []string // CORS origins allowed to call the gateway, "*" for any
+
 	// Database configuration
-	DatabaseURL     string
-	MigrationsPath  string
-	MaxOpenConns    int
-	MaxIdleConns    int
-	ConnMaxLifetime time.Duration
-	ConnMaxIdleTime time.Duration
+	Repository         string // "postgres", or "memory" for an in-process store without a database
+	DatabaseURL        string
+	DatabaseReplicaURL string // optional read-only replica serving reads
+	UserDirectory      string // "none", or "postgres" to check assignees against tenant_users
+	DeleteMode         string // "soft", or "hard" to erase deleted todos for good
+	IDScheme           string // "v7" for time-ordered ids, or "v4" for random ones
+	MigrationsPath     string
+	MaxOpenConns       int
+	MaxIdleConns       int
+	ConnMaxLifetime    time.Duration
+	ConnMaxIdleTime    time.Duration
+
+	// Retries of transient database errors
+	DBRetryMaxAttempts int // total attempts including the first; 1 disables retries
+	DBRetryBaseDelay   time.Duration
+	DBRetryMaxDelay    time.Duration
+
+	// Query diagnostics
+	SlowQueryThreshold  time.Duration // log repository operations running at least this long, 0 disables
+	EnableQueryAnalysis bool          // allow admins to run list queries under EXPLAIN ANALYZE
 
 	// Authentication & Authorization
-	JWTSecret     string
-	JWTExpiration time.Duration
+	JWTSecret            string
+	JWTPreviousSecrets   []string // still accepted for verification during rotation
+	JWTExpiration        time.Duration
+	JWTRefreshExpiration time.Duration // lifetime of issued refresh tokens
+	JWTAlgorithm         string        // HS256 (default), RS256, ES256, ...
+	JWTPublicKeyPath     string        // PEM public key for RS*/ES* algorithms
+	JWKSURL              string        // JWKS endpoint for RS*/ES* algorithms
+	APIKeys              []string      // service account keys as key:tenant_id:account_id:role1|role2
+	TenantIDPattern      string        // regexp tenant IDs in credentials must match in full
 
 	// Rate Limiting
 	RateLimitRPS   int
@@ -38,6 +86,7 @@ type Config struct {
 
 	// Observability
 	JaegerEndpoint      string
+	TraceSampleRatio    float64 // fraction of new traces sampled, 0 to 1
 	PrometheusNamespace string
 	LogLevel            string
 	LogFormat           string // json or console
@@ -45,17 +94,28 @@ type Config struct {
 	// Graceful Shutdown
 	ShutdownTimeout time.Duration
 
+	// Health Checking
+	HealthCheckInterval         time.Duration
+	HealthCheckFailureThreshold int // consecutive failed pings before reporting NOT_SERVING
+
 	// Feature Flags
-	EnableMetrics     bool
-	EnableTracing     bool
-	EnableHealthCheck bool
-	EnableReflection  bool
+	EnableMetrics        bool
+	EnablePayloadMetrics bool     // observe request/response sizes, adds per-message overhead
+	MetricsTenants       []string // tenants labeled individually in business metrics, the rest share one label
+	EnableTracing        bool
+	EnableHealthCheck    bool
+	EnableReflection     bool
 
 	// AWS Configuration
 	AWSRegion          string
 	SecretsManagerName string
 	UseSecretsManager  bool
 
+	// Attachments, stored in S3 in AWSRegion; disabled when no bucket is set
+	AttachmentsBucket   string
+	AttachmentURLExpiry time.Duration
+	MaxAttachmentSize   int64
+
 	// Cache Configuration (for idempotency)
 	CacheEnabled bool
 	CacheTTL     time.Duration
@@ -64,29 +124,94 @@ type Config struct {
 	// Timeouts
 	RequestTimeout  time.Duration
 	DatabaseTimeout time.Duration
+
+	// Event Outbox
+	OutboxPollInterval time.Duration
+
+	// Workflow
+	StatusTransitions   string // e.g. "pending=in_progress;in_progress=completed", empty uses the default workflow
+	EnableEscalation    bool   // periodically raise the priority of overdue todos
+	EscalationInterval  time.Duration
+	EnableReminders     bool          // periodically send reminders that come due
+	ReminderInterval    time.Duration // how often to scan for due reminders
+	ReminderWindow      time.Duration // how far ahead of its time a reminder may be sent
+	AllowPastDueDate    bool          // accept due dates in the past on create and update
+	BlockOnDependencies bool          // reject completing todos with open dependencies
+	PriorityWeights     string        // e.g. "high=5,critical=100" to rank priorities when sorting, empty sorts by value
+
+	// Data Retention
+	RetentionDays int // days soft-deleted todos are kept before purging, 0 disables
+
+	// Auto-archiving of completed todos
+	AutoArchiveDays      int // days after completion a completed todo is archived, 0 disables
+	AutoArchiveInterval  time.Duration
+	ArchiveRetentionDays int // days archived todos are kept before purging, 0 keeps them
+
+	// Tenant Quotas
+	MaxTodosPerTenant      int  // active todos a tenant may hold, 0 disables
+	AdminBypassTenantQuota bool // admins may create past the quota
+
+	// Field Limits
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	MaxTags              int
+	MaxTagLength         int
+
+	// Pagination
+	DefaultPageSize int
+	MaxPageSize     int // larger requested page sizes are clamped
+
+	// Batches
+	MaxBatchSize int // todos a BatchCreateTodos request may carry
 }
 
 func Load() (*Config, error) {
 	// Load .env file if exists (for local development)
 	_ = godotenv.Load()
 
+	environment := getEnv("ENVIRONMENT", "development")
+
 	cfg := &Config{
 		// Server
-		Environment: getEnv("ENVIRONMENT", "development"),
+		Environment: environment,
 		Port:        getEnvAsInt("PORT", 8080),
 		MetricsPort: getEnvAsInt("METRICS_PORT", 9090),
 
+		// Gateway
+		EnableGateway:         getEnvAsBool("ENABLE_GATEWAY", false),
+		GatewayPort:           getEnvAsInt("GATEWAY_PORT", 8081),
+		GatewayAllowedOrigins: getEnvAsSlice("GATEWAY_ALLOWED_ORIGINS", nil),
+
 		// Database
-		DatabaseURL:     getEnv("DATABASE_URL", ""),
-		MigrationsPath:  getEnv("MIGRATIONS_PATH", "./internal/infrastructure/postgres/migrations"),
-		MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
-		MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
-		ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
-		ConnMaxIdleTime: getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 1*time.Minute),
+		Repository:         getEnv("REPOSITORY", RepositoryPostgres),
+		DatabaseURL:        getEnv("DATABASE_URL", ""),
+		DatabaseReplicaURL: getEnv("DATABASE_REPLICA_URL", ""),
+		UserDirectory:      getEnv("USER_DIRECTORY", UserDirectoryNone),
+		DeleteMode:         getEnv("DELETE_MODE", DeleteModeSoft),
+		IDScheme:           getEnv("ID_SCHEME", string(domain.IDSchemeV7)),
+		MigrationsPath:     getEnv("MIGRATIONS_PATH", "./internal/infrastructure/postgres/migrations"),
+		MaxOpenConns:       getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:       getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
+		ConnMaxLifetime:    getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		ConnMaxIdleTime:    getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 1*time.Minute),
+
+		DBRetryMaxAttempts: getEnvAsInt("DB_RETRY_MAX_ATTEMPTS", 3),
+		DBRetryBaseDelay:   getEnvAsDuration("DB_RETRY_BASE_DELAY", 20*time.Millisecond),
+		DBRetryMaxDelay:    getEnvAsDuration("DB_RETRY_MAX_DELAY", 500*time.Millisecond),
+
+		SlowQueryThreshold:  getEnvAsDuration("SLOW_QUERY_THRESHOLD", 1*time.Second),
+		EnableQueryAnalysis: getEnvAsBool("ENABLE_QUERY_ANALYSIS", false),
 
 		// Auth
-		JWTSecret:     getEnv("JWT_SECRET", ""),
-		JWTExpiration: getEnvAsDuration("JWT_EXPIRATION", 24*time.Hour),
+		JWTSecret:            getEnv("JWT_SECRET", ""),
+		JWTPreviousSecrets:   getEnvAsSlice("JWT_PREVIOUS_SECRETS", nil),
+		JWTExpiration:        getEnvAsDuration("JWT_EXPIRATION", 24*time.Hour),
+		JWTRefreshExpiration: getEnvAsDuration("JWT_REFRESH_EXPIRATION", 30*24*time.Hour),
+		JWTAlgorithm:         getEnv("JWT_ALGORITHM", "HS256"),
+		JWTPublicKeyPath:     getEnv("JWT_PUBLIC_KEY_PATH", ""),
+		JWKSURL:              getEnv("JWKS_URL", ""),
+		APIKeys:              getEnvAsSlice("API_KEYS", nil),
+		TenantIDPattern:      getEnv("TENANT_ID_PATTERN", auth.DefaultTenantIDPattern),
 
 		// Rate Limiting
 		RateLimitRPS:   getEnvAsInt("RATE_LIMIT_RPS", 1000),
@@ -99,6 +224,7 @@ func Load() (*Config, error) {
 
 		// Observability
 		JaegerEndpoint:      getEnv("JAEGER_ENDPOINT", "http://localhost:14268/api/traces"),
+		TraceSampleRatio:    getEnvAsFloat("TRACE_SAMPLE_RATIO", 1.0),
 		PrometheusNamespace: getEnv("PROMETHEUS_NAMESPACE", "todo_service"),
 		LogLevel:            getEnv("LOG_LEVEL", "info"),
 		LogFormat:           getEnv("LOG_FORMAT", "json"),
@@ -106,17 +232,28 @@ func Load() (*Config, error) {
 		// Graceful Shutdown
 		ShutdownTimeout: getEnvAsDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
 
+		// Health Checking
+		HealthCheckInterval:         getEnvAsDuration("HEALTH_CHECK_INTERVAL", 10*time.Second),
+		HealthCheckFailureThreshold: getEnvAsInt("HEALTH_CHECK_FAILURE_THRESHOLD", 3),
+
 		// Feature Flags
-		EnableMetrics:     getEnvAsBool("ENABLE_METRICS", true),
-		EnableTracing:     getEnvAsBool("ENABLE_TRACING", true),
-		EnableHealthCheck: getEnvAsBool("ENABLE_HEALTH_CHECK", true),
-		EnableReflection:  getEnvAsBool("ENABLE_REFLECTION", false),
+		EnableMetrics:        getEnvAsBool("ENABLE_METRICS", true),
+		EnablePayloadMetrics: getEnvAsBool("ENABLE_PAYLOAD_METRICS", false),
+		MetricsTenants:       getEnvAsSlice("METRICS_TENANTS", nil),
+		EnableTracing:        getEnvAsBool("ENABLE_TRACING", true),
+		EnableHealthCheck:    getEnvAsBool("ENABLE_HEALTH_CHECK", true),
+		EnableReflection:     getEnvAsBool("ENABLE_REFLECTION", environment != "production"), // on outside production unless set
 
 		// AWS
 		AWSRegion:          getEnv("AWS_REGION", "us-east-1"),
 		SecretsManagerName: getEnv("SECRETS_MANAGER_NAME", ""),
 		UseSecretsManager:  getEnvAsBool("USE_SECRETS_MANAGER", false),
 
+		// Attachments
+		AttachmentsBucket:   getEnv("ATTACHMENTS_BUCKET", ""),
+		AttachmentURLExpiry: getEnvAsDuration("ATTACHMENT_URL_EXPIRY", 15*time.Minute),
+		MaxAttachmentSize:   int64(getEnvAsInt("MAX_ATTACHMENT_SIZE", 25*1024*1024)),
+
 		// Cache
 		CacheEnabled: getEnvAsBool("CACHE_ENABLED", true),
 		CacheTTL:     getEnvAsDuration("CACHE_TTL", 24*time.Hour),
@@ -125,6 +262,45 @@ func Load() (*Config, error) {
 		// Timeouts
 		RequestTimeout:  getEnvAsDuration("REQUEST_TIMEOUT", 30*time.Second),
 		DatabaseTimeout: getEnvAsDuration("DATABASE_TIMEOUT", 10*time.Second),
+
+		// Event Outbox
+		OutboxPollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", 2*time.Second),
+
+		// Workflow
+		StatusTransitions:   getEnv("STATUS_TRANSITIONS", ""),
+		EnableEscalation:    getEnvAsBool("ENABLE_ESCALATION", false),
+		EscalationInterval:  getEnvAsDuration("ESCALATION_INTERVAL", 1*time.Hour),
+		EnableReminders:     getEnvAsBool("ENABLE_REMINDERS", false),
+		ReminderInterval:    getEnvAsDuration("REMINDER_INTERVAL", 1*time.Minute),
+		ReminderWindow:      getEnvAsDuration("REMINDER_WINDOW", 1*time.Minute),
+		AllowPastDueDate:    getEnvAsBool("ALLOW_PAST_DUE_DATE", false),
+		BlockOnDependencies: getEnvAsBool("BLOCK_ON_DEPENDENCIES", false),
+		PriorityWeights:     getEnv("PRIORITY_WEIGHTS", ""),
+
+		// Data Retention
+		RetentionDays: getEnvAsInt("RETENTION_DAYS", 0),
+
+		// Auto-archiving
+		AutoArchiveDays:      getEnvAsInt("AUTO_ARCHIVE_DAYS", 0),
+		AutoArchiveInterval:  getEnvAsDuration("AUTO_ARCHIVE_INTERVAL", 1*time.Hour),
+		ArchiveRetentionDays: getEnvAsInt("ARCHIVE_RETENTION_DAYS", 0),
+
+		// Tenant Quotas
+		MaxTodosPerTenant:      getEnvAsInt("MAX_TODOS_PER_TENANT", 0),
+		AdminBypassTenantQuota: getEnvAsBool("ADMIN_BYPASS_TENANT_QUOTA", true),
+
+		// Field Limits
+		MaxTitleLength:       getEnvAsInt("MAX_TITLE_LENGTH", domain.DefaultLimits().MaxTitleLength),
+		MaxDescriptionLength: getEnvAsInt("MAX_DESCRIPTION_LENGTH", domain.DefaultLimits().MaxDescriptionLength),
+		MaxTags:              getEnvAsInt("MAX_TAGS", domain.DefaultLimits().MaxTags),
+		MaxTagLength:         getEnvAsInt("MAX_TAG_LENGTH", domain.DefaultLimits().MaxTagLength),
+
+		// Pagination
+		DefaultPageSize: getEnvAsInt("DEFAULT_PAGE_SIZE", domain.DefaultPageSizes().Default),
+		MaxPageSize:     getEnvAsInt("MAX_PAGE_SIZE", domain.DefaultPageSizes().Max),
+
+		// Batches
+		MaxBatchSize: getEnvAsInt("MAX_BATCH_SIZE", 100),
 	}
 
 	// Validate configuration
@@ -136,14 +312,60 @@ func Load() (*Config, error) {
 }
 
 func (c *Config) Validate() error {
-	// Database URL is required
-	if c.DatabaseURL == "" {
-		return fmt.Errorf("DATABASE_URL is required")
+	switch c.Repository {
+	case RepositoryPostgres:
+		// Database URL is required
+		if c.DatabaseURL == "" {
+			return fmt.Errorf("DATABASE_URL is required")
+		}
+	case RepositoryMemory:
+	default:
+		return fmt.Errorf("invalid REPOSITORY: %q, must be %q or %q", c.Repository, RepositoryPostgres, RepositoryMemory)
+	}
+
+	switch c.UserDirectory {
+	case UserDirectoryNone:
+	case UserDirectoryPostgres:
+		if c.Repository != RepositoryPostgres {
+			return fmt.Errorf("USER_DIRECTORY=%s requires REPOSITORY=%s", UserDirectoryPostgres, RepositoryPostgres)
+		}
+	default:
+		return fmt.Errorf("invalid USER_DIRECTORY: %q, must be %q or %q", c.UserDirectory, UserDirectoryNone, UserDirectoryPostgres)
+	}
+
+	if c.DeleteMode != DeleteModeSoft && c.DeleteMode != DeleteModeHard {
+		return fmt.Errorf("invalid DELETE_MODE: %q, must be %q or %q", c.DeleteMode, DeleteModeSoft, DeleteModeHard)
+	}
+
+	if err := domain.IDScheme(c.IDScheme).Validate(); err != nil {
+		return err
+	}
+
+	// JWT verification material depends on the configured algorithm
+	switch strings.ToUpper(c.JWTAlgorithm) {
+	case "HS256", "HS384", "HS512":
+		// JWT secret is required in production
+		if c.Environment == "production" && c.JWTSecret == "" {
+			return fmt.Errorf("JWT_SECRET is required in production")
+		}
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512":
+		if c.JWTPublicKeyPath == "" && c.JWKSURL == "" {
+			return fmt.Errorf("JWT_PUBLIC_KEY_PATH or JWKS_URL is required for %s", c.JWTAlgorithm)
+		}
+	default:
+		return fmt.Errorf("invalid JWT algorithm: %s", c.JWTAlgorithm)
 	}
 
-	// JWT secret is required in production
-	if c.Environment == "production" && c.JWTSecret == "" {
-		return fmt.Errorf("JWT_SECRET is required in production")
+	if c.JWTExpiration <= 0 || c.JWTRefreshExpiration < c.JWTExpiration {
+		return fmt.Errorf("JWT lifetimes must satisfy 0 < expiration (%s) <= refresh expiration (%s)",
+			c.JWTExpiration, c.JWTRefreshExpiration)
+	}
+
+	if _, err := auth.ParseStaticAPIKeyStore(c.APIKeys); err != nil {
+		return fmt.Errorf("invalid API_KEYS: %w", err)
+	}
+	if _, err := c.GetTenantIDValidator(); err != nil {
+		return err
 	}
 
 	// TLS files must exist if TLS is enabled
@@ -166,6 +388,14 @@ func (c *Config) Validate() error {
 	if c.MetricsPort < 1 || c.MetricsPort > 65535 {
 		return fmt.Errorf("invalid metrics port: %d", c.MetricsPort)
 	}
+	if c.EnableGateway {
+		if c.GatewayPort < 1 || c.GatewayPort > 65535 {
+			return fmt.Errorf("invalid gateway port: %d", c.GatewayPort)
+		}
+		if c.GatewayPort == c.Port {
+			return fmt.Errorf("gateway port must differ from the gRPC port %d", c.Port)
+		}
+	}
 
 	// Connection pool validation
 	if c.MaxOpenConns < c.MaxIdleConns {
@@ -173,15 +403,115 @@ func (c *Config) Validate() error {
 			c.MaxOpenConns, c.MaxIdleConns)
 	}
 
-	// Log level validation
-	validLogLevels := map[string]bool{
-		"debug": true,
-		"info":  true,
-		"warn":  true,
-		"error": true,
+	// Retry validation
+	if c.DBRetryMaxAttempts < 1 {
+		return fmt.Errorf("invalid database retry attempts: %d", c.DBRetryMaxAttempts)
+	}
+	if c.DBRetryBaseDelay < 0 || c.DBRetryMaxDelay < c.DBRetryBaseDelay {
+		return fmt.Errorf("database retry delays must satisfy 0 <= base (%s) <= max (%s)",
+			c.DBRetryBaseDelay, c.DBRetryMaxDelay)
+	}
+	if c.SlowQueryThreshold < 0 {
+		return fmt.Errorf("invalid slow query threshold: %s", c.SlowQueryThreshold)
+	}
+
+	// Health check validation
+	if c.EnableHealthCheck {
+		if c.HealthCheckInterval <= 0 {
+			return fmt.Errorf("invalid health check interval: %s", c.HealthCheckInterval)
+		}
+		if c.HealthCheckFailureThreshold < 1 {
+			return fmt.Errorf("invalid health check failure threshold: %d", c.HealthCheckFailureThreshold)
+		}
+	}
+
+	// Outbox validation
+	if c.OutboxPollInterval <= 0 {
+		return fmt.Errorf("invalid outbox poll interval: %s", c.OutboxPollInterval)
+	}
+
+	// Workflow validation
+	transitions, err := domain.ParseTransitionPolicy(c.StatusTransitions)
+	if err != nil {
+		return fmt.Errorf("invalid status transitions: %w", err)
+	}
+	if _, err := domain.ParsePriorityWeights(c.PriorityWeights); err != nil {
+		return fmt.Errorf("invalid priority weights: %w", err)
+	}
+
+	if c.EnableEscalation && c.EscalationInterval <= 0 {
+		return fmt.Errorf("invalid escalation interval: %s", c.EscalationInterval)
 	}
-	if !validLogLevels[c.LogLevel] {
-		return fmt.Errorf("invalid log level: %s (valid: debug, info, warn, error)", c.LogLevel)
+
+	if c.EnableReminders {
+		if c.ReminderInterval <= 0 {
+			return fmt.Errorf("invalid reminder interval: %s", c.ReminderInterval)
+		}
+		if c.ReminderWindow < 0 {
+			return fmt.Errorf("invalid reminder window: %s", c.ReminderWindow)
+		}
+	}
+
+	// Retention validation
+	if c.RetentionDays < 0 {
+		return fmt.Errorf("invalid retention days: %d", c.RetentionDays)
+	}
+
+	// Auto-archive validation
+	if c.ArchiveRetentionDays < 0 {
+		return fmt.Errorf("invalid archive retention days: %d", c.ArchiveRetentionDays)
+	}
+	if c.AutoArchiveDays < 0 {
+		return fmt.Errorf("invalid auto archive days: %d", c.AutoArchiveDays)
+	}
+	if c.AutoArchiveDays > 0 {
+		if c.AutoArchiveInterval <= 0 {
+			return fmt.Errorf("invalid auto archive interval: %s", c.AutoArchiveInterval)
+		}
+		if !transitions.Allows(domain.StatusCompleted, domain.StatusArchived) {
+			return fmt.Errorf("auto archive requires status transitions to allow completed -> archived")
+		}
+	}
+
+	// Quota validation
+	if c.MaxTodosPerTenant < 0 {
+		return fmt.Errorf("invalid max todos per tenant: %d", c.MaxTodosPerTenant)
+	}
+
+	// Attachment validation
+	if c.AttachmentsBucket != "" {
+		if c.AttachmentURLExpiry <= 0 || c.AttachmentURLExpiry > 7*24*time.Hour {
+			return fmt.Errorf("invalid attachment URL expiry: %s", c.AttachmentURLExpiry)
+		}
+		if c.MaxAttachmentSize <= 0 {
+			return fmt.Errorf("invalid max attachment size: %d", c.MaxAttachmentSize)
+		}
+	}
+
+	// Field limit validation
+	if err := c.GetLimits().Validate(); err != nil {
+		return err
+	}
+
+	// Pagination validation
+	if err := c.GetPageSizes().Validate(); err != nil {
+		return err
+	}
+
+	// Batch validation
+	if c.MaxBatchSize <= 0 {
+		return fmt.Errorf("invalid max batch size: %d", c.MaxBatchSize)
+	}
+
+	// Tracing validation; written this way round to reject NaN as well
+	if !(c.TraceSampleRatio >= 0 && c.TraceSampleRatio <= 1) {
+		return fmt.Errorf("trace sample ratio must be between 0 and 1, got %g", c.TraceSampleRatio)
+	}
+
+	// Log level validation; parsed as the logger will parse it, which also
+	// knows levels above error that would silence the service
+	if level, err := zapcore.ParseLevel(c.LogLevel); err != nil || level > zapcore.ErrorLevel {
+		return fmt.Errorf("invalid log level: %q (valid: debug, info, warn, error)", c.LogLevel)
 	}
 
 	// Log format validation
@@ -192,6 +522,15 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// JWTSecrets returns every secret accepted for token verification, primary first.
+func (c *Config) JWTSecrets() []string {
+	secrets := make([]string, 0, len(c.JWTPreviousSecrets)+1)
+	if c.JWTSecret != "" {
+		secrets = append(secrets, c.JWTSecret)
+	}
+	return append(secrets, c.JWTPreviousSecrets...)
+}
+
 func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development" || c.Environment == "dev"
 }
@@ -220,6 +559,19 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -246,6 +598,21 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return value
 }
 
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	values := make([]string, 0)
+	for _, v := range strings.Split(valueStr, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 type DatabaseConfig struct {
 	URL             string
 	MaxOpenConns    int
@@ -266,6 +633,56 @@ func (c *Config) GetDatabaseConfig() DatabaseConfig {
 	}
 }
 
+func (c *Config) GetJWTKeyConfig() auth.KeyConfig {
+	return auth.KeyConfig{
+		Algorithm:     c.JWTAlgorithm,
+		Secrets:       c.JWTSecrets(),
+		PublicKeyPath: c.JWTPublicKeyPath,
+		JWKSURL:       c.JWKSURL,
+	}
+}
+
+// GetTenantIDValidator returns the check applied to tenant IDs in credentials
+func (c *Config) GetTenantIDValidator() (*auth.TenantIDValidator, error) {
+	return auth.NewTenantIDValidator(c.TenantIDPattern)
+}
+
+// GetAPIKeyStore returns the service accounts seeded from API_KEYS, or nil
+// when none are configured
+func (c *Config) GetAPIKeyStore() (auth.APIKeyStore, error) {
+	if len(c.APIKeys) == 0 {
+		return nil, nil
+	}
+	return auth.ParseStaticAPIKeyStore(c.APIKeys)
+}
+
+// GetTokenIssuerConfig describes how issued tokens are signed, using the
+// primary secret only
+func (c *Config) GetTokenIssuerConfig() auth.IssuerConfig {
+	return auth.IssuerConfig{
+		Algorithm:  c.JWTAlgorithm,
+		Secret:     c.JWTSecret,
+		AccessTTL:  c.JWTExpiration,
+		RefreshTTL: c.JWTRefreshExpiration,
+	}
+}
+
+func (c *Config) GetLimits() domain.Limits {
+	return domain.Limits{
+		MaxTitleLength:       c.MaxTitleLength,
+		MaxDescriptionLength: c.MaxDescriptionLength,
+		MaxTags:              c.MaxTags,
+		MaxTagLength:         c.MaxTagLength,
+	}
+}
+
+func (c *Config) GetPageSizes() domain.PageSizes {
+	return domain.PageSizes{
+		Default: c.DefaultPageSize,
+		Max:     c.MaxPageSize,
+	}
+}
+
 type ServerConfig struct {
 	Port            int
 	MetricsPort     int
@@ -289,21 +706,25 @@ func (c *Config) GetServerConfig() ServerConfig {
 }
 
 type ObservabilityConfig struct {
-	EnableMetrics       bool
-	EnableTracing       bool
-	JaegerEndpoint      string
-	PrometheusNamespace string
-	LogLevel            string
-	LogFormat           string
+	EnableMetrics        bool
+	EnablePayloadMetrics bool
+	EnableTracing        bool
+	JaegerEndpoint       string
+	TraceSampleRatio     float64
+	PrometheusNamespace  string
+	LogLevel             string
+	LogFormat            string
 }
 
 func (c *Config) GetObservabilityConfig() ObservabilityConfig {
 	return ObservabilityConfig{
-		EnableMetrics:       c.EnableMetrics,
-		EnableTracing:       c.EnableTracing,
-		JaegerEndpoint:      c.JaegerEndpoint,
-		PrometheusNamespace: c.PrometheusNamespace,
-		LogLevel:            c.LogLevel,
-		LogFormat:           c.LogFormat,
+		EnableMetrics:        c.EnableMetrics,
+		EnablePayloadMetrics: c.EnablePayloadMetrics,
+		EnableTracing:        c.EnableTracing,
+		JaegerEndpoint:       c.JaegerEndpoint,
+		TraceSampleRatio:     c.TraceSampleRatio,
+		PrometheusNamespace:  c.PrometheusNamespace,
+		LogLevel:             c.LogLevel,
+		LogFormat:            c.LogFormat,
 	}
 }
@@ -0,0 +1,502 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+)
+
+// setBaseEnv sets the minimum environment Load needs to succeed
+func setBaseEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("REPOSITORY", RepositoryMemory)
+	t.Setenv("JWT_SECRET", "test-secret")
+}
+
+func TestLoadValidatesLogLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		wantErr bool
+	}{
+		{level: "debug"},
+		{level: "info"},
+		{level: "warn"},
+		{level: "error"},
+		{level: "WARN"},
+		{level: "verbose", wantErr: true},
+		{level: "fatal", wantErr: true},
+		{level: " info", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("LOG_LEVEL", tt.level)
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.LogLevel != tt.level {
+				t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, tt.level)
+			}
+		})
+	}
+}
+
+func TestLoadFieldLimits(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		setBaseEnv(t)
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := cfg.GetLimits(); got != domain.DefaultLimits() {
+			t.Errorf("GetLimits() = %+v, want %+v", got, domain.DefaultLimits())
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		setBaseEnv(t)
+		t.Setenv("MAX_TITLE_LENGTH", "80")
+		t.Setenv("MAX_DESCRIPTION_LENGTH", "500")
+		t.Setenv("MAX_TAGS", "5")
+		t.Setenv("MAX_TAG_LENGTH", "16")
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		want := domain.Limits{MaxTitleLength: 80, MaxDescriptionLength: 500, MaxTags: 5, MaxTagLength: 16}
+		if got := cfg.GetLimits(); got != want {
+			t.Errorf("GetLimits() = %+v, want %+v", got, want)
+		}
+	})
+
+	for _, key := range []string{"MAX_TITLE_LENGTH", "MAX_DESCRIPTION_LENGTH", "MAX_TAGS", "MAX_TAG_LENGTH"} {
+		t.Run(key+" zero", func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv(key, "0")
+			if _, err := Load(); err == nil {
+				t.Fatalf("Load() accepted %s=0", key)
+			}
+		})
+	}
+}
+
+func TestLoadPageSizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    domain.PageSizes
+		wantErr bool
+	}{
+		{name: "defaults", want: domain.DefaultPageSizes()},
+		{
+			name: "configured",
+			env:  map[string]string{"DEFAULT_PAGE_SIZE": "25", "MAX_PAGE_SIZE": "200"},
+			want: domain.PageSizes{Default: 25, Max: 200},
+		},
+		{name: "max below default", env: map[string]string{"DEFAULT_PAGE_SIZE": "50", "MAX_PAGE_SIZE": "10"}, wantErr: true},
+		{name: "zero default", env: map[string]string{"DEFAULT_PAGE_SIZE": "0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.GetPageSizes() != tt.want {
+				t.Errorf("GetPageSizes() = %+v, want %+v", cfg.GetPageSizes(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSlowQueryThreshold(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: time.Second},
+		{value: "250ms", want: 250 * time.Millisecond},
+		{value: "0s", want: 0},
+		{value: "-1s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			setBaseEnv(t)
+			if tt.value != "" {
+				t.Setenv("SLOW_QUERY_THRESHOLD", tt.value)
+			}
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.SlowQueryThreshold != tt.want {
+				t.Errorf("SlowQueryThreshold = %v, want %v", cfg.SlowQueryThreshold, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTokenLifetimes(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    auth.IssuerConfig
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: auth.IssuerConfig{Algorithm: "HS256", Secret: "test-secret", AccessTTL: 24 * time.Hour, RefreshTTL: 30 * 24 * time.Hour},
+		},
+		{
+			name: "configured",
+			env:  map[string]string{"JWT_EXPIRATION": "15m", "JWT_REFRESH_EXPIRATION": "168h"},
+			want: auth.IssuerConfig{Algorithm: "HS256", Secret: "test-secret", AccessTTL: 15 * time.Minute, RefreshTTL: 168 * time.Hour},
+		},
+		{name: "refresh shorter than access", env: map[string]string{"JWT_EXPIRATION": "2h", "JWT_REFRESH_EXPIRATION": "1h"}, wantErr: true},
+		{name: "no access lifetime", env: map[string]string{"JWT_EXPIRATION": "0s"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.GetTokenIssuerConfig() != tt.want {
+				t.Errorf("GetTokenIssuerConfig() = %+v, want %+v", cfg.GetTokenIssuerConfig(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      string
+		wantStore bool
+		wantErr   bool
+	}{
+		{name: "unset"},
+		{name: "configured", keys: "key-1:tenant-1:svc-1:user, key-2:tenant-1:svc-2:user|manager", wantStore: true},
+		{name: "malformed entry", keys: "key-1:tenant-1:svc-1:user,key-2:tenant-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("API_KEYS", tt.keys)
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			store, err := cfg.GetAPIKeyStore()
+			if err != nil {
+				t.Fatalf("GetAPIKeyStore: %v", err)
+			}
+			if (store != nil) != tt.wantStore {
+				t.Fatalf("GetAPIKeyStore() = %v, want a store %v", store, tt.wantStore)
+			}
+			if store == nil {
+				return
+			}
+			if account, err := store.LookupAPIKey(context.Background(), "key-2"); err != nil || account.UserID != "svc-2" {
+				t.Errorf("LookupAPIKey(key-2) = %+v, %v, want svc-2", account, err)
+			}
+		})
+	}
+}
+
+func TestLoadAllowPastDueDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "true", want: true},
+		{value: "false", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("ALLOW_PAST_DUE_DATE", tt.value)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.AllowPastDueDate != tt.want {
+				t.Errorf("AllowPastDueDate = %v, want %v", cfg.AllowPastDueDate, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadBlockOnDependencies(t *testing.T) {
+	setBaseEnv(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.BlockOnDependencies {
+		t.Error("BlockOnDependencies enabled by default")
+	}
+
+	t.Setenv("BLOCK_ON_DEPENDENCIES", "true")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.BlockOnDependencies {
+		t.Error("BLOCK_ON_DEPENDENCIES=true left BlockOnDependencies disabled")
+	}
+}
+
+func TestLoadReminders(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "disabled ignores the interval", env: map[string]string{"REMINDER_INTERVAL": "0s"}},
+		{name: "enabled", env: map[string]string{"ENABLE_REMINDERS": "true", "REMINDER_INTERVAL": "30s", "REMINDER_WINDOW": "0s"}},
+		{name: "no interval", env: map[string]string{"ENABLE_REMINDERS": "true", "REMINDER_INTERVAL": "0s"}, wantErr: true},
+		{name: "negative window", env: map[string]string{"ENABLE_REMINDERS": "true", "REMINDER_WINDOW": "-1m"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			if _, err := Load(); (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadTraceSampleRatio(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{value: "", want: 1},
+		{value: "0", want: 0},
+		{value: "0.25", want: 0.25},
+		{value: "1", want: 1},
+		{value: "not-a-number", want: 1},
+		{value: "1.5", wantErr: true},
+		{value: "-0.1", wantErr: true},
+		{value: "NaN", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("TRACE_SAMPLE_RATIO", tt.value)
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.GetObservabilityConfig().TraceSampleRatio != tt.want {
+				t.Errorf("TraceSampleRatio = %v, want %v", cfg.GetObservabilityConfig().TraceSampleRatio, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTenantIDPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		tenantID string
+		wantErr  bool
+	}{
+		{name: "default", tenantID: "tenant-1"},
+		{name: "custom", pattern: `t-[0-9]+`, tenantID: "t-42"},
+		{name: "invalid", pattern: `[`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			if tt.pattern != "" {
+				t.Setenv("TENANT_ID_PATTERN", tt.pattern)
+			}
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			tenantIDs, err := cfg.GetTenantIDValidator()
+			if err != nil {
+				t.Fatalf("GetTenantIDValidator: %v", err)
+			}
+			if err := tenantIDs.ValidateTenantID(tt.tenantID); err != nil {
+				t.Errorf("ValidateTenantID(%q) = %v", tt.tenantID, err)
+			}
+		})
+	}
+}
+
+func TestLoadRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "memory needs no database", env: map[string]string{"REPOSITORY": RepositoryMemory}, want: RepositoryMemory},
+		{name: "postgres by default", env: map[string]string{"REPOSITORY": "", "DATABASE_URL": "postgres://localhost/todos"}, want: RepositoryPostgres},
+		{name: "postgres without database", env: map[string]string{"REPOSITORY": RepositoryPostgres}, wantErr: true},
+		{name: "unknown", env: map[string]string{"REPOSITORY": "sqlite"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("DATABASE_URL", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Repository != tt.want {
+				t.Errorf("Repository = %q, want %q", cfg.Repository, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadUserDirectory(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "none by default"},
+		{name: "postgres", env: map[string]string{"USER_DIRECTORY": UserDirectoryPostgres, "REPOSITORY": RepositoryPostgres, "DATABASE_URL": "postgres://localhost/todos"}},
+		{name: "postgres without the postgres repository", env: map[string]string{"USER_DIRECTORY": UserDirectoryPostgres}, wantErr: true},
+		{name: "unknown", env: map[string]string{"USER_DIRECTORY": "ldap"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			if _, err := Load(); (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadPriorityWeights(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: ""},
+		{value: "high=5,critical=100"},
+		{value: "urgent=5", wantErr: true},
+		{value: "high=five", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("PRIORITY_WEIGHTS", tt.value)
+
+			if _, err := Load(); (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadAutoArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "disabled ignores the interval", env: map[string]string{"AUTO_ARCHIVE_INTERVAL": "0s"}},
+		{name: "enabled", env: map[string]string{"AUTO_ARCHIVE_DAYS": "30", "AUTO_ARCHIVE_INTERVAL": "10m"}},
+		{name: "negative days", env: map[string]string{"AUTO_ARCHIVE_DAYS": "-1"}, wantErr: true},
+		{name: "no interval", env: map[string]string{"AUTO_ARCHIVE_DAYS": "30", "AUTO_ARCHIVE_INTERVAL": "0s"}, wantErr: true},
+		{name: "workflow without archiving", env: map[string]string{"AUTO_ARCHIVE_DAYS": "30", "STATUS_TRANSITIONS": "pending=in_progress;in_progress=completed"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBaseEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			if _, err := Load(); (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadIDScheme(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		wantErr bool
+	}{
+		{scheme: "v7"},
+		{scheme: "v4"},
+		{scheme: "V7", wantErr: true},
+		{scheme: "v1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv("ID_SCHEME", tt.scheme)
+
+			if _, err := Load(); (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
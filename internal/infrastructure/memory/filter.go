@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// match returns the stored todos filter selects, in no particular order. It
+// follows the WHERE clause the Postgres store builds for the same filter.
+// The caller must hold mu.
+func (r *InMemoryRepository) match(filter *domain.ListFilter) []*domain.Todo {
+	now := time.Now().UTC()
+
+	matched := make([]*domain.Todo, 0)
+	for _, todo := range r.todos {
+		if matches(todo, filter, now) {
+			matched = append(matched, todo)
+		}
+	}
+	return matched
+}
+
+func matches(todo *domain.Todo, filter *domain.ListFilter, now time.Time) bool {
+	if todo.TenantID != filter.TenantID {
+		return false
+	}
+	if !filter.IncludeDeleted && todo.DeletedAt != nil {
+		return false
+	}
+	if filter.OwnerID != nil && todo.OwnerID != *filter.OwnerID {
+		return false
+	}
+	if filter.AssignedTo != nil && (todo.AssignedTo == nil || *todo.AssignedTo != *filter.AssignedTo) {
+		return false
+	}
+	if filter.VisibleTo != nil && todo.OwnerID != *filter.VisibleTo && (todo.AssignedTo == nil || *todo.AssignedTo != *filter.VisibleTo) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, todo.Status) {
+		return false
+	}
+	if len(filter.Priorities) > 0 && !slices.Contains(filter.Priorities, todo.Priority) {
+		return false
+	}
+	if len(filter.Tags) > 0 && !sharesTag(todo.Tags, filter.Tags) {
+		return false
+	}
+	if len(filter.ExcludeTags) > 0 && sharesTag(todo.Tags, filter.ExcludeTags) {
+		return false
+	}
+	if filter.AssignedToIsNull != nil && (todo.AssignedTo == nil) != *filter.AssignedToIsNull {
+		return false
+	}
+	if filter.DueDateIsNull != nil && (todo.DueDate == nil) != *filter.DueDateIsNull {
+		return false
+	}
+	if !inRange(todo.DueDate, filter.DueDateFrom, filter.DueDateTo) {
+		return false
+	}
+	if filter.DueBefore != nil && (todo.DueDate == nil || !todo.DueDate.Before(*filter.DueBefore)) {
+		return false
+	}
+	if !inRange(&todo.CreatedAt, filter.CreatedFrom, filter.CreatedTo) {
+		return false
+	}
+	if !inRange(&todo.UpdatedAt, filter.UpdatedFrom, filter.UpdatedTo) {
+		return false
+	}
+	if filter.OverdueOnly && !todo.IsOverdue(now) {
+		return false
+	}
+	if filter.SearchQuery != nil && !containsFold(todo.Title, *filter.SearchQuery) && !containsFold(todo.Description, *filter.SearchQuery) {
+		return false
+	}
+	return true
+}
+
+func sharesTag(tags, wanted []string) bool {
+	for _, tag := range tags {
+		if slices.Contains(wanted, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange reports whether t lies within the inclusive bounds; like SQL, an
+// unset t matches no bound
+func inRange(t, from, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	if from != nil && t.Before(*from) {
+		return false
+	}
+	if to != nil && t.After(*to) {
+		return false
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// sortTodos orders todos the way the Postgres store's ORDER BY does,
+// breaking ties by id
+func sortTodos(todos []*domain.Todo, filter *domain.ListFilter) {
+	specs := filter.Sort
+	if len(specs) == 0 {
+		sortBy := "created_at"
+		if domain.IsSortableField(filter.SortBy) {
+			sortBy = filter.SortBy
+		}
+		specs = []domain.SortSpec{{Field: sortBy, Ascending: filter.SortAscending}}
+	}
+
+	slices.SortFunc(todos, func(a, b *domain.Todo) int {
+		for _, spec := range specs {
+			c := compareField(a, b, spec.Field, filter.PriorityWeights)
+			if !spec.Ascending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// compareField compares a field of two todos in ascending order; as in
+// Postgres, unset times sort after every set one
+func compareField(a, b *domain.Todo, field string, weights *domain.PriorityWeights) int {
+	switch field {
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case "due_date":
+		return compareTimePtr(a.DueDate, b.DueDate)
+	case "archived_at":
+		return compareTimePtr(a.ArchivedAt, b.ArchivedAt)
+	case "priority":
+		return cmp.Compare(weights.Weight(a.Priority), weights.Weight(b.Priority))
+	case "status":
+		return cmp.Compare(a.Status, b.Status)
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	}
+	return 0
+}
+
+// compareTimePtr orders unset times after every set one
+func compareTimePtr(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
+
+// paginate returns the page of todos filter asks for
+func paginate(todos []*domain.Todo, filter *domain.ListFilter) []*domain.Todo {
+	if filter.Page < 1 || filter.PageSize < 1 {
+		return nil
+	}
+	offset := (filter.Page - 1) * filter.PageSize
+	if offset >= len(todos) {
+		return nil
+	}
+	return todos[offset:min(offset+filter.PageSize, len(todos))]
+}
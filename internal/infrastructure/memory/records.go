@@ -0,0 +1,438 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// ListTags returns up to limit distinct tags of the tenant's non-deleted
+// todos that start with prefix, ignoring case, most used first
+func (r *InMemoryRepository) ListTags(ctx context.Context, tenantID, prefix string, limit int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefix = strings.ToLower(prefix)
+	uses := make(map[string]int)
+	for _, todo := range r.todos {
+		if todo.TenantID != tenantID || todo.DeletedAt != nil {
+			continue
+		}
+		for _, tag := range todo.Tags {
+			if strings.HasPrefix(strings.ToLower(tag), prefix) {
+				uses[tag]++
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(uses))
+	for tag := range uses {
+		tags = append(tags, tag)
+	}
+	slices.SortFunc(tags, func(a, b string) int {
+		if c := cmp.Compare(uses[b], uses[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	if limit >= 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags, nil
+}
+
+// AddTagToMany adds tag to the tenant's non-deleted todos among ids that do
+// not have it yet and returns how many changed. It fails with
+// domain.ErrTooManyTags, changing nothing, if any of them would exceed the
+// tag limit.
+func (r *InMemoryRepository) AddTagToMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error) {
+	return r.updateTagOfMany(ctx, tenantID, ids, func(tags []string) ([]string, bool) {
+		if slices.Contains(tags, tag) {
+			return nil, false
+		}
+		return append(slices.Clone(tags), tag), true
+	})
+}
+
+// RemoveTagFromMany removes tag from the tenant's non-deleted todos among
+// ids that have it and returns how many changed
+func (r *InMemoryRepository) RemoveTagFromMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error) {
+	return r.updateTagOfMany(ctx, tenantID, ids, func(tags []string) ([]string, bool) {
+		if !slices.Contains(tags, tag) {
+			return nil, false
+		}
+		return slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag }), true
+	})
+}
+
+// updateTagOfMany applies change to the tags of the selected todos, leaving
+// alone those it reports unchanged so their version is kept
+func (r *InMemoryRepository) updateTagOfMany(ctx context.Context, tenantID string, ids []string, change func(tags []string) ([]string, bool)) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maxTags := domain.CurrentLimits().MaxTags
+
+	newTags := make(map[string][]string)
+	for _, id := range ids {
+		todo, ok := r.live(id, tenantID)
+		if !ok || newTags[id] != nil {
+			continue
+		}
+		tags, changed := change(todo.Tags)
+		if !changed {
+			continue
+		}
+		if len(tags) > maxTags {
+			return 0, domain.ErrTooManyTags
+		}
+		newTags[id] = tags
+	}
+
+	now := time.Now().UTC()
+	for id, tags := range newTags {
+		todo := r.todos[id]
+		old := todo.Tags
+		todo.Tags = tags
+		todo.UpdatedAt = now
+		todo.Version++
+		r.recordChange(ctx, id, tenantID, domain.ChangeUpdated, map[string]domain.FieldChange{
+			"tags": {Old: old, New: tags},
+		})
+	}
+	return int64(len(newTags)), nil
+}
+
+func (r *InMemoryRepository) GetHistory(ctx context.Context, id, tenantID string, limit int) ([]*domain.HistoryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// history is kept in insertion order, so walking it backwards is newest first
+	entries := make([]*domain.HistoryEntry, 0)
+	for i := len(r.history) - 1; i >= 0 && len(entries) < limit; i-- {
+		if entry := r.history[i]; entry.TodoID == id && entry.TenantID == tenantID {
+			c := *entry
+			entries = append(entries, &c)
+		}
+	}
+	return entries, nil
+}
+
+func (r *InMemoryRepository) AddComment(ctx context.Context, comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.todos[comment.TodoID]; !ok {
+		return domain.ErrTodoNotFound
+	}
+
+	c := *comment
+	r.comments[comment.ID] = &c
+	return nil
+}
+
+func (r *InMemoryRepository) GetComment(ctx context.Context, id, todoID, tenantID string) (*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, ok := r.comments[id]
+	if !ok || comment.TodoID != todoID || comment.TenantID != tenantID {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	c := *comment
+	return &c, nil
+}
+
+func (r *InMemoryRepository) ListComments(ctx context.Context, todoID, tenantID string, limit int) ([]*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comments := make([]*domain.Comment, 0)
+	for _, comment := range r.comments {
+		if comment.TodoID == todoID && comment.TenantID == tenantID {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+	slices.SortFunc(comments, func(a, b *domain.Comment) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	if limit >= 0 && len(comments) > limit {
+		comments = comments[:limit]
+	}
+	return comments, nil
+}
+
+func (r *InMemoryRepository) DeleteComment(ctx context.Context, id, todoID, tenantID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment, ok := r.comments[id]
+	if !ok || comment.TodoID != todoID || comment.TenantID != tenantID {
+		return domain.ErrCommentNotFound
+	}
+
+	delete(r.comments, id)
+	return nil
+}
+
+func (r *InMemoryRepository) AddAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.todos[attachment.TodoID]; !ok {
+		return domain.ErrTodoNotFound
+	}
+
+	a := *attachment
+	r.attachments = append(r.attachments, &a)
+	return nil
+}
+
+func (r *InMemoryRepository) ListAttachments(ctx context.Context, todoID, tenantID string) ([]*domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attachments := make([]*domain.Attachment, 0)
+	for _, attachment := range r.attachments {
+		if attachment.TodoID == todoID && attachment.TenantID == tenantID {
+			a := *attachment
+			attachments = append(attachments, &a)
+		}
+	}
+	slices.SortFunc(attachments, func(a, b *domain.Attachment) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return attachments, nil
+}
+
+// AddDependency records that dep.TodoID is blocked by dep.DependsOnID,
+// rejecting dependencies that would close a cycle; adding an existing
+// dependency is a no-op
+func (r *InMemoryRepository) AddDependency(ctx context.Context, dep *domain.Dependency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, todoOK := r.live(dep.TodoID, dep.TenantID)
+	_, dependsOnOK := r.live(dep.DependsOnID, dep.TenantID)
+	if !todoOK || !dependsOnOK {
+		return domain.ErrTodoNotFound
+	}
+
+	graph := make(domain.DependencyGraph)
+	for _, d := range r.dependencies {
+		if d.TenantID != dep.TenantID {
+			continue
+		}
+		if d.TodoID == dep.TodoID && d.DependsOnID == dep.DependsOnID {
+			return nil
+		}
+		graph[d.TodoID] = append(graph[d.TodoID], d.DependsOnID)
+	}
+	if err := graph.CheckCycle(dep); err != nil {
+		return err
+	}
+
+	d := *dep
+	r.dependencies = append(r.dependencies, &d)
+	return nil
+}
+
+func (r *InMemoryRepository) RemoveDependency(ctx context.Context, todoID, dependsOnID, tenantID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before := len(r.dependencies)
+	r.dependencies = slices.DeleteFunc(r.dependencies, func(d *domain.Dependency) bool {
+		return d.TodoID == todoID && d.DependsOnID == dependsOnID && d.TenantID == tenantID
+	})
+	if len(r.dependencies) == before {
+		return domain.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// ListDependencies returns the live todos todoID is blocked by and the live
+// todos it blocks, oldest first
+func (r *InMemoryRepository) ListDependencies(ctx context.Context, todoID, tenantID string) (*domain.TodoDependencies, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deps := &domain.TodoDependencies{
+		BlockedBy: make([]*domain.Todo, 0),
+		Blocks:    make([]*domain.Todo, 0),
+	}
+	for _, d := range r.dependencies {
+		if d.TenantID != tenantID {
+			continue
+		}
+		if todo, ok := r.live(d.DependsOnID, tenantID); ok && d.TodoID == todoID {
+			deps.BlockedBy = append(deps.BlockedBy, cloneTodo(todo))
+		}
+		if todo, ok := r.live(d.TodoID, tenantID); ok && d.DependsOnID == todoID {
+			deps.Blocks = append(deps.Blocks, cloneTodo(todo))
+		}
+	}
+
+	oldestFirst := func(a, b *domain.Todo) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	}
+	slices.SortFunc(deps.BlockedBy, oldestFirst)
+	slices.SortFunc(deps.Blocks, oldestFirst)
+	return deps, nil
+}
+
+// ProcessOutbox hands up to limit unsent messages to fn in the order they
+// were queued and marks each sent when fn succeeds. Failed messages stay
+// queued with their attempt count raised, so they are retried on the next call.
+func (r *InMemoryRepository) ProcessOutbox(ctx context.Context, limit int, fn func(ctx context.Context, msg *domain.OutboxMessage) error) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	r.outboxMu.Lock()
+	defer r.outboxMu.Unlock()
+
+	// fn runs without mu held, since delivering an event may read the repository
+	r.mu.RLock()
+	batch := slices.Clone(r.outbox[:min(limit, len(r.outbox))])
+	r.mu.RUnlock()
+
+	delivered := make(map[int64]bool, len(batch))
+	for _, msg := range batch {
+		c := *msg
+		err := fn(ctx, &c)
+
+		r.mu.Lock()
+		msg.Attempts++
+		r.mu.Unlock()
+
+		if err == nil {
+			delivered[msg.ID] = true
+		}
+	}
+
+	r.mu.Lock()
+	r.outbox = slices.DeleteFunc(r.outbox, func(msg *domain.OutboxMessage) bool { return delivered[msg.ID] })
+	r.mu.Unlock()
+
+	return len(delivered), nil
+}
+
+// enqueue adds event to the outbox. The caller must hold mu.
+func (r *InMemoryRepository) enqueue(event domain.DomainEvent) {
+	// Events are plain structs, which always encode
+	payload, _ := json.Marshal(event)
+
+	r.nextOutboxID++
+	r.outbox = append(r.outbox, &domain.OutboxMessage{
+		ID:        r.nextOutboxID,
+		Name:      event.EventName(),
+		TodoID:    event.AggregateID(),
+		TenantID:  event.Tenant(),
+		Payload:   payload,
+		CreatedAt: event.OccurredAt(),
+	})
+}
+
+// MoveToTenant moves the fromTenant todos among ids to toTenant together
+// with everything recorded about them. Dependencies linking a moved todo to
+// one left behind would span tenants and are dropped.
+func (r *InMemoryRepository) MoveToTenant(ctx context.Context, ids []string, fromTenant, toTenant string, mapOwner domain.OwnerMapping) (int64, error) {
+	if fromTenant == "" || toTenant == "" {
+		return 0, domain.ErrInvalidTenantID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	moved := make(map[string]*domain.Todo)
+	order := make([]string, 0, len(ids))
+	for _, id := range ids {
+		todo, ok := r.todos[id]
+		if !ok || todo.TenantID != fromTenant || moved[id] != nil {
+			continue
+		}
+		after := cloneTodo(todo)
+		after.TenantID = toTenant
+		if mapOwner != nil {
+			after.OwnerID = mapOwner(todo.OwnerID)
+		}
+		moved[id] = after
+		order = append(order, id)
+	}
+
+	// Check titles against the store as it will be once the move is done
+	for id, after := range moved {
+		if after.DeletedAt != nil {
+			continue
+		}
+		for otherID, other := range r.todos {
+			if m := moved[otherID]; m != nil {
+				other = m
+			}
+			if otherID != id && other.DeletedAt == nil && sameActiveTitle(after, other) {
+				return 0, domain.ErrDuplicateTitle
+			}
+		}
+	}
+
+	isMoved := func(id string) bool { return moved[id] != nil }
+	for _, entry := range r.history {
+		if isMoved(entry.TodoID) {
+			entry.TenantID = toTenant
+		}
+	}
+	for _, comment := range r.comments {
+		if isMoved(comment.TodoID) {
+			comment.TenantID = toTenant
+		}
+	}
+	for _, attachment := range r.attachments {
+		if isMoved(attachment.TodoID) {
+			attachment.TenantID = toTenant
+		}
+	}
+	r.dependencies = slices.DeleteFunc(r.dependencies, func(d *domain.Dependency) bool {
+		return isMoved(d.TodoID) != isMoved(d.DependsOnID)
+	})
+	for _, dep := range r.dependencies {
+		if isMoved(dep.TodoID) {
+			dep.TenantID = toTenant
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, id := range order {
+		before, after := r.todos[id], moved[id]
+		after.UpdatedAt = now
+		after.Version++
+		r.todos[id] = after
+
+		changes := map[string]domain.FieldChange{
+			"tenant_id": {Old: fromTenant, New: toTenant},
+		}
+		if after.OwnerID != before.OwnerID {
+			changes["owner_id"] = domain.FieldChange{Old: before.OwnerID, New: after.OwnerID}
+		}
+		r.recordChange(ctx, id, toTenant, domain.ChangeUpdated, changes)
+	}
+	return int64(len(order)), nil
+}
@@ -0,0 +1,683 @@
+// Package memory keeps todos in process memory. It implements the same
+// repository contract as the Postgres store, for tests and for local
+// development without a database; nothing survives a restart.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+)
+
+const systemActor = "system"
+
+// ErrQueryPlanUnavailable is returned by the query inspection methods, which
+// only make sense for a SQL store
+var ErrQueryPlanUnavailable = errors.New("query plans are not available for the in-memory repository")
+
+// InMemoryRepository is a thread-safe domain.Repository. Stored todos are
+// copied on the way in and out, so callers never share state with the store.
+type InMemoryRepository struct {
+	mu sync.RWMutex
+
+	todos        map[string]*domain.Todo
+	history      []*domain.HistoryEntry
+	comments     map[string]*domain.Comment
+	attachments  []*domain.Attachment
+	dependencies []*domain.Dependency
+
+	outbox        []*domain.OutboxMessage
+	nextHistoryID int64
+	nextOutboxID  int64
+
+	// outboxMu serializes ProcessOutbox so a message is delivered by one caller
+	outboxMu sync.Mutex
+}
+
+var _ domain.Repository = (*InMemoryRepository)(nil)
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		todos:    make(map[string]*domain.Todo),
+		comments: make(map[string]*domain.Comment),
+	}
+}
+
+func (r *InMemoryRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.todos[todo.ID]; ok {
+		return fmt.Errorf("failed to create todo: todo %s already exists", todo.ID)
+	}
+	if r.hasDuplicateTitle(todo) {
+		return domain.ErrDuplicateTitle
+	}
+
+	r.todos[todo.ID] = cloneTodo(todo)
+	r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeCreated, domain.DiffTodos(nil, todo))
+	return nil
+}
+
+// Upsert replaces the stored todo only when todo.Version is exactly one
+// ahead of it; its owner and creation time are kept
+func (r *InMemoryRepository) Upsert(ctx context.Context, todo *domain.Todo) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before, ok := r.todos[todo.ID]
+	if !ok {
+		if r.hasDuplicateTitle(todo) {
+			return false, domain.ErrDuplicateTitle
+		}
+		r.todos[todo.ID] = cloneTodo(todo)
+		r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeCreated, domain.DiffTodos(nil, todo))
+		return true, nil
+	}
+
+	if before.TenantID != todo.TenantID || before.DeletedAt != nil || before.Version != todo.Version-1 {
+		return false, fmt.Errorf("todo %s: %w", todo.ID, domain.ErrVersionMismatch)
+	}
+
+	after := cloneTodo(todo)
+	after.OwnerID = before.OwnerID
+	after.ParentID = clonePtr(before.ParentID)
+	after.CreatedAt = before.CreatedAt
+	after.DeletedAt = nil
+	if r.hasDuplicateTitle(after) {
+		return false, domain.ErrDuplicateTitle
+	}
+
+	r.todos[todo.ID] = after
+	r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeUpdated, domain.DiffTodos(before, after))
+	return false, nil
+}
+
+func (r *InMemoryRepository) GetByID(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	return r.getByID(id, tenantID, false)
+}
+
+// GetByIDIncludingDeleted retrieves a todo by ID even when it is soft-deleted
+func (r *InMemoryRepository) GetByIDIncludingDeleted(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	return r.getByID(id, tenantID, true)
+}
+
+func (r *InMemoryRepository) getByID(id, tenantID string, includeDeleted bool) (*domain.Todo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	todo, ok := r.todos[id]
+	if !ok || todo.TenantID != tenantID || (todo.DeletedAt != nil && !includeDeleted) {
+		return nil, fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+	}
+	return cloneTodo(todo), nil
+}
+
+func (r *InMemoryRepository) GetDetail(ctx context.Context, id, tenantID string) (*domain.TodoDetail, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	todo, ok := r.live(id, tenantID)
+	if !ok {
+		return nil, fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+	}
+
+	detail := &domain.TodoDetail{Todo: cloneTodo(todo), Subtasks: make([]*domain.Todo, 0)}
+	for _, comment := range r.comments {
+		if comment.TodoID == id && comment.TenantID == tenantID {
+			detail.CommentCount++
+		}
+	}
+	for _, subtask := range r.todos {
+		if subtask.ParentID != nil && *subtask.ParentID == id && subtask.TenantID == tenantID && subtask.DeletedAt == nil {
+			detail.Subtasks = append(detail.Subtasks, cloneTodo(subtask))
+		}
+	}
+	slices.SortFunc(detail.Subtasks, func(a, b *domain.Todo) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return detail, nil
+}
+
+// GetByIDs retrieves the todos with the given ids in input order, silently
+// skipping ids that are missing, deleted or belong to another tenant
+func (r *InMemoryRepository) GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*domain.Todo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	todos := make([]*domain.Todo, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		// Duplicate input ids are returned once
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if todo, ok := r.live(id, tenantID); ok {
+			todos = append(todos, cloneTodo(todo))
+		}
+	}
+	return todos, nil
+}
+
+func (r *InMemoryRepository) Update(ctx context.Context, todo *domain.Todo, expectedVersion int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before, ok := r.live(todo.ID, todo.TenantID)
+	if !ok || before.Version != expectedVersion {
+		return fmt.Errorf("todo %s: %w", todo.ID, domain.ErrVersionMismatch)
+	}
+
+	after := cloneTodo(todo)
+	after.ParentID = clonePtr(before.ParentID)
+	after.CreatedAt = before.CreatedAt
+	after.UpdatedAt = time.Now().UTC()
+	after.Version = expectedVersion + 1
+	if r.hasDuplicateTitle(after) {
+		return domain.ErrDuplicateTitle
+	}
+
+	r.todos[todo.ID] = after
+	r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeUpdated, domain.DiffTodos(before, after))
+
+	todo.Version = expectedVersion + 1
+	return nil
+}
+
+func (r *InMemoryRepository) Delete(ctx context.Context, id, tenantID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	todo, ok := r.live(id, tenantID)
+	if !ok {
+		return fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+	}
+
+	r.softDelete(ctx, todo, time.Now().UTC())
+	return nil
+}
+
+// ForceDelete removes a todo, soft-deleted or not, together with everything
+// recorded about it; only its deletion event is queued
+func (r *InMemoryRepository) ForceDelete(ctx context.Context, id, tenantID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	todo, ok := r.todos[id]
+	if !ok || todo.TenantID != tenantID {
+		return nil, fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+	}
+
+	removed := map[string]bool{id: true}
+	objectKeys := r.attachmentKeys(removed)
+	delete(r.todos, id)
+	r.eraseRecords(removed)
+
+	changes := map[string]domain.FieldChange{}
+	for _, event := range domain.EventsForChange(id, tenantID, actorFromContext(ctx), domain.ChangeDeleted, changes, time.Now().UTC()) {
+		r.enqueue(event)
+	}
+	return objectKeys, nil
+}
+
+func (r *InMemoryRepository) DeleteByFilter(ctx context.Context, filter *domain.ListFilter) (int64, error) {
+	if filter.TenantID == "" {
+		return 0, domain.ErrInvalidTenantID
+	}
+	if !filter.IsNarrowed() {
+		return 0, domain.ErrFilterTooBroad
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deletedAt := time.Now().UTC()
+	var deleted int64
+	for _, todo := range r.match(filter) {
+		if todo.DeletedAt != nil {
+			continue
+		}
+		r.softDelete(ctx, todo, deletedAt)
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (r *InMemoryRepository) softDelete(ctx context.Context, todo *domain.Todo, deletedAt time.Time) {
+	todo.DeletedAt = &deletedAt
+	todo.UpdatedAt = deletedAt
+	r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeDeleted, map[string]domain.FieldChange{
+		"deleted_at": {Old: nil, New: deletedAt},
+	})
+}
+
+func (r *InMemoryRepository) List(ctx context.Context, filter *domain.ListFilter) ([]*domain.Todo, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.match(filter)
+	sortTodos(matched, filter)
+
+	page := paginate(matched, filter)
+	todos := make([]*domain.Todo, 0, len(page))
+	for _, todo := range page {
+		todos = append(todos, cloneTodo(todo))
+	}
+	return todos, int64(len(matched)), nil
+}
+
+// DryRunList is not supported; there is no query to show
+func (r *InMemoryRepository) DryRunList(ctx context.Context, filter *domain.ListFilter) (*domain.ListQuery, error) {
+	return nil, ErrQueryPlanUnavailable
+}
+
+// AnalyzeList is not supported; there is no query plan to show
+func (r *InMemoryRepository) AnalyzeList(ctx context.Context, filter *domain.ListFilter) ([]byte, error) {
+	return nil, ErrQueryPlanUnavailable
+}
+
+func (r *InMemoryRepository) ListRefs(ctx context.Context, filter *domain.ListFilter) ([]*domain.TodoRef, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.match(filter)
+	sortTodos(matched, filter)
+
+	page := paginate(matched, filter)
+	refs := make([]*domain.TodoRef, 0, len(page))
+	for _, todo := range page {
+		refs = append(refs, &domain.TodoRef{ID: todo.ID, Version: todo.Version, UpdatedAt: todo.UpdatedAt})
+	}
+	return refs, int64(len(matched)), nil
+}
+
+func (r *InMemoryRepository) CountActive(ctx context.Context, tenantID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, todo := range r.todos {
+		if todo.TenantID == tenantID && todo.DeletedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *InMemoryRepository) CountByStatus(ctx context.Context, filter *domain.ListFilter) (map[domain.TodoStatus]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[domain.TodoStatus]int64)
+	for _, todo := range r.match(filter) {
+		counts[todo.Status]++
+	}
+	return counts, nil
+}
+
+func (r *InMemoryRepository) WorkloadByAssignee(ctx context.Context, tenantID string) (map[string]domain.WorkloadStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workload := make(map[string]domain.WorkloadStats)
+	for _, todo := range r.todos {
+		if todo.TenantID != tenantID || todo.DeletedAt != nil {
+			continue
+		}
+
+		assignee := domain.UnassignedWorkload
+		if todo.AssignedTo != nil {
+			assignee = *todo.AssignedTo
+		}
+		stats, ok := workload[assignee]
+		if !ok {
+			stats.Counts = make(map[domain.TodoStatus]int64)
+		}
+		stats.Counts[todo.Status]++
+		stats.Total++
+		workload[assignee] = stats
+	}
+	return workload, nil
+}
+
+func (r *InMemoryRepository) UpdateStatus(ctx context.Context, id, tenantID string, status domain.TodoStatus, completedAt *time.Time, completedBy *string, version int64) (*domain.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before, ok := r.live(id, tenantID)
+	if !ok || before.Version != version {
+		return nil, fmt.Errorf("todo %s: %w", id, domain.ErrVersionMismatch)
+	}
+
+	now := time.Now().UTC()
+	after := cloneTodo(before)
+	after.Status = status
+	after.CompletedAt = clonePtr(completedAt)
+	after.CompletedBy = clonePtr(completedBy)
+	switch {
+	case status != domain.StatusArchived:
+		after.ArchivedAt = nil
+	case after.ArchivedAt == nil:
+		after.ArchivedAt = &now
+	}
+	after.UpdatedAt = now
+	after.Version++
+
+	r.todos[id] = after
+	r.recordChange(ctx, id, tenantID, domain.ChangeStatusChanged, domain.DiffTodos(before, after))
+	return cloneTodo(after), nil
+}
+
+// BatchCreate stores every todo or, when any of them conflicts, none
+func (r *InMemoryRepository) BatchCreate(ctx context.Context, todos []*domain.Todo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := make(map[string]*domain.Todo, len(todos))
+	for _, todo := range todos {
+		if _, ok := r.todos[todo.ID]; ok || batch[todo.ID] != nil {
+			return fmt.Errorf("failed to insert todo %s: todo already exists", todo.ID)
+		}
+		if r.hasDuplicateTitle(todo) {
+			return domain.ErrDuplicateTitle
+		}
+		for _, other := range batch {
+			if sameActiveTitle(todo, other) {
+				return domain.ErrDuplicateTitle
+			}
+		}
+		batch[todo.ID] = todo
+	}
+
+	for _, todo := range todos {
+		r.todos[todo.ID] = cloneTodo(todo)
+		r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeCreated, domain.DiffTodos(nil, todo))
+	}
+	return nil
+}
+
+// PurgeDeleted removes todos soft-deleted before olderThan together with
+// everything recorded about them, returning the keys of their attachments
+func (r *InMemoryRepository) PurgeDeleted(ctx context.Context, olderThan time.Time) (int64, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make(map[string]bool)
+	for id, todo := range r.todos {
+		if todo.DeletedAt != nil && todo.DeletedAt.Before(olderThan) {
+			purged[id] = true
+			delete(r.todos, id)
+		}
+	}
+	if len(purged) == 0 {
+		return 0, nil, nil
+	}
+	objectKeys := r.attachmentKeys(purged)
+	r.eraseRecords(purged)
+	return int64(len(purged)), objectKeys, nil
+}
+
+// PurgeArchived removes todos archived before olderThan together with
+// everything recorded about them, returning the keys of their attachments
+func (r *InMemoryRepository) PurgeArchived(ctx context.Context, olderThan time.Time) (int64, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make(map[string]bool)
+	for id, todo := range r.todos {
+		if todo.Status == domain.StatusArchived && todo.ArchivedAt != nil && todo.ArchivedAt.Before(olderThan) {
+			purged[id] = true
+			delete(r.todos, id)
+		}
+	}
+	if len(purged) == 0 {
+		return 0, nil, nil
+	}
+	objectKeys := r.attachmentKeys(purged)
+	r.eraseRecords(purged)
+	return int64(len(purged)), objectKeys, nil
+}
+
+// attachmentKeys returns the storage keys of the attachments of the removed
+// todos. The caller must hold mu.
+func (r *InMemoryRepository) attachmentKeys(removed map[string]bool) []string {
+	var keys []string
+	for _, attachment := range r.attachments {
+		if removed[attachment.TodoID] {
+			keys = append(keys, attachment.ObjectKey)
+		}
+	}
+	return keys
+}
+
+// eraseRecords drops everything recorded about the removed todos. The caller
+// must hold mu.
+func (r *InMemoryRepository) eraseRecords(removed map[string]bool) {
+	r.history = slices.DeleteFunc(r.history, func(e *domain.HistoryEntry) bool { return removed[e.TodoID] })
+	r.attachments = slices.DeleteFunc(r.attachments, func(a *domain.Attachment) bool { return removed[a.TodoID] })
+	r.dependencies = slices.DeleteFunc(r.dependencies, func(d *domain.Dependency) bool {
+		return removed[d.TodoID] || removed[d.DependsOnID]
+	})
+	for id, comment := range r.comments {
+		if removed[comment.TodoID] {
+			delete(r.comments, id)
+		}
+	}
+	// Subtasks outlive their parent, as with ON DELETE SET NULL
+	for _, todo := range r.todos {
+		if todo.ParentID != nil && removed[*todo.ParentID] {
+			todo.ParentID = nil
+		}
+	}
+}
+
+// EscalateOverdue raises the priority of overdue, open todos below Critical
+// by one level. An empty tenantID sweeps every tenant.
+func (r *InMemoryRepository) EscalateOverdue(ctx context.Context, tenantID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	var escalated int64
+	for _, todo := range r.todos {
+		if tenantID != "" && todo.TenantID != tenantID {
+			continue
+		}
+		if todo.DeletedAt != nil || !todo.IsOverdue(now) || todo.Priority >= domain.PriorityCritical {
+			continue
+		}
+
+		todo.Priority++
+		todo.UpdatedAt = now
+		todo.Version++
+		r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeUpdated, map[string]domain.FieldChange{
+			"priority": {Old: todo.Priority - 1, New: todo.Priority},
+		})
+		escalated++
+	}
+	return escalated, nil
+}
+
+// ArchiveCompletedOlderThan archives todos completed before now-age. An
+// empty tenantID sweeps every tenant.
+func (r *InMemoryRepository) ArchiveCompletedOlderThan(ctx context.Context, tenantID string, age time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	cutoff := now.Add(-age)
+	var archived int64
+	for _, todo := range r.todos {
+		if tenantID != "" && todo.TenantID != tenantID {
+			continue
+		}
+		if todo.DeletedAt != nil || todo.Status != domain.StatusCompleted || todo.CompletedAt == nil || !todo.CompletedAt.Before(cutoff) {
+			continue
+		}
+
+		todo.Status = domain.StatusArchived
+		todo.ArchivedAt = &now
+		todo.UpdatedAt = now
+		todo.Version++
+		r.recordChange(ctx, todo.ID, todo.TenantID, domain.ChangeStatusChanged, map[string]domain.FieldChange{
+			"status":      {Old: domain.StatusCompleted, New: domain.StatusArchived},
+			"archived_at": {Old: nil, New: &now},
+		})
+		archived++
+	}
+	return archived, nil
+}
+
+// DueReminders claims every unsent reminder of an open todo that falls
+// before now+window and queues its event
+func (r *InMemoryRepository) DueReminders(ctx context.Context, window time.Duration) ([]*domain.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	todos := make([]*domain.Todo, 0)
+	for _, todo := range r.todos {
+		if todo.RemindAt == nil || todo.RemindedAt != nil || todo.DeletedAt != nil || !isOpen(todo) {
+			continue
+		}
+		if todo.RemindAt.After(now.Add(window)) {
+			continue
+		}
+
+		remindedAt := now
+		todo.RemindedAt = &remindedAt
+		r.enqueue(domain.ReminderFor(todo, now))
+		todos = append(todos, cloneTodo(todo))
+	}
+	return todos, nil
+}
+
+// DueWithin returns the tenant's open todos that are not yet overdue and
+// fall due by now+within, soonest first
+func (r *InMemoryRepository) DueWithin(ctx context.Context, tenantID string, within time.Duration) ([]*domain.Todo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now().UTC()
+	todos := make([]*domain.Todo, 0)
+	for _, todo := range r.todos {
+		if todo.TenantID != tenantID || todo.DeletedAt != nil || todo.DueDate == nil || !isOpen(todo) {
+			continue
+		}
+		if deadline, _ := todo.Deadline(); deadline.Before(now) || todo.DueDate.After(now.Add(within)) {
+			continue
+		}
+		todos = append(todos, cloneTodo(todo))
+	}
+
+	slices.SortFunc(todos, func(a, b *domain.Todo) int {
+		if c := a.DueDate.Compare(*b.DueDate); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return todos, nil
+}
+
+// live returns the tenant's stored todo with id unless it is soft-deleted.
+// The caller must hold mu.
+func (r *InMemoryRepository) live(id, tenantID string) (*domain.Todo, bool) {
+	todo, ok := r.todos[id]
+	if !ok || todo.TenantID != tenantID || todo.DeletedAt != nil {
+		return nil, false
+	}
+	return todo, true
+}
+
+// hasDuplicateTitle mirrors the unique index on active titles: another live
+// todo of the same owner may not share the title, ignoring case
+func (r *InMemoryRepository) hasDuplicateTitle(todo *domain.Todo) bool {
+	if todo.DeletedAt != nil {
+		return false
+	}
+	for _, other := range r.todos {
+		if other.ID != todo.ID && other.DeletedAt == nil && sameActiveTitle(todo, other) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameActiveTitle(a, b *domain.Todo) bool {
+	return a.TenantID == b.TenantID && a.OwnerID == b.OwnerID && strings.EqualFold(a.Title, b.Title)
+}
+
+// recordChange appends the history entry and queues the events of a
+// mutation. The actor is the authenticated user on ctx, or "system" for
+// background work. The caller must hold mu.
+func (r *InMemoryRepository) recordChange(ctx context.Context, todoID, tenantID string, changeType domain.ChangeType, changes map[string]domain.FieldChange) {
+	actorID := actorFromContext(ctx)
+
+	now := time.Now().UTC()
+	r.nextHistoryID++
+	r.history = append(r.history, &domain.HistoryEntry{
+		ID:         r.nextHistoryID,
+		TodoID:     todoID,
+		TenantID:   tenantID,
+		ActorID:    actorID,
+		ChangeType: changeType,
+		Changes:    changes,
+		CreatedAt:  now,
+	})
+
+	for _, event := range domain.EventsForChange(todoID, tenantID, actorID, changeType, changes, now) {
+		r.enqueue(event)
+	}
+}
+
+// actorFromContext returns the authenticated user on ctx, or "system"
+func actorFromContext(ctx context.Context) string {
+	if userCtx, err := auth.UserContextFromContext(ctx); err == nil {
+		return userCtx.UserID
+	}
+	return systemActor
+}
+
+func isOpen(todo *domain.Todo) bool {
+	return todo.Status != domain.StatusCompleted && todo.Status != domain.StatusArchived
+}
+
+func cloneTodo(todo *domain.Todo) *domain.Todo {
+	c := *todo
+	c.Tags = slices.Clone(todo.Tags)
+	if c.Tags == nil {
+		c.Tags = make([]string, 0)
+	}
+	c.DueDate = clonePtr(todo.DueDate)
+	c.DeletedAt = clonePtr(todo.DeletedAt)
+	c.CompletedAt = clonePtr(todo.CompletedAt)
+	c.CompletedBy = clonePtr(todo.CompletedBy)
+	c.ParentID = clonePtr(todo.ParentID)
+	c.ArchivedAt = clonePtr(todo.ArchivedAt)
+	c.RemindAt = clonePtr(todo.RemindAt)
+	c.RemindedAt = clonePtr(todo.RemindedAt)
+	c.AssignedTo = clonePtr(todo.AssignedTo)
+	c.DueDateTimezone = clonePtr(todo.DueDateTimezone)
+	c.EstimatedMinutes = clonePtr(todo.EstimatedMinutes)
+	return &c
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
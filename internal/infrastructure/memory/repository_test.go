@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/internal/domain/repositorytest"
+)
+
+func TestInMemoryRepositoryConformance(t *testing.T) {
+	repositorytest.RunRepositoryTests(t, func() domain.Repository {
+		return NewInMemoryRepository()
+	})
+}
+
+func TestInMemoryOutboxConformance(t *testing.T) {
+	repositorytest.RunOutboxTests(t, func() repositorytest.OutboxRepository {
+		return NewInMemoryRepository()
+	})
+}
+
+func TestInMemoryRepositoryIsolatesStoredTodos(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryRepository()
+
+	due := time.Now().UTC().Add(time.Hour)
+	todo, err := domain.NewTodo("original", "", "alice", "tenant-a", domain.PriorityLow,
+		domain.WithTags([]string{"backend"}), domain.WithDueDate(&due))
+	if err != nil {
+		t.Fatalf("NewTodo failed: %v", err)
+	}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// Neither the created todo nor one read back shares memory with the store
+	todo.Title = "changed after create"
+	got, err := repo.GetByID(ctx, todo.ID, "tenant-a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	got.Tags[0] = "frontend"
+	*got.DueDate = due.Add(time.Hour)
+
+	again, err := repo.GetByID(ctx, todo.ID, "tenant-a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if again.Title != "original" || again.Tags[0] != "backend" || !again.DueDate.Equal(due) {
+		t.Fatalf("expected the stored todo unchanged, got %q %v due %v", again.Title, again.Tags, again.DueDate)
+	}
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const attachmentColumns = `id, todo_id, tenant_id, object_key, filename, content_type, size_bytes, uploaded_by, created_at`
+
+func (r *PostgresRepository) AddAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.AddAttachment")
+	defer span.End()
+	defer r.logSlowQuery(span, "AddAttachment", time.Now())
+
+	logFields := []zap.Field{zap.String("attachment_id", attachment.ID), zap.String("todo_id", attachment.TodoID), zap.String("tenant_id", attachment.TenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", attachment.TodoID),
+		attribute.String("tenant.id", attachment.TenantID),
+	)
+
+	query := `
+		INSERT INTO attachments (` + attachmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		attachment.ID,
+		attachment.TodoID,
+		attachment.TenantID,
+		attachment.ObjectKey,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		r.recordError(span, "AddAttachment", err, logFields...)
+		return fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) ListAttachments(ctx context.Context, todoID, tenantID string) ([]*domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ListAttachments")
+	defer span.End()
+	defer r.logSlowQuery(span, "ListAttachments", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", todoID), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", todoID),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE todo_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, todoID, tenantID)
+	if err != nil {
+		r.recordError(span, "ListAttachments", err, logFields...)
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := make([]*domain.Attachment, 0)
+	for rows.Next() {
+		attachment := &domain.Attachment{}
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.TodoID,
+			&attachment.TenantID,
+			&attachment.ObjectKey,
+			&attachment.Filename,
+			&attachment.ContentType,
+			&attachment.SizeBytes,
+			&attachment.UploadedBy,
+			&attachment.CreatedAt,
+		)
+		if err != nil {
+			r.recordError(span, "ListAttachments", err, logFields...)
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "ListAttachments", err, logFields...)
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("returned_count", len(attachments)))
+	return attachments, nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+func newBatch(t *testing.T, n int) []*domain.Todo {
+	t.Helper()
+	todos := make([]*domain.Todo, n)
+	for i := range todos {
+		todo, err := domain.NewTodo(fmt.Sprintf("todo %d", i), "", "alice", "tenant-a", domain.PriorityMedium)
+		if err != nil {
+			t.Fatalf("failed to build todo %d: %v", i, err)
+		}
+		todos[i] = todo
+	}
+	return todos
+}
+
+func TestInsertTodosChunks(t *testing.T) {
+	tests := []struct {
+		rows   int
+		chunks []int
+	}{
+		{rows: insertChunkSize - 1, chunks: []int{insertChunkSize - 1}},
+		{rows: insertChunkSize, chunks: []int{insertChunkSize}},
+		{rows: insertChunkSize + 1, chunks: []int{insertChunkSize, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.rows), func(t *testing.T) {
+			// Record the row count of every INSERT as the mock matches it
+			var chunks []int
+			matcher := sqlmock.QueryMatcherFunc(func(expected, actual string) error {
+				if !strings.HasPrefix(actual, "INSERT INTO todos") {
+					return fmt.Errorf("unexpected query %q", actual)
+				}
+				chunks = append(chunks, strings.Count(actual, "), (")+1)
+				return nil
+			})
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(matcher))
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			for _, rows := range tt.chunks {
+				mock.ExpectExec("INSERT INTO todos").WillReturnResult(sqlmock.NewResult(0, int64(rows)))
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("Begin: %v", err)
+			}
+			if err := insertTodos(context.Background(), tx, newBatch(t, tt.rows)); err != nil {
+				t.Fatalf("insertTodos: %v", err)
+			}
+
+			if !slices.Equal(chunks, tt.chunks) {
+				t.Errorf("chunk rows = %v, want %v", chunks, tt.chunks)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const commentColumns = `id, todo_id, tenant_id, author_id, body, created_at`
+
+func (r *PostgresRepository) AddComment(ctx context.Context, comment *domain.Comment) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.AddComment")
+	defer span.End()
+	defer r.logSlowQuery(span, "AddComment", time.Now())
+
+	logFields := []zap.Field{zap.String("comment_id", comment.ID), zap.String("todo_id", comment.TodoID), zap.String("tenant_id", comment.TenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", comment.TodoID),
+		attribute.String("tenant.id", comment.TenantID),
+	)
+
+	query := `
+		INSERT INTO todo_comments (` + commentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		comment.ID,
+		comment.TodoID,
+		comment.TenantID,
+		comment.AuthorID,
+		comment.Body,
+		comment.CreatedAt,
+	)
+	if err != nil {
+		r.recordError(span, "AddComment", err, logFields...)
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) GetComment(ctx context.Context, id, todoID, tenantID string) (*domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.GetComment")
+	defer span.End()
+	defer r.logSlowQuery(span, "GetComment", time.Now())
+
+	logFields := []zap.Field{zap.String("comment_id", id), zap.String("todo_id", todoID), zap.String("tenant_id", tenantID)}
+
+	query := `
+		SELECT ` + commentColumns + `
+		FROM todo_comments
+		WHERE id = $1 AND todo_id = $2 AND tenant_id = $3
+	`
+
+	comment, err := scanComment(r.db.QueryRowContext(ctx, query, id, todoID, tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		r.recordError(span, "GetComment", err, logFields...)
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *PostgresRepository) ListComments(ctx context.Context, todoID, tenantID string, limit int) ([]*domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ListComments")
+	defer span.End()
+	defer r.logSlowQuery(span, "ListComments", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", todoID), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", todoID),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	query := `
+		SELECT ` + commentColumns + `
+		FROM todo_comments
+		WHERE todo_id = $1 AND tenant_id = $2
+		ORDER BY created_at ASC, id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, todoID, tenantID, limit)
+	if err != nil {
+		r.recordError(span, "ListComments", err, logFields...)
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]*domain.Comment, 0)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			r.recordError(span, "ListComments", err, logFields...)
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "ListComments", err, logFields...)
+		return nil, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("returned_count", len(comments)))
+	return comments, nil
+}
+
+func (r *PostgresRepository) DeleteComment(ctx context.Context, id, todoID, tenantID string) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.DeleteComment")
+	defer span.End()
+	defer r.logSlowQuery(span, "DeleteComment", time.Now())
+
+	logFields := []zap.Field{zap.String("comment_id", id), zap.String("todo_id", todoID), zap.String("tenant_id", tenantID)}
+
+	query := `DELETE FROM todo_comments WHERE id = $1 AND todo_id = $2 AND tenant_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, id, todoID, tenantID)
+	if err != nil {
+		r.recordError(span, "DeleteComment", err, logFields...)
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func scanComment(row rowScanner) (*domain.Comment, error) {
+	comment := &domain.Comment{}
+	err := row.Scan(
+		&comment.ID,
+		&comment.TodoID,
+		&comment.TenantID,
+		&comment.AuthorID,
+		&comment.Body,
+		&comment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
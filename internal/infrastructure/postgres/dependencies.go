@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// AddDependency records that dep.TodoID is blocked by dep.DependsOnID. Adds
+// within a tenant are serialized so two concurrent adds cannot close a cycle
+// neither of them sees; adding an existing dependency is a no-op.
+func (r *PostgresRepository) AddDependency(ctx context.Context, dep *domain.Dependency) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.AddDependency")
+	defer span.End()
+	defer r.logSlowQuery(span, "AddDependency", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", dep.TodoID), zap.String("depends_on_id", dep.DependsOnID), zap.String("tenant_id", dep.TenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", dep.TodoID),
+		attribute.String("depends_on.id", dep.DependsOnID),
+		attribute.String("tenant.id", dep.TenantID),
+	)
+
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('todo_dependencies:' || $1))`, dep.TenantID); err != nil {
+			return fmt.Errorf("failed to lock dependencies: %w", err)
+		}
+
+		var found int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*)
+			FROM todos
+			WHERE id IN ($1, $2) AND tenant_id = $3 AND deleted_at IS NULL
+		`, dep.TodoID, dep.DependsOnID, dep.TenantID).Scan(&found)
+		if err != nil {
+			return fmt.Errorf("failed to check todos: %w", err)
+		}
+		if found != 2 {
+			return fmt.Errorf("todo %s or %s: %w", dep.TodoID, dep.DependsOnID, domain.ErrTodoNotFound)
+		}
+
+		graph, err := reachableDependencies(ctx, tx, dep.DependsOnID, dep.TenantID)
+		if err != nil {
+			return err
+		}
+		if err := graph.CheckCycle(dep); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO todo_dependencies (todo_id, depends_on_id, tenant_id, created_by, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (todo_id, depends_on_id) DO NOTHING
+		`, dep.TodoID, dep.DependsOnID, dep.TenantID, dep.CreatedBy, dep.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add dependency: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrTodoNotFound) && !errors.Is(err, domain.ErrDependencyCycle) && !errors.Is(err, domain.ErrSelfDependency) {
+			r.recordError(span, "AddDependency", err, logFields...)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// reachableDependencies loads the part of a tenant's dependency graph that
+// is reachable from todoID
+func reachableDependencies(ctx context.Context, tx *sql.Tx, todoID, tenantID string) (domain.DependencyGraph, error) {
+	query := `
+		WITH RECURSIVE reachable(id) AS (
+			SELECT $1::uuid
+			UNION
+			SELECT d.depends_on_id
+			FROM todo_dependencies d
+			JOIN reachable ON d.todo_id = reachable.id
+			WHERE d.tenant_id = $2
+		)
+		SELECT d.todo_id, d.depends_on_id
+		FROM todo_dependencies d
+		JOIN reachable ON d.todo_id = reachable.id
+		WHERE d.tenant_id = $2
+	`
+
+	rows, err := tx.QueryContext(ctx, query, todoID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	graph := make(domain.DependencyGraph)
+	for rows.Next() {
+		var from, to string
+		if err := rows.Scan(&from, &to); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		graph[from] = append(graph[from], to)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dependencies: %w", err)
+	}
+	return graph, nil
+}
+
+func (r *PostgresRepository) RemoveDependency(ctx context.Context, todoID, dependsOnID, tenantID string) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.RemoveDependency")
+	defer span.End()
+	defer r.logSlowQuery(span, "RemoveDependency", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", todoID), zap.String("depends_on_id", dependsOnID), zap.String("tenant_id", tenantID)}
+
+	query := `DELETE FROM todo_dependencies WHERE todo_id = $1 AND depends_on_id = $2 AND tenant_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, todoID, dependsOnID, tenantID)
+	if err != nil {
+		r.recordError(span, "RemoveDependency", err, logFields...)
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrDependencyNotFound
+	}
+
+	return nil
+}
+
+// ListDependencies returns the live todos todoID is blocked by and the live
+// todos it blocks, oldest first
+func (r *PostgresRepository) ListDependencies(ctx context.Context, todoID, tenantID string) (*domain.TodoDependencies, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ListDependencies")
+	defer span.End()
+	defer r.logSlowQuery(span, "ListDependencies", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", todoID), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", todoID),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	blockedByQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE id IN (SELECT depends_on_id FROM todo_dependencies WHERE todo_id = $1 AND tenant_id = $2)
+			AND tenant_id = $2 AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC
+	`, todoColumns)
+
+	blocksQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE id IN (SELECT todo_id FROM todo_dependencies WHERE depends_on_id = $1 AND tenant_id = $2)
+			AND tenant_id = $2 AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC
+	`, todoColumns)
+
+	q := r.reader(ctx)
+
+	var err error
+	deps := &domain.TodoDependencies{}
+	if deps.BlockedBy, err = queryTodos(ctx, q, blockedByQuery, todoID, tenantID); err != nil {
+		r.recordError(span, "ListDependencies", err, logFields...)
+		return nil, err
+	}
+	if deps.Blocks, err = queryTodos(ctx, q, blocksQuery, todoID, tenantID); err != nil {
+		r.recordError(span, "ListDependencies", err, logFields...)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("blocked_by_count", len(deps.BlockedBy)),
+		attribute.Int("blocks_count", len(deps.Blocks)),
+	)
+	return deps, nil
+}
+
+func queryTodos(ctx context.Context, q querier, query string, args ...any) ([]*domain.Todo, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+	defer rows.Close()
+
+	todos := make([]*domain.Todo, 0)
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan todo: %w", err)
+		}
+		todos = append(todos, todo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating todos: %w", err)
+	}
+	return todos, nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// DueWithin returns the tenant's open todos that are not yet overdue and
+// fall due by now+within, soonest first. A todo due exactly at the end of
+// the window is included.
+func (r *PostgresRepository) DueWithin(ctx context.Context, tenantID string, within time.Duration) ([]*domain.Todo, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.DueWithin")
+	defer span.End()
+	defer r.logSlowQuery(span, "DueWithin", time.Now())
+
+	logFields := []zap.Field{zap.String("tenant_id", tenantID), zap.Duration("within", within)}
+
+	span.SetAttributes(
+		attribute.String("tenant.id", tenantID),
+		attribute.Int64("within_seconds", int64(within.Seconds())),
+	)
+
+	// The status literals match the predicate of idx_todos_open_due, which a
+	// parameter would not. due_date bounds the index scan; a deadline ends at
+	// most a day and a zone offset after its due date, so the lower bound
+	// keeps every todo that is not yet overdue.
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE tenant_id = $1
+			AND deleted_at IS NULL
+			AND due_date IS NOT NULL
+			AND status NOT IN (%d, %d)
+			AND due_date > $2::timestamptz - INTERVAL '2 days'
+			AND due_date <= $3
+			AND %s >= $2
+		ORDER BY due_date ASC, id ASC
+	`, todoColumns, domain.StatusCompleted, domain.StatusArchived, dueDeadlineSQL)
+
+	q := r.reader(ctx)
+
+	now := time.Now().UTC()
+	todos, err := queryTodos(ctx, q, query, tenantID, now, now.Add(within))
+	if err != nil {
+		r.recordError(span, "DueWithin", err, logFields...)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("returned_count", len(todos)))
+	return todos, nil
+}
@@ -0,0 +1,8 @@
+package postgres
+
+// Exported for the integration tests in package postgres_test
+var (
+	InsertTodos = insertTodos
+	CopyTodos   = copyTodos
+	CopyAllowed = copyAllowed
+)
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const systemActor = "system"
+
+func (r *PostgresRepository) GetHistory(ctx context.Context, id, tenantID string, limit int) ([]*domain.HistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.GetHistory")
+	defer span.End()
+	defer r.logSlowQuery(span, "GetHistory", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", id), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", id),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	query := `
+		SELECT id, todo_id, tenant_id, actor_id, change_type, diff, created_at
+		FROM todo_history
+		WHERE todo_id = $1 AND tenant_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id, tenantID, limit)
+	if err != nil {
+		r.recordError(span, "GetHistory", err, logFields...)
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.HistoryEntry, 0)
+	for rows.Next() {
+		entry := &domain.HistoryEntry{}
+		var diff []byte
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TodoID,
+			&entry.TenantID,
+			&entry.ActorID,
+			&entry.ChangeType,
+			&diff,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			r.recordError(span, "GetHistory", err, logFields...)
+			return nil, fmt.Errorf("failed to scan history entry: %w", err)
+		}
+
+		if err := json.Unmarshal(diff, &entry.Changes); err != nil {
+			r.recordError(span, "GetHistory", err, logFields...)
+			return nil, fmt.Errorf("failed to decode history diff: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "GetHistory", err, logFields...)
+		return nil, fmt.Errorf("error iterating history: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("returned_count", len(entries)))
+	return entries, nil
+}
+
+// recordChange appends the history row and outbox events for a mutation
+// inside the caller's transaction. The actor is the authenticated user on
+// ctx, or "system" for background work.
+func recordChange(ctx context.Context, tx *sql.Tx, todoID, tenantID string, changeType domain.ChangeType, changes map[string]domain.FieldChange) error {
+	actorID := actorFromContext(ctx)
+
+	if err := insertHistory(ctx, tx, todoID, tenantID, actorID, changeType, changes); err != nil {
+		return err
+	}
+
+	for _, event := range domain.EventsForChange(todoID, tenantID, actorID, changeType, changes, time.Now().UTC()) {
+		if err := insertOutbox(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// actorFromContext returns the authenticated user on ctx, or "system"
+func actorFromContext(ctx context.Context) string {
+	if userCtx, err := auth.UserContextFromContext(ctx); err == nil {
+		return userCtx.UserID
+	}
+	return systemActor
+}
+
+func insertHistory(ctx context.Context, tx *sql.Tx, todoID, tenantID, actorID string, changeType domain.ChangeType, changes map[string]domain.FieldChange) error {
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode history diff: %w", err)
+	}
+
+	query := `
+		INSERT INTO todo_history (todo_id, tenant_id, actor_id, change_type, diff)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	if _, err := tx.ExecContext(ctx, query, todoID, tenantID, actorID, changeType, diff); err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
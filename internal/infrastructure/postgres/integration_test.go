@@ -0,0 +1,582 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/dmehra2102/TaskForge/internal/domain/repositorytest"
+	infrapostgres "github.com/dmehra2102/TaskForge/internal/infrastructure/postgres"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+// appRole is the unprivileged role the repository connects as. The
+// container's superuser bypasses row-level security, so connecting as it
+// would hide every tenant isolation bug.
+const appRole = "taskforge_app"
+
+var (
+	adminDB *sql.DB
+	appDB   *sql.DB
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(runWithDatabase(m))
+}
+
+// runWithDatabase starts Postgres, applies the real migrations down and up
+// again, and runs the tests against it
+func runWithDatabase(m *testing.M) int {
+	ctx := context.Background()
+
+	container, err := tcpostgres.Run(ctx, "postgres:16-alpine",
+		tcpostgres.WithDatabase("taskforge"),
+		tcpostgres.WithUsername("postgres"),
+		tcpostgres.WithPassword("postgres"),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	defer func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			log.Printf("failed to terminate postgres: %v", err)
+		}
+	}()
+	if err != nil {
+		log.Printf("failed to start postgres: %v", err)
+		return 1
+	}
+
+	adminURL, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		log.Printf("failed to get connection string: %v", err)
+		return 1
+	}
+
+	if err := migrateRoundTrip(adminURL); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	if adminDB, err = sql.Open("postgres", adminURL); err != nil {
+		log.Printf("failed to open admin connection: %v", err)
+		return 1
+	}
+	defer adminDB.Close()
+
+	if err := createAppRole(ctx, adminDB); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	appURL, err := url.Parse(adminURL)
+	if err != nil {
+		log.Printf("failed to parse connection string: %v", err)
+		return 1
+	}
+	appURL.User = url.UserPassword(appRole, appRole)
+	if appDB, err = sql.Open("postgres", appURL.String()); err != nil {
+		log.Printf("failed to open app connection: %v", err)
+		return 1
+	}
+	defer appDB.Close()
+
+	return m.Run()
+}
+
+// migrateRoundTrip applies every migration, rolls them all back and applies
+// them again, so both directions of each migration are exercised
+func migrateRoundTrip(databaseURL string) error {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+	defer db.Close()
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://migrations", "postgres", driver)
+	if err != nil {
+		return fmt.Errorf("failed to create migration instance: %w", err)
+	}
+
+	if err := m.Up(); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	if err := m.Down(); err != nil {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+	if err := m.Up(); err != nil {
+		return fmt.Errorf("failed to reapply migrations: %w", err)
+	}
+	return nil
+}
+
+func createAppRole(ctx context.Context, db *sql.DB) error {
+	statements := []string{
+		fmt.Sprintf(`CREATE ROLE %s LOGIN PASSWORD '%s'`, appRole, appRole),
+		fmt.Sprintf(`GRANT USAGE ON SCHEMA public TO %s`, appRole),
+		fmt.Sprintf(`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO %s`, appRole),
+		fmt.Sprintf(`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO %s`, appRole),
+	}
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create app role: %w", err)
+		}
+	}
+	return nil
+}
+
+// newRepository empties every table and returns the repository the server
+// uses, connected as the unprivileged app role
+func newRepository(t testing.TB) domain.Repository {
+	t.Helper()
+	truncate(t)
+	return infrapostgres.NewTenantScopedRepository(infrapostgres.NewPostgresRepository(appDB, nil))
+}
+
+func truncate(t testing.TB) {
+	t.Helper()
+	_, err := adminDB.Exec(`TRUNCATE todos, todo_history, todo_audit, outbox, todo_comments,
+		attachments, todo_dependencies, tenant_users CASCADE`)
+	if err != nil {
+		t.Fatalf("failed to truncate tables: %v", err)
+	}
+}
+
+func newTodo(t testing.TB, title, tenantID string, opts ...domain.TodoOption) *domain.Todo {
+	t.Helper()
+	todo, err := domain.NewTodo(title, "", "alice", tenantID, domain.PriorityMedium, opts...)
+	if err != nil {
+		t.Fatalf("failed to build todo %q: %v", title, err)
+	}
+	return todo
+}
+
+func mustCreate(t testing.TB, repo domain.Repository, todo *domain.Todo) *domain.Todo {
+	t.Helper()
+	if err := repo.Create(context.Background(), todo); err != nil {
+		t.Fatalf("failed to create todo %q: %v", todo.Title, err)
+	}
+	return todo
+}
+
+func TestRepositoryConformance(t *testing.T) {
+	repositorytest.RunRepositoryTests(t, func() domain.Repository {
+		return newRepository(t)
+	})
+}
+
+// outboxRepository writes todos through the tenant scope, as the service
+// does, and drains the outbox unscoped, as the relay does
+type outboxRepository struct {
+	domain.Repository
+	relay *infrapostgres.PostgresRepository
+}
+
+func (r outboxRepository) ProcessOutbox(ctx context.Context, limit int, fn func(ctx context.Context, msg *domain.OutboxMessage) error) (int, error) {
+	return r.relay.ProcessOutbox(ctx, limit, fn)
+}
+
+func TestOutboxConformance(t *testing.T) {
+	repositorytest.RunOutboxTests(t, func() repositorytest.OutboxRepository {
+		return outboxRepository{
+			Repository: newRepository(t),
+			relay:      infrapostgres.NewPostgresRepository(appDB, nil),
+		}
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	todo := mustCreate(t, repo, newTodo(t, "ship", "tenant-1"))
+
+	completedAt := time.Now().UTC().Truncate(time.Microsecond)
+	completedBy := "alice"
+	got, err := repo.UpdateStatus(ctx, todo.ID, "tenant-1", domain.StatusCompleted, &completedAt, &completedBy, 1)
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if got.Status != domain.StatusCompleted || got.Version != 2 {
+		t.Fatalf("expected completed at version 2, got %v at %d", got.Status, got.Version)
+	}
+	if got.CompletedAt == nil || !got.CompletedAt.Equal(completedAt) {
+		t.Fatalf("expected completed_at %v, got %v", completedAt, got.CompletedAt)
+	}
+	if got.CompletedBy == nil || *got.CompletedBy != completedBy {
+		t.Fatalf("expected completed_by %s, got %v", completedBy, got.CompletedBy)
+	}
+
+	if _, err := repo.UpdateStatus(ctx, todo.ID, "tenant-1", domain.StatusPending, nil, nil, 1); !errors.Is(err, domain.ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch for a stale version, got %v", err)
+	}
+}
+
+func TestRowLevelSecurityDeniesByDefault(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	mustCreate(t, repo, newTodo(t, "ours", "tenant-1"))
+	mustCreate(t, repo, newTodo(t, "theirs", "tenant-2"))
+
+	count := func(t *testing.T, settings ...string) int {
+		t.Helper()
+		tx, err := appDB.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		defer tx.Rollback()
+		for i := 0; i < len(settings); i += 2 {
+			if _, err := tx.ExecContext(ctx, `SELECT set_config($1, $2, true)`, settings[i], settings[i+1]); err != nil {
+				t.Fatalf("failed to set %s: %v", settings[i], err)
+			}
+		}
+		var n int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM todos`).Scan(&n); err != nil {
+			t.Fatalf("failed to count todos: %v", err)
+		}
+		return n
+	}
+
+	if n := count(t); n != 0 {
+		t.Fatalf("expected an unscoped query to see no todos, got %d", n)
+	}
+	if n := count(t, "app.current_tenant", "tenant-1"); n != 1 {
+		t.Fatalf("expected a tenant-scoped query to see its own todo, got %d", n)
+	}
+	if n := count(t, "app.cross_tenant", "on"); n != 2 {
+		t.Fatalf("expected a cross-tenant query to see every todo, got %d", n)
+	}
+
+	// Maintenance jobs must opt in to reach across tenants
+	unscoped := infrapostgres.NewPostgresRepository(appDB, nil)
+	if err := repo.Delete(ctx, mustCreate(t, repo, newTodo(t, "stale", "tenant-1")).ID, "tenant-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	cutoff := time.Now().Add(time.Minute)
+	if n, _, err := unscoped.PurgeDeleted(ctx, cutoff); err != nil || n != 0 {
+		t.Fatalf("expected an unscoped purge to reach nothing, got %d, %v", n, err)
+	}
+	if n, _, err := unscoped.PurgeDeleted(domain.WithCrossTenant(ctx), cutoff); err != nil || n != 1 {
+		t.Fatalf("expected a cross-tenant purge to remove the deleted todo, got %d, %v", n, err)
+	}
+}
+
+func TestRepairMigrationRestoresBaseline(t *testing.T) {
+	truncate(t)
+	ctx := context.Background()
+
+	repair, err := os.ReadFile("migrations/019_repair_todos_baseline.up.sql")
+	if err != nil {
+		t.Fatalf("failed to read repair migration: %v", err)
+	}
+
+	// A database migrated before 001 was corrected lacks these
+	for _, stmt := range []string{
+		`ALTER TABLE todos ALTER COLUMN tags DROP DEFAULT`,
+		`DROP TRIGGER log_todo_status_change ON todos`,
+		`DROP TRIGGER update_todos_updated_at ON todos`,
+		`DROP FUNCTION log_status_change()`,
+		`DROP FUNCTION update_updated_at_column()`,
+		`DROP INDEX idx_todos_tags`,
+	} {
+		if _, err := adminDB.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to break schema with %q: %v", stmt, err)
+		}
+	}
+
+	// Applying it twice shows it is a no-op on an already repaired database
+	for range 2 {
+		if _, err := adminDB.ExecContext(ctx, string(repair)); err != nil {
+			t.Fatalf("failed to apply repair migration: %v", err)
+		}
+	}
+
+	var tagsDefault sql.NullString
+	err = adminDB.QueryRowContext(ctx, `
+		SELECT column_default FROM information_schema.columns
+		WHERE table_name = 'todos' AND column_name = 'tags'
+	`).Scan(&tagsDefault)
+	if err != nil || !tagsDefault.Valid {
+		t.Fatalf("expected a tags default, got %v, %v", tagsDefault, err)
+	}
+
+	var triggers, indexes int
+	err = adminDB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM pg_trigger
+		WHERE tgrelid = 'todos'::regclass
+		  AND tgname IN ('log_todo_status_change', 'update_todos_updated_at')
+	`).Scan(&triggers)
+	if err != nil || triggers != 2 {
+		t.Fatalf("expected both todos triggers, got %d, %v", triggers, err)
+	}
+	err = adminDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM pg_indexes WHERE indexname = 'idx_todos_tags'`).Scan(&indexes)
+	if err != nil || indexes != 1 {
+		t.Fatalf("expected the tags index, got %d, %v", indexes, err)
+	}
+
+	// The status trigger writes to the audit table again
+	repo := newRepository(t)
+	todo := mustCreate(t, repo, newTodo(t, "audited", "tenant-1"))
+	if _, err := repo.UpdateStatus(ctx, todo.ID, "tenant-1", domain.StatusInProgress, nil, nil, 1); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	var audited int
+	if err := adminDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM todo_audit WHERE todo_id = $1`, todo.ID).Scan(&audited); err != nil || audited != 1 {
+		t.Fatalf("expected 1 audit row, got %d, %v", audited, err)
+	}
+}
+
+// BenchmarkPreparedReuse compares a tenant read through the repository's
+// statement cache with the same statement prepared on every call
+func BenchmarkPreparedReuse(b *testing.B) {
+	repo := newRepository(b)
+	for i := range 100 {
+		mustCreate(b, repo, newTodo(b, fmt.Sprintf("todo %d", i), "tenant-a"))
+	}
+	ctx := context.Background()
+
+	// Both sides connect as the superuser so neither pays for the tenant scope
+	cached := infrapostgres.NewPostgresRepository(adminDB, nil)
+	b.Run("cached", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := cached.WorkloadByAssignee(ctx, "tenant-a"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("unprepared", func(b *testing.B) {
+		query := `
+			SELECT COALESCE(assigned_to, ''), status, COUNT(*)
+			FROM todos
+			WHERE tenant_id = $1 AND deleted_at IS NULL
+			GROUP BY assigned_to, status
+		`
+		for b.Loop() {
+			stmt, err := adminDB.PrepareContext(ctx, query)
+			if err != nil {
+				b.Fatal(err)
+			}
+			rows, err := stmt.QueryContext(ctx, "tenant-a")
+			if err != nil {
+				b.Fatal(err)
+			}
+			for rows.Next() {
+			}
+			rows.Close()
+			stmt.Close()
+		}
+	})
+}
+
+func newTodos(t testing.TB, n int, tenantID string) []*domain.Todo {
+	t.Helper()
+	todos := make([]*domain.Todo, n)
+	for i := range todos {
+		todos[i] = newTodo(t, fmt.Sprintf("todo %d", i), tenantID)
+	}
+	return todos
+}
+
+func TestBatchCreateChunkBoundaries(t *testing.T) {
+	for _, n := range []int{499, 500, 501} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			repo := newRepository(t)
+			if err := repo.BatchCreate(context.Background(), newTodos(t, n, "tenant-a")); err != nil {
+				t.Fatalf("BatchCreate: %v", err)
+			}
+
+			var count int
+			if err := adminDB.QueryRow(`SELECT COUNT(*) FROM todos`).Scan(&count); err != nil {
+				t.Fatalf("failed to count todos: %v", err)
+			}
+			if count != n {
+				t.Errorf("todos = %d, want %d", count, n)
+			}
+		})
+	}
+}
+
+// BenchmarkInsertTodos compares an INSERT per row with the multi-row INSERTs
+// BatchCreate uses
+func BenchmarkInsertTodos(b *testing.B) {
+	truncate(b)
+	todos := newTodos(b, 1000, "tenant-a")
+	ctx := context.Background()
+
+	run := func(b *testing.B, insert func(tx *sql.Tx) error) {
+		for b.Loop() {
+			tx, err := adminDB.BeginTx(ctx, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := insert(tx); err != nil {
+				b.Fatal(err)
+			}
+			tx.Rollback()
+		}
+	}
+
+	b.Run("per-row", func(b *testing.B) {
+		run(b, func(tx *sql.Tx) error {
+			for _, todo := range todos {
+				if err := infrapostgres.InsertTodos(ctx, tx, []*domain.Todo{todo}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+
+	b.Run("multi-row", func(b *testing.B) {
+		run(b, func(tx *sql.Tx) error {
+			return infrapostgres.InsertTodos(ctx, tx, todos)
+		})
+	})
+}
+
+// inTx runs fn in a transaction on db and commits it
+func inTx(t *testing.T, db *sql.DB, fn func(tx *sql.Tx) error) {
+	t.Helper()
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+}
+
+func TestCopyTodosReadsBackLikeInsert(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	parent := mustCreate(t, repo, newTodo(t, "parent", "tenant-a"))
+
+	due := time.Now().Add(48 * time.Hour).UTC()
+	shapes := []struct {
+		name      string
+		opts      []domain.TodoOption
+		hasParent bool
+	}{
+		{name: "plain"},
+		{name: "tags", opts: []domain.TodoOption{domain.WithTags([]string{"a", "b,c", `say "hi"`, `back\slash`, "{braces}"})}},
+		{name: "subtask", opts: []domain.TodoOption{domain.WithParent(&parent.ID), domain.WithDueDate(&due)}, hasParent: true},
+	}
+
+	for _, shape := range shapes {
+		t.Run(shape.name, func(t *testing.T) {
+			copied := newTodo(t, shape.name, "tenant-a", shape.opts...)
+			inserted := newTodo(t, shape.name, "tenant-a", shape.opts...)
+			inserted.CreatedAt, inserted.UpdatedAt = copied.CreatedAt, copied.UpdatedAt
+
+			inTx(t, adminDB, func(tx *sql.Tx) error {
+				return infrapostgres.CopyTodos(ctx, tx, []*domain.Todo{copied})
+			})
+			inTx(t, adminDB, func(tx *sql.Tx) error {
+				return infrapostgres.InsertTodos(ctx, tx, []*domain.Todo{inserted})
+			})
+
+			gotCopied, err := repo.GetByID(ctx, copied.ID, "tenant-a")
+			if err != nil {
+				t.Fatalf("failed to read copied todo: %v", err)
+			}
+			gotInserted, err := repo.GetByID(ctx, inserted.ID, "tenant-a")
+			if err != nil {
+				t.Fatalf("failed to read inserted todo: %v", err)
+			}
+
+			if gotCopied.CompletedAt != nil || gotCopied.ArchivedAt != nil || gotCopied.RemindedAt != nil {
+				t.Errorf("expected NULL timestamps to stay NULL, got %+v", gotCopied)
+			}
+			switch {
+			case shape.hasParent && (gotCopied.ParentID == nil || *gotCopied.ParentID != parent.ID):
+				t.Errorf("parent_id = %v, want %s", gotCopied.ParentID, parent.ID)
+			case !shape.hasParent && gotCopied.ParentID != nil:
+				t.Errorf("parent_id = %s, want NULL", *gotCopied.ParentID)
+			}
+
+			gotInserted.ID = gotCopied.ID
+			if !reflect.DeepEqual(gotCopied, gotInserted) {
+				t.Errorf("copied todo reads back as\n%+v\nwant\n%+v", gotCopied, gotInserted)
+			}
+		})
+	}
+}
+
+func TestBatchCreateFallsBackToInsertUnderRowLevelSecurity(t *testing.T) {
+	truncate(t)
+	ctx := domain.WithCrossTenant(context.Background())
+
+	allowed := func(db *sql.DB) bool {
+		var allowed bool
+		inTx(t, db, func(tx *sql.Tx) (err error) {
+			allowed, err = infrapostgres.CopyAllowed(ctx, tx)
+			return err
+		})
+		return allowed
+	}
+	if !allowed(adminDB) {
+		t.Error("expected the superuser to be allowed to COPY")
+	}
+	if allowed(appDB) {
+		t.Error("expected the app role to be refused COPY")
+	}
+
+	// A batch large enough for COPY still lands through INSERTs for the app role
+	n := 600
+	repo := infrapostgres.NewPostgresRepository(appDB, nil)
+	if err := repo.BatchCreate(ctx, newTodos(t, n, "tenant-a")); err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+	var count int
+	if err := adminDB.QueryRow(`SELECT COUNT(*) FROM todos`).Scan(&count); err != nil {
+		t.Fatalf("failed to count todos: %v", err)
+	}
+	if count != n {
+		t.Errorf("todos = %d, want %d", count, n)
+	}
+}
+
+// BenchmarkCopyTodos compares COPY with multi-row INSERTs for a 10k batch
+func BenchmarkCopyTodos(b *testing.B) {
+	truncate(b)
+	todos := newTodos(b, 10_000, "tenant-a")
+	ctx := context.Background()
+
+	run := func(b *testing.B, write func(ctx context.Context, tx *sql.Tx, todos []*domain.Todo) error) {
+		for b.Loop() {
+			tx, err := adminDB.BeginTx(ctx, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := write(ctx, tx, todos); err != nil {
+				b.Fatal(err)
+			}
+			tx.Rollback()
+		}
+	}
+
+	b.Run("copy", func(b *testing.B) { run(b, infrapostgres.CopyTodos) })
+	b.Run("insert", func(b *testing.B) { run(b, infrapostgres.InsertTodos) })
+}
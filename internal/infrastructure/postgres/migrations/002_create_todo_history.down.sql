@@ -0,0 +1,11 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS todo_history_append_only ON todo_history;
+
+-- Drop functions
+DROP FUNCTION IF EXISTS prevent_history_update();
+
+-- Drop tables
+DROP TABLE IF EXISTS todo_history;
+
+-- Drop Indexes
+DROP INDEX IF EXISTS idx_todo_history_todo_id;
@@ -0,0 +1,26 @@
+-- Append-only change history, written in the same transaction as the mutation
+CREATE TABLE IF NOT EXISTS todo_history (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id UUID NOT NULL,
+    tenant_id VARCHAR(100) NOT NULL,
+    actor_id VARCHAR(100) NOT NULL,
+    change_type VARCHAR(30) NOT NULL,
+    diff JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_todo_history_todo_id ON todo_history(todo_id, tenant_id, created_at DESC);
+
+-- Function to reject edits of recorded history
+CREATE OR REPLACE FUNCTION prevent_history_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'todo_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Trigger to keep history append-only
+CREATE TRIGGER todo_history_append_only
+    BEFORE UPDATE ON todo_history
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_history_update();
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_todos_unique_active_title;
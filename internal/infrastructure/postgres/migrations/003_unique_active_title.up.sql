@@ -0,0 +1,4 @@
+-- A title is unique per owner among active todos and reusable once soft-deleted
+CREATE UNIQUE INDEX idx_todos_unique_active_title
+    ON todos(tenant_id, owner_id, lower(title))
+    WHERE deleted_at IS NULL;
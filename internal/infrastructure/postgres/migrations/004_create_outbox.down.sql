@@ -0,0 +1,5 @@
+-- Drop tables
+DROP TABLE IF EXISTS outbox;
+
+-- Drop Indexes
+DROP INDEX IF EXISTS idx_outbox_unsent;
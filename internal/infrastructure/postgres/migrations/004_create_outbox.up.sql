@@ -0,0 +1,14 @@
+-- Transactional outbox: events are written with the mutation and relayed later
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_name VARCHAR(100) NOT NULL,
+    todo_id UUID NOT NULL,
+    tenant_id VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX idx_outbox_unsent ON outbox(id) WHERE sent_at IS NULL;
@@ -0,0 +1,2 @@
+-- Optional IANA zone; when set, a todo is due at the end of its due day in that zone
+ALTER TABLE todos ADD COLUMN due_date_timezone VARCHAR(64);
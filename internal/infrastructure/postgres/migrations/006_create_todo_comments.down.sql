@@ -0,0 +1,5 @@
+-- Drop tables
+DROP TABLE IF EXISTS todo_comments;
+
+-- Drop Indexes
+DROP INDEX IF EXISTS idx_todo_comments_todo_id;
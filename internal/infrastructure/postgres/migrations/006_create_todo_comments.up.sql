@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS todo_comments (
+    id UUID PRIMARY KEY,
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(100) NOT NULL,
+    author_id VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_todo_comments_todo_id ON todo_comments(todo_id, tenant_id, created_at);
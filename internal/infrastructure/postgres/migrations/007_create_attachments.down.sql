@@ -0,0 +1,5 @@
+-- Drop tables
+DROP TABLE IF EXISTS attachments;
+
+-- Drop Indexes
+DROP INDEX IF EXISTS idx_attachments_todo_id;
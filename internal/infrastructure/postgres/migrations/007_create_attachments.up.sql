@@ -0,0 +1,14 @@
+-- Metadata of files uploaded to object storage; rows are written once the upload is confirmed
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(100) NOT NULL,
+    object_key VARCHAR(1024) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploaded_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_todo_id ON attachments(todo_id, tenant_id, created_at);
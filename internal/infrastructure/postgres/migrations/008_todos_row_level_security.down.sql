@@ -0,0 +1,4 @@
+DROP POLICY IF EXISTS todos_tenant_isolation ON todos;
+
+ALTER TABLE todos NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE todos DISABLE ROW LEVEL SECURITY;
@@ -0,0 +1,10 @@
+-- Confine queries to app.current_tenant once a transaction sets it. Unscoped
+-- sessions, such as migrations and maintenance jobs, still see every tenant.
+ALTER TABLE todos ENABLE ROW LEVEL SECURITY;
+ALTER TABLE todos FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY todos_tenant_isolation ON todos
+    USING (
+        COALESCE(current_setting('app.current_tenant', true), '') = ''
+        OR tenant_id = current_setting('app.current_tenant', true)
+    );
@@ -0,0 +1,5 @@
+-- When the todo last became completed; cleared when it is reopened
+ALTER TABLE todos ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE;
+
+-- Best estimate for todos completed before the column existed
+UPDATE todos SET completed_at = updated_at WHERE status = 3;
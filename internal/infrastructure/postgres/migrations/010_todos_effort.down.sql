@@ -0,0 +1,2 @@
+ALTER TABLE todos DROP COLUMN IF EXISTS logged_minutes;
+ALTER TABLE todos DROP COLUMN IF EXISTS estimated_minutes;
@@ -0,0 +1,3 @@
+-- Estimated and logged effort, in minutes
+ALTER TABLE todos ADD COLUMN estimated_minutes INTEGER CHECK (estimated_minutes >= 0);
+ALTER TABLE todos ADD COLUMN logged_minutes INTEGER NOT NULL DEFAULT 0 CHECK (logged_minutes >= 0);
@@ -0,0 +1,6 @@
+-- Drop Indexes
+DROP INDEX IF EXISTS idx_todo_dependencies_tenant;
+DROP INDEX IF EXISTS idx_todo_dependencies_depends_on;
+
+-- Drop tables
+DROP TABLE IF EXISTS todo_dependencies;
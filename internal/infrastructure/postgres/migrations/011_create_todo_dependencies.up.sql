@@ -0,0 +1,13 @@
+-- todo_id is blocked by depends_on_id; both belong to tenant_id
+CREATE TABLE IF NOT EXISTS todo_dependencies (
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    depends_on_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(100) NOT NULL,
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (todo_id, depends_on_id),
+    CHECK (todo_id <> depends_on_id)
+);
+
+CREATE INDEX idx_todo_dependencies_depends_on ON todo_dependencies(depends_on_id, tenant_id);
+CREATE INDEX idx_todo_dependencies_tenant ON todo_dependencies(tenant_id);
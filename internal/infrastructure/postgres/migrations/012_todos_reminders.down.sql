@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_todos_pending_reminders;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS reminded_at;
+ALTER TABLE todos DROP COLUMN IF EXISTS remind_at;
@@ -0,0 +1,6 @@
+-- Reminder time, and when the reminder for it was sent
+ALTER TABLE todos ADD COLUMN remind_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE todos ADD COLUMN reminded_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_todos_pending_reminders ON todos(remind_at)
+    WHERE remind_at IS NOT NULL AND reminded_at IS NULL AND deleted_at IS NULL;
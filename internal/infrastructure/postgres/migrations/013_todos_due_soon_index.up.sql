@@ -0,0 +1,4 @@
+-- Open todos by due date, for due-soon dashboards. The status literals are
+-- completed (3) and archived (4); queries must repeat them to use the index.
+CREATE INDEX idx_todos_open_due ON todos(tenant_id, due_date)
+    WHERE deleted_at IS NULL AND due_date IS NOT NULL AND status NOT IN (3, 4);
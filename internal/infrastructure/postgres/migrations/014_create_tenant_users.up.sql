@@ -0,0 +1,7 @@
+-- Users known to belong to a tenant, consulted before assigning todos
+CREATE TABLE IF NOT EXISTS tenant_users (
+    tenant_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE todos DROP COLUMN IF EXISTS completed_by;
@@ -0,0 +1,2 @@
+-- Who last completed the todo; cleared when it is reopened
+ALTER TABLE todos ADD COLUMN completed_by VARCHAR(100);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_todos_archived_at;
+ALTER TABLE todos DROP COLUMN IF EXISTS archived_at;
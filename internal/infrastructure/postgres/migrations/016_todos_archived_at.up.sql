@@ -0,0 +1,9 @@
+-- When the todo was archived; cleared when it leaves the archive
+ALTER TABLE todos ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Best estimate for todos archived before the column existed
+UPDATE todos SET archived_at = updated_at WHERE status = 4;
+
+-- Archived todos by archive time, for the archive view and its purge
+CREATE INDEX idx_todos_archived_at ON todos(tenant_id, archived_at)
+    WHERE archived_at IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP POLICY IF EXISTS todos_tenant_isolation ON todos;
+
+CREATE POLICY todos_tenant_isolation ON todos
+    USING (
+        COALESCE(current_setting('app.current_tenant', true), '') = ''
+        OR tenant_id = current_setting('app.current_tenant', true)
+    );
@@ -0,0 +1,11 @@
+-- Deny by default: a transaction sees only the tenant in app.current_tenant,
+-- or every tenant when it opts in through app.cross_tenant, as the
+-- maintenance jobs do. Sessions setting neither see no todos, so later data
+-- migrations touching todos must set app.cross_tenant themselves.
+DROP POLICY IF EXISTS todos_tenant_isolation ON todos;
+
+CREATE POLICY todos_tenant_isolation ON todos
+    USING (
+        tenant_id = current_setting('app.current_tenant', true)
+        OR current_setting('app.cross_tenant', true) = 'on'
+    );
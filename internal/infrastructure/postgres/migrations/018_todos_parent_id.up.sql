@@ -0,0 +1,5 @@
+-- The todo this one is a subtask of, in the same tenant; set at creation
+ALTER TABLE todos ADD COLUMN parent_id UUID REFERENCES todos(id) ON DELETE SET NULL;
+
+-- Live subtasks of a todo, for GetTodoDetail
+CREATE INDEX idx_todos_parent_id ON todos(parent_id, tenant_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- Nothing to undo: the repaired objects belong to 001, whose down migration
+-- drops them
+SELECT 1;
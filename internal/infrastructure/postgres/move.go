@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// MoveToTenant moves the fromTenant todos among ids to toTenant in one
+// transaction, taking their history, comments and attachments along.
+// Dependencies between two moved todos move too; those linking a moved todo
+// to one left behind would span tenants and are dropped.
+func (r *PostgresRepository) MoveToTenant(ctx context.Context, ids []string, fromTenant, toTenant string, mapOwner domain.OwnerMapping) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.MoveToTenant")
+	defer span.End()
+	defer r.logSlowQuery(span, "MoveToTenant", time.Now())
+
+	logFields := []zap.Field{zap.String("from_tenant_id", fromTenant), zap.String("to_tenant_id", toTenant), zap.Int("id_count", len(ids))}
+
+	span.SetAttributes(
+		attribute.String("from_tenant.id", fromTenant),
+		attribute.String("to_tenant.id", toTenant),
+		attribute.Int("id_count", len(ids)),
+	)
+
+	if fromTenant == "" || toTenant == "" {
+		return 0, domain.ErrInvalidTenantID
+	}
+
+	var moved int64
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		owners, err := lockOwners(ctx, tx, ids, fromTenant)
+		if err != nil {
+			return err
+		}
+		if len(owners) == 0 {
+			return nil
+		}
+
+		movedIDs := make([]string, 0, len(owners))
+		for id := range owners {
+			movedIDs = append(movedIDs, id)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM todo_dependencies
+			WHERE tenant_id = $2 AND (todo_id = ANY($1)) <> (depends_on_id = ANY($1))
+		`, pq.Array(movedIDs), fromTenant)
+		if err != nil {
+			return fmt.Errorf("failed to drop cross-tenant dependencies: %w", err)
+		}
+
+		related := []string{
+			`UPDATE todo_history SET tenant_id = $1 WHERE todo_id = ANY($2) AND tenant_id = $3`,
+			`UPDATE todo_comments SET tenant_id = $1 WHERE todo_id = ANY($2) AND tenant_id = $3`,
+			`UPDATE attachments SET tenant_id = $1 WHERE todo_id = ANY($2) AND tenant_id = $3`,
+			`UPDATE todo_dependencies SET tenant_id = $1 WHERE todo_id = ANY($2) AND tenant_id = $3`,
+		}
+		for _, query := range related {
+			if _, err := tx.ExecContext(ctx, query, toTenant, pq.Array(movedIDs), fromTenant); err != nil {
+				return fmt.Errorf("failed to move related rows: %w", err)
+			}
+		}
+
+		now := time.Now().UTC()
+		for _, id := range movedIDs {
+			owner := owners[id]
+			newOwner := owner
+			if mapOwner != nil {
+				newOwner = mapOwner(owner)
+			}
+
+			_, err := tx.ExecContext(ctx, `
+				UPDATE todos
+				SET tenant_id = $1, owner_id = $2, updated_at = $3, version = version + 1
+				WHERE id = $4
+			`, toTenant, newOwner, now, id)
+			if err != nil {
+				return fmt.Errorf("failed to move todo %s: %w", id, err)
+			}
+
+			changes := map[string]domain.FieldChange{
+				"tenant_id": {Old: fromTenant, New: toTenant},
+			}
+			if newOwner != owner {
+				changes["owner_id"] = domain.FieldChange{Old: owner, New: newOwner}
+			}
+			if err := recordChange(ctx, tx, id, toTenant, domain.ChangeUpdated, changes); err != nil {
+				return err
+			}
+		}
+
+		moved = int64(len(movedIDs))
+		return nil
+	})
+	if isDuplicateTitle(err) {
+		span.SetAttributes(attribute.Bool("duplicate_title", true))
+		return 0, domain.ErrDuplicateTitle
+	}
+	if err != nil {
+		r.recordError(span, "MoveToTenant", err, logFields...)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("moved_count", moved))
+	return moved, nil
+}
+
+// lockOwners locks the tenant's todos among ids for the rest of tx and
+// returns their owners by id
+func lockOwners(ctx context.Context, tx *sql.Tx, ids []string, tenantID string) (map[string]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, owner_id
+		FROM todos
+		WHERE id = ANY($1) AND tenant_id = $2
+		FOR UPDATE
+	`, pq.Array(ids), tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock todos: %w", err)
+	}
+	defer rows.Close()
+
+	owners := make(map[string]string)
+	for rows.Next() {
+		var id, owner string
+		if err := rows.Scan(&id, &owner); err != nil {
+			return nil, fmt.Errorf("failed to scan todo: %w", err)
+		}
+		owners[id] = owner
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating todos: %w", err)
+	}
+	return owners, nil
+}
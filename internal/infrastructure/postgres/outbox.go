@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// ProcessOutbox locks up to limit unsent messages, hands each to fn and marks
+// it sent when fn succeeds. Failed messages stay unsent with their attempt
+// count and last error recorded, so they are retried on the next call. Rows
+// are locked with SKIP LOCKED so several relays never deliver the same batch.
+func (r *PostgresRepository) ProcessOutbox(ctx context.Context, limit int, fn func(ctx context.Context, msg *domain.OutboxMessage) error) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ProcessOutbox")
+	defer span.End()
+	defer r.logSlowQuery(span, "ProcessOutbox", time.Now())
+
+	logFields := []zap.Field{zap.Int("limit", limit)}
+
+	sent := 0
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		messages, err := lockUnsentOutbox(ctx, tx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			if pubErr := fn(ctx, msg); pubErr != nil {
+				_, err := tx.ExecContext(ctx,
+					`UPDATE outbox SET attempts = attempts + 1, last_error = $1 WHERE id = $2`,
+					pubErr.Error(), msg.ID,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to record outbox failure: %w", err)
+				}
+				continue
+			}
+
+			_, err := tx.ExecContext(ctx,
+				`UPDATE outbox SET sent_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1`,
+				msg.ID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to mark outbox message sent: %w", err)
+			}
+			sent++
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.recordError(span, "ProcessOutbox", err, logFields...)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int("sent_count", sent))
+	return sent, nil
+}
+
+func lockUnsentOutbox(ctx context.Context, tx *sql.Tx, limit int) ([]*domain.OutboxMessage, error) {
+	query := `
+		SELECT id, event_name, todo_id, tenant_id, payload, created_at, attempts
+		FROM outbox
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*domain.OutboxMessage, 0)
+	for rows.Next() {
+		msg := &domain.OutboxMessage{}
+		err := rows.Scan(
+			&msg.ID,
+			&msg.Name,
+			&msg.TodoID,
+			&msg.TenantID,
+			&msg.Payload,
+			&msg.CreatedAt,
+			&msg.Attempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox: %w", err)
+	}
+
+	return messages, nil
+}
+
+func insertOutbox(ctx context.Context, tx *sql.Tx, event domain.DomainEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (event_name, todo_id, tenant_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		event.EventName(),
+		event.AggregateID(),
+		event.Tenant(),
+		payload,
+		event.OccurredAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
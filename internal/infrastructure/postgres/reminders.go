@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// DueReminders claims every unsent reminder of an open todo that falls
+// before now+window, including ones missed while the scan was not running.
+// Claiming marks the reminder as sent and queues its event in the same
+// transaction, so concurrent scans never remind twice.
+func (r *PostgresRepository) DueReminders(ctx context.Context, window time.Duration) ([]*domain.Todo, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.DueReminders")
+	defer span.End()
+	defer r.logSlowQuery(span, "DueReminders", time.Now())
+
+	logFields := []zap.Field{zap.Duration("window", window)}
+
+	now := time.Now().UTC()
+	query := fmt.Sprintf(`
+		UPDATE todos
+		SET reminded_at = $1
+		WHERE remind_at IS NOT NULL
+			AND reminded_at IS NULL
+			AND deleted_at IS NULL
+			AND remind_at <= $2
+			AND status NOT IN ($3, $4)
+		RETURNING %s
+	`, todoColumns)
+
+	var todos []*domain.Todo
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query,
+			now,
+			now.Add(window),
+			domain.StatusCompleted,
+			domain.StatusArchived,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to claim reminders: %w", err)
+		}
+
+		for rows.Next() {
+			todo, err := scanTodo(rows)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan reminded todo: %w", err)
+			}
+			todos = append(todos, todo)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating reminded todos: %w", err)
+		}
+
+		for _, todo := range todos {
+			if err := insertOutbox(ctx, tx, domain.ReminderFor(todo, now)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.recordError(span, "DueReminders", err, logFields...)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("reminded_count", len(todos)))
+	return todos, nil
+}
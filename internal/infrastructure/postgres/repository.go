@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,20 +14,175 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
-const queryTimeout = 5 * time.Second
+const (
+	queryTimeout = 5 * time.Second
+
+	// maintenanceTimeout bounds background jobs that touch many rows
+	maintenanceTimeout = 5 * time.Minute
+)
+
+const (
+	uniqueViolationCode   = "23505"
+	uniqueTitleConstraint = "idx_todos_unique_active_title"
+)
+
+// todoColumns is the column list every todo read scans, in scanTodo order
+const todoColumns = `id, title, description, status, priority, due_date, tags, owner_id, assigned_to, tenant_id, created_at, updated_at, version, due_date_timezone, deleted_at, completed_at, estimated_minutes, logged_minutes, remind_at, reminded_at, completed_by, archived_at, parent_id`
+
+// dueDeadlineSQL is the instant a todo becomes overdue: its due date, or the
+// end of that day in due_date_timezone when one is set. Mirrors Todo.Deadline.
+const dueDeadlineSQL = `(CASE WHEN due_date_timezone IS NULL THEN due_date
+	ELSE (date_trunc('day', due_date AT TIME ZONE due_date_timezone) + INTERVAL '1 day') AT TIME ZONE due_date_timezone END)`
 
 type PostgresRepository struct {
 	db     *sql.DB
+	stmts  *stmtCache
 	tracer trace.Tracer
+	logger *zap.Logger
+
+	// replica, when set, serves reads that do not need read-your-writes
+	replica      *sql.DB
+	replicaStmts *stmtCache
+
+	slowQueryThreshold time.Duration
+}
+
+// RepositoryOption configures optional behavior of PostgresRepository
+type RepositoryOption func(*PostgresRepository)
+
+// WithSlowQueryThreshold logs operations taking at least threshold; 0 disables it
+func WithSlowQueryThreshold(threshold time.Duration) RepositoryOption {
+	return func(r *PostgresRepository) {
+		r.slowQueryThreshold = threshold
+	}
+}
+
+// WithReplica sends reads to a read-only replica unless the context asks for
+// read-your-writes; a nil replica keeps every read on the primary
+func WithReplica(replica *sql.DB) RepositoryOption {
+	return func(r *PostgresRepository) {
+		if replica == nil {
+			return
+		}
+		r.replica = replica
+		r.replicaStmts = newStmtCache(replica)
+	}
 }
 
-func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{
+// NewPostgresRepository creates a repository on db. A nil logger discards
+// the repository's logs.
+func NewPostgresRepository(db *sql.DB, logger *zap.Logger, opts ...RepositoryOption) *PostgresRepository {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	r := &PostgresRepository{
 		db:     db,
+		stmts:  newStmtCache(db),
 		tracer: otel.Tracer("postgres-repository"),
+		logger: logger,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// logSlowQuery logs operation and adds an event to span when it has been
+// running for at least the slow query threshold since start
+func (r *PostgresRepository) logSlowQuery(span trace.Span, operation string, start time.Time) {
+	if r.slowQueryThreshold <= 0 {
+		return
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < r.slowQueryThreshold {
+		return
+	}
+
+	span.AddEvent("slow_query", trace.WithAttributes(
+		attribute.Int64("duration_ms", elapsed.Milliseconds()),
+	))
+	r.logger.Warn("slow query",
+		zap.String("operation", operation),
+		zap.Duration("duration", elapsed),
+		zap.Duration("threshold", r.slowQueryThreshold),
+	)
+}
+
+// recordError records a failed operation on span and logs it with fields,
+// which identify what was operated on without carrying todo content
+func (r *PostgresRepository) recordError(span trace.Span, operation string, err error, fields ...zap.Field) {
+	span.RecordError(err)
+	r.logger.Error("repository operation failed",
+		append([]zap.Field{zap.String("operation", operation), zap.Error(err)}, fields...)...,
+	)
+}
+
+// todoLogFields identifies todo in logs; titles and descriptions are left out
+func todoLogFields(todo *domain.Todo) []zap.Field {
+	return []zap.Field{
+		zap.String("todo_id", todo.ID),
+		zap.String("tenant_id", todo.TenantID),
+		zap.Int64("version", todo.Version),
+	}
+}
+
+// filterLogFields describes filter in logs; search text and tags are left out
+func filterLogFields(filter *domain.ListFilter) []zap.Field {
+	return []zap.Field{
+		zap.String("tenant_id", filter.TenantID),
+		zap.Int("page", filter.Page),
+		zap.Int("page_size", filter.PageSize),
+		zap.Bool("narrowed", filter.IsNarrowed()),
+	}
+}
+
+// filterSpanAttributes records which filters are active on a span without
+// their values, which may carry user input
+func filterSpanAttributes(filter *domain.ListFilter) []attribute.KeyValue {
+	sortFields := make([]string, 0, max(len(filter.Sort), 1))
+	for _, spec := range filter.Sort {
+		if domain.IsSortableField(spec.Field) {
+			sortFields = append(sortFields, spec.Field)
+		}
+	}
+	if len(filter.Sort) == 0 {
+		sortBy := "created_at"
+		if domain.IsSortableField(filter.SortBy) {
+			sortBy = filter.SortBy
+		}
+		sortFields = append(sortFields, sortBy)
+	}
+
+	return []attribute.KeyValue{
+		attribute.Bool("filter.has_search", filter.SearchQuery != nil && *filter.SearchQuery != ""),
+		attribute.Int("filter.status_count", len(filter.Statuses)),
+		attribute.Int("filter.priority_count", len(filter.Priorities)),
+		attribute.Int("filter.tag_count", len(filter.Tags)),
+		attribute.Int("filter.exclude_tag_count", len(filter.ExcludeTags)),
+		attribute.Bool("filter.has_date_range", filter.DueDateFrom != nil || filter.DueDateTo != nil ||
+			filter.CreatedFrom != nil || filter.CreatedTo != nil ||
+			filter.UpdatedFrom != nil || filter.UpdatedTo != nil),
+		attribute.Bool("filter.overdue_only", filter.OverdueOnly),
+		attribute.String("filter.sort_by", strings.Join(sortFields, ",")),
+	}
+}
+
+// Close releases the repository's prepared statements; it does not close db
+func (r *PostgresRepository) Close() error {
+	err := r.stmts.close()
+	if r.replicaStmts != nil {
+		if replicaErr := r.replicaStmts.close(); err == nil {
+			err = replicaErr
+		}
 	}
+	return err
 }
 
 func (r *PostgresRepository) Create(ctx context.Context, todo *domain.Todo) error {
@@ -35,280 +191,1344 @@ func (r *PostgresRepository) Create(ctx context.Context, todo *domain.Todo) erro
 
 	ctx, span := r.tracer.Start(ctx, "repository.Create")
 	defer span.End()
+	defer r.logSlowQuery(span, "Create", time.Now())
+
+	logFields := todoLogFields(todo)
+
+	span.SetAttributes(
+		attribute.String("todo.id", todo.ID),
+		attribute.String("tenant.id", todo.TenantID),
+	)
+
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		if err := insertTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, todo.ID, todo.TenantID, domain.ChangeCreated, domain.DiffTodos(nil, todo))
+	})
+	if isDuplicateTitle(err) {
+		span.SetAttributes(attribute.Bool("duplicate_title", true))
+		return domain.ErrDuplicateTitle
+	}
+	if err != nil {
+		r.recordError(span, "Create", err, logFields...)
+		return fmt.Errorf("failed to create todo: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) Upsert(ctx context.Context, todo *domain.Todo) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.Upsert")
+	defer span.End()
+	defer r.logSlowQuery(span, "Upsert", time.Now())
+
+	logFields := todoLogFields(todo)
 
 	span.SetAttributes(
 		attribute.String("todo.id", todo.ID),
 		attribute.String("tenant.id", todo.TenantID),
+		attribute.Int64("version", todo.Version),
 	)
 
+	// todo.Version is the version being written; an existing row is only
+	// replaced when it is exactly one behind
 	query := `
 		INSERT INTO todos (
 			id, title, description, status, priority, due_date, tags, owner_id, assigned_to,
-			tenant_id, created_at, updated_at, version
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			tenant_id, created_at, updated_at, version, due_date_timezone, completed_at,
+			estimated_minutes, logged_minutes, remind_at, reminded_at, completed_by, archived_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+		ON CONFLICT (id) DO UPDATE SET
+			title = excluded.title,
+			description = excluded.description,
+			status = excluded.status,
+			priority = excluded.priority,
+			due_date = excluded.due_date,
+			tags = excluded.tags,
+			assigned_to = excluded.assigned_to,
+			updated_at = excluded.updated_at,
+			version = excluded.version,
+			due_date_timezone = excluded.due_date_timezone,
+			completed_at = excluded.completed_at,
+			estimated_minutes = excluded.estimated_minutes,
+			logged_minutes = excluded.logged_minutes,
+			remind_at = excluded.remind_at,
+			reminded_at = excluded.reminded_at,
+			completed_by = excluded.completed_by,
+			archived_at = excluded.archived_at
+		WHERE todos.version = excluded.version - 1
+			AND todos.tenant_id = excluded.tenant_id
+			AND todos.deleted_at IS NULL
+		RETURNING (xmax = 0)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
-		todo.ID,
-		todo.Title,
-		todo.Description,
-		todo.Status,
-		todo.Priority,
-		todo.DueDate,
-		pq.Array(todo.Tags),
-		todo.OwnerID,
-		todo.AssignedTo,
-		todo.TenantID,
-		todo.CreatedAt,
-		todo.UpdatedAt,
-		todo.Version,
-	)
+	var inserted bool
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := getForUpdate(ctx, tx, todo.ID, todo.TenantID)
+		if err != nil && !errors.Is(err, domain.ErrVersionMismatch) {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, query,
+			todo.ID,
+			todo.Title,
+			todo.Description,
+			todo.Status,
+			todo.Priority,
+			todo.DueDate,
+			pq.Array(todo.Tags),
+			todo.OwnerID,
+			todo.AssignedTo,
+			todo.TenantID,
+			todo.CreatedAt,
+			todo.UpdatedAt,
+			todo.Version,
+			todo.DueDateTimezone,
+			todo.CompletedAt,
+			todo.EstimatedMinutes,
+			todo.LoggedMinutes,
+			todo.RemindAt,
+			todo.RemindedAt,
+			todo.CompletedBy,
+			todo.ArchivedAt,
+		).Scan(&inserted)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("todo %s: %w", todo.ID, domain.ErrVersionMismatch)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to upsert todo: %w", err)
+		}
 
+		if inserted {
+			return recordChange(ctx, tx, todo.ID, todo.TenantID, domain.ChangeCreated, domain.DiffTodos(nil, todo))
+		}
+		return recordChange(ctx, tx, todo.ID, todo.TenantID, domain.ChangeUpdated, domain.DiffTodos(before, todo))
+	})
+
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		span.SetAttributes(attribute.Bool("version_mismatch", true))
+		return false, err
+	}
+	if isDuplicateTitle(err) {
+		span.SetAttributes(attribute.Bool("duplicate_title", true))
+		return false, domain.ErrDuplicateTitle
+	}
 	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to create todo: %w", err)
+		r.recordError(span, "Upsert", err, logFields...)
+		return false, err
 	}
 
-	return nil
+	span.SetAttributes(attribute.Bool("created", inserted))
+	return inserted, nil
 }
 
 func (r *PostgresRepository) GetByID(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	return r.getByID(ctx, id, tenantID, false)
+}
+
+// GetByIDIncludingDeleted retrieves a todo by ID even when it is soft-deleted
+func (r *PostgresRepository) GetByIDIncludingDeleted(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	return r.getByID(ctx, id, tenantID, true)
+}
+
+func (r *PostgresRepository) getByID(ctx context.Context, id, tenantID string, includeDeleted bool) (*domain.Todo, error) {
 	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
 	defer cancel()
 
 	ctx, span := r.tracer.Start(ctx, "repository.GetByID")
 	defer span.End()
+	defer r.logSlowQuery(span, "GetByID", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", id), zap.String("tenant_id", tenantID)}
 
 	span.SetAttributes(
 		attribute.String("todo.id", id),
 		attribute.String("tenant.id", tenantID),
+		attribute.Bool("include_deleted", includeDeleted),
 	)
 
-	query := `
-		SELECT id, title, description, status, priority, due_date, tags, owner_id, assigned_to, tenant_id, created_at, updated_at, version 
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE id = $1 AND tenant_id = $2 AND ($3 OR deleted_at IS NULL)
+	`, todoColumns)
+
+	q := r.reader(ctx)
+
+	todo, err := scanTodo(q.QueryRowContext(ctx, query, id, tenantID, includeDeleted))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetAttributes(attribute.Bool("not_found", true))
+			return nil, fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+		}
+		r.recordError(span, "GetByID", err, logFields...)
+		return nil, fmt.Errorf("failed to get todo: %w", err)
+	}
+
+	return todo, nil
+}
+
+// GetDetail retrieves a todo and counts its comments in one query, then lists
+// its live subtasks in a second one
+func (r *PostgresRepository) GetDetail(ctx context.Context, id, tenantID string) (*domain.TodoDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.GetDetail")
+	defer span.End()
+	defer r.logSlowQuery(span, "GetDetail", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", id), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", id),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	query := fmt.Sprintf(`
+		SELECT %s, (
+			SELECT COUNT(*)
+			FROM todo_comments
+			WHERE todo_comments.todo_id = todos.id AND todo_comments.tenant_id = todos.tenant_id
+		) AS comment_count
 		FROM todos
 		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
-	`
+	`, todoColumns)
 
-	todo := &domain.Todo{}
-	var tags pq.StringArray
+	q := r.reader(ctx)
 
-	err := r.db.QueryRowContext(ctx, query, id, tenantID).Scan(
-		&todo.ID,
-		&todo.Title,
-		&todo.Description,
-		&todo.Status,
-		&todo.Priority,
-		&todo.DueDate,
-		&tags,
-		&todo.OwnerID,
-		&todo.AssignedTo,
-		&todo.TenantID,
-		&todo.CreatedAt,
-		&todo.UpdatedAt,
-		&todo.Version,
+	detail := &domain.TodoDetail{}
+	var err error
+	detail.Todo, err = scanTodo(countedRow{rowScanner: q.QueryRowContext(ctx, query, id, tenantID), total: &detail.CommentCount})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetAttributes(attribute.Bool("not_found", true))
+			return nil, fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+		}
+		r.recordError(span, "GetDetail", err, logFields...)
+		return nil, fmt.Errorf("failed to get todo detail: %w", err)
+	}
+
+	subtasksQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE parent_id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC
+	`, todoColumns)
+
+	rows, err := q.QueryContext(ctx, subtasksQuery, id, tenantID)
+	if err != nil {
+		r.recordError(span, "GetDetail", err, logFields...)
+		return nil, fmt.Errorf("failed to list subtasks: %w", err)
+	}
+	defer rows.Close()
+
+	detail.Subtasks = make([]*domain.Todo, 0)
+	for rows.Next() {
+		subtask, err := scanTodo(rows)
+		if err != nil {
+			r.recordError(span, "GetDetail", err, logFields...)
+			return nil, fmt.Errorf("failed to scan subtask: %w", err)
+		}
+		detail.Subtasks = append(detail.Subtasks, subtask)
+	}
+	if err := rows.Err(); err != nil {
+		r.recordError(span, "GetDetail", err, logFields...)
+		return nil, fmt.Errorf("error iterating subtasks: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.Int64("comment_count", detail.CommentCount),
+		attribute.Int("subtask_count", len(detail.Subtasks)),
+	)
+	return detail, nil
+}
+
+// GetByIDs retrieves the todos with the given ids in input order, silently
+// skipping ids that are missing, deleted or belong to another tenant
+func (r *PostgresRepository) GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*domain.Todo, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.GetByIDs")
+	defer span.End()
+	defer r.logSlowQuery(span, "GetByIDs", time.Now())
+
+	logFields := []zap.Field{zap.Int("id_count", len(ids)), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.Int("requested_count", len(ids)),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	if len(ids) == 0 {
+		return []*domain.Todo{}, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE id = ANY($1) AND tenant_id = $2 AND deleted_at IS NULL
+	`, todoColumns)
+
+	q := r.reader(ctx)
+
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids), tenantID)
+	if err != nil {
+		r.recordError(span, "GetByIDs", err, logFields...)
+		return nil, fmt.Errorf("failed to get todos: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[string]*domain.Todo, len(ids))
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			r.recordError(span, "GetByIDs", err, logFields...)
+			return nil, fmt.Errorf("failed to scan todo: %w", err)
+		}
+		byID[todo.ID] = todo
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "GetByIDs", err, logFields...)
+		return nil, fmt.Errorf("error iterating todos: %w", err)
+	}
+
+	todos := make([]*domain.Todo, 0, len(byID))
+	for _, id := range ids {
+		if todo, ok := byID[id]; ok {
+			todos = append(todos, todo)
+			// Duplicate input ids are returned once
+			delete(byID, id)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("returned_count", len(todos)))
+	return todos, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, todo *domain.Todo, expectedVersion int64) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.Update")
+	defer span.End()
+	defer r.logSlowQuery(span, "Update", time.Now())
+
+	logFields := todoLogFields(todo)
+
+	span.SetAttributes(
+		attribute.String("todo.id", todo.ID),
+		attribute.String("tenant.id", todo.TenantID),
+		attribute.Int64("version", expectedVersion),
 	)
 
+	// Optimistic locking: update only if the stored version is the one the caller read
+	query := `
+		UPDATE todos
+		SET title = $1, description = $2, status = $3, priority = $4, due_date = $5, tags = $6, assigned_to = $7, updated_at = $8, version = version + 1, due_date_timezone = $12, completed_at = $13, owner_id = $14, estimated_minutes = $15, logged_minutes = $16, remind_at = $17, reminded_at = $18, completed_by = $19, archived_at = $20
+		WHERE id = $9 AND tenant_id = $10 AND version = $11 AND deleted_at IS NULL
+	`
+
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := getForUpdate(ctx, tx, todo.ID, todo.TenantID)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, query,
+			todo.Title,
+			todo.Description,
+			todo.Status,
+			todo.Priority,
+			todo.DueDate,
+			pq.Array(todo.Tags),
+			todo.AssignedTo,
+			time.Now().UTC(),
+			todo.ID,
+			todo.TenantID,
+			expectedVersion,
+			todo.DueDateTimezone,
+			todo.CompletedAt,
+			todo.OwnerID,
+			todo.EstimatedMinutes,
+			todo.LoggedMinutes,
+			todo.RemindAt,
+			todo.RemindedAt,
+			todo.CompletedBy,
+			todo.ArchivedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update todo: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("todo %s: %w", todo.ID, domain.ErrVersionMismatch)
+		}
+
+		return recordChange(ctx, tx, todo.ID, todo.TenantID, domain.ChangeUpdated, domain.DiffTodos(before, todo))
+	})
+
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		span.SetAttributes(attribute.Bool("version_mismatch", true))
+		return err
+	}
+	if isDuplicateTitle(err) {
+		span.SetAttributes(attribute.Bool("duplicate_title", true))
+		return domain.ErrDuplicateTitle
+	}
+	if err != nil {
+		r.recordError(span, "Update", err, logFields...)
+		return err
+	}
+
+	todo.Version = expectedVersion + 1
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id, tenantID string) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+	defer r.logSlowQuery(span, "Delete", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", id), zap.String("tenant_id", tenantID)}
+
+	// Soft delete
+	query := `
+		UPDATE todos
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+	`
+
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		deletedAt := time.Now().UTC()
+
+		result, err := tx.ExecContext(ctx, query, deletedAt, id, tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to delete todo: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+		}
+
+		return recordChange(ctx, tx, id, tenantID, domain.ChangeDeleted, map[string]domain.FieldChange{
+			"deleted_at": {Old: nil, New: deletedAt},
+		})
+	})
+
+	if err != nil && !errors.Is(err, domain.ErrTodoNotFound) {
+		r.recordError(span, "Delete", err, logFields...)
+	}
+	return err
+}
+
+// ForceDelete removes a todo, soft-deleted or not, together with its history
+// and audit rows; its comments, attachments and dependencies go with it by
+// cascade. Only the deletion event is kept, so watchers learn of it. The
+// storage keys of the removed attachments are returned, since their objects
+// outlive the rows.
+func (r *PostgresRepository) ForceDelete(ctx context.Context, id, tenantID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ForceDelete")
+	defer span.End()
+	defer r.logSlowQuery(span, "ForceDelete", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", id), zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("todo.id", id),
+		attribute.String("tenant.id", tenantID),
+	)
+
+	// Locking the todo holds off attachments being added until it is gone, so
+	// the keys read here are all the cascade removes
+	keysQuery := `
+		SELECT a.object_key
+		FROM todos t
+		LEFT JOIN attachments a ON a.todo_id = t.id
+		WHERE t.id = $1 AND t.tenant_id = $2
+		FOR UPDATE OF t
+	`
+
+	query := `
+		WITH deleted AS (
+			DELETE FROM todos
+			WHERE id = $1 AND tenant_id = $2
+			RETURNING id
+		), deleted_history AS (
+			DELETE FROM todo_history WHERE todo_id IN (SELECT id FROM deleted)
+		), deleted_audit AS (
+			DELETE FROM todo_audit WHERE todo_id IN (SELECT id FROM deleted)
+		)
+		SELECT COUNT(*) FROM deleted
+	`
+
+	var objectKeys []string
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, keysQuery, id, tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to lock todo: %w", err)
+		}
+		found := false
+		for rows.Next() {
+			var key sql.NullString
+			if err := rows.Scan(&key); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan attachment key: %w", err)
+			}
+			found = true
+			if key.Valid {
+				objectKeys = append(objectKeys, key.String)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating attachment keys: %w", err)
+		}
+		if !found {
+			return fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+		}
+
+		var deleted int64
+		if err := tx.QueryRowContext(ctx, query, id, tenantID).Scan(&deleted); err != nil {
+			return fmt.Errorf("failed to delete todo: %w", err)
+		}
+		if deleted == 0 {
+			return fmt.Errorf("todo %s: %w", id, domain.ErrTodoNotFound)
+		}
+
+		changes := map[string]domain.FieldChange{}
+		for _, event := range domain.EventsForChange(id, tenantID, actorFromContext(ctx), domain.ChangeDeleted, changes, time.Now().UTC()) {
+			if err := insertOutbox(ctx, tx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		if !errors.Is(err, domain.ErrTodoNotFound) {
+			r.recordError(span, "ForceDelete", err, logFields...)
+		}
+		return nil, err
+	}
+	return objectKeys, nil
+}
+
+func (r *PostgresRepository) DeleteByFilter(ctx context.Context, filter *domain.ListFilter) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.DeleteByFilter")
+	defer span.End()
+	defer r.logSlowQuery(span, "DeleteByFilter", time.Now())
+
+	logFields := filterLogFields(filter)
+
+	span.SetAttributes(attribute.String("tenant.id", filter.TenantID))
+
+	if filter.TenantID == "" {
+		return 0, domain.ErrInvalidTenantID
+	}
+	if !filter.IsNarrowed() {
+		return 0, domain.ErrFilterTooBroad
+	}
+
+	where, args := buildWhereClause(filter)
+	deletedAt := time.Now().UTC()
+	args = append(args, deletedAt)
+
+	query := fmt.Sprintf(`
+		UPDATE todos
+		SET deleted_at = $%d, updated_at = $%d
+		WHERE %s
+		RETURNING id
+	`, len(args), len(args), where)
+
+	var deleted int64
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to delete todos: %w", err)
+		}
+
+		ids := make([]string, 0)
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan deleted id: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating deleted ids: %w", err)
+		}
+
+		for _, id := range ids {
+			err := recordChange(ctx, tx, id, filter.TenantID, domain.ChangeDeleted, map[string]domain.FieldChange{
+				"deleted_at": {Old: nil, New: deletedAt},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		deleted = int64(len(ids))
+		return nil
+	})
+	if err != nil {
+		r.recordError(span, "DeleteByFilter", err, logFields...)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("deleted_count", deleted))
+	return deleted, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter *domain.ListFilter) ([]*domain.Todo, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.List")
+	defer span.End()
+	defer r.logSlowQuery(span, "List", time.Now())
+
+	logFields := filterLogFields(filter)
+	span.SetAttributes(filterSpanAttributes(filter)...)
+
+	query, where, _, args := buildListQuery(filter)
+
+	q := r.reader(ctx)
+
+	// Calculate offset
+	offset := (filter.Page - 1) * filter.PageSize
+
+	pageArgs := append(slices.Clone(args), filter.PageSize, offset)
+
+	rows, err := r.queryCached(ctx, q, query, pageArgs...)
+	if err != nil {
+		r.recordError(span, "List", err, logFields...)
+		return nil, 0, fmt.Errorf("failed to list todos: %w", err)
+	}
+	defer rows.Close()
+
+	var totalCount int64
+	todos := make([]*domain.Todo, 0)
+	for rows.Next() {
+		todo, err := scanTodo(countedRow{rowScanner: rows, total: &totalCount})
+		if err != nil {
+			r.recordError(span, "List", err, logFields...)
+			return nil, 0, fmt.Errorf("failed to scan todo: %w", err)
+		}
+
+		todos = append(todos, todo)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "List", err, logFields...)
+		return nil, 0, fmt.Errorf("error iterating todos: %w", err)
+	}
+
+	if len(todos) == 0 && offset > 0 {
+		// A page past the end has no row to carry the total
+		if totalCount, err = r.countWhere(ctx, q, where, args); err != nil {
+			r.recordError(span, "List", err, logFields...)
+			return nil, 0, err
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int64("total_count", totalCount),
+		attribute.Int("returned_count", len(todos)),
+	)
+
+	return todos, totalCount, nil
+}
+
+// DryRunList returns the SQL List would run for filter without running it
+func (r *PostgresRepository) DryRunList(ctx context.Context, filter *domain.ListFilter) (*domain.ListQuery, error) {
+	_, span := r.tracer.Start(ctx, "repository.DryRunList")
+	defer span.End()
+
+	query, where, orderBy, args := buildListQuery(filter)
+
+	return &domain.ListQuery{
+		SQL:      strings.TrimSpace(query),
+		Where:    where,
+		OrderBy:  orderBy,
+		ArgCount: len(args) + 2,
+	}, nil
+}
+
+// AnalyzeList runs the query of List for filter under EXPLAIN ANALYZE and
+// returns the plan as JSON. The query is executed inside a read-only
+// transaction that is rolled back.
+func (r *PostgresRepository) AnalyzeList(ctx context.Context, filter *domain.ListFilter) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.AnalyzeList")
+	defer span.End()
+	defer r.logSlowQuery(span, "AnalyzeList", time.Now())
+
+	logFields := filterLogFields(filter)
+
+	query, _, _, args := buildListQuery(filter)
+	offset := (filter.Page - 1) * filter.PageSize
+	args = append(args, filter.PageSize, offset)
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		r.recordError(span, "AnalyzeList", err, logFields...)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := applyTenantScope(ctx, tx); err != nil {
+		r.recordError(span, "AnalyzeList", err, logFields...)
+		return nil, err
+	}
+
+	var plan []byte
+	if err := tx.QueryRowContext(ctx, "EXPLAIN (ANALYZE, FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		r.recordError(span, "AnalyzeList", err, logFields...)
+		return nil, fmt.Errorf("failed to analyze list query: %w", err)
+	}
+
+	return plan, nil
+}
+
+// ListRefs is List reduced to id, version and updated_at, so sync clients can
+// diff their local copies without loading every description
+func (r *PostgresRepository) ListRefs(ctx context.Context, filter *domain.ListFilter) ([]*domain.TodoRef, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ListRefs")
+	defer span.End()
+	defer r.logSlowQuery(span, "ListRefs", time.Now())
+
+	logFields := filterLogFields(filter)
+
+	where, args := buildWhereClause(filter)
+
+	q := r.reader(ctx)
+
+	offset := (filter.Page - 1) * filter.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT id, version, updated_at, COUNT(*) OVER() AS total_count
+		FROM todos
+		WHERE %s
+		%s
+		LIMIT $%d OFFSET $%d
+	`, where, buildOrderByClause(filter), len(args)+1, len(args)+2)
+
+	pageArgs := append(slices.Clone(args), filter.PageSize, offset)
+
+	rows, err := r.queryCached(ctx, q, query, pageArgs...)
+	if err != nil {
+		r.recordError(span, "ListRefs", err, logFields...)
+		return nil, 0, fmt.Errorf("failed to list todo refs: %w", err)
+	}
+	defer rows.Close()
+
+	var totalCount int64
+	refs := make([]*domain.TodoRef, 0)
+	for rows.Next() {
+		ref := &domain.TodoRef{}
+		if err := rows.Scan(&ref.ID, &ref.Version, &ref.UpdatedAt, &totalCount); err != nil {
+			r.recordError(span, "ListRefs", err, logFields...)
+			return nil, 0, fmt.Errorf("failed to scan todo ref: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "ListRefs", err, logFields...)
+		return nil, 0, fmt.Errorf("error iterating todo refs: %w", err)
+	}
+
+	if len(refs) == 0 && offset > 0 {
+		// A page past the end has no row to carry the total
+		if totalCount, err = r.countWhere(ctx, q, where, args); err != nil {
+			r.recordError(span, "ListRefs", err, logFields...)
+			return nil, 0, err
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int64("total_count", totalCount),
+		attribute.Int("returned_count", len(refs)),
+	)
+
+	return refs, totalCount, nil
+}
+
+// countWhere counts the todos matching a clause built by buildWhereClause
+func (r *PostgresRepository) countWhere(ctx context.Context, q querier, where string, args []any) (int64, error) {
+	var count int64
+	query := fmt.Sprintf("SELECT COUNT(*) FROM todos WHERE %s", where)
+	if err := r.queryRowCached(ctx, q, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count todos: %w", err)
+	}
+	return count, nil
+}
+
+func (r *PostgresRepository) CountActive(ctx context.Context, tenantID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.CountActive")
+	defer span.End()
+	defer r.logSlowQuery(span, "CountActive", time.Now())
+
+	logFields := []zap.Field{zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(attribute.String("tenant.id", tenantID))
+
+	query := `SELECT COUNT(*) FROM todos WHERE tenant_id = $1 AND deleted_at IS NULL`
+
+	q := r.reader(ctx)
+
+	var count int64
+	if err := q.QueryRowContext(ctx, query, tenantID).Scan(&count); err != nil {
+		r.recordError(span, "CountActive", err, logFields...)
+		return 0, fmt.Errorf("failed to count active todos: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PostgresRepository) CountByStatus(ctx context.Context, filter *domain.ListFilter) (map[domain.TodoStatus]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.CountByStatus")
+	defer span.End()
+	defer r.logSlowQuery(span, "CountByStatus", time.Now())
+
+	logFields := filterLogFields(filter)
+
+	where, args := buildWhereClause(filter)
+
+	query := fmt.Sprintf("SELECT status, COUNT(*) FROM todos WHERE %s GROUP BY status", where)
+
+	q := r.reader(ctx)
+
+	rows, err := r.queryCached(ctx, q, query, args...)
+	if err != nil {
+		r.recordError(span, "CountByStatus", err, logFields...)
+		return nil, fmt.Errorf("failed to count todos by status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.TodoStatus]int64)
+	for rows.Next() {
+		var status domain.TodoStatus
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			r.recordError(span, "CountByStatus", err, logFields...)
+			return nil, fmt.Errorf("failed to scan status count: %w", err)
+		}
+		counts[status] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "CountByStatus", err, logFields...)
+		return nil, fmt.Errorf("error iterating status counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// WorkloadByAssignee counts the tenant's non-deleted todos per assignee and
+// status in one grouped query
+func (r *PostgresRepository) WorkloadByAssignee(ctx context.Context, tenantID string) (map[string]domain.WorkloadStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.WorkloadByAssignee")
+	defer span.End()
+	defer r.logSlowQuery(span, "WorkloadByAssignee", time.Now())
+
+	logFields := []zap.Field{zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(attribute.String("tenant.id", tenantID))
+
+	query := `
+		SELECT COALESCE(assigned_to, ''), status, COUNT(*)
+		FROM todos
+		WHERE tenant_id = $1 AND deleted_at IS NULL
+		GROUP BY assigned_to, status
+	`
+
+	q := r.reader(ctx)
+
+	rows, err := r.queryCached(ctx, q, query, tenantID)
+	if err != nil {
+		r.recordError(span, "WorkloadByAssignee", err, logFields...)
+		return nil, fmt.Errorf("failed to count workload: %w", err)
+	}
+	defer rows.Close()
+
+	workload := make(map[string]domain.WorkloadStats)
+	for rows.Next() {
+		var assignee string
+		var status domain.TodoStatus
+		var count int64
+		if err := rows.Scan(&assignee, &status, &count); err != nil {
+			r.recordError(span, "WorkloadByAssignee", err, logFields...)
+			return nil, fmt.Errorf("failed to scan workload count: %w", err)
+		}
+		stats, ok := workload[assignee]
+		if !ok {
+			stats.Counts = make(map[domain.TodoStatus]int64)
+		}
+		stats.Counts[status] = count
+		stats.Total += count
+		workload[assignee] = stats
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "WorkloadByAssignee", err, logFields...)
+		return nil, fmt.Errorf("error iterating workload counts: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("assignee_count", len(workload)))
+	return workload, nil
+}
+
+func (r *PostgresRepository) UpdateStatus(ctx context.Context, id, tenantID string, status domain.TodoStatus, completedAt *time.Time, completedBy *string, version int64) (*domain.Todo, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.UpdateStatus")
+	defer span.End()
+	defer r.logSlowQuery(span, "UpdateStatus", time.Now())
+
+	logFields := []zap.Field{zap.String("todo_id", id), zap.String("tenant_id", tenantID), zap.Int("status", int(status))}
+
+	query := fmt.Sprintf(`
+		UPDATE todos
+		SET status = $1, updated_at = $2, version = version + 1, completed_at = $6, completed_by = $7,
+			archived_at = CASE WHEN $1 = $8 THEN COALESCE(archived_at, $2) END
+		WHERE id = $3 AND tenant_id = $4 AND version = $5 AND deleted_at IS NULL
+		RETURNING %s
+	`, todoColumns)
+
+	var todo *domain.Todo
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := getForUpdate(ctx, tx, id, tenantID)
+		if err != nil {
+			return err
+		}
+
+		todo, err = scanTodo(tx.QueryRowContext(ctx, query, status, time.Now().UTC(), id, tenantID, version, completedAt, completedBy, domain.StatusArchived))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("todo %s: %w", id, domain.ErrVersionMismatch)
+			}
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+
+		return recordChange(ctx, tx, id, tenantID, domain.ChangeStatusChanged, domain.DiffTodos(before, todo))
+	})
+
+	if err != nil {
+		if !errors.Is(err, domain.ErrVersionMismatch) {
+			r.recordError(span, "UpdateStatus", err, logFields...)
+		}
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// BatchCreate inserts todos in a single transaction, with multi-row INSERTs
+// or, for large cross-tenant batches on a role exempt from row-level
+// security, with COPY
+func (r *PostgresRepository) BatchCreate(ctx context.Context, todos []*domain.Todo) error {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.BatchCreate")
+	defer span.End()
+	defer r.logSlowQuery(span, "BatchCreate", time.Now())
+
+	logFields := []zap.Field{zap.Int("count", len(todos))}
+
+	_, scoped := tenantScopeFromContext(ctx)
+	useCopy := len(todos) >= copyThreshold && !scoped && domain.CrossTenant(ctx)
+
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		if useCopy {
+			allowed, err := copyAllowed(ctx, tx)
+			if err != nil {
+				return err
+			}
+			useCopy = allowed
+		}
+		span.SetAttributes(attribute.Bool("copy", useCopy))
+
+		insert := insertTodos
+		if useCopy {
+			insert = copyTodos
+		}
+		if err := insert(ctx, tx, todos); err != nil {
+			return err
+		}
+
+		for _, todo := range todos {
+			if err := recordChange(ctx, tx, todo.ID, todo.TenantID, domain.ChangeCreated, domain.DiffTodos(nil, todo)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if isDuplicateTitle(err) {
+		span.SetAttributes(attribute.Bool("duplicate_title", true))
+		return domain.ErrDuplicateTitle
+	}
+	if err != nil {
+		r.recordError(span, "BatchCreate", err, logFields...)
+		return err
+	}
+
+	span.SetAttributes(attribute.Int("batch_size", len(todos)))
+	return nil
+}
+
+// PurgeDeleted hard-deletes todos soft-deleted before olderThan, together with
+// their history and audit rows. It returns the number of todos removed and
+// the storage keys of their attachments.
+func (r *PostgresRepository) PurgeDeleted(ctx context.Context, olderThan time.Time) (int64, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.PurgeDeleted")
+	defer span.End()
+	defer r.logSlowQuery(span, "PurgeDeleted", time.Now())
+
+	logFields := []zap.Field{zap.Time("older_than", olderThan)}
+
+	var (
+		purged     int64
+		objectKeys []string
+	)
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		purged, objectKeys, err = purgeTodos(ctx, tx, `t.deleted_at IS NOT NULL AND t.deleted_at < $1`, olderThan)
+		return err
+	})
+	if err != nil {
+		r.recordError(span, "PurgeDeleted", err, logFields...)
+		return 0, nil, fmt.Errorf("failed to purge deleted todos: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("purged_count", purged))
+	return purged, objectKeys, nil
+}
+
+// purgeTodos hard-deletes the todos t matching where, with their history and
+// audit rows, and returns how many it removed and their attachment keys.
+// Locking the todos first holds off attachments being added until they are
+// gone, so the keys read are all the cascade removes.
+func purgeTodos(ctx context.Context, tx *sql.Tx, where string, args ...any) (int64, []string, error) {
+	lockQuery := fmt.Sprintf(`
+		SELECT t.id, a.object_key
+		FROM todos t
+		LEFT JOIN attachments a ON a.todo_id = t.id
+		WHERE %s
+		FOR UPDATE OF t
+	`, where)
+
+	rows, err := tx.QueryContext(ctx, lockQuery, args...)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			span.SetAttributes(attribute.Bool("not_found", true))
-			return nil, domain.ErrTodoNotFound
+		return 0, nil, fmt.Errorf("failed to lock todos: %w", err)
+	}
+	var (
+		ids        []string
+		objectKeys []string
+	)
+	for rows.Next() {
+		var (
+			id  string
+			key sql.NullString
+		)
+		if err := rows.Scan(&id, &key); err != nil {
+			rows.Close()
+			return 0, nil, fmt.Errorf("failed to scan attachment key: %w", err)
 		}
-		span.RecordError(err)
-		return nil, fmt.Errorf("failed to get todo: %w", err)
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+		if key.Valid {
+			objectKeys = append(objectKeys, key.String)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("error iterating attachment keys: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil, nil
 	}
 
-	todo.Tags = tags
-	return todo, nil
+	query := `
+		WITH purged AS (
+			DELETE FROM todos
+			WHERE id = ANY($1)
+			RETURNING id
+		), purged_history AS (
+			DELETE FROM todo_history WHERE todo_id IN (SELECT id FROM purged)
+		), purged_audit AS (
+			DELETE FROM todo_audit WHERE todo_id IN (SELECT id FROM purged)
+		)
+		SELECT COUNT(*) FROM purged
+	`
+
+	var purged int64
+	if err := tx.QueryRowContext(ctx, query, pq.Array(ids)).Scan(&purged); err != nil {
+		return 0, nil, err
+	}
+	return purged, objectKeys, nil
 }
 
-func (r *PostgresRepository) Update(ctx context.Context, todo *domain.Todo) error {
-	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+// PurgeArchived hard-deletes todos archived before olderThan, together with
+// their history and audit rows. It returns the number of todos removed and
+// the storage keys of their attachments.
+func (r *PostgresRepository) PurgeArchived(ctx context.Context, olderThan time.Time) (int64, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
 	defer cancel()
 
-	ctx, span := r.tracer.Start(ctx, "repository.Update")
+	ctx, span := r.tracer.Start(ctx, "repository.PurgeArchived")
 	defer span.End()
+	defer r.logSlowQuery(span, "PurgeArchived", time.Now())
 
-	span.SetAttributes(
-		attribute.String("todo.id", todo.ID),
-		attribute.String("tenant.id", todo.TenantID),
-		attribute.Int64("version", todo.Version),
-	)
-
-	// Optimistic locking: update only if version matches
-	query := `
-		UPDATE todos
-		SET title = $1, description = $2, status = $3, priority = $4, due_date = $5, tags = $6, assigned_to = $7, updated_at = $8, version = version + 1 
-		WHERE id = $9 AND tenant_id = $10 AND version = $11 AND deleted_at IS NULL
-	`
+	logFields := []zap.Field{zap.Time("older_than", olderThan)}
 
-	result, err := r.db.ExecContext(ctx, query,
-		todo.Title,
-		todo.Description,
-		todo.Status,
-		todo.Priority,
-		todo.DueDate,
-		pq.Array(todo.Tags),
-		todo.AssignedTo,
-		time.Now().UTC(),
-		todo.ID,
-		todo.TenantID,
-		todo.Version,
+	var (
+		purged     int64
+		objectKeys []string
 	)
-
-	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to update todo: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		purged, objectKeys, err = purgeTodos(ctx, tx, `t.status = $2 AND t.archived_at < $1`, olderThan, domain.StatusArchived)
+		return err
+	})
 	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		r.recordError(span, "PurgeArchived", err, logFields...)
+		return 0, nil, fmt.Errorf("failed to purge archived todos: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		span.SetAttributes(attribute.Bool("version_mismatch", true))
-		return domain.ErrVersionMismatch
-	}
-
-	return nil
+	span.SetAttributes(attribute.Int64("purged_count", purged))
+	return purged, objectKeys, nil
 }
 
-func (r *PostgresRepository) Delete(ctx context.Context, id, tenantID string) error {
-	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+// EscalateOverdue raises the priority of overdue, open todos below Critical by
+// one level and records each change in history. An empty tenantID sweeps
+// every tenant.
+func (r *PostgresRepository) EscalateOverdue(ctx context.Context, tenantID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
 	defer cancel()
 
-	ctx, span := r.tracer.Start(ctx, "repository.Delete")
+	ctx, span := r.tracer.Start(ctx, "repository.EscalateOverdue")
 	defer span.End()
+	defer r.logSlowQuery(span, "EscalateOverdue", time.Now())
+
+	logFields := []zap.Field{zap.String("tenant_id", tenantID)}
 
-	// Soft delete
 	query := `
 		UPDATE todos
-		SET deleted_at = $1, updated_at = $1
-		WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+		SET priority = priority + 1, updated_at = NOW(), version = version + 1
+		WHERE ($1 = '' OR tenant_id = $1)
+			AND deleted_at IS NULL
+			AND due_date IS NOT NULL
+			AND ` + dueDeadlineSQL + ` < NOW()
+			AND status NOT IN ($2, $3)
+			AND priority < $4
+		RETURNING id, tenant_id, priority
 	`
 
-	result, err := r.db.ExecContext(ctx, query, time.Now().UTC(), id, tenantID)
-	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to delete todo: %w", err)
+	type escalation struct {
+		id       string
+		tenantID string
+		priority domain.TodoPriority
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+	var escalated []escalation
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query,
+			tenantID,
+			domain.StatusCompleted,
+			domain.StatusArchived,
+			domain.PriorityCritical,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to escalate todos: %w", err)
+		}
+
+		for rows.Next() {
+			var e escalation
+			if err := rows.Scan(&e.id, &e.tenantID, &e.priority); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan escalated todo: %w", err)
+			}
+			escalated = append(escalated, e)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating escalated todos: %w", err)
+		}
 
-	if rowsAffected == 0 {
-		return domain.ErrTodoNotFound
+		for _, e := range escalated {
+			changes := map[string]domain.FieldChange{
+				"priority": {Old: e.priority - 1, New: e.priority},
+			}
+			if err := recordChange(ctx, tx, e.id, e.tenantID, domain.ChangeUpdated, changes); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.recordError(span, "EscalateOverdue", err, logFields...)
+		return 0, err
 	}
 
-	return nil
+	span.SetAttributes(attribute.Int("escalated_count", len(escalated)))
+	return int64(len(escalated)), nil
 }
 
-func (r *PostgresRepository) List(ctx context.Context, filter *domain.ListFilter) ([]*domain.Todo, int64, error) {
-	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+// ArchiveCompletedOlderThan moves todos completed before now-age to Archived
+// in one statement and records each change in history. An empty tenantID
+// sweeps every tenant.
+func (r *PostgresRepository) ArchiveCompletedOlderThan(ctx context.Context, tenantID string, age time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
 	defer cancel()
 
-	ctx, span := r.tracer.Start(ctx, "repository.List")
+	ctx, span := r.tracer.Start(ctx, "repository.ArchiveCompletedOlderThan")
 	defer span.End()
+	defer r.logSlowQuery(span, "ArchiveCompletedOlderThan", time.Now())
 
-	where, args := buildWhereClause(filter)
+	logFields := []zap.Field{zap.String("tenant_id", tenantID), zap.Duration("age", age)}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM todos WHERE %s", where)
+	query := `
+		UPDATE todos
+		SET status = $2, updated_at = $5, archived_at = $5, version = version + 1
+		WHERE ($1 = '' OR tenant_id = $1)
+			AND deleted_at IS NULL
+			AND status = $3
+			AND completed_at < $4
+		RETURNING id, tenant_id
+	`
 
-	var totalCount int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount)
-	if err != nil {
-		span.RecordError(err)
-		return nil, 0, fmt.Errorf("failed to count todos: %w", err)
+	type archival struct {
+		id       string
+		tenantID string
 	}
 
-	// Build ORDER BY clause
-	orderBy := buildOrderByClause(filter)
+	now := time.Now().UTC()
+	cutoff := now.Add(-age)
 
-	// Calculate offset
-	offset := (filter.Page - 1) * filter.PageSize
+	var archived []archival
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, tenantID, domain.StatusArchived, domain.StatusCompleted, cutoff, now)
+		if err != nil {
+			return fmt.Errorf("failed to archive todos: %w", err)
+		}
 
-	// Query with pagination
-	query := fmt.Sprintf(`
-		SELECT id, title, description, status, priority, due_date, tags, owner_id, assigned_to, tenant_id, created_at, updated_at, version
-		FROM todos
-		WHERE %s
-		%s
-		LIMIT $%d OFFSET $%d
-	`, where, orderBy, len(args)+1, len(args)+2)
+		for rows.Next() {
+			var a archival
+			if err := rows.Scan(&a.id, &a.tenantID); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan archived todo: %w", err)
+			}
+			archived = append(archived, a)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating archived todos: %w", err)
+		}
 
-	args = append(args, filter.PageSize, offset)
+		for _, a := range archived {
+			changes := map[string]domain.FieldChange{
+				"status":      {Old: domain.StatusCompleted, New: domain.StatusArchived},
+				"archived_at": {Old: nil, New: &now},
+			}
+			if err := recordChange(ctx, tx, a.id, a.tenantID, domain.ChangeStatusChanged, changes); err != nil {
+				return err
+			}
+		}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+		return nil
+	})
 	if err != nil {
-		span.RecordError(err)
-		return nil, 0, fmt.Errorf("failed to list todos: %w", err)
+		r.recordError(span, "ArchiveCompletedOlderThan", err, logFields...)
+		return 0, err
 	}
-	defer rows.Close()
-
-	todos := make([]*domain.Todo, 0)
-	for rows.Next() {
-		todo := &domain.Todo{}
-		var tags pq.StringArray
-
-		err := rows.Scan(
-			&todo.ID,
-			&todo.Title,
-			&todo.Description,
-			&todo.Status,
-			&todo.Priority,
-			&todo.DueDate,
-			&tags,
-			&todo.OwnerID,
-			&todo.AssignedTo,
-			&todo.TenantID,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-			&todo.Version,
-		)
-		if err != nil {
-			span.RecordError(err)
-			return nil, 0, fmt.Errorf("failed to scan todo: %w", err)
-		}
 
-		todo.Tags = tags
-		todos = append(todos, todo)
-	}
+	span.SetAttributes(attribute.Int("archived_count", len(archived)))
+	return int64(len(archived)), nil
+}
 
-	if err = rows.Err(); err != nil {
-		span.RecordError(err)
-		return nil, 0, fmt.Errorf("error iterating todos: %w", err)
+// isDuplicateTitle reports whether err is a violation of the active-title unique index
+func isDuplicateTitle(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
 	}
-
-	span.SetAttributes(
-		attribute.Int64("total_count", totalCount),
-		attribute.Int("returned_count", len(todos)),
-	)
-
-	return todos, totalCount, nil
+	return pqErr.Code == uniqueViolationCode && pqErr.Constraint == uniqueTitleConstraint
 }
 
-func (r *PostgresRepository) UpdateStatus(ctx context.Context, id, tenantID string, status domain.TodoStatus, version int64) (*domain.Todo, error) {
-	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
-	defer cancel()
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
-	ctx, span := r.tracer.Start(ctx, "repository.UpdateStatus")
-	defer span.End()
+// countedRow scans a row carrying a trailing count column, such as
+// COUNT(*) OVER(), storing that column in total
+type countedRow struct {
+	rowScanner
+	total *int64
+}
 
-	query := `
-		UPDATE todos
-		SET status = $1, updated_at = $2, version = version + 1
-		WHERE id = $3 AND tenant_id = $4 AND version = $5 AND deleted_at IS NULL
-		RETURNING id, title, description, status, priority, due_date, tags, owner_id, assigned_to, tenant_id, created_at, updated_at, version
-	`
+func (r countedRow) Scan(dest ...any) error {
+	return r.rowScanner.Scan(append(dest, r.total)...)
+}
 
+func scanTodo(row rowScanner) (*domain.Todo, error) {
 	todo := &domain.Todo{}
 	var tags pq.StringArray
 
-	err := r.db.QueryRowContext(ctx, query, status, time.Now().UTC(), id, tenantID, version).Scan(
+	err := row.Scan(
 		&todo.ID,
 		&todo.Title,
 		&todo.Description,
@@ -322,83 +1542,199 @@ func (r *PostgresRepository) UpdateStatus(ctx context.Context, id, tenantID stri
 		&todo.CreatedAt,
 		&todo.UpdatedAt,
 		&todo.Version,
+		&todo.DueDateTimezone,
+		&todo.DeletedAt,
+		&todo.CompletedAt,
+		&todo.EstimatedMinutes,
+		&todo.LoggedMinutes,
+		&todo.RemindAt,
+		&todo.RemindedAt,
+		&todo.CompletedBy,
+		&todo.ArchivedAt,
+		&todo.ParentID,
 	)
-
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrVersionMismatch
-		}
-		span.RecordError(err)
-		return nil, fmt.Errorf("failed to update status: %w", err)
+		return nil, err
 	}
 
+	// Rows written outside this service may hold NULL tags; read them as
+	// empty, matching NewTodo
 	todo.Tags = tags
+	if todo.Tags == nil {
+		todo.Tags = make([]string, 0)
+	}
 	return todo, nil
 }
 
-func (r *PostgresRepository) BatchCreate(ctx context.Context, todos []*domain.Todo) error {
-	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
-	defer cancel()
-
-	ctx, span := r.tracer.Start(ctx, "repository.BatchCreate")
-	defer span.End()
-
+// withTx runs fn inside a transaction carrying the context's tenant scope or
+// cross-tenant opt-in, committing only when fn succeeds
+func (r *PostgresRepository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		span.RecordError(err)
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO todos (
-			id, title, description, status, priority,
-			due_date, tags, owner_id, assigned_to, tenant_id,
-			created_at, updated_at, version
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-	`)
+	if err := applyTenantScope(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// todoInsertColumns are the columns a new todo row is written with, in the
+// order of todoInsertArgs
+var todoInsertColumns = []string{
+	"id", "title", "description", "status", "priority", "due_date", "tags", "owner_id", "assigned_to",
+	"tenant_id", "created_at", "updated_at", "version", "due_date_timezone", "completed_at",
+	"estimated_minutes", "logged_minutes", "remind_at", "reminded_at", "completed_by", "archived_at",
+	"parent_id",
+}
+
+const (
+	// insertChunkSize bounds the rows of one INSERT, keeping its parameters
+	// well under Postgres' limit of 65535
+	insertChunkSize = 500
+
+	// copyThreshold is the batch size from which cross-tenant batches are
+	// written with COPY rather than INSERTs
+	copyThreshold = 500
+)
+
+// copyAllowed reports whether COPY FROM can write todos in tx. Postgres
+// rejects it on tables with row-level security, except for superusers and
+// BYPASSRLS roles.
+func copyAllowed(ctx context.Context, tx *sql.Tx) (bool, error) {
+	var allowed bool
+	query := `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`
+	if err := tx.QueryRowContext(ctx, query).Scan(&allowed); err != nil {
+		return false, fmt.Errorf("failed to check role privileges: %w", err)
+	}
+	return allowed, nil
+}
+
+func insertTodo(ctx context.Context, tx *sql.Tx, todo *domain.Todo) error {
+	return insertTodos(ctx, tx, []*domain.Todo{todo})
+}
+
+// insertTodos inserts todos with one multi-row INSERT per chunk
+func insertTodos(ctx context.Context, tx *sql.Tx, todos []*domain.Todo) error {
+	for chunk := range slices.Chunk(todos, insertChunkSize) {
+		var (
+			values strings.Builder
+			args   []any
+		)
+		for i, todo := range chunk {
+			row := todoInsertArgs(todo)
+			if i > 0 {
+				values.WriteString(", ")
+			}
+			values.WriteString("(")
+			for j := range row {
+				if j > 0 {
+					values.WriteString(", ")
+				}
+				fmt.Fprintf(&values, "$%d", len(args)+j+1)
+			}
+			values.WriteString(")")
+			args = append(args, row...)
+		}
+
+		query := fmt.Sprintf(`INSERT INTO todos (%s) VALUES %s`, strings.Join(todoInsertColumns, ", "), values.String())
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert todos: %w", err)
+		}
+	}
+	return nil
+}
+
+// copyTodos writes todos with the COPY protocol inside tx. Tags travel as
+// array literals, so rows read back the same as inserted ones. COPY is
+// refused on tables under row-level security, so tenant-scoped transactions
+// must use insertTodos.
+func copyTodos(ctx context.Context, tx *sql.Tx, todos []*domain.Todo) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("todos", todoInsertColumns...))
 	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to prepare statement: %w", err)
+		return fmt.Errorf("failed to start copy: %w", err)
 	}
 	defer stmt.Close()
 
 	for _, todo := range todos {
-		_, err := stmt.ExecContext(ctx,
-			todo.ID,
-			todo.Title,
-			todo.Description,
-			todo.Status,
-			todo.Priority,
-			todo.DueDate,
-			pq.Array(todo.Tags),
-			todo.OwnerID,
-			todo.AssignedTo,
-			todo.TenantID,
-			todo.CreatedAt,
-			todo.UpdatedAt,
-			todo.Version,
-		)
-		if err != nil {
-			span.RecordError(err)
-			return fmt.Errorf("failed to insert todo %s: %w", todo.ID, err)
+		if _, err := stmt.ExecContext(ctx, todoInsertArgs(todo)...); err != nil {
+			return fmt.Errorf("failed to copy todo %s: %w", todo.ID, err)
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	// The final Exec flushes the buffered rows and reports any row error
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to copy todos: %w", err)
 	}
+	return stmt.Close()
+}
 
-	span.SetAttributes(attribute.Int("batch_size", len(todos)))
-	return nil
+func todoInsertArgs(todo *domain.Todo) []any {
+	return []any{
+		todo.ID,
+		todo.Title,
+		todo.Description,
+		todo.Status,
+		todo.Priority,
+		todo.DueDate,
+		pq.Array(todo.Tags),
+		todo.OwnerID,
+		todo.AssignedTo,
+		todo.TenantID,
+		todo.CreatedAt,
+		todo.UpdatedAt,
+		todo.Version,
+		todo.DueDateTimezone,
+		todo.CompletedAt,
+		todo.EstimatedMinutes,
+		todo.LoggedMinutes,
+		todo.RemindAt,
+		todo.RemindedAt,
+		todo.CompletedBy,
+		todo.ArchivedAt,
+		todo.ParentID,
+	}
+}
+
+// getForUpdate locks and returns the current row so a mutation can diff against it.
+// A missing row is reported as a version mismatch, matching the optimistic update path.
+func getForUpdate(ctx context.Context, tx *sql.Tx, id, tenantID string) (*domain.Todo, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, todoColumns)
+
+	todo, err := scanTodo(tx.QueryRowContext(ctx, query, id, tenantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("todo %s: %w", id, domain.ErrVersionMismatch)
+		}
+		return nil, fmt.Errorf("failed to lock todo: %w", err)
+	}
+	return todo, nil
 }
 
 func buildWhereClause(filter *domain.ListFilter) (string, []any) {
-	conditions := []string{"tenant_id = $1", "delete_at IS NULL"}
+	conditions := []string{"tenant_id = $1"}
 	args := []any{filter.TenantID}
 	argCount := 1
 
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
 	if filter.OwnerID != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("owner_id = $%d", argCount))
@@ -411,6 +1747,12 @@ func buildWhereClause(filter *domain.ListFilter) (string, []any) {
 		args = append(args, *filter.AssignedTo)
 	}
 
+	if filter.VisibleTo != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("(owner_id = $%d OR assigned_to = $%d)", argCount, argCount))
+		args = append(args, *filter.VisibleTo)
+	}
+
 	if len(filter.Statuses) > 0 {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argCount))
@@ -437,6 +1779,21 @@ func buildWhereClause(filter *domain.ListFilter) (string, []any) {
 		args = append(args, pq.Array(filter.Tags))
 	}
 
+	if len(filter.ExcludeTags) > 0 {
+		argCount++
+		// Untagged rows may hold NULL, which would otherwise drop them from the result
+		conditions = append(conditions, fmt.Sprintf("NOT (COALESCE(tags, '{}') && $%d)", argCount))
+		args = append(args, pq.Array(filter.ExcludeTags))
+	}
+
+	if filter.AssignedToIsNull != nil {
+		conditions = append(conditions, nullCondition("assigned_to", *filter.AssignedToIsNull))
+	}
+
+	if filter.DueDateIsNull != nil {
+		conditions = append(conditions, nullCondition("due_date", *filter.DueDateIsNull))
+	}
+
 	if filter.DueDateFrom != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("due_date >= $%d", argCount))
@@ -449,6 +1806,42 @@ func buildWhereClause(filter *domain.ListFilter) (string, []any) {
 		args = append(args, *filter.DueDateTo)
 	}
 
+	if filter.DueBefore != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("due_date < $%d", argCount))
+		args = append(args, *filter.DueBefore)
+	}
+
+	if filter.CreatedFrom != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argCount))
+		args = append(args, *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argCount))
+		args = append(args, *filter.CreatedTo)
+	}
+
+	if filter.UpdatedFrom != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("updated_at >= $%d", argCount))
+		args = append(args, *filter.UpdatedFrom)
+	}
+
+	if filter.UpdatedTo != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("updated_at <= $%d", argCount))
+		args = append(args, *filter.UpdatedTo)
+	}
+
+	if filter.OverdueOnly {
+		argCount += 2
+		conditions = append(conditions, fmt.Sprintf("due_date IS NOT NULL AND %s < NOW() AND status NOT IN ($%d, $%d)", dueDeadlineSQL, argCount-1, argCount))
+		args = append(args, domain.StatusCompleted, domain.StatusArchived)
+	}
+
 	if filter.SearchQuery != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d)", argCount, argCount))
@@ -458,25 +1851,80 @@ func buildWhereClause(filter *domain.ListFilter) (string, []any) {
 	return strings.Join(conditions, " AND "), args
 }
 
-func buildOrderByClause(filter *domain.ListFilter) string {
-	validSortFields := map[string]bool{
-		"created_at": true,
-		"updated_at": true,
-		"due_date":   true,
-		"priority":   true,
-		"status":     true,
-		"title":      true,
+// buildListQuery returns the page query of List for filter, its WHERE and
+// ORDER BY clauses and the arguments of the WHERE clause. The query takes
+// the page size and offset as two further arguments.
+func buildListQuery(filter *domain.ListFilter) (query, where, orderBy string, args []any) {
+	where, args = buildWhereClause(filter)
+	orderBy = buildOrderByClause(filter)
+
+	// The window count is taken before LIMIT applies, so every row carries
+	// the total number of matches
+	query = fmt.Sprintf(`
+		SELECT %s, COUNT(*) OVER() AS total_count
+		FROM todos
+		WHERE %s
+		%s
+		LIMIT $%d OFFSET $%d
+	`, todoColumns, where, orderBy, len(args)+1, len(args)+2)
+
+	return query, where, orderBy, args
+}
+
+// nullCondition matches rows where column is NULL, or not NULL when isNull is false
+func nullCondition(column string, isNull bool) string {
+	if isNull {
+		return column + " IS NULL"
+	}
+	return column + " IS NOT NULL"
+}
+
+// priorityRankSQL returns the expression ranking a todo's priority by
+// weights, or the priority column itself when there are none. The weights are
+// configured integers, so they are inlined rather than bound.
+func priorityRankSQL(weights *domain.PriorityWeights) string {
+	priorities := weights.Priorities()
+	if len(priorities) == 0 {
+		return "priority"
+	}
+
+	var b strings.Builder
+	b.WriteString("(CASE priority")
+	for _, priority := range priorities {
+		fmt.Fprintf(&b, " WHEN %d THEN %d", priority, weights.Weight(priority))
 	}
+	b.WriteString(" ELSE priority END)")
+	return b.String()
+}
 
-	sortBy := "created_at"
-	if validSortFields[filter.SortBy] {
-		sortBy = filter.SortBy
+func buildOrderByClause(filter *domain.ListFilter) string {
+	specs := filter.Sort
+	if len(specs) == 0 {
+		sortBy := "created_at"
+		if domain.IsSortableField(filter.SortBy) {
+			sortBy = filter.SortBy
+		}
+		specs = []domain.SortSpec{{Field: sortBy, Ascending: filter.SortAscending}}
 	}
 
-	order := "DESC"
-	if filter.SortAscending {
-		order = "ASC"
+	columns := make([]string, 0, len(specs)+1)
+	for _, spec := range specs {
+		if !domain.IsSortableField(spec.Field) {
+			continue
+		}
+		order := "DESC"
+		if spec.Ascending {
+			order = "ASC"
+		}
+		column := spec.Field
+		if column == "priority" {
+			column = priorityRankSQL(filter.PriorityWeights)
+		}
+		columns = append(columns, column+" "+order)
 	}
 
-	return fmt.Sprintf("ORDER BY %s %s", sortBy, order)
+	// id breaks ties so rows sharing a sort value keep a stable order across pages
+	columns = append(columns, "id ASC")
+
+	return "ORDER BY " + strings.Join(columns, ", ")
 }
@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// newObservedRepository is newMockRepository with the repository's logs
+// captured
+func newObservedRepository(t *testing.T, opts ...RepositoryOption) (*PostgresRepository, sqlmock.Sqlmock, *observer.ObservedLogs) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	return NewPostgresRepository(db, zap.New(core), opts...), mock, logs
+}
+
+func TestBuildOrderByClause(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter domain.ListFilter
+		want   string
+	}{
+		{name: "default", want: "ORDER BY created_at DESC, id ASC"},
+		{
+			name:   "sort field",
+			filter: domain.ListFilter{SortBy: "due_date", SortAscending: true},
+			want:   "ORDER BY due_date ASC, id ASC",
+		},
+		{
+			name:   "unknown sort field",
+			filter: domain.ListFilter{SortBy: "owner_id; DROP TABLE todos"},
+			want:   "ORDER BY created_at DESC, id ASC",
+		},
+		{
+			name: "multiple fields",
+			filter: domain.ListFilter{
+				SortBy: "title",
+				Sort:   []domain.SortSpec{{Field: "status", Ascending: true}, {Field: "due_date"}},
+			},
+			want: "ORDER BY status ASC, due_date DESC, id ASC",
+		},
+		{
+			name:   "unknown field among several",
+			filter: domain.ListFilter{Sort: []domain.SortSpec{{Field: "owner_id"}, {Field: "title", Ascending: true}}},
+			want:   "ORDER BY title ASC, id ASC",
+		},
+		{
+			name: "priority weights",
+			filter: domain.ListFilter{
+				Sort:            []domain.SortSpec{{Field: "priority"}},
+				PriorityWeights: domain.NewPriorityWeights(map[domain.TodoPriority]int{domain.PriorityCritical: 100, domain.PriorityHigh: 5}),
+			},
+			want: "ORDER BY (CASE priority WHEN 3 THEN 5 WHEN 4 THEN 100 ELSE priority END) DESC, id ASC",
+		},
+		{
+			name:   "empty priority weights",
+			filter: domain.ListFilter{SortBy: "priority", PriorityWeights: domain.NewPriorityWeights(nil)},
+			want:   "ORDER BY priority DESC, id ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildOrderByClause(&tt.filter); got != tt.want {
+				t.Errorf("buildOrderByClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlowQueryLogging(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		delay     time.Duration
+		logged    bool
+	}{
+		{name: "slow", threshold: 10 * time.Millisecond, delay: 20 * time.Millisecond, logged: true},
+		{name: "fast", threshold: time.Second},
+		{name: "disabled", delay: 20 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, logs := newObservedRepository(t, WithSlowQueryThreshold(tt.threshold))
+			mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todos`).
+				WithArgs("tenant-a").
+				WillDelayFor(tt.delay).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+			if _, err := repo.CountActive(context.Background(), "tenant-a"); err != nil {
+				t.Fatalf("CountActive: %v", err)
+			}
+
+			slow := logs.FilterMessage("slow query").AllUntimed()
+			if !tt.logged {
+				if len(slow) != 0 {
+					t.Fatalf("expected no slow query log, got %v", slow)
+				}
+				return
+			}
+			if len(slow) != 1 {
+				t.Fatalf("expected 1 slow query log, got %d", len(slow))
+			}
+			fields := slow[0].ContextMap()
+			if slow[0].Level != zapcore.WarnLevel || fields["operation"] != "CountActive" {
+				t.Errorf("slow query log = %v %v, want a warning for CountActive", slow[0].Level, fields)
+			}
+			if d, _ := fields["duration"].(time.Duration); d < tt.delay {
+				t.Errorf("duration = %v, want at least %v", fields["duration"], tt.delay)
+			}
+		})
+	}
+}
+
+func TestFailedOperationsAreLogged(t *testing.T) {
+	todo, err := domain.NewTodo("secret plans", "do not log me", "alice", "tenant-a", domain.PriorityHigh)
+	if err != nil {
+		t.Fatalf("NewTodo: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		expect    func(mock sqlmock.Sqlmock)
+		call      func(repo *PostgresRepository) error
+		operation string
+		fields    map[string]any
+	}{
+		{
+			name:      "create",
+			expect:    func(mock sqlmock.Sqlmock) { mock.ExpectBegin().WillReturnError(errConnectionLost) },
+			call:      func(repo *PostgresRepository) error { return repo.Create(context.Background(), todo) },
+			operation: "Create",
+			fields:    map[string]any{"todo_id": todo.ID, "tenant_id": "tenant-a", "version": int64(1)},
+		},
+		{
+			name: "count active",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todos`).WillReturnError(errConnectionLost)
+			},
+			call: func(repo *PostgresRepository) error {
+				_, err := repo.CountActive(context.Background(), "tenant-a")
+				return err
+			},
+			operation: "CountActive",
+			fields:    map[string]any{"tenant_id": "tenant-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, logs := newObservedRepository(t)
+			tt.expect(mock)
+
+			if err := tt.call(repo); err == nil {
+				t.Fatal("expected the operation to fail")
+			}
+
+			failed := logs.FilterMessage("repository operation failed").AllUntimed()
+			if len(failed) != 1 {
+				t.Fatalf("expected 1 failure log, got %d", len(failed))
+			}
+			fields := failed[0].ContextMap()
+			if failed[0].Level != zapcore.ErrorLevel || fields["operation"] != tt.operation || fields["error"] == nil {
+				t.Errorf("failure log = %v %v, want an error for %s", failed[0].Level, fields, tt.operation)
+			}
+			for key, want := range tt.fields {
+				if fields[key] != want {
+					t.Errorf("%s = %v, want %v", key, fields[key], want)
+				}
+			}
+			for key, value := range fields {
+				if value == todo.Title || value == todo.Description {
+					t.Errorf("todo content logged as %s", key)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterSpanAttributes(t *testing.T) {
+	search := "quarterly report"
+	empty := ""
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		filter domain.ListFilter
+		want   map[string]string
+	}{
+		{
+			name:   "tenant only",
+			filter: domain.ListFilter{TenantID: "tenant-a"},
+			want: map[string]string{
+				"filter.has_search": "false", "filter.status_count": "0", "filter.priority_count": "0",
+				"filter.tag_count": "0", "filter.exclude_tag_count": "0", "filter.has_date_range": "false",
+				"filter.overdue_only": "false", "filter.sort_by": "created_at",
+			},
+		},
+		{
+			name: "every filter",
+			filter: domain.ListFilter{
+				TenantID:    "tenant-a",
+				SearchQuery: &search,
+				Statuses:    []domain.TodoStatus{domain.StatusPending, domain.StatusInProgress},
+				Priorities:  []domain.TodoPriority{domain.PriorityHigh},
+				Tags:        []string{"work", "q3"},
+				ExcludeTags: []string{"done"},
+				UpdatedFrom: &now,
+				OverdueOnly: true,
+				Sort:        []domain.SortSpec{{Field: "priority"}, {Field: "title; DROP TABLE todos"}, {Field: "due_date"}},
+			},
+			want: map[string]string{
+				"filter.has_search": "true", "filter.status_count": "2", "filter.priority_count": "1",
+				"filter.tag_count": "2", "filter.exclude_tag_count": "1", "filter.has_date_range": "true",
+				"filter.overdue_only": "true", "filter.sort_by": "priority,due_date",
+			},
+		},
+		{
+			name:   "blank search and legacy sort",
+			filter: domain.ListFilter{TenantID: "tenant-a", SearchQuery: &empty, SortBy: "title"},
+			want: map[string]string{
+				"filter.has_search": "false", "filter.status_count": "0", "filter.priority_count": "0",
+				"filter.tag_count": "0", "filter.exclude_tag_count": "0", "filter.has_date_range": "false",
+				"filter.overdue_only": "false", "filter.sort_by": "title",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]string)
+			for _, attr := range filterSpanAttributes(&tt.filter) {
+				got[string(attr.Key)] = attr.Value.Emit()
+				if strings.Contains(attr.Value.Emit(), search) || strings.Contains(attr.Value.Emit(), "work") {
+					t.Errorf("attribute %s leaks a filter value: %q", attr.Key, attr.Value.Emit())
+				}
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("filterSpanAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// scanFunc adapts a function to rowScanner
+type scanFunc func(dest ...any) error
+
+func (f scanFunc) Scan(dest ...any) error { return f(dest...) }
+
+func TestScanTodoTags(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  any
+		want []string
+	}{
+		{name: "null", raw: nil, want: []string{}},
+		{name: "empty", raw: []byte("{}"), want: []string{}},
+		{name: "tags", raw: []byte("{backend,urgent}"), want: []string{"backend", "urgent"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Tags are the seventh column scanned
+			todo, err := scanTodo(scanFunc(func(dest ...any) error {
+				return dest[6].(sql.Scanner).Scan(tt.raw)
+			}))
+			if err != nil {
+				t.Fatalf("scanTodo failed: %v", err)
+			}
+			if todo.Tags == nil || !slices.Equal(todo.Tags, tt.want) {
+				t.Fatalf("expected tags %#v, got %#v", tt.want, todo.Tags)
+			}
+		})
+	}
+}
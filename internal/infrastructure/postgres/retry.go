@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"syscall"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// RetryPolicy bounds how often and how patiently transient errors are retried
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; 1 disables retries
+	BaseDelay   time.Duration // wait before the first retry, doubled on each further retry
+	MaxDelay    time.Duration // cap on the wait between attempts
+}
+
+// backoff returns the wait before retry number attempt (starting at 1): the
+// exponential delay capped at MaxDelay, with its upper half jittered so
+// callers failing together do not retry in lockstep
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 30 && p.BaseDelay<<shift < p.MaxDelay {
+		delay = p.BaseDelay << shift
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// isSerializationFailure reports whether err aborted a transaction that can
+// safely be run again from the start
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailureCode || pqErr.Code == deadlockDetectedCode
+}
+
+// isTransient reports whether err is likely to go away on its own: a
+// serialization failure, or a lost or refused connection
+func isTransient(err error) bool {
+	if isSerializationFailure(err) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code.Class() == "08": // connection exception
+			return true
+		case pqErr.Code == "57P01", pqErr.Code == "57P02", pqErr.Code == "57P03": // server shutting down or starting
+			return true
+		}
+		return false
+	}
+
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retry runs fn until it succeeds, returns an error retriable rejects, or
+// policy runs out of attempts. It stops early when ctx is done.
+func retry[T any](ctx context.Context, policy RetryPolicy, retriable func(error) bool, fn func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || !retriable(err) {
+			return result, err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryingRepository retries the reads of the repository it wraps on
+// transient errors, and its optimistic updates on serialization failures.
+// Other writes are passed through: after a lost connection it is unknown
+// whether they were applied, so they are not safe to repeat.
+type RetryingRepository struct {
+	domain.Repository
+	policy RetryPolicy
+}
+
+var _ domain.Repository = (*RetryingRepository)(nil)
+
+func NewRetryingRepository(repo domain.Repository, policy RetryPolicy) *RetryingRepository {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &RetryingRepository{Repository: repo, policy: policy}
+}
+
+func (r *RetryingRepository) GetByID(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	return retry(ctx, r.policy, isTransient, func() (*domain.Todo, error) {
+		return r.Repository.GetByID(ctx, id, tenantID)
+	})
+}
+
+func (r *RetryingRepository) GetByIDIncludingDeleted(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	return retry(ctx, r.policy, isTransient, func() (*domain.Todo, error) {
+		return r.Repository.GetByIDIncludingDeleted(ctx, id, tenantID)
+	})
+}
+
+func (r *RetryingRepository) GetDetail(ctx context.Context, id, tenantID string) (*domain.TodoDetail, error) {
+	return retry(ctx, r.policy, isTransient, func() (*domain.TodoDetail, error) {
+		return r.Repository.GetDetail(ctx, id, tenantID)
+	})
+}
+
+func (r *RetryingRepository) GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*domain.Todo, error) {
+	return retry(ctx, r.policy, isTransient, func() ([]*domain.Todo, error) {
+		return r.Repository.GetByIDs(ctx, ids, tenantID)
+	})
+}
+
+func (r *RetryingRepository) List(ctx context.Context, filter *domain.ListFilter) ([]*domain.Todo, int64, error) {
+	var total int64
+	todos, err := retry(ctx, r.policy, isTransient, func() ([]*domain.Todo, error) {
+		todos, count, err := r.Repository.List(ctx, filter)
+		total = count
+		return todos, err
+	})
+	return todos, total, err
+}
+
+func (r *RetryingRepository) ListRefs(ctx context.Context, filter *domain.ListFilter) ([]*domain.TodoRef, int64, error) {
+	var total int64
+	refs, err := retry(ctx, r.policy, isTransient, func() ([]*domain.TodoRef, error) {
+		refs, count, err := r.Repository.ListRefs(ctx, filter)
+		total = count
+		return refs, err
+	})
+	return refs, total, err
+}
+
+func (r *RetryingRepository) CountActive(ctx context.Context, tenantID string) (int64, error) {
+	return retry(ctx, r.policy, isTransient, func() (int64, error) {
+		return r.Repository.CountActive(ctx, tenantID)
+	})
+}
+
+func (r *RetryingRepository) CountByStatus(ctx context.Context, filter *domain.ListFilter) (map[domain.TodoStatus]int64, error) {
+	return retry(ctx, r.policy, isTransient, func() (map[domain.TodoStatus]int64, error) {
+		return r.Repository.CountByStatus(ctx, filter)
+	})
+}
+
+func (r *RetryingRepository) WorkloadByAssignee(ctx context.Context, tenantID string) (map[string]domain.WorkloadStats, error) {
+	return retry(ctx, r.policy, isTransient, func() (map[string]domain.WorkloadStats, error) {
+		return r.Repository.WorkloadByAssignee(ctx, tenantID)
+	})
+}
+
+func (r *RetryingRepository) DueWithin(ctx context.Context, tenantID string, within time.Duration) ([]*domain.Todo, error) {
+	return retry(ctx, r.policy, isTransient, func() ([]*domain.Todo, error) {
+		return r.Repository.DueWithin(ctx, tenantID, within)
+	})
+}
+
+func (r *RetryingRepository) ListTags(ctx context.Context, tenantID, prefix string, limit int) ([]string, error) {
+	return retry(ctx, r.policy, isTransient, func() ([]string, error) {
+		return r.Repository.ListTags(ctx, tenantID, prefix, limit)
+	})
+}
+
+func (r *RetryingRepository) ListDependencies(ctx context.Context, todoID, tenantID string) (*domain.TodoDependencies, error) {
+	return retry(ctx, r.policy, isTransient, func() (*domain.TodoDependencies, error) {
+		return r.Repository.ListDependencies(ctx, todoID, tenantID)
+	})
+}
+
+// Update is retried on serialization failures only, which roll the whole
+// transaction back; the version check still guards against lost updates
+func (r *RetryingRepository) Update(ctx context.Context, todo *domain.Todo, expectedVersion int64) error {
+	_, err := retry(ctx, r.policy, isSerializationFailure, func() (struct{}, error) {
+		return struct{}{}, r.Repository.Update(ctx, todo, expectedVersion)
+	})
+	return err
+}
+
+// UpdateStatus is retried on serialization failures only, like Update
+func (r *RetryingRepository) UpdateStatus(ctx context.Context, id, tenantID string, status domain.TodoStatus, completedAt *time.Time, completedBy *string, version int64) (*domain.Todo, error) {
+	return retry(ctx, r.policy, isSerializationFailure, func() (*domain.Todo, error) {
+		return r.Repository.UpdateStatus(ctx, id, tenantID, status, completedAt, completedBy, version)
+	})
+}
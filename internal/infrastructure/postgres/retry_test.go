@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+var (
+	errConnectionLost = &pq.Error{Code: "08006"}
+	errSerialization  = &pq.Error{Code: serializationFailureCode}
+	errUndefinedTable = &pq.Error{Code: "42P01"}
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+func TestRetryingRepositoryRetriesTransientReads(t *testing.T) {
+	const query = `SELECT COUNT\(\*\) FROM todos`
+
+	tests := []struct {
+		name   string
+		errs   []error // returned by successive attempts; the rest succeed
+		calls  int
+		result error
+	}{
+		{name: "first attempt succeeds", calls: 1},
+		{name: "lost connection", errs: []error{errConnectionLost}, calls: 2},
+		{name: "serialization failure", errs: []error{errSerialization, errConnectionLost}, calls: 3},
+		{name: "attempts exhausted", errs: []error{errConnectionLost, errConnectionLost, errConnectionLost}, calls: 3, result: errConnectionLost},
+		{name: "permanent error", errs: []error{errUndefinedTable}, calls: 1, result: errUndefinedTable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			retrying := NewRetryingRepository(repo, testRetryPolicy)
+
+			for i := range tt.calls {
+				expect := mock.ExpectQuery(query).WithArgs("tenant-a")
+				if i < len(tt.errs) {
+					expect.WillReturnError(tt.errs[i])
+				} else {
+					expect.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+				}
+			}
+
+			count, err := retrying.CountActive(context.Background(), "tenant-a")
+			if tt.result != nil {
+				if !errors.Is(err, tt.result) {
+					t.Fatalf("CountActive error = %v, want %v", err, tt.result)
+				}
+			} else if err != nil || count != 7 {
+				t.Fatalf("CountActive = %d, %v, want 7", count, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRetryingRepositoryRetriesWritesOnSerializationFailureOnly(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	retrying := NewRetryingRepository(repo, testRetryPolicy)
+
+	// The aborted transaction is run again; the lost connection is not, as
+	// the write may have been applied
+	for _, err := range []error{errSerialization, errConnectionLost} {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FOR UPDATE`).WillReturnError(err)
+		mock.ExpectRollback()
+	}
+
+	_, err := retrying.UpdateStatus(context.Background(), "todo-1", "tenant-a", domain.StatusCompleted, nil, nil, 1)
+	if !errors.Is(err, errConnectionLost) {
+		t.Fatalf("UpdateStatus error = %v, want %v", err, errConnectionLost)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	calls := 0
+	_, err := retry(ctx, policy, isTransient, func() (int, error) {
+		calls++
+		cancel()
+		return 0, errConnectionLost
+	})
+	if !errors.Is(err, errConnectionLost) || calls != 1 {
+		t.Fatalf("retry = %d calls, %v, want 1 call returning the last error", calls, err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	tests := []struct {
+		attempt int
+		full    time.Duration
+	}{
+		{attempt: 1, full: 10 * time.Millisecond},
+		{attempt: 2, full: 20 * time.Millisecond},
+		{attempt: 3, full: 40 * time.Millisecond},
+		{attempt: 4, full: 50 * time.Millisecond},
+		{attempt: 64, full: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.attempt), func(t *testing.T) {
+			for range 100 {
+				if d := policy.backoff(tt.attempt); d < tt.full/2 || d > tt.full {
+					t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, d, tt.full/2, tt.full)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// maxCachedStatements bounds the statement cache; past it the least recently
+// used statement is evicted
+const maxCachedStatements = 256
+
+// stmtCache holds prepared statements keyed by their SQL text. Filtered
+// queries are built from the shape of the filter, with values bound as
+// parameters, so repeated calls with the same shape share one statement.
+type stmtCache struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	stmts map[string]*cachedStmt
+	lru   *list.List // of *cachedStmt, most recently used first
+}
+
+// cachedStmt counts the callers between get and release, so a statement
+// evicted while in use is closed only once the last of them is done
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	elem    *list.Element
+	refs    int
+	evicted bool
+}
+
+func newStmtCache(db *sql.DB) *stmtCache {
+	return &stmtCache{
+		db:    db,
+		stmts: make(map[string]*cachedStmt),
+		lru:   list.New(),
+	}
+}
+
+// get returns the prepared statement for query, preparing it on first use
+// and evicting the least recently used statement when the cache is full.
+// The caller must call release once it has run the statement.
+func (c *stmtCache) get(ctx context.Context, query string) (stmt *sql.Stmt, release func(), err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.stmts[query]
+	if ok {
+		c.lru.MoveToFront(cached.elem)
+	} else {
+		prepared, err := c.db.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(c.stmts) >= maxCachedStatements {
+			c.evict(c.lru.Back().Value.(*cachedStmt))
+		}
+		cached = &cachedStmt{query: query, stmt: prepared}
+		cached.elem = c.lru.PushFront(cached)
+		c.stmts[query] = cached
+	}
+
+	cached.refs++
+	return cached.stmt, func() { c.release(cached) }, nil
+}
+
+func (c *stmtCache) release(cached *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached.refs--
+	if cached.evicted && cached.refs == 0 {
+		cached.stmt.Close()
+	}
+}
+
+// evict drops cached from the cache, closing it unless a caller still holds it
+func (c *stmtCache) evict(cached *cachedStmt) {
+	c.lru.Remove(cached.elem)
+	delete(c.stmts, cached.query)
+	cached.evicted = true
+	if cached.refs == 0 {
+		cached.stmt.Close()
+	}
+}
+
+// close releases every cached statement
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for query, cached := range c.stmts {
+		if err := cached.stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.stmts, query)
+	}
+	c.lru.Init()
+	return firstErr
+}
+
+// prepared returns the cached statement for query bound to q, and a func to
+// call once it has run. It returns a nil statement when query cannot be
+// prepared, in which case the caller runs query directly on q to report the
+// error. A scoped querier must be unwrapped by the caller first, with its
+// scope applied to query.
+func (r *PostgresRepository) prepared(ctx context.Context, q querier, query string) (*sql.Stmt, func()) {
+	cache := r.stmts
+	if replica, ok := q.(replicaQuerier); ok {
+		cache, q = r.replicaStmts, replica.querier
+	}
+
+	stmt, release, err := cache.get(ctx, query)
+	if err != nil {
+		return nil, nil
+	}
+	if tx, ok := q.(*sql.Tx); ok {
+		return tx.StmtContext(ctx, stmt), release
+	}
+	return stmt, release
+}
+
+// unscoped applies the scope of a scoped querier to query itself, returning
+// the querier underneath so the statement can come from the cache
+func unscoped(q querier, query string, args []any) (querier, string, []any) {
+	if scoped, ok := q.(scopedQuerier); ok {
+		query, args = scoped.scope(query, args)
+		return scoped.querier, query, args
+	}
+	return q, query, args
+}
+
+// queryCached runs query on q through a cached prepared statement
+func (r *PostgresRepository) queryCached(ctx context.Context, q querier, query string, args ...any) (*sql.Rows, error) {
+	q, query, args = unscoped(q, query, args)
+	if stmt, release := r.prepared(ctx, q, query); stmt != nil {
+		// Rows keep the statement open until they are closed
+		defer release()
+		return stmt.QueryContext(ctx, args...)
+	}
+	return q.QueryContext(ctx, query, args...)
+}
+
+// queryRowCached runs a single-row query on q through a cached prepared statement
+func (r *PostgresRepository) queryRowCached(ctx context.Context, q querier, query string, args ...any) *sql.Row {
+	q, query, args = unscoped(q, query, args)
+	if stmt, release := r.prepared(ctx, q, query); stmt != nil {
+		defer release()
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return q.QueryRowContext(ctx, query, args...)
+}
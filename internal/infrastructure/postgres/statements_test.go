@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func cacheQuery(i int) string {
+	return fmt.Sprintf("SELECT id FROM todos WHERE priority = %d", i)
+}
+
+func TestStmtCacheReusesStatements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	cache := newStmtCache(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(cacheQuery(0)))
+
+	first, release, err := cache.get(context.Background(), cacheQuery(0))
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	release()
+	second, release, err := cache.get(context.Background(), cacheQuery(0))
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	release()
+
+	if first != second {
+		t.Error("second get prepared the query again")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStmtCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	cache := newStmtCache(db)
+	ctx := context.Background()
+
+	for i := range maxCachedStatements {
+		prepare := mock.ExpectPrepare(regexp.QuoteMeta(cacheQuery(i)))
+		if i == 1 {
+			prepare.WillBeClosed()
+		}
+		_, release, err := cache.get(ctx, cacheQuery(i))
+		if err != nil {
+			t.Fatalf("get %d: %v", i, err)
+		}
+		release()
+	}
+
+	// Using query 0 again leaves query 1 as the least recently used
+	if _, release, err := cache.get(ctx, cacheQuery(0)); err != nil {
+		t.Fatalf("get 0: %v", err)
+	} else {
+		release()
+	}
+
+	mock.ExpectPrepare(regexp.QuoteMeta(cacheQuery(maxCachedStatements)))
+	if _, release, err := cache.get(ctx, cacheQuery(maxCachedStatements)); err != nil {
+		t.Fatalf("get past the cap: %v", err)
+	} else {
+		release()
+	}
+
+	if got := len(cache.stmts); got != maxCachedStatements {
+		t.Errorf("cached statements = %d, want %d", got, maxCachedStatements)
+	}
+	if _, ok := cache.stmts[cacheQuery(1)]; ok {
+		t.Error("least recently used statement is still cached")
+	}
+	if _, ok := cache.stmts[cacheQuery(0)]; !ok {
+		t.Error("recently used statement was evicted")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStmtCacheClosesEvictedStatementOnRelease(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	cache := newStmtCache(db)
+	ctx := context.Background()
+
+	mock.ExpectPrepare(regexp.QuoteMeta(cacheQuery(0))).WillBeClosed()
+	_, held, err := cache.get(ctx, cacheQuery(0))
+	if err != nil {
+		t.Fatalf("get 0: %v", err)
+	}
+
+	for i := 1; i <= maxCachedStatements; i++ {
+		mock.ExpectPrepare(regexp.QuoteMeta(cacheQuery(i)))
+		_, release, err := cache.get(ctx, cacheQuery(i))
+		if err != nil {
+			t.Fatalf("get %d: %v", i, err)
+		}
+		release()
+	}
+
+	if _, ok := cache.stmts[cacheQuery(0)]; ok {
+		t.Fatal("held statement was not evicted")
+	}
+	if err := mock.ExpectationsWereMet(); err == nil {
+		t.Fatal("held statement was closed before its release")
+	}
+
+	held()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkStmtCache(b *testing.B) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+	cache := newStmtCache(db)
+	ctx := context.Background()
+
+	b.Run("reuse", func(b *testing.B) {
+		mock.ExpectPrepare(regexp.QuoteMeta(cacheQuery(0)))
+		for b.Loop() {
+			_, release, err := cache.get(ctx, cacheQuery(0))
+			if err != nil {
+				b.Fatal(err)
+			}
+			release()
+		}
+	})
+
+	b.Run("prepare", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			query := cacheQuery(i + 1)
+			mock.ExpectPrepare(regexp.QuoteMeta(query))
+			stmt, err := db.PrepareContext(ctx, query)
+			if err != nil {
+				b.Fatal(err)
+			}
+			stmt.Close()
+		}
+	})
+}
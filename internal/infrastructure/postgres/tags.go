@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// likeEscaper escapes the LIKE wildcards of a literal prefix
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListTags returns up to limit distinct tags of the tenant's non-deleted
+// todos that start with prefix, ignoring case, most used first
+func (r *PostgresRepository) ListTags(ctx context.Context, tenantID, prefix string, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository.ListTags")
+	defer span.End()
+	defer r.logSlowQuery(span, "ListTags", time.Now())
+
+	logFields := []zap.Field{zap.String("tenant_id", tenantID)}
+
+	span.SetAttributes(
+		attribute.String("tenant.id", tenantID),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+		SELECT tag
+		FROM todos, unnest(tags) AS tag
+		WHERE tenant_id = $1 AND deleted_at IS NULL AND lower(tag) LIKE $2
+		GROUP BY tag
+		ORDER BY COUNT(*) DESC, tag ASC
+		LIMIT $3
+	`
+
+	q := r.reader(ctx)
+
+	pattern := likeEscaper.Replace(strings.ToLower(prefix)) + "%"
+
+	rows, err := q.QueryContext(ctx, query, tenantID, pattern, limit)
+	if err != nil {
+		r.recordError(span, "ListTags", err, logFields...)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			r.recordError(span, "ListTags", err, logFields...)
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.recordError(span, "ListTags", err, logFields...)
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("returned_count", len(tags)))
+	return tags, nil
+}
+
+// AddTagToMany adds tag to the tenant's non-deleted todos among ids that do
+// not have it yet and returns how many changed. It fails with
+// domain.ErrTooManyTags, changing nothing, if any of them would exceed the
+// tag limit.
+func (r *PostgresRepository) AddTagToMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error) {
+	return r.updateTagOfMany(ctx, "AddTagToMany", tenantID, ids, tag,
+		`array_append(t.tags, $3)`, `NOT ($3 = ANY(t.tags))`)
+}
+
+// RemoveTagFromMany removes tag from the tenant's non-deleted todos among
+// ids that have it and returns how many changed
+func (r *PostgresRepository) RemoveTagFromMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error) {
+	return r.updateTagOfMany(ctx, "RemoveTagFromMany", tenantID, ids, tag,
+		`array_remove(t.tags, $3)`, `$3 = ANY(t.tags)`)
+}
+
+// updateTagOfMany sets tags to newTags on the selected todos matching
+// condition in one statement, recording a history entry for each. Todos the
+// change would not affect are left alone, so their version is kept.
+func (r *PostgresRepository) updateTagOfMany(ctx context.Context, op, tenantID string, ids []string, tag, newTags, condition string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "repository."+op)
+	defer span.End()
+	defer r.logSlowQuery(span, op, time.Now())
+
+	logFields := []zap.Field{zap.String("tenant_id", tenantID), zap.Int("id_count", len(ids))}
+
+	span.SetAttributes(
+		attribute.String("tenant.id", tenantID),
+		attribute.Int("id_count", len(ids)),
+	)
+
+	// The self-join reads each row as it was before the update, for history
+	query := fmt.Sprintf(`
+		UPDATE todos t
+		SET tags = %s, updated_at = $4, version = t.version + 1
+		FROM todos old
+		WHERE old.id = t.id
+			AND t.id = ANY($1) AND t.tenant_id = $2 AND t.deleted_at IS NULL
+			AND %s
+		RETURNING t.id, old.tags, t.tags
+	`, newTags, condition)
+
+	maxTags := domain.CurrentLimits().MaxTags
+
+	var updated int64
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, pq.Array(ids), tenantID, tag, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to update tags: %w", err)
+		}
+
+		type tagChange struct {
+			id       string
+			old, new []string
+		}
+		var changes []tagChange
+		for rows.Next() {
+			var c tagChange
+			if err := rows.Scan(&c.id, pq.Array(&c.old), pq.Array(&c.new)); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan updated tags: %w", err)
+			}
+			changes = append(changes, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating updated tags: %w", err)
+		}
+
+		for _, c := range changes {
+			if len(c.new) > maxTags {
+				return domain.ErrTooManyTags
+			}
+			err := recordChange(ctx, tx, c.id, tenantID, domain.ChangeUpdated, map[string]domain.FieldChange{
+				"tags": {Old: c.old, New: c.new},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		updated = int64(len(changes))
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrTooManyTags) {
+			r.recordError(span, op, err, logFields...)
+		}
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("updated_count", updated))
+	return updated, nil
+}
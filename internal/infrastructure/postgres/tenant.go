@@ -0,0 +1,457 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+type tenantScopeKey struct{}
+
+// withTenantScope marks ctx so queries run with app.current_tenant set to
+// tenantID, letting the row-level security policies on todos filter by it
+func withTenantScope(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantScopeKey{}, tenantID)
+}
+
+func tenantScopeFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantScopeKey{}).(string)
+	return tenantID, ok
+}
+
+// scopeSetting returns the setting that gives queries for ctx their tenant
+// scope, app.current_tenant, or their cross-tenant opt-in, app.cross_tenant.
+// ok is false when ctx has neither, leaving row-level security to deny every
+// todo.
+func scopeSetting(ctx context.Context) (name, value string, ok bool) {
+	if tenantID, ok := tenantScopeFromContext(ctx); ok {
+		return "app.current_tenant", tenantID, true
+	}
+	if domain.CrossTenant(ctx) {
+		return "app.cross_tenant", "on", true
+	}
+	return "", "", false
+}
+
+// applyTenantScope sets the scope of ctx for the rest of tx
+func applyTenantScope(ctx context.Context, tx *sql.Tx) error {
+	name, value, ok := scopeSetting(ctx)
+	if !ok {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT set_config($1, $2, true)`, name, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", name, err)
+	}
+	return nil
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// replicaQuerier marks a querier running on the read replica, so prepared
+// statements come from the replica's cache
+type replicaQuerier struct {
+	querier
+}
+
+// scopedQuerier runs every statement with a scope setting the statement sets
+// itself, so a scoped read is a single round trip on the pooled connections
+// and their prepared statements rather than a transaction of its own
+type scopedQuerier struct {
+	querier
+	name, value string
+}
+
+func (q scopedQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	query, args = q.scope(query, args)
+	return q.querier.ExecContext(ctx, query, args...)
+}
+
+func (q scopedQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	query, args = q.scope(query, args)
+	return q.querier.QueryContext(ctx, query, args...)
+}
+
+func (q scopedQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	query, args = q.scope(query, args)
+	return q.querier.QueryRowContext(ctx, query, args...)
+}
+
+// scope wraps query so it sets the scope for its own implicit transaction
+// before reading anything. query is joined laterally to the set_config row,
+// so Postgres must produce that row before scanning query, and OFFSET 0 keeps
+// query from being flattened into the join where that order would be lost.
+// With a single outer row the nested loop keeps the rows in query's order.
+func (q scopedQuerier) scope(query string, args []any) (string, []any) {
+	wrapped := fmt.Sprintf(`SELECT scoped.* FROM (SELECT set_config('%s', $%d, true) AS value) AS scope
+		CROSS JOIN LATERAL (SELECT * FROM (%s) AS q WHERE scope.value IS NOT NULL OFFSET 0) AS scoped`,
+		q.name, len(args)+1, query)
+	return wrapped, append(slices.Clone(args), q.value)
+}
+
+// reader returns where read queries should run: the replica when one is
+// configured and ctx does not ask for read-your-writes, otherwise the primary.
+// For a tenant-scoped or cross-tenant context every query carries the scope.
+func (r *PostgresRepository) reader(ctx context.Context) querier {
+	var q querier = r.db
+	if r.replica != nil && !domain.ReadYourWrites(ctx) {
+		q = replicaQuerier{r.replica}
+	}
+
+	if name, value, ok := scopeSetting(ctx); ok {
+		q = scopedQuerier{querier: q, name: name, value: value}
+	}
+	return q
+}
+
+// TenantScopedRepository guards a PostgresRepository against calls that lose
+// their tenant: it rejects an empty tenant ID on every call and runs each
+// query with app.current_tenant set, so row-level security confines it to
+// that tenant even if a query forgets its tenant_id predicate.
+type TenantScopedRepository struct {
+	*PostgresRepository
+}
+
+var _ domain.Repository = (*TenantScopedRepository)(nil)
+
+func NewTenantScopedRepository(repo *PostgresRepository) *TenantScopedRepository {
+	return &TenantScopedRepository{PostgresRepository: repo}
+}
+
+func scope(ctx context.Context, tenantID string) (context.Context, error) {
+	if tenantID == "" {
+		return nil, domain.ErrInvalidTenantID
+	}
+	return withTenantScope(ctx, tenantID), nil
+}
+
+func (r *TenantScopedRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	ctx, err := scope(ctx, todo.TenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.Create(ctx, todo)
+}
+
+func (r *TenantScopedRepository) Upsert(ctx context.Context, todo *domain.Todo) (bool, error) {
+	ctx, err := scope(ctx, todo.TenantID)
+	if err != nil {
+		return false, err
+	}
+	return r.PostgresRepository.Upsert(ctx, todo)
+}
+
+func (r *TenantScopedRepository) GetByID(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.GetByID(ctx, id, tenantID)
+}
+
+func (r *TenantScopedRepository) GetByIDIncludingDeleted(ctx context.Context, id, tenantID string) (*domain.Todo, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.GetByIDIncludingDeleted(ctx, id, tenantID)
+}
+
+func (r *TenantScopedRepository) GetDetail(ctx context.Context, id, tenantID string) (*domain.TodoDetail, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.GetDetail(ctx, id, tenantID)
+}
+
+func (r *TenantScopedRepository) GetByIDs(ctx context.Context, ids []string, tenantID string) ([]*domain.Todo, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.GetByIDs(ctx, ids, tenantID)
+}
+
+func (r *TenantScopedRepository) Update(ctx context.Context, todo *domain.Todo, expectedVersion int64) error {
+	ctx, err := scope(ctx, todo.TenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.Update(ctx, todo, expectedVersion)
+}
+
+func (r *TenantScopedRepository) Delete(ctx context.Context, id, tenantID string) error {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.Delete(ctx, id, tenantID)
+}
+
+func (r *TenantScopedRepository) ForceDelete(ctx context.Context, id, tenantID string) ([]string, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.ForceDelete(ctx, id, tenantID)
+}
+
+func (r *TenantScopedRepository) DeleteByFilter(ctx context.Context, filter *domain.ListFilter) (int64, error) {
+	ctx, err := scope(ctx, filter.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return r.PostgresRepository.DeleteByFilter(ctx, filter)
+}
+
+func (r *TenantScopedRepository) List(ctx context.Context, filter *domain.ListFilter) ([]*domain.Todo, int64, error) {
+	ctx, err := scope(ctx, filter.TenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.PostgresRepository.List(ctx, filter)
+}
+
+func (r *TenantScopedRepository) DryRunList(ctx context.Context, filter *domain.ListFilter) (*domain.ListQuery, error) {
+	ctx, err := scope(ctx, filter.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.DryRunList(ctx, filter)
+}
+
+func (r *TenantScopedRepository) AnalyzeList(ctx context.Context, filter *domain.ListFilter) ([]byte, error) {
+	ctx, err := scope(ctx, filter.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.AnalyzeList(ctx, filter)
+}
+
+func (r *TenantScopedRepository) ListRefs(ctx context.Context, filter *domain.ListFilter) ([]*domain.TodoRef, int64, error) {
+	ctx, err := scope(ctx, filter.TenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.PostgresRepository.ListRefs(ctx, filter)
+}
+
+func (r *TenantScopedRepository) CountActive(ctx context.Context, tenantID string) (int64, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return r.PostgresRepository.CountActive(ctx, tenantID)
+}
+
+func (r *TenantScopedRepository) CountByStatus(ctx context.Context, filter *domain.ListFilter) (map[domain.TodoStatus]int64, error) {
+	ctx, err := scope(ctx, filter.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.CountByStatus(ctx, filter)
+}
+
+func (r *TenantScopedRepository) WorkloadByAssignee(ctx context.Context, tenantID string) (map[string]domain.WorkloadStats, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.WorkloadByAssignee(ctx, tenantID)
+}
+
+func (r *TenantScopedRepository) UpdateStatus(ctx context.Context, id, tenantID string, status domain.TodoStatus, completedAt *time.Time, completedBy *string, version int64) (*domain.Todo, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.UpdateStatus(ctx, id, tenantID, status, completedAt, completedBy, version)
+}
+
+// BatchCreate requires every todo of the batch to belong to the same tenant
+func (r *TenantScopedRepository) BatchCreate(ctx context.Context, todos []*domain.Todo) error {
+	if len(todos) == 0 {
+		return r.PostgresRepository.BatchCreate(ctx, todos)
+	}
+
+	tenantID := todos[0].TenantID
+	for _, todo := range todos {
+		if todo.TenantID != tenantID {
+			return domain.ErrInvalidTenantID
+		}
+	}
+
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.BatchCreate(ctx, todos)
+}
+
+// PurgeArchived is not tenant-scoped; it is a maintenance job spanning every tenant,
+// so ctx must opt in with domain.WithCrossTenant
+func (r *TenantScopedRepository) PurgeArchived(ctx context.Context, olderThan time.Time) (int64, []string, error) {
+	return r.PostgresRepository.PurgeArchived(ctx, olderThan)
+}
+
+// PurgeDeleted is not tenant-scoped; it is a maintenance job spanning every tenant,
+// so ctx must opt in with domain.WithCrossTenant
+func (r *TenantScopedRepository) PurgeDeleted(ctx context.Context, olderThan time.Time) (int64, []string, error) {
+	return r.PostgresRepository.PurgeDeleted(ctx, olderThan)
+}
+
+// DueReminders is not tenant-scoped; it is a maintenance job spanning every tenant,
+// so ctx must opt in with domain.WithCrossTenant
+func (r *TenantScopedRepository) DueReminders(ctx context.Context, window time.Duration) ([]*domain.Todo, error) {
+	return r.PostgresRepository.DueReminders(ctx, window)
+}
+
+// MoveToTenant runs cross-tenant, since it writes rows into a tenant other
+// than the one they are read from; it still requires both tenants
+func (r *TenantScopedRepository) MoveToTenant(ctx context.Context, ids []string, fromTenant, toTenant string, mapOwner domain.OwnerMapping) (int64, error) {
+	if fromTenant == "" || toTenant == "" {
+		return 0, domain.ErrInvalidTenantID
+	}
+	return r.PostgresRepository.MoveToTenant(domain.WithCrossTenant(ctx), ids, fromTenant, toTenant, mapOwner)
+}
+
+// ArchiveCompletedOlderThan requires a tenant; run it on the unscoped
+// repository with a cross-tenant context to cover every tenant
+func (r *TenantScopedRepository) ArchiveCompletedOlderThan(ctx context.Context, tenantID string, age time.Duration) (int64, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return r.PostgresRepository.ArchiveCompletedOlderThan(ctx, tenantID, age)
+}
+
+// EscalateOverdue requires a tenant; run it on the unscoped repository with a
+// cross-tenant context to cover every tenant
+func (r *TenantScopedRepository) EscalateOverdue(ctx context.Context, tenantID string) (int64, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return r.PostgresRepository.EscalateOverdue(ctx, tenantID)
+}
+
+func (r *TenantScopedRepository) DueWithin(ctx context.Context, tenantID string, within time.Duration) ([]*domain.Todo, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.DueWithin(ctx, tenantID, within)
+}
+
+func (r *TenantScopedRepository) ListTags(ctx context.Context, tenantID, prefix string, limit int) ([]string, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.ListTags(ctx, tenantID, prefix, limit)
+}
+
+func (r *TenantScopedRepository) AddTagToMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return r.PostgresRepository.AddTagToMany(ctx, tenantID, ids, tag)
+}
+
+func (r *TenantScopedRepository) RemoveTagFromMany(ctx context.Context, tenantID string, ids []string, tag string) (int64, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return r.PostgresRepository.RemoveTagFromMany(ctx, tenantID, ids, tag)
+}
+
+func (r *TenantScopedRepository) GetHistory(ctx context.Context, id, tenantID string, limit int) ([]*domain.HistoryEntry, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.GetHistory(ctx, id, tenantID, limit)
+}
+
+func (r *TenantScopedRepository) AddComment(ctx context.Context, comment *domain.Comment) error {
+	ctx, err := scope(ctx, comment.TenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.AddComment(ctx, comment)
+}
+
+func (r *TenantScopedRepository) GetComment(ctx context.Context, id, todoID, tenantID string) (*domain.Comment, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.GetComment(ctx, id, todoID, tenantID)
+}
+
+func (r *TenantScopedRepository) ListComments(ctx context.Context, todoID, tenantID string, limit int) ([]*domain.Comment, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.ListComments(ctx, todoID, tenantID, limit)
+}
+
+func (r *TenantScopedRepository) DeleteComment(ctx context.Context, id, todoID, tenantID string) error {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.DeleteComment(ctx, id, todoID, tenantID)
+}
+
+func (r *TenantScopedRepository) AddAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	ctx, err := scope(ctx, attachment.TenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.AddAttachment(ctx, attachment)
+}
+
+func (r *TenantScopedRepository) ListAttachments(ctx context.Context, todoID, tenantID string) ([]*domain.Attachment, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.ListAttachments(ctx, todoID, tenantID)
+}
+
+func (r *TenantScopedRepository) AddDependency(ctx context.Context, dep *domain.Dependency) error {
+	ctx, err := scope(ctx, dep.TenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.AddDependency(ctx, dep)
+}
+
+func (r *TenantScopedRepository) RemoveDependency(ctx context.Context, todoID, dependsOnID, tenantID string) error {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return r.PostgresRepository.RemoveDependency(ctx, todoID, dependsOnID, tenantID)
+}
+
+func (r *TenantScopedRepository) ListDependencies(ctx context.Context, todoID, tenantID string) (*domain.TodoDependencies, error) {
+	ctx, err := scope(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.PostgresRepository.ListDependencies(ctx, todoID, tenantID)
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+func newMockRepository(t *testing.T) (*PostgresRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewPostgresRepository(db, nil), mock
+}
+
+func TestScopedReadRunsWithoutTransaction(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	scoped := NewTenantScopedRepository(repo)
+
+	// The scope is set inside the statement, bound as its last parameter, so
+	// neither read begins a transaction and both share one prepared statement.
+	prepare := mock.ExpectPrepare(`SELECT set_config\('app.current_tenant', \$2, true\)`)
+	for range 2 {
+		prepare.ExpectQuery().
+			WithArgs("tenant-a", "tenant-a").
+			WillReturnRows(sqlmock.NewRows([]string{"assigned_to", "status", "count"}))
+	}
+
+	for range 2 {
+		if _, err := scoped.WorkloadByAssignee(context.Background(), "tenant-a"); err != nil {
+			t.Fatalf("WorkloadByAssignee: %v", err)
+		}
+	}
+
+	mock.ExpectQuery(`SELECT set_config\('app.current_tenant', \$4, true\)`).
+		WithArgs("todo-1", "tenant-a", false, "tenant-a").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if _, err := scoped.GetByID(context.Background(), "todo-1", "tenant-a"); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("GetByID error = %v, want %v", err, domain.ErrTodoNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnscopedReadRunsQueryAsIs(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectQuery(`^\s*SELECT .+ FROM todos`).
+		WithArgs("todo-1", "tenant-a", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if _, err := repo.GetByID(context.Background(), "todo-1", "tenant-a"); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("GetByID error = %v, want %v", err, domain.ErrTodoNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadsGoToReplicaUnlessReadYourWrites(t *testing.T) {
+	primary, primaryMock := newMockRepository(t)
+	replicaDB, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { replicaDB.Close() })
+	WithReplica(replicaDB)(primary)
+	scoped := NewTenantScopedRepository(primary)
+
+	// Plain and scoped reads run on the replica, preparing statements there
+	replicaMock.ExpectQuery(`^\s*SELECT .+ FROM todos`).
+		WithArgs("todo-1", "tenant-a", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	replicaMock.ExpectPrepare(`SELECT set_config\('app.current_tenant', \$2, true\)`).
+		ExpectQuery().
+		WithArgs("tenant-a", "tenant-a").
+		WillReturnRows(sqlmock.NewRows([]string{"assigned_to", "status", "count"}))
+
+	if _, err := primary.GetByID(context.Background(), "todo-1", "tenant-a"); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("GetByID error = %v, want %v", err, domain.ErrTodoNotFound)
+	}
+	if _, err := scoped.WorkloadByAssignee(context.Background(), "tenant-a"); err != nil {
+		t.Fatalf("WorkloadByAssignee: %v", err)
+	}
+
+	// Asking for read-your-writes keeps the read on the primary
+	primaryMock.ExpectQuery(`^\s*SELECT .+ FROM todos`).
+		WithArgs("todo-1", "tenant-a", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if _, err := primary.GetByID(domain.WithReadYourWrites(context.Background()), "todo-1", "tenant-a"); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Fatalf("GetByID error = %v, want %v", err, domain.ErrTodoNotFound)
+	}
+
+	for _, mock := range []sqlmock.Sqlmock{primaryMock, replicaMock} {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
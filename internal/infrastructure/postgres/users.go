@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UserDirectory looks users up in the tenant_users table, which is
+// maintained by whatever provisions users for the service
+type UserDirectory struct {
+	db     *sql.DB
+	tracer trace.Tracer
+}
+
+var _ domain.UserDirectory = (*UserDirectory)(nil)
+
+func NewUserDirectory(db *sql.DB) *UserDirectory {
+	return &UserDirectory{
+		db:     db,
+		tracer: otel.Tracer("postgres-repository"),
+	}
+}
+
+// Exists reports whether userID is listed as a user of tenantID
+func (d *UserDirectory) Exists(ctx context.Context, tenantID, userID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	ctx, span := d.tracer.Start(ctx, "userDirectory.Exists")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant.id", tenantID))
+
+	query := `SELECT EXISTS (SELECT 1 FROM tenant_users WHERE tenant_id = $1 AND user_id = $2)`
+
+	var exists bool
+	if err := d.db.QueryRowContext(ctx, query, tenantID, userID).Scan(&exists); err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to look up user: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestUserDirectoryExists(t *testing.T) {
+	const query = `SELECT EXISTS \(SELECT 1 FROM tenant_users WHERE tenant_id = \$1 AND user_id = \$2\)`
+
+	tests := []struct {
+		name    string
+		exists  bool
+		err     error
+		wantErr bool
+	}{
+		{name: "listed", exists: true},
+		{name: "not listed"},
+		{name: "query fails", err: errConnectionLost, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			expect := mock.ExpectQuery(query).WithArgs("tenant-a", "bob")
+			if tt.err != nil {
+				expect.WillReturnError(tt.err)
+			} else {
+				expect.WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.exists))
+			}
+
+			exists, err := NewUserDirectory(db).Exists(context.Background(), "tenant-a", "bob")
+			if tt.wantErr {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Exists error = %v, want %v", err, tt.err)
+				}
+			} else if err != nil || exists != tt.exists {
+				t.Fatalf("Exists = %v, %v, want %v", exists, err, tt.exists)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
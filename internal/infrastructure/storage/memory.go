@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+// MemoryStorage is an in-memory ObjectStorage for tests and local
+// development. Uploads are simulated with Put.
+type MemoryStorage struct {
+	mu       sync.RWMutex
+	objects  map[string]domain.ObjectInfo
+	presigns []string
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		objects: make(map[string]domain.ObjectInfo),
+	}
+}
+
+func (m *MemoryStorage) PresignPut(ctx context.Context, key, contentType string, sizeBytes int64, expiry time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.presigns = append(m.presigns, key)
+
+	query := url.Values{}
+	query.Set("content-type", contentType)
+	query.Set("size", fmt.Sprint(sizeBytes))
+	query.Set("expires", time.Now().Add(expiry).UTC().Format(time.RFC3339))
+	return "memory://" + url.PathEscape(key) + "?" + query.Encode(), nil
+}
+
+func (m *MemoryStorage) Stat(ctx context.Context, key string) (*domain.ObjectInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	info, ok := m.objects[key]
+	if !ok {
+		return nil, domain.ErrObjectNotFound
+	}
+	return &info, nil
+}
+
+func (m *MemoryStorage) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.objects, key)
+	return nil
+}
+
+// Put stores an object as if a client had uploaded it to a presigned URL
+func (m *MemoryStorage) Put(key, contentType string, sizeBytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[key] = domain.ObjectInfo{ContentType: contentType, SizeBytes: sizeBytes}
+}
+
+// Presigned returns the keys upload URLs were issued for, in order
+func (m *MemoryStorage) Presigned() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, len(m.presigns))
+	copy(keys, m.presigns)
+	return keys
+}
@@ -0,0 +1,168 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingSink keeps every audit record, failing writes with err
+type recordingSink struct {
+	records []AuditRecord
+	err     error
+}
+
+func (s *recordingSink) Write(ctx context.Context, record AuditRecord) error {
+	s.records = append(s.records, record)
+	return s.err
+}
+
+func TestAuditInterceptorRecordsCalls(t *testing.T) {
+	caller := &auth.UserContext{UserID: "user-1", TenantID: "tenant-1"}
+
+	tests := []struct {
+		name   string
+		method string
+		caller *auth.UserContext
+		req    any
+		resp   any
+		err    error
+		want   *AuditRecord
+	}{
+		{
+			name:   "request todo ID",
+			method: "/todo.v1.TodoService/GetTodo",
+			caller: caller,
+			req:    &todov1.GetTodoRequest{Id: "todo-1"},
+			want:   &AuditRecord{Code: "OK", TodoID: "todo-1"},
+		},
+		{
+			name:   "created todo ID",
+			method: "/todo.v1.TodoService/CreateTodo",
+			caller: caller,
+			req:    &todov1.CreateTodoRequest{Title: "new"},
+			resp:   &todov1.CreateTodoResponse{Todo: &todov1.Todo{Id: "todo-2"}},
+			want:   &AuditRecord{Code: "OK", TodoID: "todo-2"},
+		},
+		{
+			name:   "failed call",
+			method: "/todo.v1.TodoService/GetTodo",
+			caller: caller,
+			req:    &todov1.GetTodoRequest{Id: "missing"},
+			err:    status.Error(codes.NotFound, "todo not found"),
+			want:   &AuditRecord{Code: "NotFound", TodoID: "missing"},
+		},
+		{
+			name:   "public method",
+			method: "/grpc.health.v1.Health/Check",
+			caller: caller,
+		},
+		{
+			name:   "unauthenticated",
+			method: "/todo.v1.TodoService/GetTodo",
+			req:    &todov1.GetTodoRequest{Id: "todo-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			interceptor := AuditInterceptor(sink, zap.NewNop())
+
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = auth.ContextWithUserContext(ctx, tt.caller)
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			resp, err := interceptor(ctx, tt.req, info, func(ctx context.Context, req any) (any, error) {
+				return tt.resp, tt.err
+			})
+			if err != tt.err || resp != tt.resp {
+				t.Fatalf("interceptor = %v, %v, want the handler result", resp, err)
+			}
+
+			if tt.want == nil {
+				if len(sink.records) != 0 {
+					t.Fatalf("expected no audit record, got %+v", sink.records)
+				}
+				return
+			}
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 audit record, got %d", len(sink.records))
+			}
+			got := sink.records[0]
+			if got.Time.IsZero() {
+				t.Error("audit record has no time")
+			}
+			want := *tt.want
+			want.Time = got.Time
+			want.UserID, want.TenantID, want.Method = caller.UserID, caller.TenantID, tt.method
+			if got != want {
+				t.Errorf("audit record = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestAuditInterceptorLogsSinkFailures(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	interceptor := AuditInterceptor(&recordingSink{err: errors.New("disk full")}, zap.New(core))
+
+	ctx := auth.ContextWithUserContext(context.Background(), &auth.UserContext{UserID: "user-1", TenantID: "tenant-1"})
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/GetTodo"}
+
+	// A lost audit record does not fail the call
+	_, err := interceptor(ctx, &todov1.GetTodoRequest{Id: "todo-1"}, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if logs.FilterMessage("failed to write audit record").Len() != 1 {
+		t.Errorf("expected the sink failure logged, got %v", logs.AllUntimed())
+	}
+}
+
+func TestJSONAuditSinkWritesLines(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONAuditSink(&buf)
+
+	records := []AuditRecord{
+		{UserID: "user-1", TenantID: "tenant-1", Method: "/todo.v1.TodoService/GetTodo", Code: "OK", TodoID: "todo-1"},
+		{UserID: "user-2", TenantID: "tenant-1", Method: "/todo.v1.TodoService/ListTodos", Code: "OK"},
+	}
+	for _, record := range records {
+		if err := sink.Write(context.Background(), record); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != len(records) {
+		t.Fatalf("expected %d lines, got %q", len(records), buf.String())
+	}
+	for i, line := range lines {
+		var got AuditRecord
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		if got != records[i] {
+			t.Errorf("line %d = %+v, want %+v", i, got, records[i])
+		}
+	}
+	if bytes.Contains(lines[1], []byte("todo_id")) {
+		t.Errorf("empty todo ID written: %s", lines[1])
+	}
+}
@@ -2,7 +2,10 @@ package interceptors
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dmehra2102/TaskForge/pkg/auth"
 	"github.com/golang-jwt/jwt/v5"
@@ -15,9 +18,21 @@ import (
 var publicMethods = map[string]bool{
 	"/grpc.health.v1.Health/Check": true,
 	"/grpc.health.v1.Health/Watch": true,
+
+	// Token issuance authenticates with credentials or a refresh token instead
+	"/todo.v1.AuthService/Login":        true,
+	"/todo.v1.AuthService/RefreshToken": true,
 }
 
-func AuthInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
+const apiKeyHeader = "x-api-key"
+
+// AuthInterceptor validates the bearer JWT on every non-public call. keyFunc
+// decides which signing methods are accepted and resolves the verification
+// key, see auth.NewKeyfunc. Calls without a JWT may instead authenticate as a
+// service account with an x-api-key header known to apiKeys; a nil store
+// disables API keys. Callers whose tenant ID tenantIDs rejects are
+// unauthenticated.
+func AuthInterceptor(keyFunc auth.Keyfunc, apiKeys auth.APIKeyStore, tenantIDs *auth.TenantIDValidator) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req any,
@@ -28,53 +43,173 @@ func AuthInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		// Etract Metadata
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
+		ctx, err = authenticate(ctx, keyFunc, apiKeys, tenantIDs)
+		if err != nil {
+			return nil, err
 		}
 
-		// Get authorization header
-		authHeader := md.Get("authorization")
-		if len(authHeader) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor is the streaming counterpart of AuthInterceptor
+func StreamAuthInterceptor(keyFunc auth.Keyfunc, apiKeys auth.APIKeyStore, tenantIDs *auth.TenantIDValidator) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, ss)
 		}
 
-		// Parse token
-		tokenString := strings.TrimPrefix(authHeader[0], "Bearer ")
-		if tokenString == authHeader[0] {
-			return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
+		ctx, err := authenticate(ss.Context(), keyFunc, apiKeys, tenantIDs)
+		if err != nil {
+			return err
 		}
 
-		// Validate JWT
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, status.Error(codes.Unauthenticated, "invalid token signing method")
-			}
-			return []byte(jwtSecret), nil
-		})
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		if err != nil || !token.Valid {
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
+// contextServerStream overrides the context of a wrapped stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate verifies the bearer token, or failing that the API key, in the
+// incoming metadata and returns a context carrying the caller's UserContext
+func authenticate(ctx context.Context, keyFunc auth.Keyfunc, apiKeys auth.APIKeyStore, tenantIDs *auth.TenantIDValidator) (context.Context, error) {
+	userCtx, err := authenticateCaller(ctx, keyFunc, apiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tenantIDs.ValidateTenantID(userCtx.TenantID); err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid tenant ID")
+	}
+
+	return auth.ContextWithUserContext(ctx, userCtx), nil
+}
+
+// authenticateCaller resolves the caller from the bearer token or API key
+func authenticateCaller(ctx context.Context, keyFunc auth.Keyfunc, apiKeys auth.APIKeyStore) (*auth.UserContext, error) {
+	// Etract Metadata
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	// Get authorization header; a JWT takes precedence over an API key
+	authHeader := md.Get("authorization")
+	if len(authHeader) == 0 {
+		if apiKey := md.Get(apiKeyHeader); len(apiKey) > 0 && apiKeys != nil {
+			return authenticateAPIKey(ctx, apiKeys, apiKey[0])
 		}
+		return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	// Parse token
+	tokenString := strings.TrimPrefix(authHeader[0], "Bearer ")
+	if tokenString == authHeader[0] {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
 
-		// Extract claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "invalid token claims")
+	// Validate JWT
+	token, err := jwt.Parse(tokenString, keyFunc.WithContext(ctx), jwt.WithExpirationRequired())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return nil, status.Error(codes.Unauthenticated, "token has expired")
+		case errors.Is(err, jwt.ErrTokenRequiredClaimMissing), errors.Is(err, jwt.ErrInvalidType):
+			return nil, status.Error(codes.Unauthenticated, "token is missing a valid exp claim")
+		case errors.Is(err, auth.ErrInvalidSigningMethod):
+			return nil, status.Error(codes.Unauthenticated, "invalid token signing method")
+		default:
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
 		}
+	}
+	if !token.Valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	// Extract claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token claims")
+	}
+
+	userCtx, err := userContextFromClaims(claims)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return userCtx, nil
+}
 
-		userCtx := &auth.UserContext{
-			UserID:   claims["user_id"].(string),
-			TenantID: claims["tenant_id"].(string),
-			Roles:    extractRoles(claims["roles"]),
+// authenticateAPIKey resolves key to the service account it belongs to
+func authenticateAPIKey(ctx context.Context, apiKeys auth.APIKeyStore, key string) (*auth.UserContext, error) {
+	userCtx, err := apiKeys.LookupAPIKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnknownAPIKey) {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
 		}
+		return nil, status.Error(codes.Internal, "failed to verify API key")
+	}
 
-		// Add to context
-		ctx = auth.ContextWithUserContext(ctx, userCtx)
+	return userCtx, nil
+}
 
-		return handler(ctx, req)
+// userContextFromClaims builds a UserContext from validated token claims,
+// rejecting tokens whose required claims are missing, mistyped or expired.
+func userContextFromClaims(claims jwt.MapClaims) (*auth.UserContext, error) {
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, errors.New("token is missing a valid exp claim")
+	}
+	if !exp.After(time.Now()) {
+		return nil, errors.New("token has expired")
 	}
+	if tokenType, _ := claims[auth.TokenTypeClaim].(string); tokenType == auth.TokenTypeRefresh {
+		return nil, errors.New("refresh tokens cannot authenticate requests")
+	}
+
+	userID, err := stringClaim(claims, "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID, err := stringClaim(claims, "tenant_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.UserContext{
+		UserID:   userID,
+		TenantID: tenantID,
+		Roles:    extractRoles(claims["roles"]),
+	}, nil
+}
+
+func stringClaim(claims jwt.MapClaims, key string) (string, error) {
+	raw, ok := claims[key]
+	if !ok {
+		return "", fmt.Errorf("token is missing %s claim", key)
+	}
+
+	value, ok := raw.(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("token has invalid %s claim", key)
+	}
+
+	return value, nil
 }
 
 func extractRoles(rolesInterface any) []string {
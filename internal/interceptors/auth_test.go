@@ -0,0 +1,281 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dmehra2102/TaskForge/pkg/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"user_id":   "user-1",
+		"tenant_id": "tenant-1",
+		"roles":     []any{"user"},
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func newTestAuthInterceptor(t *testing.T, secrets ...string) grpc.UnaryServerInterceptor {
+	t.Helper()
+	if len(secrets) == 0 {
+		secrets = []string{testSecret}
+	}
+	keyFunc, err := auth.NewKeyfunc(auth.KeyConfig{Algorithm: "HS256", Secrets: secrets})
+	if err != nil {
+		t.Fatalf("failed to build keyfunc: %v", err)
+	}
+	tenantIDs, err := auth.NewTenantIDValidator(auth.DefaultTenantIDPattern)
+	if err != nil {
+		t.Fatalf("failed to build tenant ID validator: %v", err)
+	}
+	return AuthInterceptor(keyFunc, nil, tenantIDs)
+}
+
+// callWithToken runs interceptor for a bearer token and returns the
+// authenticated caller, or the error the call was rejected with
+func callWithToken(interceptor grpc.UnaryServerInterceptor, token string) (*auth.UserContext, error) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/GetTodo"}
+
+	var userCtx *auth.UserContext
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		var err error
+		userCtx, err = auth.UserContextFromContext(ctx)
+		return nil, err
+	})
+	return userCtx, err
+}
+
+func TestAuthInterceptorClaims(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(jwt.MapClaims)
+		wantMsg string
+	}{
+		{
+			name:   "valid token",
+			mutate: func(jwt.MapClaims) {},
+		},
+		{
+			name:    "missing user_id",
+			mutate:  func(c jwt.MapClaims) { delete(c, "user_id") },
+			wantMsg: "token is missing user_id claim",
+		},
+		{
+			name:    "non-string tenant_id",
+			mutate:  func(c jwt.MapClaims) { c["tenant_id"] = 42 },
+			wantMsg: "token has invalid tenant_id claim",
+		},
+		{
+			name:    "expired token",
+			mutate:  func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+			wantMsg: "token has expired",
+		},
+		{
+			name:    "missing exp",
+			mutate:  func(c jwt.MapClaims) { delete(c, "exp") },
+			wantMsg: "token is missing a valid exp claim",
+		},
+		{
+			name:    "refresh token",
+			mutate:  func(c jwt.MapClaims) { c[auth.TokenTypeClaim] = auth.TokenTypeRefresh },
+			wantMsg: "refresh tokens cannot authenticate requests",
+		},
+	}
+
+	interceptor := newTestAuthInterceptor(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.mutate(claims)
+
+			userCtx, err := callWithToken(interceptor, signToken(t, testSecret, claims))
+			if tt.wantMsg == "" {
+				if err != nil {
+					t.Fatalf("expected success, got %v", err)
+				}
+				if userCtx.UserID != "user-1" || userCtx.TenantID != "tenant-1" {
+					t.Fatalf("unexpected caller: %+v", userCtx)
+				}
+				return
+			}
+
+			st, _ := status.FromError(err)
+			if st.Code() != codes.Unauthenticated {
+				t.Fatalf("expected Unauthenticated, got %v", err)
+			}
+			if st.Message() != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, st.Message())
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorRejectsBadHeaders(t *testing.T) {
+	interceptor := newTestAuthInterceptor(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/GetTodo"}
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+
+	tests := map[string]context.Context{
+		"no metadata":     context.Background(),
+		"no header":       metadata.NewIncomingContext(context.Background(), metadata.Pairs()),
+		"not bearer":      metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc")),
+		"garbage token":   metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc")),
+		"invalid tenant":  metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signToken(t, testSecret, jwt.MapClaims{"user_id": "u", "tenant_id": "../etc", "exp": time.Now().Add(time.Hour).Unix()}))),
+		"wrong signature": metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signToken(t, "other-secret", validClaims()))),
+	}
+
+	for name, ctx := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := interceptor(ctx, nil, info, handler)
+			if status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("expected Unauthenticated, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorSkipsPublicMethods(t *testing.T) {
+	interceptor := newTestAuthInterceptor(t)
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.AuthService/Login"}
+
+	called := false
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	if err != nil || !called {
+		t.Fatalf("expected public method to reach the handler, got %v", err)
+	}
+}
+
+func TestAuthInterceptorAPIKeys(t *testing.T) {
+	keyFunc, err := auth.NewKeyfunc(auth.KeyConfig{Algorithm: "HS256", Secrets: []string{testSecret}})
+	if err != nil {
+		t.Fatalf("failed to build keyfunc: %v", err)
+	}
+	tenantIDs, err := auth.NewTenantIDValidator(auth.DefaultTenantIDPattern)
+	if err != nil {
+		t.Fatalf("failed to build tenant ID validator: %v", err)
+	}
+	apiKeys, err := auth.ParseStaticAPIKeyStore([]string{"svc-key:tenant-2:svc-1:user|manager", "bad-tenant-key:../etc:svc-2:user"})
+	if err != nil {
+		t.Fatalf("failed to build API key store: %v", err)
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/GetTodo"}
+
+	tests := []struct {
+		name     string
+		apiKeys  auth.APIKeyStore
+		md       metadata.MD
+		wantUser string
+		wantCode codes.Code
+	}{
+		{name: "service account", apiKeys: apiKeys, md: metadata.Pairs("x-api-key", "svc-key"), wantUser: "svc-1"},
+		{name: "unknown key", apiKeys: apiKeys, md: metadata.Pairs("x-api-key", "other-key"), wantCode: codes.Unauthenticated},
+		{name: "invalid tenant", apiKeys: apiKeys, md: metadata.Pairs("x-api-key", "bad-tenant-key"), wantCode: codes.Unauthenticated},
+		{
+			name:     "token takes precedence",
+			apiKeys:  apiKeys,
+			md:       metadata.Pairs("x-api-key", "svc-key", "authorization", "Bearer "+signToken(t, testSecret, validClaims())),
+			wantUser: "user-1",
+		},
+		{
+			name:     "invalid token does not fall back",
+			apiKeys:  apiKeys,
+			md:       metadata.Pairs("x-api-key", "svc-key", "authorization", "Bearer abc"),
+			wantCode: codes.Unauthenticated,
+		},
+		{name: "no key store", md: metadata.Pairs("x-api-key", "svc-key"), wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := AuthInterceptor(keyFunc, tt.apiKeys, tenantIDs)
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var userCtx *auth.UserContext
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				var err error
+				userCtx, err = auth.UserContextFromContext(ctx)
+				return nil, err
+			})
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("expected %v, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if userCtx.UserID != tt.wantUser {
+				t.Fatalf("UserID = %q, want %q", userCtx.UserID, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestStreamAuthInterceptorValidatesTenant(t *testing.T) {
+	keyFunc, err := auth.NewKeyfunc(auth.KeyConfig{Algorithm: "HS256", Secrets: []string{testSecret}})
+	if err != nil {
+		t.Fatalf("failed to build keyfunc: %v", err)
+	}
+	tenantIDs, err := auth.NewTenantIDValidator(auth.DefaultTenantIDPattern)
+	if err != nil {
+		t.Fatalf("failed to build tenant ID validator: %v", err)
+	}
+	interceptor := StreamAuthInterceptor(keyFunc, nil, tenantIDs)
+
+	malformed := validClaims()
+	malformed["tenant_id"] = "tenant 1; DROP"
+
+	tests := []struct {
+		name     string
+		claims   jwt.MapClaims
+		wantCode codes.Code
+	}{
+		{name: "valid tenant", claims: validClaims(), wantCode: codes.OK},
+		{name: "malformed tenant", claims: malformed, wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signToken(t, testSecret, tt.claims)))
+
+			var tenantID string
+			err := interceptor(nil, &fakeServerStream{ctx: ctx}, watchInfo, func(srv any, ss grpc.ServerStream) error {
+				userCtx, err := auth.UserContextFromContext(ss.Context())
+				if err != nil {
+					return err
+				}
+				tenantID = userCtx.TenantID
+				return nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected %v, got %v", tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && tenantID != "tenant-1" {
+				t.Fatalf("expected the handler to see tenant-1, got %q", tenantID)
+			}
+		})
+	}
+}
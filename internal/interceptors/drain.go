@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errServerDraining is the cancellation cause of streams asked to finish
+var errServerDraining = status.Error(codes.Unavailable, "server is shutting down")
+
+// StreamDrainer tracks open server streams so shutdown can ask them to finish
+// and wait for them. Long-running handlers should return when their context
+// is done; context.Cause then reports an Unavailable status.
+type StreamDrainer struct {
+	mu       sync.Mutex
+	draining bool
+	wg       sync.WaitGroup
+
+	drainCtx   context.Context
+	startDrain context.CancelFunc
+}
+
+func NewStreamDrainer() *StreamDrainer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StreamDrainer{
+		drainCtx:   ctx,
+		startDrain: cancel,
+	}
+}
+
+// StreamInterceptor registers each stream with the drainer and rejects new
+// streams once draining has started
+func (d *StreamDrainer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		d.mu.Lock()
+		if d.draining {
+			d.mu.Unlock()
+			return errServerDraining
+		}
+		d.wg.Add(1)
+		d.mu.Unlock()
+		defer d.wg.Done()
+
+		ctx, cancel := context.WithCancelCause(ss.Context())
+		defer cancel(nil)
+		stop := context.AfterFunc(d.drainCtx, func() { cancel(errServerDraining) })
+		defer stop()
+
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// Drain cancels every open stream and waits for their handlers to return,
+// or for ctx to be done, whichever comes first
+func (d *StreamDrainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+
+	d.startDrain()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
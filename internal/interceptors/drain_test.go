@@ -0,0 +1,101 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream is a server stream that only carries a context
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+var watchInfo = &grpc.StreamServerInfo{FullMethod: "/todo.v1.TodoService/WatchTodo", IsServerStream: true}
+
+// openStream runs a stream through the drainer in the background. started is
+// closed once the handler runs; ended receives the cause its context ended
+// with before it returns, unless it ignores draining and waits for release.
+func openStream(drainer *StreamDrainer, ignoreDrain bool, release <-chan struct{}) (started <-chan struct{}, ended <-chan error) {
+	startedCh, endedCh := make(chan struct{}), make(chan error, 1)
+	stream := &fakeServerStream{ctx: context.Background()}
+
+	go drainer.StreamInterceptor()(nil, stream, watchInfo, func(srv any, ss grpc.ServerStream) error {
+		close(startedCh)
+		if ignoreDrain {
+			<-release
+			endedCh <- nil
+			return nil
+		}
+		<-ss.Context().Done()
+		endedCh <- context.Cause(ss.Context())
+		return nil
+	})
+	return startedCh, endedCh
+}
+
+func TestStreamDrainerCancelsOpenStreams(t *testing.T) {
+	drainer := NewStreamDrainer()
+
+	started, ended := openStream(drainer, false, nil)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := drainer.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	// Drain only returns once the handler has
+	select {
+	case err := <-ended:
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("stream ended with %v, want Unavailable", err)
+		}
+	default:
+		t.Fatal("Drain returned before the stream handler")
+	}
+
+	err := drainer.StreamInterceptor()(nil, &fakeServerStream{ctx: context.Background()}, watchInfo, func(srv any, ss grpc.ServerStream) error {
+		t.Error("handler ran for a stream opened while draining")
+		return nil
+	})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("new stream while draining = %v, want Unavailable", err)
+	}
+}
+
+func TestStreamDrainerGivesUpAtDeadline(t *testing.T) {
+	drainer := NewStreamDrainer()
+
+	release := make(chan struct{})
+	started, ended := openStream(drainer, true, release)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := drainer.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Drain = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	if err := <-ended; err != nil {
+		t.Fatalf("stream ended with %v", err)
+	}
+}
+
+func TestStreamDrainerWithoutStreams(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := NewStreamDrainer().Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/dmehra2102/TaskForge/pkg/requestid"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
@@ -26,8 +28,13 @@ func LoggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 
 		requestID := getOrGenerateRequestID(ctx)
 
-		// Add request ID to context
+		// Add request ID to context, for outgoing calls and for handler logs
 		ctx = metadata.AppendToOutgoingContext(ctx, requestIDKey, requestID)
+		ctx = requestid.NewContext(ctx, requestID)
+
+		// Return it to the client so errors can be matched to these log lines.
+		// SetHeader only fails outside a real server transport.
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
 
 		// Log request
 		logger.Info("gRPC request started",
@@ -45,7 +52,7 @@ func LoggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 				zap.String("method", info.FullMethod),
 				zap.String("request_id", requestID),
 				zap.Duration("duration", duration),
-				zap.String("cpde", st.Code().String()),
+				zap.String("code", st.Code().String()),
 				zap.Error(err),
 			)
 		} else {
@@ -53,6 +60,7 @@ func LoggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 				zap.String("method", info.FullMethod),
 				zap.String("request_id", requestID),
 				zap.Duration("duration", duration),
+				zap.String("code", codes.OK.String()),
 			)
 		}
 
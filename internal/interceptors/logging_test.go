@@ -0,0 +1,156 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/dmehra2102/TaskForge/pkg/requestid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestLoggingInterceptorLogsCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		level   zapcore.Level
+		message string
+		code    string
+	}{
+		{name: "success", level: zapcore.InfoLevel, message: "gRPC request completed", code: "OK"},
+		{
+			name:    "failure",
+			err:     status.Error(codes.NotFound, "todo not found"),
+			level:   zapcore.ErrorLevel,
+			message: "gRPC request failed",
+			code:    "NotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			interceptor := LoggingInterceptor(zap.New(core))
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDKey, "req-1"))
+			info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/GetTodo"}
+
+			var handlerID string
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				handlerID, _ = requestid.FromContext(ctx)
+				return nil, tt.err
+			})
+			if err != tt.err {
+				t.Fatalf("interceptor error = %v, want %v", err, tt.err)
+			}
+			if handlerID != "req-1" {
+				t.Errorf("handler request ID = %q, want %q", handlerID, "req-1")
+			}
+
+			entries := logs.AllUntimed()
+			if len(entries) != 2 {
+				t.Fatalf("expected 2 log entries, got %d", len(entries))
+			}
+			if entries[0].Message != "gRPC request started" {
+				t.Errorf("first entry = %q, want the request start", entries[0].Message)
+			}
+
+			done := entries[1]
+			if done.Level != tt.level || done.Message != tt.message {
+				t.Errorf("last entry = %v %q, want %v %q", done.Level, done.Message, tt.level, tt.message)
+			}
+			fields := done.ContextMap()
+			if fields["code"] != tt.code {
+				t.Errorf("code = %v, want %q", fields["code"], tt.code)
+			}
+			if _, ok := fields["cpde"]; ok {
+				t.Error("entry still carries the misspelled cpde field")
+			}
+			if fields["method"] != info.FullMethod || fields["request_id"] != "req-1" {
+				t.Errorf("method, request_id = %v, %v, want %s, req-1", fields["method"], fields["request_id"], info.FullMethod)
+			}
+		})
+	}
+}
+
+func TestLoggingInterceptorGeneratesRequestID(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	interceptor := LoggingInterceptor(zap.New(core))
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/GetTodo"}
+
+	var handlerID string
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		handlerID, _ = requestid.FromContext(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	entries := logs.AllUntimed()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	started, done := entries[0].ContextMap()["request_id"], entries[1].ContextMap()["request_id"]
+	if started == "" || started != done {
+		t.Errorf("request IDs = %v, %v, want the same generated ID", started, done)
+	}
+	if handlerID != started {
+		t.Errorf("handler request ID = %q, want the logged %v", handlerID, started)
+	}
+}
+
+func TestLoggingInterceptorReturnsRequestIDHeader(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(LoggingInterceptor(zap.NewNop())))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	tests := []struct {
+		name    string
+		service string
+		code    codes.Code
+	}{
+		{name: "success", code: codes.OK},
+		// The health server answers NotFound for services it does not know
+		{name: "failure", service: "unknown", code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDKey, "req-"+tt.name)
+
+			var header metadata.MD
+			_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: tt.service}, grpc.Header(&header))
+			if status.Code(err) != tt.code {
+				t.Fatalf("Check error = %v, want %v", err, tt.code)
+			}
+			if got := header.Get(requestIDKey); len(got) != 1 || got[0] != "req-"+tt.name {
+				t.Errorf("%s header = %v, want [req-%s]", requestIDKey, got, tt.name)
+			}
+		})
+	}
+}
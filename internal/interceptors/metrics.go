@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -35,9 +36,31 @@ var (
 		},
 		[]string{"method"},
 	)
+
+	// Buckets span 64B up to the 4MB message size cap
+	grpcRequestBytes = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_bytes",
+			Help:    "Histogram of gRPC request payload sizes in bytes",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 9),
+		},
+		[]string{"method"},
+	)
+
+	grpcResponseBytes = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_response_bytes",
+			Help:    "Histogram of gRPC response payload sizes in bytes",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 9),
+		},
+		[]string{"method"},
+	)
 )
 
-func MetricsInterceptor() grpc.UnaryServerInterceptor {
+// MetricsInterceptor records request counts, durations and in-flight
+// requests. observePayloadSizes additionally records request and response
+// sizes, which costs an extra size computation per message.
+func MetricsInterceptor(observePayloadSizes bool) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req any,
@@ -49,8 +72,16 @@ func MetricsInterceptor() grpc.UnaryServerInterceptor {
 		grpcActiveRequests.WithLabelValues(info.FullMethod).Inc()
 		defer grpcActiveRequests.WithLabelValues(info.FullMethod).Dec()
 
+		if observePayloadSizes {
+			observePayloadSize(grpcRequestBytes, info.FullMethod, req)
+		}
+
 		resp, err = handler(ctx, req)
 
+		if observePayloadSizes && err == nil {
+			observePayloadSize(grpcResponseBytes, info.FullMethod, resp)
+		}
+
 		duration := time.Since(start).Seconds()
 		grpcRequestDuration.WithLabelValues(info.FullMethod).Observe(duration)
 
@@ -64,3 +95,9 @@ func MetricsInterceptor() grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+func observePayloadSize(histogram *prometheus.HistogramVec, method string, payload any) {
+	if msg, ok := payload.(proto.Message); ok {
+		histogram.WithLabelValues(method).Observe(float64(proto.Size(msg)))
+	}
+}
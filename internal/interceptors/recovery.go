@@ -4,12 +4,28 @@ import (
 	"context"
 	"runtime/debug"
 
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// errorIDKey is the response header carrying the ID a recovered panic was
+// logged under, so a client-reported error can be found in the logs
+const errorIDKey = "x-error-id"
+
+var grpcPanicsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "grpc_panics_total",
+		Help: "Total number of panics recovered from gRPC handlers",
+	},
+	[]string{"method"},
+)
+
 func RecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -19,11 +35,9 @@ func RecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	) (resp any, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.Error("panic recovered",
-					zap.String("method", info.FullMethod),
-					zap.Any("panic", r),
-					zap.String("stack", string(debug.Stack())),
-				)
+				errorID := logPanic(logger, info.FullMethod, r)
+				// Fails only if the handler already sent headers
+				_ = grpc.SetHeader(ctx, metadata.Pairs(errorIDKey, errorID))
 				err = status.Error(codes.Internal, "internal server error")
 			}
 		}()
@@ -31,3 +45,41 @@ func RecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// StreamRecoveryInterceptor is the streaming counterpart of RecoveryInterceptor
+func StreamRecoveryInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				errorID := logPanic(logger, info.FullMethod, r)
+				// Streams may have sent headers already; trailers always go out
+				if ss.SetHeader(metadata.Pairs(errorIDKey, errorID)) != nil {
+					ss.SetTrailer(metadata.Pairs(errorIDKey, errorID))
+				}
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// logPanic logs a recovered panic with its stack under a new error ID and
+// counts it. Only the ID is meant for the client.
+func logPanic(logger *zap.Logger, method string, r any) string {
+	errorID := uuid.New().String()
+	grpcPanicsTotal.WithLabelValues(method).Inc()
+
+	logger.Error("panic recovered",
+		zap.String("method", method),
+		zap.String("error_id", errorID),
+		zap.Any("panic", r),
+		zap.String("stack", string(debug.Stack())),
+	)
+	return errorID
+}
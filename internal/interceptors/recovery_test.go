@@ -0,0 +1,136 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// panickingHealthServer panics on Check unless asked about "ok"
+type panickingHealthServer struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (panickingHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.Service != "ok" {
+		panic("boom")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func TestRecoveryInterceptorReturnsErrorID(t *testing.T) {
+	const method = "/grpc.health.v1.Health/Check"
+	core, logs := observer.New(zapcore.ErrorLevel)
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(RecoveryInterceptor(zap.New(core))))
+	healthpb.RegisterHealthServer(server, panickingHealthServer{})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	panics := testutil.ToFloat64(grpcPanicsTotal.WithLabelValues(method))
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "ok"}); err != nil {
+		t.Fatalf("Check without a panic: %v", err)
+	}
+
+	var header metadata.MD
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() != "internal server error" {
+		t.Fatalf("Check error = %v, want a generic Internal error", err)
+	}
+
+	entries := logs.FilterMessage("panic recovered").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected one panic logged, got %d", len(entries))
+	}
+	logged := entries[0].ContextMap()
+	if got := header.Get(errorIDKey); len(got) != 1 || got[0] != logged["error_id"] {
+		t.Errorf("%s header = %v, want the logged error ID %v", errorIDKey, got, logged["error_id"])
+	}
+	if logged["method"] != method || logged["stack"] == "" {
+		t.Errorf("log fields = %v, want the method and stack", logged)
+	}
+	if got := testutil.ToFloat64(grpcPanicsTotal.WithLabelValues(method)) - panics; got != 1 {
+		t.Errorf("grpc_panics_total increased by %v, want 1", got)
+	}
+}
+
+// headerRecordingStream records the error ID a stream was sent, failing
+// SetHeader once headers are marked sent
+type headerRecordingStream struct {
+	fakeServerStream
+	headersSent     bool
+	header, trailer metadata.MD
+}
+
+func (s *headerRecordingStream) SetHeader(md metadata.MD) error {
+	if s.headersSent {
+		return errors.New("headers already sent")
+	}
+	s.header = md
+	return nil
+}
+
+func (s *headerRecordingStream) SetTrailer(md metadata.MD) { s.trailer = md }
+
+func TestStreamRecoveryInterceptorReturnsErrorID(t *testing.T) {
+	tests := []struct {
+		name        string
+		headersSent bool
+	}{
+		{name: "in header"},
+		{name: "in trailer once headers are sent", headersSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.ErrorLevel)
+			stream := &headerRecordingStream{fakeServerStream: fakeServerStream{ctx: context.Background()}, headersSent: tt.headersSent}
+
+			err := StreamRecoveryInterceptor(zap.New(core))(nil, stream, watchInfo, func(srv any, ss grpc.ServerStream) error {
+				panic("boom")
+			})
+			if status.Code(err) != codes.Internal {
+				t.Fatalf("interceptor error = %v, want Internal", err)
+			}
+
+			errorID := logs.All()[0].ContextMap()["error_id"]
+			sent, unused := stream.header, stream.trailer
+			if tt.headersSent {
+				sent, unused = unused, sent
+			}
+			if got := sent.Get(errorIDKey); len(got) != 1 || got[0] != errorID {
+				t.Errorf("%s = %v, want the logged error ID %v", errorIDKey, got, errorID)
+			}
+			if unused != nil {
+				t.Errorf("error ID sent twice: header %v, trailer %v", stream.header, stream.trailer)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ValidationInterceptor rejects requests breaking the field rules declared
+// in the proto files with InvalidArgument, listing every violated field,
+// before they reach the handler. Handlers and the domain still validate
+// everything the rules cannot express.
+func ValidationInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := validateMessage(msg); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidationInterceptor validates each message a client streams in
+// like ValidationInterceptor
+func StreamValidationInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &validatingServerStream{ServerStream: ss})
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return validateMessage(msg)
+	}
+	return nil
+}
+
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+func (v *fieldViolations) add(field, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+// validateMessage checks msg and the messages nested in it against their
+// field rules
+func validateMessage(msg proto.Message) error {
+	var v fieldViolations
+	collectViolations(msg.ProtoReflect(), "", &v)
+	if len(v) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Field + ": " + violation.Description
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(messages, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func collectViolations(m protoreflect.Message, prefix string, v *fieldViolations) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+
+		if rules, ok := proto.GetExtension(fd.Options(), todov1.E_Rules).(*todov1.FieldRules); ok && rules != nil {
+			checkField(m, fd, rules, path, v)
+		}
+
+		if fd.Kind() != protoreflect.MessageKind || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				collectViolations(list.Get(j).Message(), fmt.Sprintf("%s[%d].", path, j), v)
+			}
+			continue
+		}
+		collectViolations(m.Get(fd).Message(), path+".", v)
+	}
+}
+
+func checkField(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules *todov1.FieldRules, path string, v *fieldViolations) {
+	if fd.IsList() {
+		list := m.Get(fd).List()
+		if rules.MaxItems != nil && list.Len() > int(rules.GetMaxItems()) {
+			v.add(path, fmt.Sprintf("must have at most %d items", rules.GetMaxItems()))
+		}
+		for i := 0; i < list.Len(); i++ {
+			checkValue(fd, list.Get(i), rules, fmt.Sprintf("%s[%d]", path, i), v)
+		}
+		return
+	}
+
+	// An unset optional field has no value to check
+	if fd.HasPresence() && !m.Has(fd) {
+		return
+	}
+	checkValue(fd, m.Get(fd), rules, path, v)
+}
+
+func checkValue(fd protoreflect.FieldDescriptor, value protoreflect.Value, rules *todov1.FieldRules, path string, v *fieldViolations) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if rules.MaxLen != nil && utf8.RuneCountInString(value.String()) > int(rules.GetMaxLen()) {
+			v.add(path, fmt.Sprintf("must be at most %d characters", rules.GetMaxLen()))
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n := value.Int()
+		if rules.Gte != nil && n < rules.GetGte() {
+			v.add(path, fmt.Sprintf("must be at least %d", rules.GetGte()))
+		}
+		if rules.Lte != nil && n > rules.GetLte() {
+			v.add(path, fmt.Sprintf("must be at most %d", rules.GetLte()))
+		}
+	case protoreflect.EnumKind:
+		if rules.GetDefinedOnly() && fd.Enum().Values().ByNumber(value.Enum()) == nil {
+			v.add(path, "must be a defined value")
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package interceptors
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	todov1 "github.com/dmehra2102/TaskForge/api/proto/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// violations returns the fields named by the BadRequest detail of err
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	interceptor := ValidationInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.TodoService/CreateTodo"}
+
+	tests := []struct {
+		name   string
+		req    proto.Message
+		fields []string
+	}{
+		{name: "valid", req: &todov1.CreateTodoRequest{Title: "ok", Priority: todov1.TodoPriority_TODO_PRIORITY_LOW, Tags: []string{"a"}}},
+		{name: "unspecified enum is defined", req: &todov1.CreateTodoRequest{Title: "ok"}},
+		{
+			name: "every violated field",
+			req: &todov1.CreateTodoRequest{
+				Title:       strings.Repeat("x", 1001),
+				Description: strings.Repeat("x", 10001),
+				Priority:    99,
+			},
+			fields: []string{"title", "description", "priority"},
+		},
+		{name: "length counts characters", req: &todov1.CreateTodoRequest{Title: strings.Repeat("é", 1000)}},
+		{name: "too many tags", req: &todov1.CreateTodoRequest{Tags: make([]string, 101)}, fields: []string{"tags"}},
+		{name: "tag too long", req: &todov1.CreateTodoRequest{Tags: []string{"ok", strings.Repeat("x", 201)}}, fields: []string{"tags[1]"}},
+		{name: "range bounds", req: &todov1.ListTodosRequest{Page: -1, PageSize: 1001}, fields: []string{"page", "page_size"}},
+		{name: "enum list", req: &todov1.ListTodosRequest{StatusFilter: []todov1.TodoStatus{todov1.TodoStatus_TODO_STATUS_PENDING, 42}}, fields: []string{"status_filter[1]"}},
+		{name: "nested message", req: &todov1.UpdateTodoRequest{Todo: &todov1.Todo{Title: strings.Repeat("x", 1001)}}, fields: []string{"todo.title"}},
+		{
+			name: "repeated nested message",
+			req: &todov1.BatchCreateTodosRequest{Requests: []*todov1.CreateTodoRequest{
+				{Title: "ok"},
+				{Title: "ok", Priority: 99},
+			}},
+			fields: []string{"requests[1].priority"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := interceptor(context.Background(), tt.req, info, func(ctx context.Context, req any) (any, error) {
+				called = true
+				return nil, nil
+			})
+			if len(tt.fields) == 0 {
+				if err != nil || !called {
+					t.Fatalf("expected the request passed on, got %v", err)
+				}
+				return
+			}
+			if called {
+				t.Fatal("expected the handler not to run")
+			}
+			if fields := violations(t, err); !slices.Equal(fields, tt.fields) {
+				t.Fatalf("violated fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+// recvStream is a server stream that receives req
+type recvStream struct {
+	fakeServerStream
+	req proto.Message
+}
+
+func (s *recvStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestStreamValidationInterceptor(t *testing.T) {
+	interceptor := StreamValidationInterceptor()
+
+	tests := []struct {
+		name     string
+		req      proto.Message
+		wantCode codes.Code
+	}{
+		{name: "valid", req: &todov1.ListTodosRequest{PageSize: 10}, wantCode: codes.OK},
+		{name: "invalid", req: &todov1.ListTodosRequest{PageSize: 1001}, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &recvStream{fakeServerStream: fakeServerStream{ctx: context.Background()}, req: tt.req}
+			err := interceptor(nil, stream, watchInfo, func(srv any, ss grpc.ServerStream) error {
+				return ss.RecvMsg(&todov1.ListTodosRequest{})
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("expected %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
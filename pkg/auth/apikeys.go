@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnknownAPIKey = errors.New("unknown API key")
+
+// APIKeyStore resolves API keys to the service account they authenticate,
+// returning ErrUnknownAPIKey for keys it does not know
+type APIKeyStore interface {
+	LookupAPIKey(ctx context.Context, key string) (*UserContext, error)
+}
+
+// StaticAPIKeyStore holds a fixed set of keys, e.g. seeded from config. Keys
+// are kept and looked up by their SHA-256 digest only.
+type StaticAPIKeyStore struct {
+	accounts map[[sha256.Size]byte]UserContext
+}
+
+func NewStaticAPIKeyStore() *StaticAPIKeyStore {
+	return &StaticAPIKeyStore{accounts: make(map[[sha256.Size]byte]UserContext)}
+}
+
+// ParseStaticAPIKeyStore builds a store from entries of the form
+// key:tenant_id:account_id:role1|role2
+func ParseStaticAPIKeyStore(entries []string) (*StaticAPIKeyStore, error) {
+	store := NewStaticAPIKeyStore()
+	for i, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("API key entry %d must have the form key:tenant_id:account_id:roles", i)
+		}
+
+		var roles []string
+		for _, role := range strings.Split(parts[3], "|") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+
+		store.Add(parts[0], UserContext{
+			UserID:   parts[2],
+			TenantID: parts[1],
+			Roles:    roles,
+		})
+	}
+	return store, nil
+}
+
+// Add registers key for the given service account
+func (s *StaticAPIKeyStore) Add(key string, account UserContext) {
+	s.accounts[sha256.Sum256([]byte(key))] = account
+}
+
+func (s *StaticAPIKeyStore) LookupAPIKey(ctx context.Context, key string) (*UserContext, error) {
+	account, ok := s.accounts[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, ErrUnknownAPIKey
+	}
+
+	// Callers get their own copy so the store cannot be modified through it
+	account.Roles = append([]string(nil), account.Roles...)
+	return &account, nil
+}
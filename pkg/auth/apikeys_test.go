@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseStaticAPIKeyStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		wantErr bool
+	}{
+		{name: "no entries"},
+		{name: "valid entries", entries: []string{"key-1:tenant-1:svc-1:user|manager", "key-2:tenant-2:svc-2:"}},
+		{name: "missing roles part", entries: []string{"key-1:tenant-1:svc-1"}, wantErr: true},
+		{name: "too many parts", entries: []string{"key-1:tenant-1:svc-1:user:extra"}, wantErr: true},
+		{name: "empty key", entries: []string{":tenant-1:svc-1:user"}, wantErr: true},
+		{name: "empty tenant", entries: []string{"key-1::svc-1:user"}, wantErr: true},
+		{name: "empty account", entries: []string{"key-1:tenant-1::user"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStaticAPIKeyStore(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStaticAPIKeyStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaticAPIKeyStoreLookup(t *testing.T) {
+	store, err := ParseStaticAPIKeyStore([]string{"key-1:tenant-1:svc-1: user | manager ||", "key-2:tenant-2:svc-2:"})
+	if err != nil {
+		t.Fatalf("ParseStaticAPIKeyStore: %v", err)
+	}
+	ctx := context.Background()
+
+	account, err := store.LookupAPIKey(ctx, "key-1")
+	if err != nil {
+		t.Fatalf("LookupAPIKey: %v", err)
+	}
+	if account.UserID != "svc-1" || account.TenantID != "tenant-1" || !slices.Equal(account.Roles, []string{"user", "manager"}) {
+		t.Fatalf("account = %+v, want svc-1 in tenant-1 with user and manager", account)
+	}
+
+	if account, err := store.LookupAPIKey(ctx, "key-2"); err != nil || len(account.Roles) != 0 {
+		t.Fatalf("LookupAPIKey(key-2) = %+v, %v, want an account without roles", account, err)
+	}
+
+	for _, key := range []string{"", "key-3", "KEY-1"} {
+		if _, err := store.LookupAPIKey(ctx, key); !errors.Is(err, ErrUnknownAPIKey) {
+			t.Errorf("LookupAPIKey(%q) error = %v, want ErrUnknownAPIKey", key, err)
+		}
+	}
+}
+
+func TestStaticAPIKeyStoreReturnsCopies(t *testing.T) {
+	store := NewStaticAPIKeyStore()
+	store.Add("key-1", UserContext{UserID: "svc-1", TenantID: "tenant-1", Roles: []string{"user"}})
+
+	account, err := store.LookupAPIKey(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("LookupAPIKey: %v", err)
+	}
+	account.Roles[0] = "admin"
+	account.TenantID = "tenant-2"
+
+	again, err := store.LookupAPIKey(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("LookupAPIKey: %v", err)
+	}
+	if again.TenantID != "tenant-1" || !slices.Equal(again.Roles, []string{"user"}) {
+		t.Fatalf("account = %+v, want the store unchanged", again)
+	}
+}
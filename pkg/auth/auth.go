@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"errors"
-	"slices"
 
 	"github.com/dmehra2102/TaskForge/internal/domain"
 )
@@ -32,64 +31,136 @@ func UserContextFromContext(ctx context.Context) (*UserContext, error) {
 	return userCtx, nil
 }
 
-type Authorizer struct{}
+// Authorizer decides what a caller may do from the permissions their roles
+// grant, confined to the caller's tenant and, for permissions without an
+// _all variant held, to the todos they own or are assigned
+type Authorizer struct {
+	roles RolePermissions
+}
 
+// NewAuthorizer creates an authorizer using DefaultRolePermissions
 func NewAuthorizer() *Authorizer {
-	return &Authorizer{}
+	return NewAuthorizerWithRoles(DefaultRolePermissions())
 }
 
-func (a *Authorizer) CanCreate(userCtx *UserContext) bool {
-	return hasRole(userCtx, "user") || hasRole(userCtx, "admin")
+// NewAuthorizerWithRoles creates an authorizer granting permissions by roles
+func NewAuthorizerWithRoles(roles RolePermissions) *Authorizer {
+	return &Authorizer{roles: roles}
 }
 
-func (a *Authorizer) CanRead(userCtx *UserContext, todo *domain.Todo) bool {
-	if hasRole(userCtx, "admin") && userCtx.TenantID == todo.TenantID {
-		return true
-	}
+// CanActOnTenant reports whether the caller may act on todos of tenantID at
+// all. It needs no todo, so requests naming another tenant can be refused
+// before anything is read.
+func (a *Authorizer) CanActOnTenant(userCtx *UserContext, tenantID string) bool {
+	return userCtx.TenantID == tenantID
+}
 
-	// Users can read their own todos or todos assigned to them
-	if userCtx.TenantID == todo.TenantID {
-		if todo.OwnerID == userCtx.UserID {
-			return true
-		}
-		if todo.AssignedTo != nil && *todo.AssignedTo == userCtx.UserID {
-			return true
-		}
-	}
+func (a *Authorizer) CanCreate(userCtx *UserContext) bool {
+	return a.has(userCtx, PermTodoCreate)
+}
 
-	return false
+func (a *Authorizer) CanRead(userCtx *UserContext, todo *domain.Todo) bool {
+	return a.canActOn(userCtx, todo, PermTodoRead, PermTodoReadAll)
 }
 
 func (a *Authorizer) CanUpdate(userCtx *UserContext, todo *domain.Todo) bool {
-	if hasRole(userCtx, "admin") && userCtx.TenantID == todo.TenantID {
+	return a.canActOn(userCtx, todo, PermTodoUpdate, PermTodoUpdateAll)
+}
+
+// CanReassign allows changing who a todo is assigned to. Besides those who
+// may update the todo, managers may reassign any todo of their tenant.
+func (a *Authorizer) CanReassign(userCtx *UserContext, todo *domain.Todo) bool {
+	if a.CanUpdate(userCtx, todo) {
 		return true
 	}
+	return a.has(userCtx, PermTodoReassign) && a.CanActOnTenant(userCtx, todo.TenantID)
+}
 
-	if userCtx.TenantID == todo.TenantID {
-		if todo.OwnerID == userCtx.UserID {
-			return true
-		}
-		if todo.AssignedTo != nil && *todo.AssignedTo == userCtx.UserID {
-			return true
-		}
+// CanTransferOwnership allows the owner of a todo, or a caller who may
+// transfer any todo of the tenant, to hand the todo to another owner
+func (a *Authorizer) CanTransferOwnership(userCtx *UserContext, todo *domain.Todo) bool {
+	if !a.CanActOnTenant(userCtx, todo.TenantID) {
+		return false
 	}
-
-	return false
+	if a.has(userCtx, PermTodoTransferAll) {
+		return true
+	}
+	return a.has(userCtx, PermTodoUpdate) && todo.OwnerID == userCtx.UserID
 }
 
+// CanDelete allows owners, but not assignees, to delete their todos
 func (a *Authorizer) CanDelete(userCtx *UserContext, todo *domain.Todo) bool {
-	if hasRole(userCtx, "admin") && userCtx.TenantID == todo.TenantID {
+	if !a.CanActOnTenant(userCtx, todo.TenantID) {
+		return false
+	}
+	if a.has(userCtx, PermTodoDeleteAll) {
 		return true
 	}
+	return a.has(userCtx, PermTodoDelete) && todo.OwnerID == userCtx.UserID
+}
 
-	// Only owners can delete their todos
-	return userCtx.TenantID == todo.TenantID && todo.OwnerID == userCtx.UserID
+// CanDeleteComment allows the comment's author or a tenant admin to delete it
+func (a *Authorizer) CanDeleteComment(userCtx *UserContext, comment *domain.Comment) bool {
+	if !a.CanActOnTenant(userCtx, comment.TenantID) {
+		return false
+	}
+	return a.has(userCtx, PermTodoDeleteAll) || comment.AuthorID == userCtx.UserID
 }
 
 func (a *Authorizer) CanReadAll(userCtx *UserContext) bool {
-	return hasRole(userCtx, "admin")
+	return a.has(userCtx, PermTodoReadAll)
 }
 
-func hasRole(userCtx *UserContext, role string) bool {
-	return slices.Contains(userCtx.Roles, role)
+// CanDeleteAll allows deleting todos owned by others in the tenant
+func (a *Authorizer) CanDeleteAll(userCtx *UserContext) bool {
+	return a.has(userCtx, PermTodoDeleteAll)
+}
+
+// CanReadDeleted allows fetching soft-deleted todos, e.g. to investigate a deletion
+func (a *Authorizer) CanReadDeleted(userCtx *UserContext) bool {
+	return a.has(userCtx, PermTodoReadDeleted)
+}
+
+// CanHardDelete allows removing todos for good rather than soft-deleting them
+func (a *Authorizer) CanHardDelete(userCtx *UserContext) bool {
+	return a.has(userCtx, PermTodoHardDelete)
+}
+
+// CanViewWorkload allows viewing the tenant's todo counts per assignee
+func (a *Authorizer) CanViewWorkload(userCtx *UserContext) bool {
+	return a.has(userCtx, PermWorkloadRead)
+}
+
+// CanInspectQueries allows viewing the SQL generated for a filter
+func (a *Authorizer) CanInspectQueries(userCtx *UserContext) bool {
+	return a.has(userCtx, PermQueryInspect)
+}
+
+func (a *Authorizer) CanBypassQuota(userCtx *UserContext) bool {
+	return a.has(userCtx, PermTenantQuotaBypass)
+}
+
+// CanMoveTenants allows moving todos from any tenant to any other
+func (a *Authorizer) CanMoveTenants(userCtx *UserContext) bool {
+	return a.has(userCtx, PermTenantMove)
+}
+
+func (a *Authorizer) has(userCtx *UserContext, permission Permission) bool {
+	return a.roles.grants(userCtx.Roles, permission)
+}
+
+// canActOn allows acting on any todo of the caller's tenant with all, or on
+// the todos they own or are assigned with own
+func (a *Authorizer) canActOn(userCtx *UserContext, todo *domain.Todo, own, all Permission) bool {
+	if !a.CanActOnTenant(userCtx, todo.TenantID) {
+		return false
+	}
+	if a.has(userCtx, all) {
+		return true
+	}
+	if !a.has(userCtx, own) {
+		return false
+	}
+	return todo.OwnerID == userCtx.UserID ||
+		(todo.AssignedTo != nil && *todo.AssignedTo == userCtx.UserID)
 }
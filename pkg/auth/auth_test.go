@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dmehra2102/TaskForge/internal/domain"
+)
+
+func TestCanReassign(t *testing.T) {
+	authz := NewAuthorizer()
+	assignee := "carol"
+	todo := &domain.Todo{OwnerID: "alice", AssignedTo: &assignee, TenantID: "tenant-a"}
+
+	tests := []struct {
+		name   string
+		caller UserContext
+		want   bool
+	}{
+		{name: "owner", caller: UserContext{UserID: "alice", TenantID: "tenant-a", Roles: []string{"user"}}, want: true},
+		{name: "assignee", caller: UserContext{UserID: "carol", TenantID: "tenant-a", Roles: []string{"user"}}, want: true},
+		{name: "other user", caller: UserContext{UserID: "bob", TenantID: "tenant-a", Roles: []string{"user"}}},
+		{name: "manager", caller: UserContext{UserID: "mia", TenantID: "tenant-a", Roles: []string{"manager"}}, want: true},
+		{name: "manager of another tenant", caller: UserContext{UserID: "mia", TenantID: "tenant-b", Roles: []string{"manager"}}},
+		{name: "admin", caller: UserContext{UserID: "root", TenantID: "tenant-a", Roles: []string{"admin"}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authz.CanReassign(&tt.caller, todo); got != tt.want {
+				t.Errorf("CanReassign() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Reassigning does not let a manager edit anything else
+	manager := &UserContext{UserID: "mia", TenantID: "tenant-a", Roles: []string{"manager"}}
+	if authz.CanUpdate(manager, todo) {
+		t.Error("manager may update the todo")
+	}
+}
+
+func TestDefaultRolePermissions(t *testing.T) {
+	authz := NewAuthorizer()
+	assignee := "carol"
+	todo := &domain.Todo{OwnerID: "alice", AssignedTo: &assignee, TenantID: "tenant-a"}
+
+	caller := func(userID, tenantID string, roles ...string) *UserContext {
+		return &UserContext{UserID: userID, TenantID: tenantID, Roles: roles}
+	}
+
+	tests := []struct {
+		name                      string
+		caller                    *UserContext
+		read, update, del, create bool
+	}{
+		{name: "owner", caller: caller("alice", "tenant-a", "user"), read: true, update: true, del: true, create: true},
+		{name: "assignee", caller: caller("carol", "tenant-a", "user"), read: true, update: true, create: true},
+		{name: "other user", caller: caller("bob", "tenant-a", "user"), create: true},
+		{name: "admin", caller: caller("root", "tenant-a", "admin"), read: true, update: true, del: true, create: true},
+		{name: "admin of another tenant", caller: caller("root", "tenant-b", "admin"), create: true},
+		{name: "owner in another tenant", caller: caller("alice", "tenant-b", "user"), create: true},
+		{name: "unknown role", caller: caller("alice", "tenant-a", "guest")},
+		{name: "no roles", caller: caller("alice", "tenant-a")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authz.CanRead(tt.caller, todo); got != tt.read {
+				t.Errorf("CanRead() = %v, want %v", got, tt.read)
+			}
+			if got := authz.CanUpdate(tt.caller, todo); got != tt.update {
+				t.Errorf("CanUpdate() = %v, want %v", got, tt.update)
+			}
+			if got := authz.CanDelete(tt.caller, todo); got != tt.del {
+				t.Errorf("CanDelete() = %v, want %v", got, tt.del)
+			}
+			if got := authz.CanCreate(tt.caller); got != tt.create {
+				t.Errorf("CanCreate() = %v, want %v", got, tt.create)
+			}
+		})
+	}
+}
+
+func TestAuthorizerWithCustomRoles(t *testing.T) {
+	authz := NewAuthorizerWithRoles(RolePermissions{
+		"auditor": {PermTodoReadAll, PermTodoReadDeleted},
+		"editor":  {PermTodoUpdate},
+	})
+	todo := &domain.Todo{OwnerID: "alice", TenantID: "tenant-a"}
+
+	auditor := &UserContext{UserID: "audra", TenantID: "tenant-a", Roles: []string{"auditor"}}
+	if !authz.CanRead(auditor, todo) || !authz.CanReadAll(auditor) || !authz.CanReadDeleted(auditor) {
+		t.Error("auditor may not read the tenant's todos")
+	}
+	if authz.CanUpdate(auditor, todo) || authz.CanCreate(auditor) || authz.CanHardDelete(auditor) {
+		t.Error("auditor holds a permission its role does not grant")
+	}
+
+	// Roles not in the mapping grant nothing, even with built-in names
+	admin := &UserContext{UserID: "root", TenantID: "tenant-a", Roles: []string{"admin"}}
+	if authz.CanRead(admin, todo) || authz.CanDeleteAll(admin) {
+		t.Error("unmapped admin role granted permissions")
+	}
+
+	// Permissions of several roles add up
+	owner := &UserContext{UserID: "alice", TenantID: "tenant-a", Roles: []string{"editor", "auditor"}}
+	if !authz.CanUpdate(owner, todo) || !authz.CanRead(owner, todo) {
+		t.Error("permissions of several roles were not combined")
+	}
+	if editor := (&UserContext{UserID: "ed", TenantID: "tenant-a", Roles: []string{"editor"}}); authz.CanUpdate(editor, todo) {
+		t.Error("own-todo permission extended to another user's todo")
+	}
+}
+
+func TestCanTransferOwnership(t *testing.T) {
+	authz := NewAuthorizer()
+	assignee := "carol"
+	todo := &domain.Todo{OwnerID: "alice", AssignedTo: &assignee, TenantID: "tenant-a"}
+
+	tests := []struct {
+		name   string
+		caller UserContext
+		want   bool
+	}{
+		{name: "owner", caller: UserContext{UserID: "alice", TenantID: "tenant-a", Roles: []string{"user"}}, want: true},
+		{name: "assignee", caller: UserContext{UserID: "carol", TenantID: "tenant-a", Roles: []string{"user"}}},
+		{name: "manager", caller: UserContext{UserID: "mia", TenantID: "tenant-a", Roles: []string{"manager"}}},
+		{name: "admin", caller: UserContext{UserID: "root", TenantID: "tenant-a", Roles: []string{"admin"}}, want: true},
+		{name: "admin of another tenant", caller: UserContext{UserID: "root", TenantID: "tenant-b", Roles: []string{"admin"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authz.CanTransferOwnership(&tt.caller, todo); got != tt.want {
+				t.Errorf("CanTransferOwnership() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanMoveTenants(t *testing.T) {
+	authz := NewAuthorizer()
+
+	tests := []struct {
+		roles []string
+		want  bool
+	}{
+		{roles: []string{"user"}},
+		{roles: []string{"manager"}},
+		{roles: []string{"admin"}},
+		{roles: []string{"platform_admin"}, want: true},
+		{roles: []string{"user", "platform_admin"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.roles, "+"), func(t *testing.T) {
+			caller := &UserContext{UserID: "root", TenantID: "tenant-a", Roles: tt.roles}
+			if got := authz.CanMoveTenants(caller); got != tt.want {
+				t.Errorf("CanMoveTenants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanViewWorkload(t *testing.T) {
+	authz := NewAuthorizer()
+
+	for role, want := range map[string]bool{"user": false, "manager": true, "admin": true, "platform_admin": false} {
+		t.Run(role, func(t *testing.T) {
+			caller := &UserContext{UserID: "u", TenantID: "tenant-a", Roles: []string{role}}
+			if got := authz.CanViewWorkload(caller); got != want {
+				t.Errorf("CanViewWorkload() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// Token types, carried in the token_type claim. Tokens without the claim are
+// treated as access tokens, so externally minted tokens keep working.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	TokenTypeClaim = "token_type"
+)
+
+// CredentialVerifier checks a user's credentials and returns who they are,
+// or ErrInvalidCredentials when they do not match
+type CredentialVerifier interface {
+	VerifyCredentials(ctx context.Context, tenantID, username, password string) (*UserContext, error)
+}
+
+// IssuerConfig describes how tokens are signed and how long they live
+type IssuerConfig struct {
+	// Algorithm is the HMAC signing algorithm, HS256 by default
+	Algorithm  string
+	Secret     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// TokenPair is an access token together with the refresh token that renews it
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// TokenIssuer signs access and refresh tokens for authenticated users
+type TokenIssuer struct {
+	method     jwt.SigningMethod
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+
+	// keyFunc verifies refresh tokens, accepting the same keys as requests do
+	keyFunc Keyfunc
+}
+
+// NewTokenIssuer creates an issuer signing with cfg.Secret. Only HMAC
+// algorithms are supported since asymmetric deployments mint tokens elsewhere.
+func NewTokenIssuer(cfg IssuerConfig, keyFunc Keyfunc) (*TokenIssuer, error) {
+	var method jwt.SigningMethod
+	switch strings.ToUpper(cfg.Algorithm) {
+	case "", "HS256":
+		method = jwt.SigningMethodHS256
+	case "HS384":
+		method = jwt.SigningMethodHS384
+	case "HS512":
+		method = jwt.SigningMethodHS512
+	default:
+		return nil, fmt.Errorf("token issuance requires an HMAC algorithm, got %s", cfg.Algorithm)
+	}
+
+	if cfg.Secret == "" {
+		return nil, errors.New("token issuance requires a signing secret")
+	}
+	if cfg.AccessTTL <= 0 || cfg.RefreshTTL < cfg.AccessTTL {
+		return nil, fmt.Errorf("token lifetimes must satisfy 0 < access (%s) <= refresh (%s)",
+			cfg.AccessTTL, cfg.RefreshTTL)
+	}
+
+	return &TokenIssuer{
+		method:     method,
+		secret:     []byte(cfg.Secret),
+		accessTTL:  cfg.AccessTTL,
+		refreshTTL: cfg.RefreshTTL,
+		keyFunc:    keyFunc,
+	}, nil
+}
+
+// Issue signs a new token pair for userCtx
+func (i *TokenIssuer) Issue(userCtx *UserContext) (*TokenPair, error) {
+	now := time.Now()
+	pair := &TokenPair{
+		AccessExpiresAt:  now.Add(i.accessTTL),
+		RefreshExpiresAt: now.Add(i.refreshTTL),
+	}
+
+	var err error
+	pair.AccessToken, err = i.sign(userCtx, TokenTypeAccess, now, pair.AccessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	pair.RefreshToken, err = i.sign(userCtx, TokenTypeRefresh, now, pair.RefreshExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Refresh verifies a refresh token and issues a new pair for the same user
+func (i *TokenIssuer) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	token, err := jwt.Parse(refreshToken, i.keyFunc.WithContext(ctx), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+	if tokenType, _ := claims[TokenTypeClaim].(string); tokenType != TokenTypeRefresh {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	userID, _ := claims["user_id"].(string)
+	tenantID, _ := claims["tenant_id"].(string)
+	if userID == "" || tenantID == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var roles []string
+	if rawRoles, ok := claims["roles"].([]any); ok {
+		for _, role := range rawRoles {
+			if roleStr, ok := role.(string); ok {
+				roles = append(roles, roleStr)
+			}
+		}
+	}
+
+	return i.Issue(&UserContext{
+		UserID:   userID,
+		TenantID: tenantID,
+		Roles:    roles,
+	})
+}
+
+func (i *TokenIssuer) sign(userCtx *UserContext, tokenType string, issuedAt, expiresAt time.Time) (string, error) {
+	roles := userCtx.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	claims := jwt.MapClaims{
+		"sub":          userCtx.UserID,
+		"user_id":      userCtx.UserID,
+		"tenant_id":    userCtx.TenantID,
+		"roles":        roles,
+		TokenTypeClaim: tokenType,
+		"iat":          issuedAt.Unix(),
+		"exp":          expiresAt.Unix(),
+	}
+
+	signed, err := jwt.NewWithClaims(i.method, claims).SignedString(i.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s token: %w", tokenType, err)
+	}
+	return signed, nil
+}
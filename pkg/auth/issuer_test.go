@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestIssuer(t *testing.T, secret string) *TokenIssuer {
+	t.Helper()
+	keyFunc, err := NewKeyfunc(KeyConfig{Algorithm: "HS256", Secrets: []string{secret}})
+	if err != nil {
+		t.Fatalf("NewKeyfunc: %v", err)
+	}
+	issuer, err := NewTokenIssuer(IssuerConfig{Secret: secret, AccessTTL: time.Minute, RefreshTTL: time.Hour}, keyFunc)
+	if err != nil {
+		t.Fatalf("NewTokenIssuer: %v", err)
+	}
+	return issuer
+}
+
+func TestNewTokenIssuerValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     IssuerConfig
+		wantErr bool
+	}{
+		{name: "default algorithm", cfg: IssuerConfig{Secret: "s", AccessTTL: time.Minute, RefreshTTL: time.Hour}},
+		{name: "HS512", cfg: IssuerConfig{Algorithm: "hs512", Secret: "s", AccessTTL: time.Minute, RefreshTTL: time.Minute}},
+		{name: "asymmetric algorithm", cfg: IssuerConfig{Algorithm: "RS256", Secret: "s", AccessTTL: time.Minute, RefreshTTL: time.Hour}, wantErr: true},
+		{name: "no secret", cfg: IssuerConfig{AccessTTL: time.Minute, RefreshTTL: time.Hour}, wantErr: true},
+		{name: "no access lifetime", cfg: IssuerConfig{Secret: "s", RefreshTTL: time.Hour}, wantErr: true},
+		{name: "refresh shorter than access", cfg: IssuerConfig{Secret: "s", AccessTTL: time.Hour, RefreshTTL: time.Minute}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTokenIssuer(tt.cfg, nil); (err != nil) != tt.wantErr {
+				t.Fatalf("NewTokenIssuer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenIssuerIssuesAndRefreshes(t *testing.T) {
+	issuer := newTestIssuer(t, "issuer-secret")
+	user := &UserContext{UserID: "alice", TenantID: "tenant-a", Roles: []string{"user", "manager"}}
+
+	pair, err := issuer.Issue(user)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !pair.RefreshExpiresAt.After(pair.AccessExpiresAt) {
+		t.Errorf("refresh token expires at %v, before the access token at %v", pair.RefreshExpiresAt, pair.AccessExpiresAt)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(pair.AccessToken, claims, func(*jwt.Token) (any, error) { return []byte("issuer-secret"), nil }); err != nil {
+		t.Fatalf("parse access token: %v", err)
+	}
+	if claims["user_id"] != "alice" || claims["tenant_id"] != "tenant-a" || claims[TokenTypeClaim] != TokenTypeAccess {
+		t.Errorf("access token claims = %v", claims)
+	}
+
+	refreshed, err := issuer.Refresh(context.Background(), pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	claims = jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(refreshed.AccessToken, claims, func(*jwt.Token) (any, error) { return []byte("issuer-secret"), nil }); err != nil {
+		t.Fatalf("parse refreshed access token: %v", err)
+	}
+	var roles []string
+	for _, role := range claims["roles"].([]any) {
+		roles = append(roles, role.(string))
+	}
+	if claims["user_id"] != "alice" || !slices.Equal(roles, user.Roles) {
+		t.Errorf("refreshed claims = %v, want alice with roles %v", claims, user.Roles)
+	}
+}
+
+func TestTokenIssuerRejectsInvalidRefreshTokens(t *testing.T) {
+	issuer := newTestIssuer(t, "issuer-secret")
+	pair, err := issuer.Issue(&UserContext{UserID: "alice", TenantID: "tenant-a"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	foreign, err := newTestIssuer(t, "other-secret").Issue(&UserContext{UserID: "alice", TenantID: "tenant-a"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":      "alice",
+		"tenant_id":    "tenant-a",
+		TokenTypeClaim: TokenTypeRefresh,
+		"exp":          time.Now().Add(-time.Minute).Unix(),
+	}).SignedString([]byte("issuer-secret"))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	tests := map[string]string{
+		"access token": pair.AccessToken,
+		"other secret": foreign.RefreshToken,
+		"expired":      expired,
+		"not a token":  "garbage",
+		"empty":        "",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := issuer.Refresh(context.Background(), token); !errors.Is(err, ErrInvalidRefreshToken) {
+				t.Fatalf("Refresh() error = %v, want %v", err, ErrInvalidRefreshToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	ErrInvalidSigningMethod = errors.New("invalid token signing method")
+	ErrUnknownKey           = errors.New("no verification key found for token")
+)
+
+const (
+	jwksRefreshInterval = 5 * time.Minute
+	jwksFetchTimeout    = 10 * time.Second
+
+	// jwksMinRefreshInterval bounds how often unknown key ids can force a
+	// fetch, so tokens with made-up kids cannot hammer the JWKS endpoint
+	jwksMinRefreshInterval = 30 * time.Second
+)
+
+// KeyConfig describes how incoming JWTs are verified.
+type KeyConfig struct {
+	// Algorithm selects the accepted signing family, e.g. HS256, RS256 or ES256
+	Algorithm string
+
+	// Secrets are the HMAC secrets accepted in HS* mode, primary first
+	Secrets []string
+
+	// PublicKeyPath is a PEM-encoded public key used in RS*/ES* mode
+	PublicKeyPath string
+
+	// JWKSURL is a JWKS endpoint used in RS*/ES* mode, taking precedence over PublicKeyPath
+	JWKSURL string
+}
+
+// Keyfunc resolves the verification key for a token. ctx bounds any network
+// lookup the resolution needs, such as a JWKS refresh.
+type Keyfunc func(ctx context.Context, token *jwt.Token) (any, error)
+
+// WithContext binds ctx to f so it can be handed to jwt.Parse.
+func (f Keyfunc) WithContext(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		return f(ctx, token)
+	}
+}
+
+type keyFamily int
+
+const (
+	familyHMAC keyFamily = iota + 1
+	familyRSA
+	familyECDSA
+)
+
+// NewKeyfunc builds a Keyfunc that only accepts tokens signed with the
+// configured algorithm family and resolves the matching verification key.
+func NewKeyfunc(cfg KeyConfig) (Keyfunc, error) {
+	family, err := algorithmFamily(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolve Keyfunc
+	switch {
+	case family == familyHMAC:
+		keys := make([]jwt.VerificationKey, 0, len(cfg.Secrets))
+		for _, secret := range cfg.Secrets {
+			if secret != "" {
+				keys = append(keys, []byte(secret))
+			}
+		}
+		keySet := jwt.VerificationKeySet{Keys: keys}
+		resolve = func(context.Context, *jwt.Token) (any, error) {
+			return keySet, nil
+		}
+	case cfg.JWKSURL != "":
+		resolve = newJWKSCache(cfg.JWKSURL, family).keyfunc
+	case cfg.PublicKeyPath != "":
+		key, err := loadPublicKey(cfg.PublicKeyPath, family)
+		if err != nil {
+			return nil, err
+		}
+		resolve = func(context.Context, *jwt.Token) (any, error) {
+			return key, nil
+		}
+	default:
+		return nil, fmt.Errorf("%s requires a public key path or JWKS URL", cfg.Algorithm)
+	}
+
+	return func(ctx context.Context, token *jwt.Token) (any, error) {
+		if !family.accepts(token.Method) {
+			return nil, ErrInvalidSigningMethod
+		}
+		return resolve(ctx, token)
+	}, nil
+}
+
+func algorithmFamily(algorithm string) (keyFamily, error) {
+	switch strings.ToUpper(algorithm) {
+	case "", "HS256", "HS384", "HS512":
+		return familyHMAC, nil
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		return familyRSA, nil
+	case "ES256", "ES384", "ES512":
+		return familyECDSA, nil
+	default:
+		return 0, fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+}
+
+func (f keyFamily) accepts(method jwt.SigningMethod) bool {
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return f == familyHMAC
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return f == familyRSA
+	case *jwt.SigningMethodECDSA:
+		return f == familyECDSA
+	default:
+		return false
+	}
+}
+
+func loadPublicKey(path string, family keyFamily) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	switch family {
+	case familyRSA:
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA public key: %w", err)
+		}
+		return key, nil
+	case familyECDSA:
+		key, err := jwt.ParseECPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ECDSA public key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("public keys are not used for HMAC verification")
+	}
+}
+
+// jwksCache fetches a JWKS document lazily and refreshes it periodically or
+// when a token references a key id that is not cached yet. Concurrent
+// refreshes share a single fetch, and cached keys keep verifying tokens while
+// a refresh is pending or failing.
+type jwksCache struct {
+	url    string
+	family keyFamily
+	client *http.Client
+	group  singleflight.Group
+
+	mu          sync.RWMutex
+	keys        map[string]any
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func newJWKSCache(url string, family keyFamily) *jwksCache {
+	return &jwksCache{
+		url:    url,
+		family: family,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+	}
+}
+
+func (c *jwksCache) keyfunc(ctx context.Context, token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, found, stale := c.lookup(kid)
+	if found && !stale {
+		return key, nil
+	}
+
+	// Unknown kids are answered from the negative cache until the minimum
+	// interval has passed since the last fetch attempt
+	if !c.refreshDue(found) {
+		if found {
+			return key, nil
+		}
+		return nil, ErrUnknownKey
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		if found {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	if key, found, _ := c.lookup(kid); found {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// lookup resolves kid from the cached set and reports whether the set is past
+// its refresh interval.
+func (c *jwksCache) lookup(kid string) (key any, found, stale bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stale = time.Since(c.fetchedAt) > jwksRefreshInterval
+
+	// Tokens without a kid are only resolvable when the set has a single key
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true, stale
+		}
+	}
+
+	key, found = c.keys[kid]
+	return key, found, stale
+}
+
+// refreshDue reports whether a fetch may start now. A stale set is always
+// refreshed, while a miss on a fresh set waits out jwksMinRefreshInterval.
+func (c *jwksCache) refreshDue(found bool) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if found || c.attemptedAt.IsZero() {
+		return true
+	}
+	return time.Since(c.attemptedAt) >= jwksMinRefreshInterval
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refresh fetches the JWKS document, sharing one in-flight request between
+// concurrent callers.
+func (c *jwksCache) refresh(ctx context.Context) error {
+	_, err, _ := c.group.Do("jwks", func() (any, error) {
+		return nil, c.fetch(ctx)
+	})
+	return err
+}
+
+func (c *jwksCache) fetch(ctx context.Context) error {
+	// Stamped on completion so callers arriving mid-fetch still join it
+	defer func() {
+		c.mu.Lock()
+		c.attemptedAt = time.Now()
+		c.mu.Unlock()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]any, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey(c.family)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (k jsonWebKey) publicKey(family keyFamily) (any, error) {
+	switch {
+	case k.Kty == "RSA" && family == familyRSA:
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case k.Kty == "EC" && family == familyECDSA:
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("key type %s does not match configured algorithm", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK component: %w", err)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
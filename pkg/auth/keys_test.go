@@ -0,0 +1,269 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksServer serves a JWKS document for key and counts the fetches it sees.
+// release, when non-nil, holds every response until it is closed.
+type jwksServer struct {
+	*httptest.Server
+	fetches atomic.Int32
+	release chan struct{}
+}
+
+func newJWKSServer(t *testing.T, kid string, key *rsa.PublicKey) *jwksServer {
+	t.Helper()
+	s := &jwksServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		if s.release != nil {
+			<-s.release
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return key
+}
+
+func tokenWithKid(kid string) *jwt.Token {
+	token := jwt.New(jwt.SigningMethodRS256)
+	token.Header["kid"] = kid
+	return token
+}
+
+func TestJWKSCacheResolvesKnownKid(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	cache := newJWKSCache(server.URL, familyRSA)
+
+	for range 3 {
+		got, err := cache.keyfunc(context.Background(), tokenWithKid("k1"))
+		if err != nil {
+			t.Fatalf("expected key, got %v", err)
+		}
+		if !got.(*rsa.PublicKey).Equal(&key.PublicKey) {
+			t.Fatal("resolved key does not match the served key")
+		}
+	}
+	if n := server.fetches.Load(); n != 1 {
+		t.Fatalf("expected 1 fetch, got %d", n)
+	}
+}
+
+func TestJWKSCacheNegativeCachesUnknownKids(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	cache := newJWKSCache(server.URL, familyRSA)
+
+	for _, kid := range []string{"bogus-1", "bogus-2", "bogus-3"} {
+		if _, err := cache.keyfunc(context.Background(), tokenWithKid(kid)); !errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("expected ErrUnknownKey for %s, got %v", kid, err)
+		}
+	}
+	if n := server.fetches.Load(); n != 1 {
+		t.Fatalf("expected unknown kids to share 1 fetch, got %d", n)
+	}
+
+	// Once the minimum interval has passed, an unknown kid may fetch again
+	cache.mu.Lock()
+	cache.attemptedAt = time.Now().Add(-jwksMinRefreshInterval)
+	cache.mu.Unlock()
+
+	cache.keyfunc(context.Background(), tokenWithKid("bogus-4"))
+	if n := server.fetches.Load(); n != 2 {
+		t.Fatalf("expected a second fetch after the minimum interval, got %d", n)
+	}
+}
+
+func TestJWKSCacheSharesConcurrentRefreshes(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	server.release = make(chan struct{})
+	cache := newJWKSCache(server.URL, familyRSA)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.keyfunc(context.Background(), tokenWithKid("k1"))
+			errs <- err
+		}()
+	}
+
+	// Let the callers pile up behind the first fetch before releasing it
+	for server.fetches.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(server.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("expected every caller to resolve the key, got %v", err)
+		}
+	}
+	if n := server.fetches.Load(); n != 1 {
+		t.Fatalf("expected concurrent callers to share 1 fetch, got %d", n)
+	}
+}
+
+func TestJWKSCacheServesStaleKeysWhenRefreshFails(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	cache := newJWKSCache(server.URL, familyRSA)
+
+	if _, err := cache.keyfunc(context.Background(), tokenWithKid("k1")); err != nil {
+		t.Fatalf("expected key, got %v", err)
+	}
+
+	server.Close()
+	cache.mu.Lock()
+	cache.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+	cache.mu.Unlock()
+
+	if _, err := cache.keyfunc(context.Background(), tokenWithKid("k1")); err != nil {
+		t.Fatalf("expected the stale key while the endpoint is down, got %v", err)
+	}
+}
+
+func TestJWKSCacheUsesRequestContext(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	server.release = make(chan struct{})
+	t.Cleanup(func() { close(server.release) })
+	cache := newJWKSCache(server.URL, familyRSA)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cache.keyfunc(ctx, tokenWithKid("k1"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the fetch to stop with the request context, got %v", err)
+	}
+}
+
+func TestNewKeyfuncAcceptsPreviousSecret(t *testing.T) {
+	keyFunc, err := NewKeyfunc(KeyConfig{Algorithm: "HS256", Secrets: []string{"current", "previous"}})
+	if err != nil {
+		t.Fatalf("failed to build keyfunc: %v", err)
+	}
+
+	claims := jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}
+	for _, secret := range []string{"current", "previous"} {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		if _, err := jwt.Parse(signed, keyFunc.WithContext(context.Background())); err != nil {
+			t.Fatalf("expected token signed with %s secret to verify, got %v", secret, err)
+		}
+	}
+
+	signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("retired"))
+	if _, err := jwt.Parse(signed, keyFunc.WithContext(context.Background())); err == nil {
+		t.Fatal("expected token signed with a retired secret to be rejected")
+	}
+}
+
+func TestNewKeyfuncAcceptsRS256TokenFromJWKS(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	keyFunc, err := NewKeyfunc(KeyConfig{Algorithm: "RS256", JWKSURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to build keyfunc: %v", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	parsed, err := jwt.Parse(signed, keyFunc.WithContext(context.Background()))
+	if err != nil {
+		t.Fatalf("expected the RS256 token to verify, got %v", err)
+	}
+	if sub, _ := parsed.Claims.GetSubject(); sub != "alice" {
+		t.Fatalf("expected subject alice, got %q", sub)
+	}
+
+	// A token signed by another key under the same kid must not verify
+	forged, _ := token.SignedString(newRSAKey(t))
+	if _, err := jwt.Parse(forged, keyFunc.WithContext(context.Background())); err == nil {
+		t.Fatal("expected a token signed with another key to be rejected")
+	}
+}
+
+func TestNewKeyfuncRejectsHS256InRS256Mode(t *testing.T) {
+	key := newRSAKey(t)
+	server := newJWKSServer(t, "k1", &key.PublicKey)
+	keyFunc, err := NewKeyfunc(KeyConfig{Algorithm: "RS256", JWKSURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to build keyfunc: %v", err)
+	}
+
+	// The classic confusion attack signs with the public key as an HMAC secret
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()})
+	token.Header["kid"] = "k1"
+	for name, secret := range map[string][]byte{"pem": publicPEM, "der": publicDER, "modulus": key.N.Bytes()} {
+		signed, err := token.SignedString(secret)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		if _, err := jwt.Parse(signed, keyFunc.WithContext(context.Background())); !errors.Is(err, ErrInvalidSigningMethod) {
+			t.Fatalf("expected ErrInvalidSigningMethod for HS256 keyed with the %s public key, got %v", name, err)
+		}
+	}
+	if n := server.fetches.Load(); n != 0 {
+		t.Fatalf("expected the wrong algorithm to be refused before any key lookup, got %d fetches", n)
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import "slices"
+
+// Permission is an action a role may grant. Permissions ending in _all
+// extend an action from the caller's own todos to every todo of their tenant.
+type Permission string
+
+const (
+	PermTodoCreate    Permission = "todo:create"
+	PermTodoRead      Permission = "todo:read"
+	PermTodoReadAll   Permission = "todo:read_all"
+	PermTodoUpdate    Permission = "todo:update"
+	PermTodoUpdateAll Permission = "todo:update_all"
+	PermTodoDelete    Permission = "todo:delete"
+	PermTodoDeleteAll Permission = "todo:delete_all"
+	PermTodoReassign  Permission = "todo:reassign"
+
+	// PermWorkloadRead allows viewing how many todos each user of the tenant
+	// is assigned
+	PermWorkloadRead Permission = "workload:read"
+
+	// PermTodoTransferAll allows transferring todos the caller does not own;
+	// owners holding PermTodoUpdate may always transfer their own
+	PermTodoTransferAll Permission = "todo:transfer_all"
+
+	PermTodoReadDeleted   Permission = "todo:read_deleted"
+	PermTodoHardDelete    Permission = "todo:hard_delete"
+	PermQueryInspect      Permission = "query:inspect"
+	PermTenantQuotaBypass Permission = "tenant:quota_bypass"
+
+	// PermTenantMove allows moving todos between tenants; it spans tenants,
+	// so no tenant role grants it
+	PermTenantMove Permission = "tenant:move"
+)
+
+// RolePermissions maps a role name to the permissions it grants
+type RolePermissions map[string][]Permission
+
+// DefaultRolePermissions returns the built-in roles: users manage the todos
+// they own or are assigned, managers may reassign any todo of their tenant,
+// admins may do everything within their tenant and platform admins may move
+// todos across tenants.
+func DefaultRolePermissions() RolePermissions {
+	return RolePermissions{
+		"user": {
+			PermTodoCreate,
+			PermTodoRead,
+			PermTodoUpdate,
+			PermTodoDelete,
+		},
+		"manager": {
+			PermTodoReassign,
+			PermWorkloadRead,
+		},
+		"admin": {
+			PermTodoCreate,
+			PermTodoRead,
+			PermTodoReadAll,
+			PermTodoUpdate,
+			PermTodoUpdateAll,
+			PermTodoDelete,
+			PermTodoDeleteAll,
+			PermTodoReassign,
+			PermWorkloadRead,
+			PermTodoTransferAll,
+			PermTodoReadDeleted,
+			PermTodoHardDelete,
+			PermQueryInspect,
+			PermTenantQuotaBypass,
+		},
+		"platform_admin": {
+			PermTenantMove,
+		},
+	}
+}
+
+// grants reports whether any of roles grants permission
+func (p RolePermissions) grants(roles []string, permission Permission) bool {
+	for _, role := range roles {
+		if slices.Contains(p[role], permission) {
+			return true
+		}
+	}
+	return false
+}
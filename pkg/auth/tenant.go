@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultTenantIDPattern accepts UUIDs and slugs of letters, digits, '-' and
+// '_' that fit the tenant_id column
+const DefaultTenantIDPattern = `^[A-Za-z0-9][A-Za-z0-9_-]{0,99}$`
+
+var ErrMalformedTenantID = errors.New("malformed tenant ID")
+
+// TenantIDValidator checks the tenant IDs credentials carry before they
+// reach any query
+type TenantIDValidator struct {
+	pattern *regexp.Regexp
+}
+
+// NewTenantIDValidator accepts tenant IDs matching pattern in full
+func NewTenantIDValidator(pattern string) (*TenantIDValidator, error) {
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID pattern: %w", err)
+	}
+	return &TenantIDValidator{pattern: re}, nil
+}
+
+// ValidateTenantID returns ErrMalformedTenantID unless tenantID matches
+func (v *TenantIDValidator) ValidateTenantID(tenantID string) error {
+	if !v.pattern.MatchString(tenantID) {
+		return ErrMalformedTenantID
+	}
+	return nil
+}
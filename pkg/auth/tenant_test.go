@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTenantIDValidator(t *testing.T) {
+	v, err := NewTenantIDValidator(DefaultTenantIDPattern)
+	if err != nil {
+		t.Fatalf("NewTenantIDValidator: %v", err)
+	}
+
+	tests := []struct {
+		tenantID string
+		valid    bool
+	}{
+		{tenantID: "tenant-1", valid: true},
+		{tenantID: "acme_corp", valid: true},
+		{tenantID: "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b", valid: true},
+		{tenantID: strings.Repeat("a", 100), valid: true},
+		{tenantID: strings.Repeat("a", 101)},
+		{tenantID: ""},
+		{tenantID: "-leading-dash"},
+		{tenantID: "../etc"},
+		{tenantID: "tenant 1"},
+		{tenantID: "tenant-1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tenantID, func(t *testing.T) {
+			err := v.ValidateTenantID(tt.tenantID)
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateTenantID(%q) = %v, want nil", tt.tenantID, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrMalformedTenantID) {
+				t.Fatalf("ValidateTenantID(%q) = %v, want ErrMalformedTenantID", tt.tenantID, err)
+			}
+		})
+	}
+}
+
+func TestNewTenantIDValidatorAnchorsPattern(t *testing.T) {
+	// A pattern without anchors must still match the whole ID
+	v, err := NewTenantIDValidator(`acme|globex`)
+	if err != nil {
+		t.Fatalf("NewTenantIDValidator: %v", err)
+	}
+	for tenantID, valid := range map[string]bool{"acme": true, "globex": true, "acme-evil": false, "xglobex": false} {
+		if err := v.ValidateTenantID(tenantID); (err == nil) != valid {
+			t.Errorf("ValidateTenantID(%q) = %v, want valid %v", tenantID, err, valid)
+		}
+	}
+
+	if _, err := NewTenantIDValidator(`[`); err == nil {
+		t.Fatal("expected an invalid pattern to be rejected")
+	}
+}
@@ -0,0 +1,20 @@
+// Package requestid carries the ID the logging interceptor assigns to each
+// request, so logs written while handling it can be joined to the request log
+package requestid
+
+import "context"
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// FromContext returns the request ID carried by ctx, if any
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok && id != ""
+}
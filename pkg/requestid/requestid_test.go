@@ -0,0 +1,29 @@
+package requestid
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   string
+		wantOK bool
+	}{
+		{name: "set", ctx: NewContext(context.Background(), "req-1"), want: "req-1", wantOK: true},
+		{name: "unset", ctx: context.Background()},
+		{name: "empty", ctx: NewContext(context.Background(), "")},
+		{name: "other key", ctx: context.WithValue(context.Background(), "request_id", "req-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromContext(tt.ctx)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("FromContext() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}